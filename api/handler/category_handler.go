@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandler struct {
+	categoryUsecase usecase.CategoryUsecase
+}
+
+func NewCategoryHandler(u usecase.CategoryUsecase) *CategoryHandler {
+	return &CategoryHandler{categoryUsecase: u}
+}
+
+// CreateCategory handles adding a category to the tree
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req dto.CategoryRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	category, err := h.categoryUsecase.CreateCategory(usecase.CategoryInput{Name: req.Name, ParentID: req.ParentID})
+	if err != nil {
+		handleCategoryError(c, err, consts.ErrFailedCreateCategory)
+		return
+	}
+
+	utility.LogSuccess("category created successfully", category.ID(), category.Name())
+	c.JSON(http.StatusCreated, transformer.TransformCategoryEntityToResponse(category))
+}
+
+// GetCategoryList returns the whole category tree
+func (h *CategoryHandler) GetCategoryList(c *gin.Context) {
+	categories, err := h.categoryUsecase.ListCategories()
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveCategory, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("categories retrieved successfully", len(categories))
+	c.JSON(http.StatusOK, transformer.TransformCategoryTreeToResponse(categories))
+}
+
+// GetCategory retrieves a category with its ancestors and children
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidCategoryID, http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.categoryUsecase.GetCategory(id)
+	if err != nil {
+		if err == usecase.ErrCategoryNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrCategoryNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveCategory, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("category retrieved successfully", detail.Category.ID())
+	c.JSON(http.StatusOK, transformer.TransformCategoryDetailToResponse(detail.Category, detail.Ancestors, detail.Children))
+}
+
+// UpdateCategory renames a category and moves it, with everything below it, to a new parent
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidCategoryID, http.StatusBadRequest)
+		return
+	}
+
+	var req dto.CategoryRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	category, err := h.categoryUsecase.UpdateCategory(id, usecase.CategoryInput{Name: req.Name, ParentID: req.ParentID})
+	if err != nil {
+		handleCategoryError(c, err, consts.ErrFailedUpdateCategory)
+		return
+	}
+
+	utility.LogSuccess("category updated successfully", category.ID(), category.Name())
+	c.JSON(http.StatusOK, transformer.TransformCategoryEntityToResponse(category))
+}
+
+// DeleteCategory removes a category that has neither subcategories nor products
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidCategoryID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.categoryUsecase.DeleteCategory(id); err != nil {
+		handleCategoryError(c, err, consts.ErrFailedDeleteCategory)
+		return
+	}
+
+	utility.LogSuccess("category deleted successfully", id)
+	c.Status(http.StatusNoContent)
+}
+
+// handleCategoryError maps category use case errors to HTTP responses
+func handleCategoryError(c *gin.Context, err error, failureMessage string) {
+	switch {
+	case errors.Is(err, usecase.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrCategoryNotFound})
+	case errors.Is(err, usecase.ErrParentCategoryNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrParentCategoryNotFound})
+	case errors.Is(err, usecase.ErrCategoryNameTaken):
+		c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrCategoryNameTaken})
+	case errors.Is(err, usecase.ErrCategoryNotEmpty):
+		c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrCategoryNotEmpty})
+	case errors.Is(err, usecase.ErrInvalidInput):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+	default:
+		helper_handler.HandleErrorResponse(c, err, failureMessage, http.StatusInternalServerError)
+	}
+}
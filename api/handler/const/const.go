@@ -2,10 +2,143 @@ package consts
 
 // Error messages
 const (
-	ErrInvalidProductID   = "invalid product ID"
-	ErrProductNotFound    = "product not found"
-	ErrFailedCreate       = "failed to create product"
-	ErrFailedUpdate       = "failed to update product"
-	ErrFailedRetrieve     = "failed to retrieve product"
-	ErrInvalidRequestBody = "invalid request body" // New constant for invalid request body
+	ErrInvalidProductID    = "invalid product ID"
+	ErrProductNotFound     = "product not found"
+	ErrFailedCreate        = "failed to create product"
+	ErrFailedUpdate        = "failed to update product"
+	ErrFailedRetrieve      = "failed to retrieve product"
+	ErrFailedDelete        = "failed to delete product"
+	ErrFailedRestore       = "failed to restore product"
+	ErrProductNotStockable = "product is discontinued or deleted and cannot take new stock"
+	ErrInvalidRequestBody  = "invalid request body" // New constant for invalid request body
+	ErrSKUTaken            = "sku already exists"
+	ErrBarcodeNotFound     = "barcode not found"
+	ErrBarcodeTaken        = "barcode already exists"
+	ErrLabelTooSmall       = "label is too small for its barcode"
+	ErrFailedRenderLabel   = "failed to render product label"
+	ErrUnsupportedPatch    = "patch must be sent as application/merge-patch+json or application/json"
+	ErrIfMatchRequired     = "If-Match header with the product's ETag is required"
+	ErrVersionMismatch     = "product was changed since it was read"
+	ErrFailedRetrieveAudit = "failed to retrieve product history"
+	ErrNoProductHistory    = "product has no recorded history at that time"
+	ErrIncludeDeletedAdmin = "only administrators can include deleted products"
+
+	ErrInvalidProductImportID  = "invalid product import ID"
+	ErrProductImportNotFound   = "product import not found"
+	ErrImportFileRequired      = "a .csv or .xlsx file must be uploaded in the file form field"
+	ErrImportFileTooLarge      = "import file is too large"
+	ErrImportFileEmpty         = "import file has no header row"
+	ErrFailedImportProducts    = "failed to import products"
+	ErrImportFailedPartWay     = "import failed part way, the rows before failed_at_line were imported"
+	ErrFailedRetrieveImport    = "failed to retrieve product import"
+	ErrFailedWriteImportReport = "failed to write product import report"
+	ErrFailedExportProducts    = "failed to export products"
+
+	ErrProductVariantExists   = "product variant already exists"
+	ErrFailedGenerateVariants = "failed to generate product variants"
+
+	ErrInvalidWarehouseID      = "invalid warehouse ID"
+	ErrWarehouseNotFound       = "warehouse not found"
+	ErrWarehouseCodeTaken      = "warehouse code already exists"
+	ErrFailedCreateWarehouse   = "failed to create warehouse"
+	ErrFailedRetrieveWarehouse = "failed to retrieve warehouse"
+	ErrFailedRetrieveStock     = "failed to retrieve stock levels"
+
+	ErrInsufficientStock       = "insufficient stock"
+	ErrFailedRecordMovement    = "failed to record stock movement"
+	ErrFailedRetrieveMovements = "failed to retrieve stock movements"
+	ErrFailedReconcileStock    = "failed to reconcile stock"
+
+	ErrLotNotFound        = "lot not found"
+	ErrFailedRetrieveLots = "failed to retrieve lots"
+
+	ErrSerialNotFound       = "serial not found"
+	ErrFailedRetrieveSerial = "failed to retrieve serial"
+
+	ErrUnitOfMeasureNotFound = "unit of measure not found"
+	ErrUnitCodeTaken         = "unit code already exists"
+	ErrFailedCreateUnit      = "failed to create unit of measure"
+	ErrFailedRetrieveUnits   = "failed to retrieve units of measure"
+	ErrFailedSetProductUnit  = "failed to set product unit conversion"
+
+	ErrInvalidCategoryID      = "invalid category ID"
+	ErrCategoryNotFound       = "category not found"
+	ErrParentCategoryNotFound = "parent category not found"
+	ErrCategoryNameTaken      = "category name already exists below the parent"
+	ErrCategoryNotEmpty       = "category still has subcategories or products"
+	ErrFailedCreateCategory   = "failed to create category"
+	ErrFailedRetrieveCategory = "failed to retrieve category"
+	ErrFailedUpdateCategory   = "failed to update category"
+	ErrFailedDeleteCategory   = "failed to delete category"
+
+	ErrInvalidReservationID      = "invalid reservation ID"
+	ErrReservationNotFound       = "reservation not found"
+	ErrReservationNotPending     = "reservation is no longer pending"
+	ErrReservationExpired        = "reservation has expired"
+	ErrFailedCreateReservation   = "failed to create reservation"
+	ErrFailedRetrieveReservation = "failed to retrieve reservation"
+	ErrFailedUpdateReservation   = "failed to update reservation"
+
+	ErrInvalidTransferID      = "invalid transfer ID"
+	ErrTransferNotFound       = "transfer not found"
+	ErrTransferNotDraft       = "transfer is no longer a draft"
+	ErrTransferNotInTransit   = "transfer is not in transit"
+	ErrFailedCreateTransfer   = "failed to create transfer"
+	ErrFailedRetrieveTransfer = "failed to retrieve transfer"
+	ErrFailedUpdateTransfer   = "failed to update transfer"
+
+	ErrInvalidSupplierID      = "invalid supplier ID"
+	ErrSupplierNotFound       = "supplier not found"
+	ErrSupplierCodeTaken      = "supplier code already exists"
+	ErrFailedCreateSupplier   = "failed to create supplier"
+	ErrFailedRetrieveSupplier = "failed to retrieve supplier"
+
+	ErrInvalidWebhookID                = "invalid webhook ID"
+	ErrWebhookNotFound                 = "webhook not found"
+	ErrFailedCreateWebhook             = "failed to create webhook"
+	ErrFailedRetrieveWebhook           = "failed to retrieve webhook"
+	ErrFailedUpdateWebhook             = "failed to update webhook"
+	ErrFailedDeleteWebhook             = "failed to delete webhook"
+	ErrFailedRetrieveWebhookDeliveries = "failed to retrieve webhook deliveries"
+
+	ErrInvalidPurchaseOrderID      = "invalid purchase order ID"
+	ErrPurchaseOrderNotFound       = "purchase order not found"
+	ErrPurchaseOrderNotDraft       = "purchase order is no longer a draft"
+	ErrPurchaseOrderNotReceivable  = "purchase order is not open for receiving"
+	ErrPurchaseOrderNotClosable    = "purchase order cannot be closed before goods are received"
+	ErrFailedCreatePurchaseOrder   = "failed to create purchase order"
+	ErrFailedRetrievePurchaseOrder = "failed to retrieve purchase order"
+	ErrFailedUpdatePurchaseOrder   = "failed to update purchase order"
+
+	ErrInvalidSalesOrderID      = "invalid sales order ID"
+	ErrSalesOrderNotFound       = "sales order not found"
+	ErrFailedCreateSalesOrder   = "failed to create sales order"
+	ErrFailedRetrieveSalesOrder = "failed to retrieve sales order"
+	ErrFailedUpdateSalesOrder   = "failed to update sales order"
+
+	ErrFailedSetReorderPolicy      = "failed to set reorder policy"
+	ErrFailedRetrieveReorderPolicy = "failed to retrieve reorder policies"
+	ErrFailedRetrieveLowStockAlert = "failed to retrieve low-stock alerts"
+)
+
+// Media types
+const (
+	ContentTypeMergePatch = "application/merge-patch+json" // JSON Merge Patch (RFC 7396)
+	ContentTypeJSON       = "application/json"
+	ContentTypeCSV        = "text/csv; charset=utf-8"
+	ContentTypeNDJSON     = "application/x-ndjson" // JSON Lines, one document per line
+	ContentTypeXLSX       = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Request and response headers
+const (
+	HeaderActor   = "X-Actor" // Identifies who performs a change
+	DefaultActor  = "anonymous"
+	HeaderETag    = "ETag"     // Version of the returned product
+	HeaderIfMatch = "If-Match" // Version of the product an update was based on
+
+	HeaderRequestID = "X-Request-ID" // Identifies a request in the audit trail
+
+	HeaderAdminToken = "X-Admin-Token" // Token identifying an administrator
+	ContextKeyAdmin  = "admin"         // Set on the context of requests made by an administrator
 )
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// CategoryRequest represents the request body for creating or updating a category
+type CategoryRequest struct {
+	Name     string `json:"name" validate:"required,max=255"`
+	ParentID uint   `json:"parent_id"` // Omitted or zero places the category at the root
+}
+
+// AssignProductCategoryRequest represents the request body for filing a product under a category
+type AssignProductCategoryRequest struct {
+	CategoryID uint `json:"category_id"` // Omitted or zero removes the product from its category
+}
+
+// Validate performs validation on CategoryRequest and returns custom error messages if validation fails.
+func (r *CategoryRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parseCategoryValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// Validate performs validation on AssignProductCategoryRequest and returns custom error messages if validation fails.
+func (r *AssignProductCategoryRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parseCategoryValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseCategoryValidationErrors converts the validation errors into a map of custom error messages.
+func parseCategoryValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = getCategoryCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCategoryCustomErrorMessage returns custom error messages for validation rules.
+func getCategoryCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Name.required": "Category name is required.",
+		"Name.max":      "Category name must be less than 255 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
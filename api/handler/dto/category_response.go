@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// CategoryResponse represents the response body for a category
+type CategoryResponse struct {
+	ID        uint      `json:"id"`
+	ParentID  *uint     `json:"parent_id"` // Null for a root category
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CategoryDetailResponse represents a category with the path leading to it and its direct children
+type CategoryDetailResponse struct {
+	CategoryResponse
+	Ancestors []*CategoryResponse `json:"ancestors"` // From the root down to the parent
+	Children  []*CategoryResponse `json:"children"`
+}
+
+// CategoryTreeResponse represents a category with everything below it
+type CategoryTreeResponse struct {
+	ID       uint                    `json:"id"`
+	Name     string                  `json:"name"`
+	Children []*CategoryTreeResponse `json:"children"`
+}
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ExpiringLotQueryParams defines the query parameters for the expiring-soon lot report
+type ExpiringLotQueryParams struct {
+	Days        int  `json:"days" validate:"min=1,max=365"`
+	WarehouseID uint `json:"warehouse_id"`
+	Limit       int  `json:"limit" validate:"min=1,max=100"`
+	Offset      int  `json:"offset" validate:"min=0"`
+}
+
+// Validate performs validation on the query parameters and returns custom error messages
+func (p *ExpiringLotQueryParams) Validate(queryParams url.Values) map[string]string {
+	errors := make(map[string]string)
+
+	if warehouseID := queryParams.Get("warehouse_id"); warehouseID != "" {
+		id, err := strconv.ParseUint(warehouseID, 10, 32)
+		if err != nil || id == 0 {
+			errors["WarehouseID"] = "warehouse_id must be a positive number."
+		}
+		p.WarehouseID = uint(id)
+	}
+
+	// If days, limit or offset are not provided, set default values
+	p.Days = 30
+	if days := queryParams.Get("days"); days != "" {
+		p.Days, _ = strconv.Atoi(days)
+	}
+
+	p.Limit = 50
+	if limit := queryParams.Get("limit"); limit != "" {
+		p.Limit, _ = strconv.Atoi(limit)
+	}
+
+	p.Offset = 0
+	if offset := queryParams.Get("offset"); offset != "" {
+		var err error
+		if p.Offset, err = strconv.Atoi(offset); err != nil {
+			p.Offset = -1
+		}
+	}
+
+	// Perform validation using the validator package
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		for field, message := range p.parseValidationErrors(err.(validator.ValidationErrors)) {
+			errors[field] = message
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// parseValidationErrors converts validation errors into custom error messages
+func (p *ExpiringLotQueryParams) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = p.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages based on the field and tag
+func (p *ExpiringLotQueryParams) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Days.min":   "days must be a number between 1 and 365.",
+		"Days.max":   "days must be a number between 1 and 365.",
+		"Limit.min":  "limit must be a number between 1 and 100.",
+		"Limit.max":  "limit must be a number between 1 and 100.",
+		"Offset.min": "offset must be a number greater than or equal to 0.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+
+	return "Invalid field"
+}
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// LabelQueryParams defines the query parameters for rendering a product label
+type LabelQueryParams struct {
+	Format   string  `json:"format" validate:"oneof=png svg"`
+	WidthMM  float64 `json:"width" validate:"min=10,max=200"`  // Label width in millimetres
+	HeightMM float64 `json:"height" validate:"min=10,max=200"` // Label height in millimetres
+	DPI      int     `json:"dpi" validate:"min=72,max=600"`    // Printer resolution in dots per inch
+}
+
+// Validate performs validation on the query parameters and returns custom error messages
+func (p *LabelQueryParams) Validate(queryParams url.Values) map[string]string {
+	errors := make(map[string]string)
+
+	// If a parameter is not provided, default to a 50 x 25 mm PNG for a 203 DPI label printer
+	// Unparsable values are left out of range so that validation rejects them
+	p.Format = "png"
+	if format := queryParams.Get("format"); format != "" {
+		p.Format = format
+	}
+
+	p.WidthMM = 50
+	if width := queryParams.Get("width"); width != "" {
+		p.WidthMM, _ = strconv.ParseFloat(width, 64)
+	}
+
+	p.HeightMM = 25
+	if height := queryParams.Get("height"); height != "" {
+		p.HeightMM, _ = strconv.ParseFloat(height, 64)
+	}
+
+	p.DPI = 203
+	if dpi := queryParams.Get("dpi"); dpi != "" {
+		p.DPI, _ = strconv.Atoi(dpi)
+	}
+
+	// Perform validation using the validator package
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		for field, message := range p.parseValidationErrors(err.(validator.ValidationErrors)) {
+			errors[field] = message
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// parseValidationErrors converts validation errors into custom error messages
+func (p *LabelQueryParams) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = p.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages based on the field and tag
+func (p *LabelQueryParams) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Format.oneof": "format must be either 'png' or 'svg'.",
+		"WidthMM.min":  "width must be a number of millimetres between 10 and 200.",
+		"WidthMM.max":  "width must be a number of millimetres between 10 and 200.",
+		"HeightMM.min": "height must be a number of millimetres between 10 and 200.",
+		"HeightMM.max": "height must be a number of millimetres between 10 and 200.",
+		"DPI.min":      "dpi must be a number between 72 and 600.",
+		"DPI.max":      "dpi must be a number between 72 and 600.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+
+	return "Invalid field"
+}
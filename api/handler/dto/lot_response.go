@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// LotResponse represents the response body for a lot
+type LotResponse struct {
+	ID             uint       `json:"id"`
+	ProductID      uint       `json:"product_id"`
+	WarehouseID    uint       `json:"warehouse_id"`
+	LotNumber      string     `json:"lot_number"`
+	ManufacturedAt *time.Time `json:"manufactured_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	Expired        bool       `json:"expired"`
+	Quantity       int64      `json:"quantity"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// LotListResponse represents the response body for a paginated list of lots
+type LotListResponse struct {
+	Lots []*LotResponse `json:"lots"`
+	PaginationResponse
+}
@@ -0,0 +1,17 @@
+package dto
+
+// PaginationResponse represents the pagination metadata returned with list responses
+type PaginationResponse struct {
+	Total   int64   `json:"total"`
+	Limit   int     `json:"limit"`
+	Offset  int     `json:"offset"`
+	HasMore bool    `json:"has_more"`
+	Next    *string `json:"next"`
+	Prev    *string `json:"prev"`
+}
+
+// ProductListResponse represents the response body for a paginated list of products
+type ProductListResponse struct {
+	Products []*ProductResponse `json:"products"`
+	PaginationResponse
+}
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"net/url"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Formats the product catalogue can be exported in
+const (
+	ExportFormatCSV   = "csv"
+	ExportFormatJSONL = "jsonl" // One product response per line
+	ExportFormatXLSX  = "xlsx"
+)
+
+// ProductExportQueryParams defines the query parameters for exporting the product catalogue. The
+// filters are those of the product list, without pagination.
+type ProductExportQueryParams struct {
+	Format         string `json:"format" validate:"oneof=csv jsonl xlsx"`
+	SearchTerm     string `json:"search"`
+	CategoryID     uint   `json:"category_id"` // Includes the products of every category below it
+	SortBy         string `json:"sortBy" validate:"omitempty,oneof=name sku"`
+	SortDirection  string `json:"sortDirection" validate:"oneof=asc desc"`
+	IncludeDeleted bool   `json:"include_deleted"` // Exports soft-deleted products as well
+}
+
+// Validate performs validation on the query parameters and returns custom error messages
+func (p *ProductExportQueryParams) Validate(queryParams url.Values) map[string]string {
+	errors := make(map[string]string)
+
+	// Manually extract and assign the query parameters
+	p.SearchTerm = queryParams.Get("search")
+	p.SortBy = queryParams.Get("sortBy")
+	p.CategoryID = parseCategoryID(queryParams, errors)
+	p.IncludeDeleted = parseIncludeDeleted(queryParams, errors)
+
+	// If a parameter is not provided, export CSV in the order products were created
+	p.Format = ExportFormatCSV
+	if format := queryParams.Get("format"); format != "" {
+		p.Format = format
+	}
+
+	p.SortDirection = "asc"
+	if sortDirection := queryParams.Get("sortDirection"); sortDirection != "" {
+		p.SortDirection = sortDirection
+	}
+
+	// Perform validation using the validator package
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		for field, message := range p.parseValidationErrors(err.(validator.ValidationErrors)) {
+			errors[field] = message
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// parseValidationErrors converts validation errors into custom error messages
+func (p *ProductExportQueryParams) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = p.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages based on the field and tag
+func (p *ProductExportQueryParams) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Format.oneof":        "format must be either 'csv', 'jsonl' or 'xlsx'.",
+		"SortBy.oneof":        "sortBy must be either 'name' or 'sku'.",
+		"SortDirection.oneof": "sortDirection must be either 'asc' or 'desc'.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+
+	return "Invalid field"
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ProductHistoryQueryParams defines the query parameters for listing the audit trail of a product
+type ProductHistoryQueryParams struct {
+	Limit  int `json:"limit" validate:"min=1,max=100"`
+	Offset int `json:"offset" validate:"min=0"`
+}
+
+// Validate performs validation on the query parameters and returns custom error messages
+func (p *ProductHistoryQueryParams) Validate(queryParams url.Values) map[string]string {
+	errors := make(map[string]string)
+
+	// If limit or offset are not provided, set default values
+	p.Limit = 50
+	if limit := queryParams.Get("limit"); limit != "" {
+		p.Limit, _ = strconv.Atoi(limit)
+	}
+
+	p.Offset = 0
+	if offset := queryParams.Get("offset"); offset != "" {
+		var err error
+		if p.Offset, err = strconv.Atoi(offset); err != nil {
+			p.Offset = -1
+		}
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			switch err.Field() {
+			case "Limit":
+				errors["Limit"] = "limit must be a number between 1 and 100."
+			case "Offset":
+				errors["Offset"] = "offset must be a number greater than or equal to 0."
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// ProductSnapshotQueryParams defines the query parameters for rebuilding a product as of a point in time
+type ProductSnapshotQueryParams struct {
+	AsOf time.Time `json:"as_of"`
+}
+
+// Validate reads the query parameters and returns custom error messages
+func (p *ProductSnapshotQueryParams) Validate(queryParams url.Values) map[string]string {
+	errors := make(map[string]string)
+
+	if asOf := parseTimeParam(queryParams, "as_of", "AsOf", errors); asOf != nil {
+		p.AsOf = *asOf
+	} else if _, malformed := errors["AsOf"]; !malformed {
+		errors["AsOf"] = "as_of is required."
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
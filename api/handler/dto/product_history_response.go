@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// ProductAuditRecordResponse represents one change in the audit trail of a product
+type ProductAuditRecordResponse struct {
+	ID         uint                   `json:"id"`
+	ProductID  uint                   `json:"product_id"`
+	Action     string                 `json:"action"`
+	Actor      string                 `json:"actor"`
+	RequestID  string                 `json:"request_id"`
+	SourceIP   string                 `json:"source_ip"`
+	Changes    []*FieldChangeResponse `json:"changes"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// FieldChangeResponse represents the value of one product field before and after a change
+type FieldChangeResponse struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"` // Null for the fields of a created product
+	After  interface{} `json:"after"`
+}
+
+// ProductHistoryResponse represents the response body for a paginated audit trail
+type ProductHistoryResponse struct {
+	Records []*ProductAuditRecordResponse `json:"records"`
+	PaginationResponse
+}
@@ -0,0 +1,173 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fields of a product a column of an import file can fill
+const (
+	ImportFieldName       = "name"
+	ImportFieldSKU        = "sku"
+	ImportFieldSerialized = "serialized"
+	ImportFieldStockUnit  = "stock_unit"
+	ImportFieldCategoryID = "category_id"
+)
+
+// importFields lists the fields in the order their columns are looked for
+var importFields = []string{ImportFieldName, ImportFieldSKU, ImportFieldSerialized, ImportFieldStockUnit, ImportFieldCategoryID}
+
+// ProductImportRequest represents the form fields sent along with a product import file. Mapping names
+// the column holding each field, for files whose headers differ from the field names.
+type ProductImportRequest struct {
+	DryRun  bool
+	Mapping map[string]string // Header of the column keyed by field, unmapped fields use their own name
+}
+
+// Validate reads the dry_run and mapping form fields and returns custom error messages if they are invalid
+func (r *ProductImportRequest) Validate(form url.Values) map[string]string {
+	errors := make(map[string]string)
+
+	r.DryRun = false
+	if dryRun := form.Get("dry_run"); dryRun != "" {
+		var err error
+		if r.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+			errors["DryRun"] = "dry_run must be true or false."
+		}
+	}
+
+	r.Mapping = map[string]string{}
+	if mapping := form.Get("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &r.Mapping); err != nil {
+			errors["Mapping"] = "mapping must be a JSON object naming the column of each field."
+		} else {
+			for field, column := range r.Mapping {
+				if !isImportField(field) {
+					errors["Mapping"] = fmt.Sprintf("mapping may only name the fields %s.", strings.Join(importFields, ", "))
+					break
+				}
+				if strings.TrimSpace(column) == "" {
+					errors["Mapping"] = "mapping must name a column for every field it lists."
+					break
+				}
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// isImportField reports whether a column can be mapped to the field
+func isImportField(field string) bool {
+	for _, known := range importFields {
+		if field == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ProductImportColumns locates the fields of a product among the columns of an import file
+type ProductImportColumns map[string]int
+
+// NewProductImportColumns finds the column of every field in the header row of a file. Headers are
+// matched ignoring case, surrounding spaces, and spaces or dashes standing for underscores. The name
+// column is required, and every column named in the mapping must be present.
+func NewProductImportColumns(header []string, mapping map[string]string) (ProductImportColumns, map[string]string) {
+	positions := make(map[string]int, len(header))
+	for i, title := range header {
+		if _, seen := positions[normalizeImportHeader(title)]; !seen {
+			positions[normalizeImportHeader(title)] = i
+		}
+	}
+
+	columns := ProductImportColumns{}
+	var missing []string
+	for _, field := range importFields {
+		title, mapped := mapping[field]
+		if !mapped {
+			title = field
+		}
+		if position, ok := positions[normalizeImportHeader(title)]; ok {
+			columns[field] = position
+		} else if mapped {
+			missing = append(missing, fmt.Sprintf("%q", title))
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		sort.Strings(missing)
+		return nil, map[string]string{"Mapping": fmt.Sprintf("file has no column %s.", strings.Join(missing, ", "))}
+	case !columns.Has(ImportFieldName):
+		return nil, map[string]string{"File": "file must have a name column, or a mapping naming the column holding product names."}
+	}
+	return columns, nil
+}
+
+// normalizeImportHeader reduces a header to the form fields are named in
+func normalizeImportHeader(title string) string {
+	title = strings.ToLower(strings.TrimSpace(title))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(title)
+}
+
+// Has reports whether the file has a column for the field
+func (c ProductImportColumns) Has(field string) bool {
+	_, ok := c[field]
+	return ok
+}
+
+// cell returns the trimmed text of the field in a row, empty when the file has no column for it
+func (c ProductImportColumns) cell(cells []string, field string) string {
+	position, ok := c[field]
+	if !ok || position >= len(cells) {
+		return ""
+	}
+	return strings.TrimSpace(cells[position])
+}
+
+// Request builds the create request of a product from the cells of a row and validates it. Cells that
+// cannot be read as their field are reported alongside the messages of CreateProductRequest. An empty
+// serialized cell leaves serializedGiven unset.
+func (c ProductImportColumns) Request(cells []string) (req CreateProductRequest, serializedGiven bool, errors map[string]string) {
+	errors = make(map[string]string)
+	req = CreateProductRequest{
+		Name:      c.cell(cells, ImportFieldName),
+		SKU:       c.cell(cells, ImportFieldSKU),
+		StockUnit: c.cell(cells, ImportFieldStockUnit),
+	}
+
+	if serialized := c.cell(cells, ImportFieldSerialized); serialized != "" {
+		switch strings.ToLower(serialized) {
+		case "true", "yes", "y", "1":
+			req.Serialized, serializedGiven = true, true
+		case "false", "no", "n", "0":
+			serializedGiven = true
+		default:
+			errors["Serialized"] = "Serialized must be true or false."
+		}
+	}
+
+	if categoryID := c.cell(cells, ImportFieldCategoryID); categoryID != "" {
+		id, err := strconv.ParseUint(categoryID, 10, 32)
+		if err != nil || id == 0 {
+			errors["CategoryID"] = "Category ID must be a positive whole number."
+		}
+		req.CategoryID = uint(id)
+	}
+
+	for field, message := range req.Validate() {
+		errors[field] = message
+	}
+	if len(errors) > 0 {
+		return req, serializedGiven, errors
+	}
+	return req, serializedGiven, nil
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// ProductImportResponse represents the response body for the report of a product import
+type ProductImportResponse struct {
+	ID           uint                         `json:"id"`
+	FileName     string                       `json:"file_name"`
+	DryRun       bool                         `json:"dry_run"`
+	Status       string                       `json:"status"`                   // completed, or failed when a batch failed
+	FailedAtLine *int                         `json:"failed_at_line,omitempty"` // First line of the rows left unimported
+	TotalRows    int                          `json:"total_rows"`
+	Created      int                          `json:"created"`
+	Updated      int                          `json:"updated"`
+	Unchanged    int                          `json:"unchanged"`
+	Rejected     int                          `json:"rejected"`
+	RejectedRows []RejectedProductRowResponse `json:"rejected_rows"`
+	ReportURL    string                       `json:"report_url"` // CSV of the rejected rows
+	CreatedAt    time.Time                    `json:"created_at"`
+}
+
+// RejectedProductRowResponse represents a row of an import file that was not imported
+type RejectedProductRowResponse struct {
+	Line   int               `json:"line"`
+	SKU    string            `json:"sku"`
+	Name   string            `json:"name"`
+	Errors map[string]string `json:"errors"`
+}
@@ -9,21 +9,29 @@ import (
 
 // ProductListQueryParams defines the query parameters for listing products
 type ProductListQueryParams struct {
-	SearchTerm    string `json:"search"`
-	SortBy        string `json:"sortBy" validate:"oneof=name sku"`
-	SortDirection string `json:"sortDirection" validate:"oneof=asc desc"`
-	Limit         int    `json:"limit"`
-	Offset        int    `json:"offset"`
+	SearchTerm     string `json:"search"`
+	CategoryID     uint   `json:"category_id"` // Includes the products of every category below it
+	SortBy         string `json:"sortBy" validate:"oneof=name sku"`
+	SortDirection  string `json:"sortDirection" validate:"oneof=asc desc"`
+	Limit          int    `json:"limit" validate:"min=1,max=100"`
+	Offset         int    `json:"offset" validate:"min=0"`
+	IncludeDeleted bool   `json:"include_deleted"` // Lists soft-deleted products as well
 }
 
 // Validate performs validation on the query parameters and returns custom error messages
 func (p *ProductListQueryParams) Validate(queryParams url.Values) map[string]string {
+	errors := make(map[string]string)
+
 	// Manually extract and assign the query parameters
 	p.SearchTerm = queryParams.Get("search")
 	p.SortBy = queryParams.Get("sortBy")
 	p.SortDirection = queryParams.Get("sortDirection")
 
+	p.CategoryID = parseCategoryID(queryParams, errors)
+	p.IncludeDeleted = parseIncludeDeleted(queryParams, errors)
+
 	// If limit or offset are not provided, set default values
+	// Unparsable values are left out of range so that validation rejects them
 	if limit := queryParams.Get("limit"); limit != "" {
 		p.Limit, _ = strconv.Atoi(limit)
 	} else {
@@ -31,21 +39,54 @@ func (p *ProductListQueryParams) Validate(queryParams url.Values) map[string]str
 	}
 
 	if offset := queryParams.Get("offset"); offset != "" {
-		p.Offset, _ = strconv.Atoi(offset)
+		var err error
+		if p.Offset, err = strconv.Atoi(offset); err != nil {
+			p.Offset = -1
+		}
 	} else {
 		p.Offset = 0 // default value
 	}
 
 	// Perform validation using the validator package
 	validate := validator.New()
-	err := validate.Struct(p)
-	if err != nil {
-		return p.parseValidationErrors(err.(validator.ValidationErrors))
+	if err := validate.Struct(p); err != nil {
+		for field, message := range p.parseValidationErrors(err.(validator.ValidationErrors)) {
+			errors[field] = message
+		}
 	}
 
+	if len(errors) > 0 {
+		return errors
+	}
 	return nil
 }
 
+// parseCategoryID reads the category_id filter, recording an error when it is not a positive number
+func parseCategoryID(queryParams url.Values, errors map[string]string) uint {
+	value := queryParams.Get("category_id")
+	if value == "" {
+		return 0
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || id == 0 {
+		errors["CategoryID"] = "category_id must be a positive number."
+	}
+	return uint(id)
+}
+
+// parseIncludeDeleted reads the include_deleted flag, recording an error when it is not a boolean
+func parseIncludeDeleted(queryParams url.Values, errors map[string]string) bool {
+	value := queryParams.Get("include_deleted")
+	if value == "" {
+		return false
+	}
+	includeDeleted, err := strconv.ParseBool(value)
+	if err != nil {
+		errors["IncludeDeleted"] = "include_deleted must be either 'true' or 'false'."
+	}
+	return includeDeleted
+}
+
 // parseValidationErrors converts validation errors into custom error messages
 func (p *ProductListQueryParams) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
 	errors := make(map[string]string)
@@ -63,6 +104,9 @@ func (p *ProductListQueryParams) getCustomErrorMessage(fieldWithTag string) stri
 	customMessages := map[string]string{
 		"SortBy.oneof":        "sortBy must be either 'name' or 'sku'.",
 		"SortDirection.oneof": "sortDirection must be either 'asc' or 'desc'.",
+		"Limit.min":           "limit must be a number between 1 and 100.",
+		"Limit.max":           "limit must be a number between 1 and 100.",
+		"Offset.min":          "offset must be a number greater than or equal to 0.",
 	}
 
 	if message, exists := customMessages[fieldWithTag]; exists {
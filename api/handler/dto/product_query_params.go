@@ -0,0 +1,20 @@
+package dto
+
+import "net/url"
+
+// ProductQueryParams defines the query parameters for retrieving a single product
+type ProductQueryParams struct {
+	IncludeDeleted bool `json:"include_deleted"` // Finds soft-deleted products as well
+}
+
+// Validate reads the query parameters and returns custom error messages
+func (p *ProductQueryParams) Validate(queryParams url.Values) map[string]string {
+	errors := make(map[string]string)
+
+	p.IncludeDeleted = parseIncludeDeleted(queryParams, errors)
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
@@ -6,7 +6,11 @@ import (
 
 // CreateProductRequest represents the request body for creating a product
 type CreateProductRequest struct {
-	Name string `json:"name" validate:"required,min=2,max=255"`
+	Name       string `json:"name" validate:"required,min=2,max=255"`
+	SKU        string `json:"sku" validate:"omitempty,max=100"`       // Manufacturer or legacy SKU, omitted generates one
+	Serialized bool   `json:"serialized"`                             // Tracks every unit by its serial number
+	StockUnit  string `json:"stock_unit" validate:"omitempty,max=20"` // Unit code stock is kept in, defaults to EA
+	CategoryID uint   `json:"category_id"`                            // Category the product is filed under, omitted leaves it uncategorised
 }
 
 // Validate performs JSON decoding and validation on CreateProductRequest and returns custom error messages if validation fails.
@@ -40,6 +44,52 @@ func (r *CreateProductRequest) getCustomErrorMessage(fieldWithTag string) string
 		"Name.required": "Product name is required.",
 		"Name.min":      "Product name must be at least 2 characters long.",
 		"Name.max":      "Product name must be less than 255 characters long.",
+		"SKU.max":       "SKU must be at most 100 characters long.",
+		"StockUnit.max": "Stock unit must be less than 20 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
+
+// AddProductBarcodeRequest represents the request body for assigning a barcode to a product
+type AddProductBarcodeRequest struct {
+	Code string `json:"code" validate:"required,numeric,max=14"` // EAN-8, UPC-A, EAN-13 or GTIN-14 digits
+}
+
+// Validate performs validation on AddProductBarcodeRequest and returns custom error messages if validation fails.
+func (r *AddProductBarcodeRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *AddProductBarcodeRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = r.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *AddProductBarcodeRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Code.required": "Barcode is required.",
+		"Code.numeric":  "Barcode may only contain digits.",
+		"Code.max":      "Barcode must be at most 14 digits long.",
 	}
 
 	if message, exists := customMessages[fieldWithTag]; exists {
@@ -4,9 +4,55 @@ import "time"
 
 // ProductResponse represents the response body for a product
 type ProductResponse struct {
-	ID        uint      `json:"id"`
-	Name      string    `json:"name"`
-	SKU       string    `json:"sku"`
+	ID          uint               `json:"id"`
+	Name        string             `json:"name"`
+	SKU         string             `json:"sku"`
+	Description string             `json:"description"`
+	Brand       string             `json:"brand"`
+	Status      string             `json:"status"`
+	WeightGrams int64              `json:"weight_grams"` // Zero while the weight is unknown
+	Dimensions  DimensionsResponse `json:"dimensions"`
+	Tags        []string           `json:"tags"`
+	Attributes  map[string]string  `json:"attributes"`
+	Serialized  bool               `json:"serialized"`
+	StockUnit   string             `json:"stock_unit"`
+	CategoryID  *uint              `json:"category_id"` // Null while the product is uncategorised
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	DeletedAt   *time.Time         `json:"deleted_at,omitempty"` // Set while the product is soft-deleted
+	Version     int64              `json:"version"`              // Sent back in If-Match when updating the product
+
+	ParentID *uint                    `json:"parent_id,omitempty"` // Set when the product is a variant
+	Options  []*VariantOptionResponse `json:"options,omitempty"`   // Option values of a variant
+	Variants []*ProductResponse       `json:"variants,omitempty"`  // Variants the product comes in
+
+	SKUAliases []*SKUAliasResponse `json:"sku_aliases,omitempty"` // Former SKUs that still find the product
+	Barcodes   []*BarcodeResponse  `json:"barcodes,omitempty"`    // Barcodes printed on the product
+}
+
+// DimensionsResponse represents the packed size of a product in millimetres. Zero sides are unknown.
+type DimensionsResponse struct {
+	LengthMM int64 `json:"length_mm"`
+	WidthMM  int64 `json:"width_mm"`
+	HeightMM int64 `json:"height_mm"`
+}
+
+// BarcodeResponse represents a barcode of a product
+type BarcodeResponse struct {
+	Code      string    `json:"code"`
+	Type      string    `json:"type"`
+	GTIN      string    `json:"gtin"` // Code padded to 14 digits
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SKUAliasResponse represents a former SKU of a product
+type SKUAliasResponse struct {
+	SKU       string    `json:"sku"`
+	RetiredAt time.Time `json:"retired_at"`
+}
+
+// VariantOptionResponse represents the value a variant takes on one option axis
+type VariantOptionResponse struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
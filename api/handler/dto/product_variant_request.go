@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// OptionAxisRequest represents one dimension a product comes in, like size, with its values
+type OptionAxisRequest struct {
+	Name   string   `json:"name" validate:"required,max=50"`
+	Values []string `json:"values" validate:"required,min=1,max=50,dive,required,max=50"`
+}
+
+// GenerateVariantsRequest represents the request body for generating the variants of a product
+type GenerateVariantsRequest struct {
+	Options []OptionAxisRequest `json:"options" validate:"required,min=1,max=5,dive"`
+}
+
+// Validate performs validation on GenerateVariantsRequest and returns custom error messages if validation fails.
+func (r *GenerateVariantsRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *GenerateVariantsRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := fieldName(err) + "." + err.Tag()
+		errors[fieldName(err)] = r.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *GenerateVariantsRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Options.required": "At least one option axis is required.",
+		"Options.min":      "At least one option axis is required.",
+		"Options.max":      "A product can vary in at most 5 option axes.",
+		"Name.required":    "Option name is required.",
+		"Name.max":         "Option name must be less than 50 characters long.",
+		"Values.required":  "Every option axis requires at least one value.",
+		"Values.min":       "Every option axis requires at least one value.",
+		"Values.max":       "An option axis can have at most 50 values.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// PurchaseOrderLineRequest is the ordered quantity and unit cost of one product
+type PurchaseOrderLineRequest struct {
+	ProductID uint   `json:"product_id" validate:"required"`
+	Quantity  int64  `json:"quantity" validate:"required,min=1"`
+	Unit      string `json:"unit" validate:"max=20"`     // Defaults to the stock unit of the product
+	UnitCost  int64  `json:"unit_cost" validate:"min=0"` // In minor currency units per stock unit, e.g. cents
+}
+
+// CreatePurchaseOrderRequest represents the request body for drafting a purchase order
+type CreatePurchaseOrderRequest struct {
+	SupplierID  uint                       `json:"supplier_id" validate:"required"`
+	WarehouseID uint                       `json:"warehouse_id" validate:"required"`
+	Reference   string                     `json:"reference" validate:"max=100"`
+	Currency    string                     `json:"currency" validate:"required,len=3,alpha"`
+	Lines       []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// GoodsReceiptLineRequest is the quantity of one product delivered against a purchase order
+type GoodsReceiptLineRequest struct {
+	ProductID uint     `json:"product_id" validate:"required"`
+	Quantity  int64    `json:"quantity" validate:"required,min=1"`
+	Unit      string   `json:"unit" validate:"max=20"`                             // Defaults to the stock unit of the product
+	Serials   []string `json:"serials" validate:"omitempty,dive,required,max=100"` // Required for serialized products
+}
+
+// ReceivePurchaseOrderRequest represents the request body for receiving goods.
+// Leaving out the lines receives everything outstanding.
+type ReceivePurchaseOrderRequest struct {
+	Lines []GoodsReceiptLineRequest `json:"lines" validate:"dive"`
+}
+
+// Validate performs validation on CreatePurchaseOrderRequest and returns custom error messages if validation fails.
+func (r *CreatePurchaseOrderRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parsePurchaseOrderValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// Validate performs validation on ReceivePurchaseOrderRequest and returns custom error messages if validation fails.
+func (r *ReceivePurchaseOrderRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parsePurchaseOrderValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parsePurchaseOrderValidationErrors converts the validation errors into a map of custom error messages.
+func parsePurchaseOrderValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		field := fieldName(err)
+		fieldWithTag := field + "." + err.Tag()
+		errors[field] = getPurchaseOrderCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getPurchaseOrderCustomErrorMessage returns custom error messages for validation rules.
+func getPurchaseOrderCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"SupplierID.required":  "Supplier ID is required.",
+		"WarehouseID.required": "Warehouse ID is required.",
+		"Reference.max":        "Reference must be less than 100 characters long.",
+		"Currency.required":    "Currency is required.",
+		"Currency.len":         "Currency must be a three-letter ISO 4217 code.",
+		"Currency.alpha":       "Currency must be a three-letter ISO 4217 code.",
+		"Lines.required":       "At least one line is required.",
+		"Lines.min":            "At least one line is required.",
+		"ProductID.required":   "Product ID is required on every line.",
+		"Quantity.required":    "Quantity is required on every line.",
+		"Quantity.min":         "Quantity must be at least 1.",
+		"UnitCost.min":         "Unit cost cannot be negative.",
+		"Unit.max":             "Unit must be less than 20 characters long.",
+		"Serials.required":     "Serial numbers cannot be empty.",
+		"Serials.max":          "Serial numbers must be less than 100 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// PurchaseOrderLineResponse represents one product on a purchase order
+type PurchaseOrderLineResponse struct {
+	ProductID        uint  `json:"product_id"`
+	Quantity         int64 `json:"quantity"`
+	ReceivedQuantity int64 `json:"received_quantity"`
+	Outstanding      int64 `json:"outstanding"`
+	UnitCost         int64 `json:"unit_cost"`
+	LineTotal        int64 `json:"line_total"`
+}
+
+// PurchaseOrderResponse represents the response body for a purchase order. Amounts are in minor currency units.
+type PurchaseOrderResponse struct {
+	ID          uint                         `json:"id"`
+	SupplierID  uint                         `json:"supplier_id"`
+	WarehouseID uint                         `json:"warehouse_id"`
+	Status      string                       `json:"status"`
+	Reference   string                       `json:"reference"`
+	Currency    string                       `json:"currency"`
+	Total       int64                        `json:"total"`
+	Lines       []*PurchaseOrderLineResponse `json:"lines"`
+	SubmittedAt *time.Time                   `json:"submitted_at"`
+	ClosedAt    *time.Time                   `json:"closed_at"`
+	CreatedAt   time.Time                    `json:"created_at"`
+	UpdatedAt   time.Time                    `json:"updated_at"`
+}
+
+// PurchaseOrderListResponse represents the response body for a paginated list of purchase orders
+type PurchaseOrderListResponse struct {
+	PurchaseOrders []*PurchaseOrderResponse `json:"purchase_orders"`
+	PaginationResponse
+}
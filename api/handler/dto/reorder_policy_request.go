@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// SetReorderPolicyRequest represents the request body for setting the reorder policy of a product in a warehouse
+type SetReorderPolicyRequest struct {
+	ProductID           uint  `json:"product_id" validate:"required"`
+	WarehouseID         uint  `json:"warehouse_id" validate:"required"`
+	ReorderPoint        int64 `json:"reorder_point" validate:"min=0"`
+	ReorderQuantity     int64 `json:"reorder_quantity" validate:"required,min=1"`
+	SafetyStock         int64 `json:"safety_stock" validate:"min=0,ltefield=ReorderPoint"`
+	PreferredSupplierID uint  `json:"preferred_supplier_id"` // Optional, enables drafting purchase orders for alerts
+}
+
+// Validate performs validation on SetReorderPolicyRequest and returns custom error messages if validation fails.
+func (r *SetReorderPolicyRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *SetReorderPolicyRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = r.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *SetReorderPolicyRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"ProductID.required":       "Product ID is required.",
+		"WarehouseID.required":     "Warehouse ID is required.",
+		"ReorderPoint.min":         "Reorder point cannot be negative.",
+		"ReorderQuantity.required": "Reorder quantity is required.",
+		"ReorderQuantity.min":      "Reorder quantity must be at least 1.",
+		"SafetyStock.min":          "Safety stock cannot be negative.",
+		"SafetyStock.ltefield":     "Safety stock cannot exceed the reorder point.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// ReorderPolicyResponse represents the response body for a reorder policy
+type ReorderPolicyResponse struct {
+	ID                  uint      `json:"id"`
+	ProductID           uint      `json:"product_id"`
+	WarehouseID         uint      `json:"warehouse_id"`
+	ReorderPoint        int64     `json:"reorder_point"`
+	ReorderQuantity     int64     `json:"reorder_quantity"`
+	SafetyStock         int64     `json:"safety_stock"`
+	PreferredSupplierID *uint     `json:"preferred_supplier_id"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// LowStockAlertResponse represents the response body for a low-stock alert
+type LowStockAlertResponse struct {
+	ID                uint       `json:"id"`
+	ProductID         uint       `json:"product_id"`
+	WarehouseID       uint       `json:"warehouse_id"`
+	Available         int64      `json:"available"`
+	ReorderPoint      int64      `json:"reorder_point"`
+	SafetyStock       int64      `json:"safety_stock"`
+	BelowSafetyStock  bool       `json:"below_safety_stock"`
+	SuggestedQuantity int64      `json:"suggested_quantity"`
+	PurchaseOrderID   *uint      `json:"purchase_order_id"`
+	Status            string     `json:"status"`
+	RaisedAt          time.Time  `json:"raised_at"`
+	ResolvedAt        *time.Time `json:"resolved_at"`
+}
+
+// LowStockAlertListResponse represents the response body for a paginated list of low-stock alerts
+type LowStockAlertListResponse struct {
+	LowStockAlerts []*LowStockAlertResponse `json:"low_stock_alerts"`
+	PaginationResponse
+}
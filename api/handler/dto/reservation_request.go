@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// CreateReservationRequest represents the request body for reserving stock
+type CreateReservationRequest struct {
+	ProductID   uint   `json:"product_id" validate:"required"`
+	WarehouseID uint   `json:"warehouse_id" validate:"required"`
+	Quantity    int64  `json:"quantity" validate:"required,min=1"`
+	Reference   string `json:"reference" validate:"max=100"`
+	TTLSeconds  int    `json:"ttl_seconds" validate:"min=0,max=86400"`
+}
+
+// ConfirmReservationRequest represents the request body for confirming a reservation.
+// Only reservations of serialized products need to name the issued units.
+type ConfirmReservationRequest struct {
+	Serials []string `json:"serials" validate:"dive,required,max=100"`
+}
+
+// Validate performs validation on CreateReservationRequest and returns custom error messages if validation fails.
+func (r *CreateReservationRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *CreateReservationRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = r.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *CreateReservationRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"ProductID.required":   "Product ID is required.",
+		"WarehouseID.required": "Warehouse ID is required.",
+		"Quantity.required":    "Quantity is required.",
+		"Quantity.min":         "Quantity must be at least 1.",
+		"Reference.max":        "Reference must be less than 100 characters long.",
+		"TTLSeconds.min":       "ttl_seconds must be between 0 and 86400.",
+		"TTLSeconds.max":       "ttl_seconds must be between 0 and 86400.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
+
+// Validate performs validation on ConfirmReservationRequest and returns custom error messages if validation fails.
+func (r *ConfirmReservationRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		errors := make(map[string]string)
+		for _, err := range err.(validator.ValidationErrors) {
+			field := fieldName(err)
+			errors[field] = r.getCustomErrorMessage(field + "." + err.Tag())
+		}
+		return errors
+	}
+
+	return nil
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *ConfirmReservationRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Serials.required": "Serial numbers cannot be empty.",
+		"Serials.max":      "Serial numbers must be less than 100 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
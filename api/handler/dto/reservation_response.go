@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// ReservationResponse represents the response body for a reservation
+type ReservationResponse struct {
+	ID          uint      `json:"id"`
+	ProductID   uint      `json:"product_id"`
+	WarehouseID uint      `json:"warehouse_id"`
+	Quantity    int64     `json:"quantity"`
+	Status      string    `json:"status"`
+	Reference   string    `json:"reference"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
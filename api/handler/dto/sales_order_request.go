@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// SalesOrderLineRequest is the ordered quantity of one product
+type SalesOrderLineRequest struct {
+	ProductID uint   `json:"product_id" validate:"required"`
+	Quantity  int64  `json:"quantity" validate:"required,min=1"`
+	Unit      string `json:"unit" validate:"max=20"` // Defaults to the stock unit of the product
+}
+
+// CreateSalesOrderRequest represents the request body for placing a sales order
+type CreateSalesOrderRequest struct {
+	WarehouseID       uint                    `json:"warehouse_id" validate:"required"`
+	CustomerReference string                  `json:"customer_reference" validate:"max=100"`
+	Lines             []SalesOrderLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// ShipmentLineRequest names the units of one serialized product that leave with a shipment
+type ShipmentLineRequest struct {
+	ProductID uint     `json:"product_id" validate:"required"`
+	Serials   []string `json:"serials" validate:"required,min=1,dive,required,max=100"`
+}
+
+// ShipSalesOrderRequest represents the request body for shipping a sales order.
+// Only orders with serialized products need to name the shipped units.
+type ShipSalesOrderRequest struct {
+	Lines []ShipmentLineRequest `json:"lines" validate:"dive"`
+}
+
+// Validate performs validation on CreateSalesOrderRequest and returns custom error messages if validation fails.
+func (r *CreateSalesOrderRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *CreateSalesOrderRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = r.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *CreateSalesOrderRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"WarehouseID.required":  "Warehouse ID is required.",
+		"CustomerReference.max": "Customer reference must be less than 100 characters long.",
+		"Lines.required":        "At least one line is required.",
+		"Lines.min":             "At least one line is required.",
+		"ProductID.required":    "Product ID is required on every line.",
+		"Quantity.required":     "Quantity is required on every line.",
+		"Quantity.min":          "Quantity must be at least 1.",
+		"Unit.max":              "Unit must be less than 20 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
+
+// Validate performs validation on ShipSalesOrderRequest and returns custom error messages if validation fails.
+func (r *ShipSalesOrderRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		errors := make(map[string]string)
+		for _, err := range err.(validator.ValidationErrors) {
+			field := fieldName(err)
+			errors[field] = r.getCustomErrorMessage(field + "." + err.Tag())
+		}
+		return errors
+	}
+
+	return nil
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *ShipSalesOrderRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"ProductID.required": "Product ID is required on every line.",
+		"Serials.required":   "Serial numbers are required on every line and cannot be empty.",
+		"Serials.min":        "Serial numbers are required on every line.",
+		"Serials.max":        "Serial numbers must be less than 100 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
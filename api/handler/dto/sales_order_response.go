@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// SalesOrderLineResponse represents one product on a sales order
+type SalesOrderLineResponse struct {
+	ProductID         uint  `json:"product_id"`
+	Quantity          int64 `json:"quantity"`
+	AllocatedQuantity int64 `json:"allocated_quantity"`
+	Backordered       int64 `json:"backordered"`
+}
+
+// SalesOrderResponse represents the response body for a sales order
+type SalesOrderResponse struct {
+	ID                uint                      `json:"id"`
+	WarehouseID       uint                      `json:"warehouse_id"`
+	CustomerReference string                    `json:"customer_reference"`
+	Status            string                    `json:"status"`
+	Backordered       int64                     `json:"backordered"`
+	Lines             []*SalesOrderLineResponse `json:"lines"`
+	ShippedAt         *time.Time                `json:"shipped_at"`
+	CreatedAt         time.Time                 `json:"created_at"`
+	UpdatedAt         time.Time                 `json:"updated_at"`
+}
+
+// SalesOrderListResponse represents the response body for a paginated list of sales orders
+type SalesOrderListResponse struct {
+	SalesOrders []*SalesOrderResponse `json:"sales_orders"`
+	PaginationResponse
+}
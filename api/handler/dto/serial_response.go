@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// SerialResponse represents the response body for a serialized unit with its movement history
+type SerialResponse struct {
+	ID           uint                     `json:"id"`
+	ProductID    uint                     `json:"product_id"`
+	SerialNumber string                   `json:"serial_number"`
+	Status       string                   `json:"status"`
+	WarehouseID  *uint                    `json:"warehouse_id"` // Null while the unit is in transit or issued
+	History      []*StockMovementResponse `json:"history"`
+	CreatedAt    time.Time                `json:"created_at"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// StockLevelResponse represents the quantity of one product in one warehouse. Quantities are fractional
+// when a requested unit is larger than the stock unit, e.g. 30 each are 1.25 cases of 24.
+type StockLevelResponse struct {
+	ProductID   uint      `json:"product_id"`
+	WarehouseID uint      `json:"warehouse_id"`
+	OnHand      float64   `json:"on_hand"`
+	Reserved    float64   `json:"reserved"`
+	Available   float64   `json:"available"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// StockTotalsResponse represents quantities summed over a set of stock levels
+type StockTotalsResponse struct {
+	OnHand    float64 `json:"on_hand"`
+	Reserved  float64 `json:"reserved"`
+	Available float64 `json:"available"`
+}
+
+// ProductStockResponse represents the stock of a product across warehouses
+type ProductStockResponse struct {
+	ProductID   uint                  `json:"product_id"`
+	Unit        string                `json:"unit"`
+	Totals      StockTotalsResponse   `json:"totals"`
+	StockLevels []*StockLevelResponse `json:"stock_levels"`
+}
+
+// WarehouseStockResponse represents the stock of every product held in a warehouse
+type WarehouseStockResponse struct {
+	WarehouseID uint                  `json:"warehouse_id"`
+	Unit        string                `json:"unit,omitempty"` // Only set when a unit was requested
+	Totals      StockTotalsResponse   `json:"totals"`
+	StockLevels []*StockLevelResponse `json:"stock_levels"`
+}
@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// StockMovementListQueryParams defines the query parameters for listing the movements of a product
+type StockMovementListQueryParams struct {
+	WarehouseID uint       `json:"warehouse_id"`
+	Type        string     `json:"type" validate:"omitempty,oneof=receipt issue adjustment transfer_in transfer_out"`
+	From        *time.Time `json:"from"`
+	To          *time.Time `json:"to"`
+	Limit       int        `json:"limit" validate:"min=1,max=100"`
+	Offset      int        `json:"offset" validate:"min=0"`
+}
+
+// Validate performs validation on the query parameters and returns custom error messages
+func (p *StockMovementListQueryParams) Validate(queryParams url.Values) map[string]string {
+	errors := make(map[string]string)
+
+	if warehouseID := queryParams.Get("warehouse_id"); warehouseID != "" {
+		id, err := strconv.ParseUint(warehouseID, 10, 32)
+		if err != nil || id == 0 {
+			errors["WarehouseID"] = "warehouse_id must be a positive number."
+		}
+		p.WarehouseID = uint(id)
+	}
+
+	p.Type = queryParams.Get("type")
+	p.From = parseTimeParam(queryParams, "from", "From", errors)
+	p.To = parseTimeParam(queryParams, "to", "To", errors)
+
+	// If limit or offset are not provided, set default values
+	p.Limit = 50
+	if limit := queryParams.Get("limit"); limit != "" {
+		p.Limit, _ = strconv.Atoi(limit)
+	}
+
+	p.Offset = 0
+	if offset := queryParams.Get("offset"); offset != "" {
+		var err error
+		if p.Offset, err = strconv.Atoi(offset); err != nil {
+			p.Offset = -1
+		}
+	}
+
+	// Perform validation using the validator package
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		for field, message := range p.parseValidationErrors(err.(validator.ValidationErrors)) {
+			errors[field] = message
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// parseTimeParam parses an optional RFC 3339 query parameter, recording an error message when it is malformed
+func parseTimeParam(queryParams url.Values, key string, field string, errors map[string]string) *time.Time {
+	value := queryParams.Get(key)
+	if value == "" {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		errors[field] = key + " must be an RFC 3339 timestamp."
+		return nil
+	}
+	return &parsed
+}
+
+// parseValidationErrors converts validation errors into custom error messages
+func (p *StockMovementListQueryParams) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = p.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages based on the field and tag
+func (p *StockMovementListQueryParams) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Type.oneof": "type must be one of 'receipt', 'issue', 'adjustment', 'transfer_in' or 'transfer_out'.",
+		"Limit.min":  "limit must be a number between 1 and 100.",
+		"Limit.max":  "limit must be a number between 1 and 100.",
+		"Offset.min": "offset must be a number greater than or equal to 0.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+
+	return "Invalid field"
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// CreateStockMovementRequest represents the request body for recording a stock movement
+type CreateStockMovementRequest struct {
+	ProductID   uint       `json:"product_id" validate:"required"`
+	WarehouseID uint       `json:"warehouse_id" validate:"required"`
+	Type        string     `json:"type" validate:"required,oneof=receipt issue adjustment transfer_in transfer_out"`
+	Quantity    int64      `json:"quantity" validate:"required"`
+	Unit        string     `json:"unit" validate:"max=20"` // Defaults to the stock unit of the product
+	ReasonCode  string     `json:"reason_code" validate:"required,max=50"`
+	Reference   string     `json:"reference" validate:"max=100"`
+	OccurredAt  *time.Time `json:"occurred_at"`
+
+	// Lot tracking, for perishables. Expiry is required the first time a lot is received.
+	LotNumber      string     `json:"lot_number" validate:"max=100"`
+	ManufacturedAt *time.Time `json:"manufactured_at" validate:"omitempty,excluded_without=LotNumber"`
+	ExpiresAt      *time.Time `json:"expires_at" validate:"omitempty,excluded_without=LotNumber"`
+
+	// Serial numbers of every unit that moves, required for serialized products
+	Serials []string `json:"serials" validate:"omitempty,dive,required,max=100"`
+}
+
+// Validate performs validation on CreateStockMovementRequest and returns custom error messages if validation fails.
+func (r *CreateStockMovementRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *CreateStockMovementRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		field := fieldName(err)
+		fieldWithTag := field + "." + err.Tag()
+		errors[field] = r.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *CreateStockMovementRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"ProductID.required":   "Product ID is required.",
+		"WarehouseID.required": "Warehouse ID is required.",
+		"Type.required":        "Movement type is required.",
+		"Type.oneof":           "Movement type must be one of 'receipt', 'issue', 'adjustment', 'transfer_in' or 'transfer_out'.",
+		"Quantity.required":    "Quantity is required and cannot be zero.",
+		"Unit.max":             "Unit must be less than 20 characters long.",
+		"ReasonCode.required":  "Reason code is required.",
+		"ReasonCode.max":       "Reason code must be less than 50 characters long.",
+		"Reference.max":        "Reference must be less than 100 characters long.",
+		"LotNumber.max":        "Lot number must be less than 100 characters long.",
+		"Serials.required":     "Serial numbers cannot be empty.",
+		"Serials.max":          "Serial numbers must be less than 100 characters long.",
+
+		"ManufacturedAt.excluded_without": "Manufacture date requires a lot number.",
+		"ExpiresAt.excluded_without":      "Expiry date requires a lot number.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// StockMovementResponse represents the response body for a ledger movement
+type StockMovementResponse struct {
+	ID           uint      `json:"id"`
+	ProductID    uint      `json:"product_id"`
+	WarehouseID  uint      `json:"warehouse_id"`
+	Type         string    `json:"type"`
+	Quantity     int64     `json:"quantity"`
+	BalanceAfter int64     `json:"balance_after"`
+	ReasonCode   string    `json:"reason_code"`
+	Reference    string    `json:"reference"`
+	Actor        string    `json:"actor"`
+	OccurredAt   time.Time `json:"occurred_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// StockMovementListResponse represents the response body for a paginated movement history
+type StockMovementListResponse struct {
+	Movements []*StockMovementResponse `json:"movements"`
+	PaginationResponse
+}
+
+// StockReconciliationResponse compares the stored on-hand quantity of a warehouse with its ledger
+type StockReconciliationResponse struct {
+	WarehouseID   uint  `json:"warehouse_id"`
+	OnHand        int64 `json:"on_hand"`
+	LedgerBalance int64 `json:"ledger_balance"`
+	Discrepancy   int64 `json:"discrepancy"`
+	InSync        bool  `json:"in_sync"`
+}
+
+// ProductStockReconciliationResponse represents the reconciliation of a product across warehouses
+type ProductStockReconciliationResponse struct {
+	ProductID       uint                           `json:"product_id"`
+	InSync          bool                           `json:"in_sync"`
+	Reconciliations []*StockReconciliationResponse `json:"reconciliations"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// CreateSupplierRequest represents the request body for creating a supplier
+type CreateSupplierRequest struct {
+	Code         string `json:"code" validate:"required,min=2,max=50,alphanum"`
+	Name         string `json:"name" validate:"required,min=2,max=255"`
+	ContactEmail string `json:"contact_email" validate:"omitempty,email,max=255"`
+}
+
+// Validate performs validation on CreateSupplierRequest and returns custom error messages if validation fails.
+func (r *CreateSupplierRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *CreateSupplierRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = r.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *CreateSupplierRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Code.required":      "Supplier code is required.",
+		"Code.min":           "Supplier code must be at least 2 characters long.",
+		"Code.max":           "Supplier code must be less than 50 characters long.",
+		"Code.alphanum":      "Supplier code may only contain letters and digits.",
+		"Name.required":      "Supplier name is required.",
+		"Name.min":           "Supplier name must be at least 2 characters long.",
+		"Name.max":           "Supplier name must be less than 255 characters long.",
+		"ContactEmail.email": "Contact email must be a valid email address.",
+		"ContactEmail.max":   "Contact email must be less than 255 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// SupplierResponse represents the response body for a supplier
+type SupplierResponse struct {
+	ID           uint      `json:"id"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	ContactEmail string    `json:"contact_email"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,139 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// TransferLineRequest is the quantity of one product on a transfer or receipt
+type TransferLineRequest struct {
+	ProductID uint  `json:"product_id" validate:"required"`
+	Quantity  int64 `json:"quantity" validate:"required,min=1"`
+}
+
+// CreateTransferRequest represents the request body for drafting a transfer
+type CreateTransferRequest struct {
+	SourceWarehouseID      uint                  `json:"source_warehouse_id" validate:"required"`
+	DestinationWarehouseID uint                  `json:"destination_warehouse_id" validate:"required,nefield=SourceWarehouseID"`
+	Reference              string                `json:"reference" validate:"max=100"`
+	Lines                  []TransferLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// TransferSerialsRequest names the units of one serialized product that leave with a dispatch
+type TransferSerialsRequest struct {
+	ProductID uint     `json:"product_id" validate:"required"`
+	Serials   []string `json:"serials" validate:"required,min=1,dive,required,max=100"`
+}
+
+// DispatchTransferRequest represents the request body for dispatching a transfer.
+// Only transfers of serialized products need to name the dispatched units.
+type DispatchTransferRequest struct {
+	Lines []TransferSerialsRequest `json:"lines" validate:"dive"`
+}
+
+// ReceiveTransferLineRequest is the received quantity of one product. Serialized products name the
+// units that arrived.
+type ReceiveTransferLineRequest struct {
+	ProductID uint     `json:"product_id" validate:"required"`
+	Quantity  int64    `json:"quantity" validate:"required,min=1"`
+	Serials   []string `json:"serials" validate:"dive,required,max=100"`
+}
+
+// ReceiveTransferRequest represents the request body for receiving a transfer.
+// Leaving out the lines receives everything still in transit.
+type ReceiveTransferRequest struct {
+	Lines []ReceiveTransferLineRequest `json:"lines" validate:"dive"`
+}
+
+// CloseTransferRequest represents the request body for closing a transfer, writing off what has not arrived
+type CloseTransferRequest struct {
+	ReasonCode string `json:"reason_code" validate:"required,max=50"`
+}
+
+// Validate performs validation on CreateTransferRequest and returns custom error messages if validation fails.
+func (r *CreateTransferRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parseTransferValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// Validate performs validation on DispatchTransferRequest and returns custom error messages if validation fails.
+func (r *DispatchTransferRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parseTransferValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// Validate performs validation on ReceiveTransferRequest and returns custom error messages if validation fails.
+func (r *ReceiveTransferRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parseTransferValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// Validate performs validation on CloseTransferRequest and returns custom error messages if validation fails.
+func (r *CloseTransferRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parseTransferValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseTransferValidationErrors converts the validation errors into a map of custom error messages.
+func parseTransferValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		field := fieldName(err)
+		errors[field] = getTransferCustomErrorMessage(field + "." + err.Tag())
+	}
+
+	return errors
+}
+
+// getTransferCustomErrorMessage returns custom error messages for validation rules.
+func getTransferCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"SourceWarehouseID.required":      "Source warehouse ID is required.",
+		"DestinationWarehouseID.required": "Destination warehouse ID is required.",
+		"DestinationWarehouseID.nefield":  "Destination warehouse must differ from the source warehouse.",
+		"Reference.max":                   "Reference must be less than 100 characters long.",
+		"Lines.required":                  "At least one line is required.",
+		"Lines.min":                       "At least one line is required.",
+		"ProductID.required":              "Product ID is required on every line.",
+		"Quantity.required":               "Quantity is required on every line.",
+		"Quantity.min":                    "Quantity must be at least 1.",
+		"Serials.required":                "Serial numbers are required on every line and cannot be empty.",
+		"Serials.min":                     "Serial numbers are required on every line.",
+		"Serials.max":                     "Serial numbers must be less than 100 characters long.",
+		"ReasonCode.required":             "Reason code is required.",
+		"ReasonCode.max":                  "Reason code must be less than 50 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// TransferLotResponse represents the part of a transfer line taken out of one lot
+type TransferLotResponse struct {
+	LotNumber        string     `json:"lot_number"`
+	ManufacturedAt   *time.Time `json:"manufactured_at"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	Quantity         int64      `json:"quantity"`
+	ReceivedQuantity int64      `json:"received_quantity"`
+}
+
+// TransferLineResponse represents one product on a transfer
+type TransferLineResponse struct {
+	ProductID        uint                   `json:"product_id"`
+	Quantity         int64                  `json:"quantity"`
+	ReceivedQuantity int64                  `json:"received_quantity"`
+	InTransit        int64                  `json:"in_transit"`
+	WrittenOff       int64                  `json:"written_off"`
+	Lots             []*TransferLotResponse `json:"lots"`
+}
+
+// TransferResponse represents the response body for a transfer
+type TransferResponse struct {
+	ID                     uint                    `json:"id"`
+	SourceWarehouseID      uint                    `json:"source_warehouse_id"`
+	DestinationWarehouseID uint                    `json:"destination_warehouse_id"`
+	Status                 string                  `json:"status"`
+	Reference              string                  `json:"reference"`
+	InTransit              int64                   `json:"in_transit"`
+	WrittenOff             int64                   `json:"written_off"`
+	Lines                  []*TransferLineResponse `json:"lines"`
+	DispatchedAt           *time.Time              `json:"dispatched_at"`
+	ReceivedAt             *time.Time              `json:"received_at"`
+	ClosedAt               *time.Time              `json:"closed_at"`
+	CloseReasonCode        string                  `json:"close_reason_code,omitempty"`
+	CreatedAt              time.Time               `json:"created_at"`
+	UpdatedAt              time.Time               `json:"updated_at"`
+}
+
+// TransferListResponse represents the response body for a paginated list of transfers
+type TransferListResponse struct {
+	Transfers []*TransferResponse `json:"transfers"`
+	PaginationResponse
+}
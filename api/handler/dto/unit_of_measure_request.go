@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// CreateUnitOfMeasureRequest represents the request body for adding a unit to the catalogue
+type CreateUnitOfMeasureRequest struct {
+	Code string `json:"code" validate:"required,max=20,alphanum"`
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// SetProductUnitRequest represents the request body for converting a unit to the stock unit of a product
+type SetProductUnitRequest struct {
+	UnitCode string `json:"unit_code" validate:"required,max=20"`
+	Factor   int64  `json:"factor" validate:"required,min=1"` // Stock units in one of the unit
+}
+
+// Validate performs validation on CreateUnitOfMeasureRequest and returns custom error messages if validation fails.
+func (r *CreateUnitOfMeasureRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parseUnitOfMeasureValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// Validate performs validation on SetProductUnitRequest and returns custom error messages if validation fails.
+func (r *SetProductUnitRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return parseUnitOfMeasureValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseUnitOfMeasureValidationErrors converts the validation errors into a map of custom error messages.
+func parseUnitOfMeasureValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = getUnitOfMeasureCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getUnitOfMeasureCustomErrorMessage returns custom error messages for validation rules.
+func getUnitOfMeasureCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Code.required":     "Unit code is required.",
+		"Code.max":          "Unit code must be less than 20 characters long.",
+		"Code.alphanum":     "Unit code may only contain letters and digits.",
+		"Name.required":     "Unit name is required.",
+		"Name.max":          "Unit name must be less than 100 characters long.",
+		"UnitCode.required": "Unit code is required.",
+		"UnitCode.max":      "Unit code must be less than 20 characters long.",
+		"Factor.required":   "Conversion factor is required.",
+		"Factor.min":        "Conversion factor must be at least 1.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
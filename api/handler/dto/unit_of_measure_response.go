@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// UnitOfMeasureResponse represents the response body for a unit of the catalogue
+type UnitOfMeasureResponse struct {
+	ID        uint      `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProductUnitResponse represents how many stock units of a product one of a unit holds
+type ProductUnitResponse struct {
+	UnitCode  string    `json:"unit_code"`
+	Factor    int64     `json:"factor"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProductUnitsResponse represents the stock unit of a product and its conversions
+type ProductUnitsResponse struct {
+	ProductID   uint                   `json:"product_id"`
+	StockUnit   string                 `json:"stock_unit"`
+	Conversions []*ProductUnitResponse `json:"conversions"`
+}
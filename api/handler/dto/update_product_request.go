@@ -1,6 +1,12 @@
 package dto
 
 import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -47,3 +53,276 @@ func (r *UpdateProductRequest) getCustomErrorMessage(fieldWithTag string) string
 	}
 	return "Invalid field"
 }
+
+// ChangeProductSKURequest represents the request body for reassigning the SKU of a product
+type ChangeProductSKURequest struct {
+	SKU string `json:"sku" validate:"required,max=100"`
+}
+
+// Validate performs validation on ChangeProductSKURequest and returns custom error messages if validation fails.
+func (r *ChangeProductSKURequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *ChangeProductSKURequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = r.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *ChangeProductSKURequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"SKU.required": "SKU is required.",
+		"SKU.max":      "SKU must be at most 100 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
+
+// Limits on the tags and custom attributes of a patch
+const (
+	maxPatchTags                 = 50
+	maxPatchTagLength            = 50
+	maxPatchAttributes           = 50
+	maxPatchAttributeValueLength = 255
+)
+
+// attributeNamePattern matches names of custom attributes: a letter followed by up to 49 letters,
+// digits, '_' or '-'
+var attributeNamePattern = regexp.MustCompile(`^\pL[\pL\pN_-]{0,49}$`)
+
+// patchProductMembers lists the members a product patch may contain
+var patchProductMembers = map[string]bool{
+	"name": true, "description": true, "brand": true, "status": true,
+	"weight_grams": true, "dimensions": true, "tags": true, "attributes": true,
+}
+
+// PatchProductRequest represents a JSON Merge Patch (RFC 7396) of a product. Members left out of the
+// body are not changed and null removes an attribute; nil fields are the ones left out. Null
+// description, brand, weight, dimensions and tags are decoded as their empty values.
+type PatchProductRequest struct {
+	Name        *string                 `json:"name" validate:"omitempty,min=2,max=255"`
+	Description *string                 `json:"description" validate:"omitempty,max=5000"`
+	Brand       *string                 `json:"brand" validate:"omitempty,max=100"`
+	Status      *string                 `json:"status" validate:"omitempty,oneof=draft active discontinued archived"`
+	WeightGrams *int64                  `json:"weight_grams" validate:"omitempty,min=0"`
+	Dimensions  *PatchDimensionsRequest `json:"dimensions"`
+	Tags        *[]string               `json:"tags"`
+	Attributes  map[string]*string      `json:"attributes"` // Null values remove the attribute
+
+	ClearAttributes bool            `json:"-"` // Set by a null attributes member
+	members         map[string]bool // Members present in the body, null ones included
+}
+
+// PatchDimensionsRequest represents a merge patch of the packed size of a product in millimetres.
+// Null sides are decoded as zero, which marks them as unknown.
+type PatchDimensionsRequest struct {
+	LengthMM *int64 `json:"length_mm" validate:"omitempty,min=0"`
+	WidthMM  *int64 `json:"width_mm" validate:"omitempty,min=0"`
+	HeightMM *int64 `json:"height_mm" validate:"omitempty,min=0"`
+}
+
+// errPatchNotObject is returned when a merge patch is not a JSON object, which would replace the
+// whole product
+var errPatchNotObject = errors.New("merge patch must be a JSON object")
+
+// UnmarshalJSON decodes a product patch and records which members it contains
+func (r *PatchProductRequest) UnmarshalJSON(data []byte) error {
+	members, err := decodeMergePatch(data)
+	if err != nil {
+		return err
+	}
+
+	type plain PatchProductRequest // Decodes the fields without recursing into UnmarshalJSON
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*r = PatchProductRequest(decoded)
+	r.members = members
+
+	empty := ""
+	var zero int64
+	if members["description"] && r.Description == nil {
+		r.Description = &empty
+	}
+	if members["brand"] && r.Brand == nil {
+		r.Brand = &empty
+	}
+	if members["weight_grams"] && r.WeightGrams == nil {
+		r.WeightGrams = &zero
+	}
+	if members["dimensions"] && r.Dimensions == nil {
+		r.Dimensions = &PatchDimensionsRequest{LengthMM: &zero, WidthMM: &zero, HeightMM: &zero}
+	}
+	if members["tags"] && r.Tags == nil {
+		r.Tags = &[]string{}
+	}
+	r.ClearAttributes = members["attributes"] && r.Attributes == nil
+	return nil
+}
+
+// UnmarshalJSON decodes a dimensions patch, turning null sides into zero
+func (r *PatchDimensionsRequest) UnmarshalJSON(data []byte) error {
+	members, err := decodeMergePatch(data)
+	if err != nil {
+		return err
+	}
+
+	type plain PatchDimensionsRequest // Decodes the fields without recursing into UnmarshalJSON
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*r = PatchDimensionsRequest(decoded)
+
+	var zero int64
+	for member, side := range map[string]**int64{"length_mm": &r.LengthMM, "width_mm": &r.WidthMM, "height_mm": &r.HeightMM} {
+		if members[member] && *side == nil {
+			*side = &zero
+		}
+	}
+	return nil
+}
+
+// decodeMergePatch returns the members of a merge patch object
+func decodeMergePatch(data []byte) (map[string]bool, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, errPatchNotObject
+	}
+	members := make(map[string]bool, len(raw))
+	for member := range raw {
+		members[member] = true
+	}
+	return members, nil
+}
+
+// Validate performs validation on PatchProductRequest and returns custom error messages if validation fails.
+func (r *PatchProductRequest) Validate() map[string]string {
+	errors := make(map[string]string)
+
+	// Create a new validator instance
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		errors = r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	unknown := make([]string, 0)
+	for member := range r.members {
+		if !patchProductMembers[member] {
+			unknown = append(unknown, member)
+		}
+	}
+	sort.Strings(unknown)
+	for _, member := range unknown {
+		errors[member] = "Unknown field."
+	}
+
+	if r.members["name"] && r.Name == nil {
+		errors["Name"] = "Product name cannot be removed."
+	}
+	if r.members["status"] && r.Status == nil {
+		errors["Status"] = "Status cannot be removed."
+	}
+	if message := validatePatchTags(r.Tags); message != "" {
+		errors["Tags"] = message
+	}
+	if message := validatePatchAttributes(r.Attributes); message != "" {
+		errors["Attributes"] = message
+	}
+
+	if len(errors) == 0 {
+		return nil
+	}
+	return errors
+}
+
+// validatePatchTags returns the message for invalid tags, or an empty string when they are valid
+func validatePatchTags(tags *[]string) string {
+	if tags == nil {
+		return ""
+	}
+	if len(*tags) > maxPatchTags {
+		return "A product can have at most 50 tags."
+	}
+	for _, tag := range *tags {
+		if tag == "" || utf8.RuneCountInString(tag) > maxPatchTagLength {
+			return "Tags must be between 1 and 50 characters long."
+		}
+	}
+	return ""
+}
+
+// validatePatchAttributes returns the message for invalid custom attributes, or an empty string when
+// they are valid
+func validatePatchAttributes(attributes map[string]*string) string {
+	if len(attributes) > maxPatchAttributes {
+		return "A product can have at most 50 attributes."
+	}
+	for name, value := range attributes {
+		if !attributeNamePattern.MatchString(name) {
+			return "Attribute names must be up to 50 letters, digits, '_' or '-' and start with a letter."
+		}
+		if value != nil && utf8.RuneCountInString(*value) > maxPatchAttributeValueLength {
+			return "Attribute values must be at most 255 characters long."
+		}
+	}
+	return ""
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+// Errors of a side are reported on Dimensions.
+func (r *PatchProductRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		field := fieldName(err)
+		switch field {
+		case "LengthMM", "WidthMM", "HeightMM":
+			field = "Dimensions"
+		}
+		errors[field] = r.getCustomErrorMessage(field + "." + err.Tag())
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *PatchProductRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Name.min":        "Product name must be at least 2 characters long.",
+		"Name.max":        "Product name must be less than 255 characters long.",
+		"Description.max": "Description must be at most 5000 characters long.",
+		"Brand.max":       "Brand must be at most 100 characters long.",
+		"Status.oneof":    "Status must be one of draft, active, discontinued or archived.",
+		"WeightGrams.min": "Weight must not be negative.",
+		"Dimensions.min":  "Dimensions must not be negative.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
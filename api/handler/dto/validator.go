@@ -1,5 +1,21 @@
 package dto
 
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
 type Validator interface {
 	Validate() map[string]string
 }
+
+// fieldName returns the name of the field that failed validation without the index of a slice
+// element, so "Serials[2]" is reported as "Serials"
+func fieldName(err validator.FieldError) string {
+	field := err.Field()
+	if i := strings.IndexByte(field, '['); i >= 0 {
+		return field[:i]
+	}
+	return field
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// CreateWarehouseRequest represents the request body for creating a warehouse
+type CreateWarehouseRequest struct {
+	Code string `json:"code" validate:"required,min=2,max=50,alphanum"`
+	Name string `json:"name" validate:"required,min=2,max=255"`
+}
+
+// Validate performs validation on CreateWarehouseRequest and returns custom error messages if validation fails.
+func (r *CreateWarehouseRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *CreateWarehouseRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = r.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *CreateWarehouseRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Code.required": "Warehouse code is required.",
+		"Code.min":      "Warehouse code must be at least 2 characters long.",
+		"Code.max":      "Warehouse code must be less than 50 characters long.",
+		"Code.alphanum": "Warehouse code may only contain letters and digits.",
+		"Name.required": "Warehouse name is required.",
+		"Name.min":      "Warehouse name must be at least 2 characters long.",
+		"Name.max":      "Warehouse name must be less than 255 characters long.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
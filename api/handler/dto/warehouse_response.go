@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// WarehouseResponse represents the response body for a warehouse
+type WarehouseResponse struct {
+	ID        uint      `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
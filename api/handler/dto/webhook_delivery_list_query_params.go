@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// WebhookDeliveryListQueryParams defines the query parameters for listing the deliveries of a webhook
+type WebhookDeliveryListQueryParams struct {
+	Status string `json:"status" validate:"omitempty,oneof=pending succeeded dead"`
+	Limit  int    `json:"limit" validate:"min=1,max=100"`
+	Offset int    `json:"offset" validate:"min=0"`
+}
+
+// Validate performs validation on the query parameters and returns custom error messages
+func (p *WebhookDeliveryListQueryParams) Validate(queryParams url.Values) map[string]string {
+	p.Status = queryParams.Get("status")
+
+	// If limit or offset are not provided, set default values
+	p.Limit = 50
+	if limit := queryParams.Get("limit"); limit != "" {
+		p.Limit, _ = strconv.Atoi(limit)
+	}
+
+	p.Offset = 0
+	if offset := queryParams.Get("offset"); offset != "" {
+		var err error
+		if p.Offset, err = strconv.Atoi(offset); err != nil {
+			p.Offset = -1
+		}
+	}
+
+	// Perform validation using the validator package
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		return p.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+	return nil
+}
+
+// parseValidationErrors converts validation errors into custom error messages
+func (p *WebhookDeliveryListQueryParams) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		fieldWithTag := err.Field() + "." + err.Tag()
+		errors[err.Field()] = p.getCustomErrorMessage(fieldWithTag)
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages based on the field and tag
+func (p *WebhookDeliveryListQueryParams) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"Status.oneof": "status must be 'pending', 'succeeded' or 'dead'.",
+		"Limit.min":    "limit must be a number between 1 and 100.",
+		"Limit.max":    "limit must be a number between 1 and 100.",
+		"Offset.min":   "offset must be a number greater than or equal to 0.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+
+	return "Invalid field"
+}
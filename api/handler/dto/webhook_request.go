@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// WebhookRequest represents the request body for creating or updating a webhook subscription. An omitted
+// secret is generated on creation and kept on update, and omitted event types subscribe to every event.
+type WebhookRequest struct {
+	URL        string   `json:"url" validate:"required,max=2048,url"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"event_types" validate:"dive,oneof=ProductCreated ProductRenamed ProductUpdated ProductDeleted ProductRestored StockAdjusted"`
+}
+
+// Validate performs validation on WebhookRequest and returns custom error messages if validation fails.
+func (r *WebhookRequest) Validate() map[string]string {
+
+	// Create a new validator instance
+	validate := validator.New()
+	err := validate.Struct(r)
+	if err != nil {
+		return r.parseValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	return nil
+}
+
+// parseValidationErrors converts the validation errors into a map of custom error messages.
+func (r *WebhookRequest) parseValidationErrors(validationErrors validator.ValidationErrors) map[string]string {
+	errors := make(map[string]string)
+
+	for _, err := range validationErrors {
+		field := fieldName(err)
+		errors[field] = r.getCustomErrorMessage(field + "." + err.Tag())
+	}
+
+	return errors
+}
+
+// getCustomErrorMessage returns custom error messages for validation rules.
+func (r *WebhookRequest) getCustomErrorMessage(fieldWithTag string) string {
+	customMessages := map[string]string{
+		"URL.required":     "Webhook URL is required.",
+		"URL.max":          "Webhook URL must be less than 2048 characters long.",
+		"URL.url":          "Webhook URL must be an absolute http or https URL.",
+		"Secret.min":       "Webhook secret must be at least 16 characters long.",
+		"Secret.max":       "Webhook secret must be less than 255 characters long.",
+		"EventTypes.oneof": "Event types must be ProductCreated, ProductRenamed, ProductUpdated, ProductDeleted, ProductRestored or StockAdjusted.",
+	}
+
+	if message, exists := customMessages[fieldWithTag]; exists {
+		return message
+	}
+	return "Invalid field"
+}
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	consts "inventory_management/api/handler/const"
 	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
 	"inventory_management/pkg/utility"
 	"io"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -48,8 +51,109 @@ func ParseIDFromParam(c *gin.Context) (uint, error) {
 	return uint(id), nil
 }
 
+// ParseUintParam extracts and validates a positive numeric URL parameter by name.
+func ParseUintParam(c *gin.Context, key string) (uint, error) {
+	value, err := strconv.ParseUint(c.Param(key), 10, 32)
+	if err != nil || value == 0 {
+		return 0, fmt.Errorf("invalid %s parameter: %v", key, err)
+	}
+	return uint(value), nil
+}
+
+// GetActor returns who performs the request, as identified by the X-Actor header.
+func GetActor(c *gin.Context) string {
+	if actor := c.GetHeader(consts.HeaderActor); actor != "" {
+		return actor
+	}
+	return consts.DefaultActor
+}
+
+// ErrIfMatchRequired is returned when an update does not say which version of the product it is based on
+var ErrIfMatchRequired = errors.New(consts.ErrIfMatchRequired)
+
+// ErrIfMatchUnmatched is returned when the If-Match header names no version a product could have
+var ErrIfMatchUnmatched = errors.New(consts.ErrVersionMismatch)
+
+// SetETag sends the version of a product as its strong entity tag, e.g. "3"
+func SetETag(c *gin.Context, version int64) {
+	c.Header(consts.HeaderETag, strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ParseIfMatch returns the product version named by the If-Match header. "*" matches any version and
+// yields zero. Weak tags and lists of tags never match a single current version and are rejected.
+func ParseIfMatch(c *gin.Context) (int64, error) {
+	header := strings.TrimSpace(c.GetHeader(consts.HeaderIfMatch))
+	if header == "" {
+		return 0, ErrIfMatchRequired
+	}
+	if header == "*" {
+		return 0, nil
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err != nil || !strings.HasPrefix(header, `"`) {
+		return 0, ErrIfMatchUnmatched
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, ErrIfMatchUnmatched
+	}
+	return version, nil
+}
+
+// IsAdmin reports whether the request was made by an administrator, as marked by the admin middleware
+func IsAdmin(c *gin.Context) bool {
+	return c.GetBool(consts.ContextKeyAdmin)
+}
+
+// GetChangeOrigin returns who performs the request together with its request ID and client address,
+// as recorded in the audit trail
+func GetChangeOrigin(c *gin.Context) entity.ChangeOrigin {
+	return entity.ChangeOrigin{
+		Actor:     GetActor(c),
+		RequestID: c.GetHeader(consts.HeaderRequestID),
+		SourceIP:  c.ClientIP(),
+	}
+}
+
 // HandleErrorResponse is a reusable function to handle error responses and logging.
 func HandleErrorResponse(c *gin.Context, err error, errorMessage string, statusCode int) {
 	utility.LogError(errorMessage, "", err)
 	c.JSON(statusCode, gin.H{"errors": errorMessage})
 }
+
+// BuildPagination builds the pagination metadata for a list response, including links to the next and previous pages.
+func BuildPagination(c *gin.Context, total int64, limit int, offset int) dto.PaginationResponse {
+	pagination := dto.PaginationResponse{
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: int64(offset+limit) < total,
+	}
+
+	if pagination.HasMore {
+		next := pageLink(c.Request.URL, limit, offset+limit)
+		pagination.Next = &next
+	}
+
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prev := pageLink(c.Request.URL, limit, prevOffset)
+		pagination.Prev = &prev
+	}
+
+	return pagination
+}
+
+// pageLink rebuilds the request URL with the given limit and offset, preserving the other query parameters.
+func pageLink(requestURL *url.URL, limit int, offset int) string {
+	query := requestURL.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+
+	link := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+	return link.String()
+}
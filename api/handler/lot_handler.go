@@ -0,0 +1,70 @@
+package handler
+
+import (
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LotHandler struct {
+	lotUsecase usecase.LotUsecase
+}
+
+func NewLotHandler(u usecase.LotUsecase) *LotHandler {
+	return &LotHandler{lotUsecase: u}
+}
+
+// GetProductLots retrieves the lots of a product across warehouses
+func (h *LotHandler) GetProductLots(c *gin.Context) {
+	productID, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	lots, err := h.lotUsecase.GetProductLots(productID)
+	if err != nil {
+		if err == usecase.ErrProductNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveLots, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product lots retrieved successfully", productID, len(lots))
+	c.JSON(http.StatusOK, transformer.TransformLotEntitiesToResponse(lots, time.Now()))
+}
+
+// GetExpiringLots reports the lots holding stock that expire within the requested number of days
+func (h *LotHandler) GetExpiringLots(c *gin.Context) {
+	queryParams := dto.ExpiringLotQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	within := time.Duration(queryParams.Days) * 24 * time.Hour
+	lots, total, err := h.lotUsecase.ListExpiringLots(within, usecase.ExpiringLotFilter{
+		WarehouseID: queryParams.WarehouseID,
+		Limit:       queryParams.Limit,
+		Offset:      queryParams.Offset,
+	})
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveLots, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("expiring lots retrieved successfully", len(lots))
+	c.JSON(http.StatusOK, dto.LotListResponse{
+		Lots:               transformer.TransformLotEntitiesToResponse(lots, time.Now()),
+		PaginationResponse: helper_handler.BuildPagination(c, total, queryParams.Limit, queryParams.Offset),
+	})
+}
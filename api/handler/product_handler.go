@@ -1,13 +1,23 @@
 package handler
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	consts "inventory_management/api/handler/const"
 	"inventory_management/api/handler/dto"
 	helper_handler "inventory_management/api/handler/helper"
 	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/label"
+	"inventory_management/internal/spreadsheet"
 	"inventory_management/internal/usecase"
 	"inventory_management/pkg/utility"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -37,8 +47,30 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	}
 
 	// Create product using the usecase
-	product, err := h.productUsecase.CreateProduct(req.Name)
+	product, err := h.productUsecase.CreateProduct(usecase.ProductInput{
+		Name:       req.Name,
+		SKU:        req.SKU,
+		Serialized: req.Serialized,
+		StockUnit:  req.StockUnit,
+		CategoryID: req.CategoryID,
+	}, helper_handler.GetChangeOrigin(c))
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+			return
+		}
+		if err == usecase.ErrUnitOfMeasureNotFound {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrUnitOfMeasureNotFound})
+			return
+		}
+		if err == usecase.ErrCategoryNotFound {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrCategoryNotFound})
+			return
+		}
+		if err == usecase.ErrSKUTaken {
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrSKUTaken})
+			return
+		}
 		// If there's an error creating the product, return the correct error message
 		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedCreate, http.StatusInternalServerError)
 		return
@@ -47,10 +79,12 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	// Transform and send a success response
 	productResponse := transformer.TransformProductEntityToResponse(product)
 	utility.LogSuccess("product created successfully", product.ID(), product.Name())
+	helper_handler.SetETag(c, product.Version())
 	c.JSON(http.StatusCreated, productResponse)
 }
 
-// GetProduct retrieves a product by its ID
+// GetProduct retrieves a product by its ID together with its variants. Only administrators may ask for
+// a deleted product.
 func (h *ProductHandler) GetProduct(c *gin.Context) {
 	id, err := helper_handler.ParseIDFromParam(c)
 	if err != nil {
@@ -58,7 +92,22 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := h.productUsecase.GetProductByID(id)
+	queryParams := dto.ProductQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+	if queryParams.IncludeDeleted && !helper_handler.IsAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"errors": consts.ErrIncludeDeletedAdmin})
+		return
+	}
+
+	var product *entity.Product
+	if queryParams.IncludeDeleted {
+		product, err = h.productUsecase.GetProductIncludingDeleted(id)
+	} else {
+		product, err = h.productUsecase.GetProductByID(id)
+	}
 	if err != nil {
 		if err == usecase.ErrProductNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
@@ -68,11 +117,207 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		return
 	}
 
-	productResponse := transformer.TransformProductEntityToResponse(product)
+	variants, err := h.productUsecase.GetProductVariants(id)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieve, http.StatusInternalServerError)
+		return
+	}
+
+	aliases, err := h.productUsecase.GetSKUAliases(id)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieve, http.StatusInternalServerError)
+		return
+	}
+
+	barcodes, err := h.productUsecase.GetBarcodes(id)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieve, http.StatusInternalServerError)
+		return
+	}
+
+	productResponse := transformer.TransformProductWithVariantsToResponse(product, variants.VariantOf, variants.Variants)
+	productResponse.SKUAliases = transformer.TransformSKUAliasesToResponse(aliases)
+	productResponse.Barcodes = transformer.TransformBarcodesToResponse(barcodes)
 	utility.LogSuccess("product retrieved successfully", product.ID(), product.Name())
+	helper_handler.SetETag(c, product.Version())
 	c.JSON(http.StatusOK, productResponse)
 }
 
+// GetProductBySKU retrieves the product carrying a SKU, or the product a former SKU now belongs to
+func (h *ProductHandler) GetProductBySKU(c *gin.Context) {
+	product, err := h.productUsecase.GetProductBySKU(c.Param("sku"))
+	if err != nil {
+		if err == usecase.ErrProductNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieve, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product retrieved by sku successfully", product.ID(), product.SKU())
+	helper_handler.SetETag(c, product.Version())
+	c.JSON(http.StatusOK, transformer.TransformProductEntityToResponse(product))
+}
+
+// GetProductByBarcode retrieves the product carrying a scanned EAN-8, UPC-A, EAN-13 or GTIN-14 barcode
+func (h *ProductHandler) GetProductByBarcode(c *gin.Context) {
+	product, err := h.productUsecase.GetProductByBarcode(c.Param("code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		case err == usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieve, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product retrieved by barcode successfully", product.ID(), c.Param("code"))
+	helper_handler.SetETag(c, product.Version())
+	c.JSON(http.StatusOK, transformer.TransformProductEntityToResponse(product))
+}
+
+// GetProductBarcodes lists the barcodes of a product
+func (h *ProductHandler) GetProductBarcodes(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.productUsecase.GetProductByID(id); err != nil {
+		if err == usecase.ErrProductNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieve, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	barcodes, err := h.productUsecase.GetBarcodes(id)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieve, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("product barcodes retrieved successfully", id, len(barcodes))
+	c.JSON(http.StatusOK, transformer.TransformBarcodesToResponse(barcodes))
+}
+
+// AddProductBarcode assigns a barcode to a product after checking its check digit
+func (h *ProductHandler) AddProductBarcode(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	var req dto.AddProductBarcodeRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	barcode, err := h.productUsecase.AddBarcode(id, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		case err == usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case err == usecase.ErrBarcodeTaken:
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrBarcodeTaken})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedUpdate, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product barcode added successfully", id, barcode.Code())
+	c.JSON(http.StatusCreated, transformer.TransformBarcodeToResponse(barcode))
+}
+
+// RemoveProductBarcode takes a barcode off a product
+func (h *ProductHandler) RemoveProductBarcode(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.productUsecase.RemoveBarcode(id, c.Param("code")); err != nil {
+		if err == usecase.ErrBarcodeNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrBarcodeNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedUpdate, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product barcode removed successfully", id, c.Param("code"))
+	c.Status(http.StatusNoContent)
+}
+
+// GetProductLabel renders the shelf label of a product as a PNG or SVG with its name, SKU and barcode
+func (h *ProductHandler) GetProductLabel(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	queryParams := dto.LabelQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	productLabel, err := h.productUsecase.GetProductLabel(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		case err == usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRenderLabel, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Render into a buffer so that a label that does not fit can still be answered with an error
+	var rendered bytes.Buffer
+	size := label.Size{WidthMM: queryParams.WidthMM, HeightMM: queryParams.HeightMM, DPI: queryParams.DPI}
+	contentType := "image/png"
+	if queryParams.Format == "svg" {
+		contentType = "image/svg+xml"
+		err = productLabel.WriteSVG(&rendered, size)
+	} else {
+		err = productLabel.WritePNG(&rendered, size)
+	}
+	if err != nil {
+		if errors.Is(err, label.ErrLabelTooSmall) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrLabelTooSmall})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRenderLabel, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product label rendered successfully", id, queryParams.Format, productLabel.Symbology())
+	c.Data(http.StatusOK, contentType, rendered.Bytes())
+}
+
 // UpdateProductName handles updating a product's name
 func (h *ProductHandler) UpdateProductName(c *gin.Context) {
 	var req dto.UpdateProductRequest
@@ -94,22 +339,343 @@ func (h *ProductHandler) UpdateProductName(c *gin.Context) {
 		return
 	}
 
-	product, err := h.productUsecase.UpdateProductName(id, req.Name)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.productUsecase.UpdateProductName(id, req.Name, version, helper_handler.GetChangeOrigin(c))
+	if err != nil {
+		switch err {
+		case usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case usecase.ErrProductVersionMismatch:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"errors": consts.ErrVersionMismatch})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedUpdate, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	productResponse := transformer.TransformProductEntityToResponse(product)
+	utility.LogSuccess("product updated successfully", product.ID(), product.Name())
+	helper_handler.SetETag(c, product.Version())
+	c.JSON(http.StatusOK, productResponse)
+}
+
+// PatchProduct handles a JSON Merge Patch of a product, changing only the attributes in the body
+func (h *ProductHandler) PatchProduct(c *gin.Context) {
+	if contentType := c.ContentType(); contentType != consts.ContentTypeMergePatch && contentType != consts.ContentTypeJSON {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"errors": consts.ErrUnsupportedPatch})
+		return
+	}
+
+	var req dto.PatchProductRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	patch := usecase.ProductPatch{
+		Name:            req.Name,
+		Description:     req.Description,
+		Brand:           req.Brand,
+		WeightGrams:     req.WeightGrams,
+		Tags:            req.Tags,
+		ClearAttributes: req.ClearAttributes,
+		Attributes:      req.Attributes,
+	}
+	if req.Status != nil {
+		status := entity.ProductStatus(*req.Status)
+		patch.Status = &status
+	}
+	if req.Dimensions != nil {
+		patch.Dimensions = &usecase.DimensionsPatch{
+			LengthMM: req.Dimensions.LengthMM,
+			WidthMM:  req.Dimensions.WidthMM,
+			HeightMM: req.Dimensions.HeightMM,
+		}
+	}
+
+	product, err := h.productUsecase.PatchProduct(id, patch, version, helper_handler.GetChangeOrigin(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrProductNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case errors.Is(err, usecase.ErrProductVersionMismatch):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"errors": consts.ErrVersionMismatch})
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedUpdate, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product patched successfully", product.ID(), product.Name())
+	helper_handler.SetETag(c, product.Version())
+	c.JSON(http.StatusOK, transformer.TransformProductEntityToResponse(product))
+}
+
+// ifMatchVersion reads the product version an update is based on from the If-Match header. It answers
+// 428 when the header is missing and 412 when it can never match, and then reports false.
+func ifMatchVersion(c *gin.Context) (int64, bool) {
+	version, err := helper_handler.ParseIfMatch(c)
+	switch err {
+	case nil:
+		return version, true
+	case helper_handler.ErrIfMatchRequired:
+		c.JSON(http.StatusPreconditionRequired, gin.H{"errors": consts.ErrIfMatchRequired})
+	default:
+		c.JSON(http.StatusPreconditionFailed, gin.H{"errors": consts.ErrVersionMismatch})
+	}
+	return 0, false
+}
+
+// DeleteProduct soft-deletes a product
+func (h *ProductHandler) DeleteProduct(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.productUsecase.DeleteProduct(id, version, helper_handler.GetChangeOrigin(c))
+	if err != nil {
+		switch err {
+		case usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case usecase.ErrProductVersionMismatch:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"errors": consts.ErrVersionMismatch})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedDelete, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product deleted successfully", id)
+	helper_handler.SetETag(c, product.Version())
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreProduct brings back a soft-deleted product
+func (h *ProductHandler) RestoreProduct(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.productUsecase.RestoreProduct(id, version, helper_handler.GetChangeOrigin(c))
+	if err != nil {
+		switch err {
+		case usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case usecase.ErrProductVersionMismatch:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"errors": consts.ErrVersionMismatch})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRestore, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product restored successfully", product.ID(), product.Name())
+	helper_handler.SetETag(c, product.Version())
+	c.JSON(http.StatusOK, transformer.TransformProductEntityToResponse(product))
+}
+
+// GetProductHistory lists the audit trail of a product, oldest change first
+func (h *ProductHandler) GetProductHistory(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	queryParams := dto.ProductHistoryQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	records, total, err := h.productUsecase.GetProductHistory(usecase.ProductAuditFilter{
+		ProductID: id,
+		Limit:     queryParams.Limit,
+		Offset:    queryParams.Offset,
+	})
 	if err != nil {
 		if err == usecase.ErrProductNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
 		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveAudit, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product history retrieved successfully", id, len(records))
+	c.JSON(http.StatusOK, dto.ProductHistoryResponse{
+		Records:            transformer.TransformProductAuditRecordsToResponse(records),
+		PaginationResponse: helper_handler.BuildPagination(c, total, queryParams.Limit, queryParams.Offset),
+	})
+}
+
+// GetProductSnapshot rebuilds a product as it was at the time given in the as_of query parameter
+func (h *ProductHandler) GetProductSnapshot(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	queryParams := dto.ProductSnapshotQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	product, err := h.productUsecase.GetProductAsOf(id, queryParams.AsOf)
+	if err != nil {
+		switch err {
+		case usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case usecase.ErrNoProductHistory:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrNoProductHistory})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveAudit, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product snapshot rebuilt successfully", id, queryParams.AsOf)
+	c.JSON(http.StatusOK, transformer.TransformProductEntityToResponse(product))
+}
+
+// AssignProductCategory files a product under a category, or removes it from its category
+func (h *ProductHandler) AssignProductCategory(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	var req dto.AssignProductCategoryRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.productUsecase.AssignCategory(id, req.CategoryID, version, helper_handler.GetChangeOrigin(c))
+	if err != nil {
+		switch err {
+		case usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case usecase.ErrProductVersionMismatch:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"errors": consts.ErrVersionMismatch})
+		case usecase.ErrCategoryNotFound:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrCategoryNotFound})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedUpdate, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product category assigned successfully", product.ID(), product.CategoryID())
+	helper_handler.SetETag(c, product.Version())
+	c.JSON(http.StatusOK, transformer.TransformProductEntityToResponse(product))
+}
+
+// ChangeProductSKU reassigns the SKU of a product and keeps the previous SKU as an alias
+func (h *ProductHandler) ChangeProductSKU(c *gin.Context) {
+	id, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	var req dto.ChangeProductSKURequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	product, aliases, err := h.productUsecase.ChangeSKU(id, req.SKU, version, helper_handler.GetChangeOrigin(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		case err == usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case err == usecase.ErrProductVersionMismatch:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"errors": consts.ErrVersionMismatch})
+		case err == usecase.ErrSKUTaken:
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrSKUTaken})
+		default:
 			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedUpdate, http.StatusInternalServerError)
 		}
 		return
 	}
 
 	productResponse := transformer.TransformProductEntityToResponse(product)
-	utility.LogSuccess("product updated successfully", product.ID(), product.Name())
+	productResponse.SKUAliases = transformer.TransformSKUAliasesToResponse(aliases)
+	utility.LogSuccess("product sku changed successfully", product.ID(), product.SKU())
+	helper_handler.SetETag(c, product.Version())
 	c.JSON(http.StatusOK, productResponse)
 }
 
-// GetProductList handles listing products with filters, sorting, and pagination
+// GetProductList handles listing products with search and category filters, sorting, and pagination.
+// Only administrators may include deleted products.
 func (h *ProductHandler) GetProductList(c *gin.Context) {
 	queryParams := dto.ProductListQueryParams{}
 
@@ -119,15 +685,21 @@ func (h *ProductHandler) GetProductList(c *gin.Context) {
 		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
 		return
 	}
+	if queryParams.IncludeDeleted && !helper_handler.IsAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"errors": consts.ErrIncludeDeletedAdmin})
+		return
+	}
 
 	// Fetch the products based on filters, sorting, and pagination
-	products, err := h.productUsecase.ListProducts(
-		queryParams.SearchTerm,
-		queryParams.SortBy,
-		queryParams.SortDirection,
-		queryParams.Limit,
-		queryParams.Offset,
-	)
+	products, total, err := h.productUsecase.ListProducts(usecase.ProductListFilter{
+		SearchTerm:     queryParams.SearchTerm,
+		CategoryID:     queryParams.CategoryID,
+		SortBy:         queryParams.SortBy,
+		SortDirection:  queryParams.SortDirection,
+		Limit:          queryParams.Limit,
+		Offset:         queryParams.Offset,
+		IncludeDeleted: queryParams.IncludeDeleted,
+	})
 	if err != nil {
 		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieve, http.StatusInternalServerError)
 		return
@@ -140,8 +712,91 @@ func (h *ProductHandler) GetProductList(c *gin.Context) {
 	}
 
 	utility.LogSuccess("product list retrieved successfully", len(products), "products")
-	c.JSON(http.StatusOK, gin.H{
-		"products": productResponses,
-		"total":    len(products),
+	c.JSON(http.StatusOK, dto.ProductListResponse{
+		Products:           productResponses,
+		PaginationResponse: helper_handler.BuildPagination(c, total, queryParams.Limit, queryParams.Offset),
 	})
 }
+
+// exportContentTypes maps the formats of a catalogue export to the media type they are sent as
+var exportContentTypes = map[string]string{
+	dto.ExportFormatCSV:   consts.ContentTypeCSV,
+	dto.ExportFormatJSONL: consts.ContentTypeNDJSON,
+	dto.ExportFormatXLSX:  consts.ContentTypeXLSX,
+}
+
+// ExportProducts downloads every product matching the list filters as CSV, JSON Lines or XLSX. Rows are
+// written as they are read from the database, so the size of the catalogue is not bounded by memory.
+// A failure once rows have been sent can only cut the download short, the status being sent already.
+func (h *ProductHandler) ExportProducts(c *gin.Context) {
+	queryParams := dto.ProductExportQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+	if queryParams.IncludeDeleted && !helper_handler.IsAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"errors": consts.ErrIncludeDeletedAdmin})
+		return
+	}
+
+	c.Header("Content-Type", exportContentTypes[queryParams.Format])
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"products-%s.%s\"", time.Now().UTC().Format("20060102"), queryParams.Format))
+
+	exported, err := h.writeProductExport(c.Writer, queryParams.Format, usecase.ProductListFilter{
+		SearchTerm:     queryParams.SearchTerm,
+		CategoryID:     queryParams.CategoryID,
+		SortBy:         queryParams.SortBy,
+		SortDirection:  queryParams.SortDirection,
+		IncludeDeleted: queryParams.IncludeDeleted,
+	})
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedExportProducts, http.StatusInternalServerError)
+			return
+		}
+		utility.LogError(consts.ErrFailedExportProducts, queryParams.Format, err)
+		return
+	}
+
+	utility.LogSuccess("products exported successfully", exported, queryParams.Format)
+}
+
+// writeProductExport writes the products matching the filter to w in the given format and returns how
+// many were written. Output is buffered, so nothing reaches w when the export fails early on.
+func (h *ProductHandler) writeProductExport(w io.Writer, format string, filter usecase.ProductListFilter) (int, error) {
+	buffered := bufio.NewWriterSize(w, 64<<10)
+	exported := 0
+
+	if format == dto.ExportFormatJSONL {
+		encoder := json.NewEncoder(buffered)
+		err := h.productUsecase.ExportProducts(filter, func(p *entity.Product) error {
+			exported++
+			return encoder.Encode(transformer.TransformProductEntityToResponse(p))
+		})
+		if err != nil {
+			return exported, err
+		}
+		return exported, buffered.Flush()
+	}
+
+	writer, err := spreadsheet.NewWriter(spreadsheet.Format(format), buffered)
+	if err != nil {
+		return 0, err
+	}
+	if err := writer.WriteRow(transformer.ProductExportHeader); err != nil {
+		return 0, err
+	}
+	err = h.productUsecase.ExportProducts(filter, func(p *entity.Product) error {
+		exported++
+		return writer.WriteRow(transformer.TransformProductEntityToExportRecord(p))
+	})
+	if err != nil {
+		return exported, err
+	}
+	if err := writer.Close(); err != nil {
+		return exported, err
+	}
+	return exported, buffered.Flush()
+}
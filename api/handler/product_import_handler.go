@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/spreadsheet"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize caps the size of an uploaded import file, which is read into memory whole
+const maxImportFileSize = 20 << 20
+
+type ProductImportHandler struct {
+	productImportUsecase usecase.ProductImportUsecase
+}
+
+func NewProductImportHandler(u usecase.ProductImportUsecase) *ProductImportHandler {
+	return &ProductImportHandler{productImportUsecase: u}
+}
+
+// ImportProducts imports the products of an uploaded CSV or XLSX file. The first non-blank row holds
+// the column headers. Rows failing validation are rejected with the messages product creation answers
+// with, and the other rows are imported. The report of the import is returned and stored. When a batch
+// of rows fails, the batches before it stay imported and the report, marked as failed at the first
+// line of the failed batch, is returned with the error.
+func (h *ProductImportHandler) ImportProducts(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"errors": consts.ErrImportFileTooLarge})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"errors": consts.ErrImportFileRequired})
+		return
+	}
+	format, err := spreadsheet.FormatOf(fileHeader.Filename)
+	if err != nil {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"errors": err.Error()})
+		return
+	}
+
+	var req dto.ProductImportRequest
+	if validationErrors := req.Validate(c.Request.Form); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	rows, err := readImportFile(fileHeader, format)
+	if err != nil {
+		switch {
+		case errors.Is(err, spreadsheet.ErrTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"errors": consts.ErrImportFileTooLarge})
+		case errors.Is(err, spreadsheet.ErrMalformed):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedImportProducts, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Blank rows are left out, the first remaining row holds the headers
+	var columns dto.ProductImportColumns
+	var importRows []usecase.ProductImportRow
+	for _, row := range rows {
+		if row.IsBlank() {
+			continue
+		}
+		if columns == nil {
+			var validationErrors map[string]string
+			if columns, validationErrors = dto.NewProductImportColumns(row.Cells, req.Mapping); validationErrors != nil {
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+				return
+			}
+			continue
+		}
+
+		request, serializedGiven, validationErrors := columns.Request(row.Cells)
+		importRows = append(importRows, usecase.ProductImportRow{
+			Line: row.Line,
+			Product: usecase.ProductInput{
+				Name:       request.Name,
+				SKU:        request.SKU,
+				Serialized: request.Serialized,
+				StockUnit:  request.StockUnit,
+				CategoryID: request.CategoryID,
+			},
+			SerializedGiven: serializedGiven,
+			Errors:          validationErrors,
+		})
+	}
+	if columns == nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrImportFileEmpty})
+		return
+	}
+
+	report, err := h.productImportUsecase.ImportProducts(usecase.ProductImportInput{
+		FileName: fileHeader.Filename,
+		DryRun:   req.DryRun,
+		Rows:     importRows,
+	}, helper_handler.GetChangeOrigin(c))
+	if err != nil {
+		if report == nil {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedImportProducts, http.StatusInternalServerError)
+			return
+		}
+		// The batches before the failure stay imported, the stored report tells which rows they were
+		utility.LogError(consts.ErrImportFailedPartWay, report.FileName(), err)
+		c.Header("Location", fmt.Sprintf("/api/v1/products/imports/%d", report.ID()))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"errors": consts.ErrImportFailedPartWay,
+			"import": transformer.TransformProductImportEntityToResponse(report),
+		})
+		return
+	}
+
+	utility.LogSuccess("products imported successfully", report.ID(), report.FileName(), report.DryRun(), report.TotalRows(), report.Rejected())
+	c.Header("Location", fmt.Sprintf("/api/v1/products/imports/%d", report.ID()))
+	c.JSON(http.StatusCreated, transformer.TransformProductImportEntityToResponse(report))
+}
+
+// GetProductImport retrieves the report of a product import by its ID
+func (h *ProductImportHandler) GetProductImport(c *gin.Context) {
+	report, ok := h.findProductImport(c)
+	if !ok {
+		return
+	}
+
+	utility.LogSuccess("product import retrieved successfully", report.ID(), report.FileName())
+	c.JSON(http.StatusOK, transformer.TransformProductImportEntityToResponse(report))
+}
+
+// GetProductImportReport downloads the rejected rows of a product import as CSV, one line for every
+// field a row failed on
+func (h *ProductImportHandler) GetProductImportReport(c *gin.Context) {
+	report, ok := h.findProductImport(c)
+	if !ok {
+		return
+	}
+
+	records := [][]string{{"line", "sku", "name", "field", "message"}}
+	for _, row := range report.RejectedRows() {
+		fields := make([]string, 0, len(row.Errors))
+		for field := range row.Errors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			records = append(records, []string{strconv.Itoa(row.Line), row.SKU, row.Name, field, row.Errors[field]})
+		}
+	}
+
+	var rendered bytes.Buffer
+	if err := spreadsheet.WriteCSV(&rendered, records); err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedWriteImportReport, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("product import report downloaded successfully", report.ID(), report.Rejected())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"product-import-%d-report.csv\"", report.ID()))
+	c.Data(http.StatusOK, consts.ContentTypeCSV, rendered.Bytes())
+}
+
+// findProductImport loads the import named in the URL and answers the request itself when it cannot
+func (h *ProductImportHandler) findProductImport(c *gin.Context) (*entity.ProductImport, bool) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductImportID, http.StatusBadRequest)
+		return nil, false
+	}
+
+	report, err := h.productImportUsecase.GetProductImport(id)
+	if err != nil {
+		if err == usecase.ErrProductImportNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductImportNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveImport, http.StatusInternalServerError)
+		}
+		return nil, false
+	}
+	return report, true
+}
+
+// readImportFile reads the rows of an uploaded file
+func readImportFile(fileHeader *multipart.FileHeader, format spreadsheet.Format) ([]spreadsheet.Row, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return spreadsheet.Read(format, data)
+}
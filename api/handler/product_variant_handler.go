@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductVariantHandler struct {
+	variantUsecase usecase.ProductVariantUsecase
+}
+
+func NewProductVariantHandler(u usecase.ProductVariantUsecase) *ProductVariantHandler {
+	return &ProductVariantHandler{variantUsecase: u}
+}
+
+// GenerateVariants creates a variant of a product for every combination of its option axes
+func (h *ProductVariantHandler) GenerateVariants(c *gin.Context) {
+	productID, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	var req dto.GenerateVariantsRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	axes := make([]usecase.OptionAxisInput, len(req.Options))
+	for i, option := range req.Options {
+		axes[i] = usecase.OptionAxisInput{Name: option.Name, Values: option.Values}
+	}
+
+	product, variants, err := h.variantUsecase.GenerateVariants(productID, axes, helper_handler.GetChangeOrigin(c))
+	if err != nil {
+		switch {
+		case err == usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case err == usecase.ErrProductVariantExists:
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrProductVariantExists})
+		case err == usecase.ErrSKUTaken:
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrSKUTaken})
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedGenerateVariants, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product variants generated successfully", product.ID(), len(variants))
+	c.JSON(http.StatusOK, transformer.TransformProductWithVariantsToResponse(product, nil, variants))
+}
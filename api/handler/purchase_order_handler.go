@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PurchaseOrderHandler struct {
+	purchaseOrderUsecase usecase.PurchaseOrderUsecase
+}
+
+func NewPurchaseOrderHandler(u usecase.PurchaseOrderUsecase) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{purchaseOrderUsecase: u}
+}
+
+// CreatePurchaseOrder handles drafting a purchase order with a supplier
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *gin.Context) {
+	var req dto.CreatePurchaseOrderRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	lines := make([]usecase.PurchaseOrderLineInput, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = usecase.PurchaseOrderLineInput{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			Unit:      line.Unit,
+			UnitCost:  line.UnitCost,
+		}
+	}
+
+	order, err := h.purchaseOrderUsecase.CreatePurchaseOrder(usecase.PurchaseOrderInput{
+		SupplierID:  req.SupplierID,
+		WarehouseID: req.WarehouseID,
+		Reference:   req.Reference,
+		Currency:    req.Currency,
+		Lines:       lines,
+	})
+	if err != nil {
+		if err == usecase.ErrSupplierNotFound {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrSupplierNotFound})
+		} else {
+			handleStockError(c, err, consts.ErrFailedCreatePurchaseOrder)
+		}
+		return
+	}
+
+	utility.LogSuccess("purchase order created successfully", order.ID(), order.SupplierID(), order.Total())
+	c.JSON(http.StatusCreated, transformer.TransformPurchaseOrderEntityToResponse(order))
+}
+
+// GetPurchaseOrder retrieves a purchase order by its ID
+func (h *PurchaseOrderHandler) GetPurchaseOrder(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidPurchaseOrderID, http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.purchaseOrderUsecase.GetPurchaseOrderByID(id)
+	if err != nil {
+		if err == usecase.ErrPurchaseOrderNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrPurchaseOrderNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrievePurchaseOrder, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("purchase order retrieved successfully", order.ID())
+	c.JSON(http.StatusOK, transformer.TransformPurchaseOrderEntityToResponse(order))
+}
+
+// GetPurchaseOrderList lists purchase orders filtered by status and supplier with pagination
+func (h *PurchaseOrderHandler) GetPurchaseOrderList(c *gin.Context) {
+	queryParams := dto.PurchaseOrderListQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	orders, total, err := h.purchaseOrderUsecase.ListPurchaseOrders(usecase.PurchaseOrderFilter{
+		Status:     entity.PurchaseOrderStatus(queryParams.Status),
+		SupplierID: queryParams.SupplierID,
+		Limit:      queryParams.Limit,
+		Offset:     queryParams.Offset,
+	})
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrievePurchaseOrder, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("purchase orders retrieved successfully", len(orders))
+	c.JSON(http.StatusOK, dto.PurchaseOrderListResponse{
+		PurchaseOrders:     transformer.TransformPurchaseOrderEntitiesToResponse(orders),
+		PaginationResponse: helper_handler.BuildPagination(c, total, queryParams.Limit, queryParams.Offset),
+	})
+}
+
+// SubmitPurchaseOrder sends a draft purchase order to its supplier
+func (h *PurchaseOrderHandler) SubmitPurchaseOrder(c *gin.Context) {
+	h.transitionPurchaseOrder(c, "purchase order submitted successfully", h.purchaseOrderUsecase.SubmitPurchaseOrder)
+}
+
+// ReceivePurchaseOrder books delivered goods into the receiving warehouse. An empty body receives
+// everything outstanding.
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(c *gin.Context) {
+	var req dto.ReceivePurchaseOrderRequest
+
+	if c.Request.ContentLength != 0 {
+		validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+		if validationErrors != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+			return
+		}
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+			return
+		}
+	}
+
+	lines := make([]usecase.GoodsReceiptLineInput, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = usecase.GoodsReceiptLineInput{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			Unit:      line.Unit,
+			Serials:   line.Serials,
+		}
+	}
+
+	h.transitionPurchaseOrder(c, "purchase order received successfully", func(id uint) (*entity.PurchaseOrder, error) {
+		return h.purchaseOrderUsecase.ReceivePurchaseOrder(id, lines, helper_handler.GetActor(c))
+	})
+}
+
+// ClosePurchaseOrder finishes a received or partially received purchase order
+func (h *PurchaseOrderHandler) ClosePurchaseOrder(c *gin.Context) {
+	h.transitionPurchaseOrder(c, "purchase order closed successfully", h.purchaseOrderUsecase.ClosePurchaseOrder)
+}
+
+// transitionPurchaseOrder parses the purchase order ID, runs a state transition and writes the response
+func (h *PurchaseOrderHandler) transitionPurchaseOrder(c *gin.Context, successMessage string, transition func(id uint) (*entity.PurchaseOrder, error)) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidPurchaseOrderID, http.StatusBadRequest)
+		return
+	}
+
+	order, err := transition(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrPurchaseOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrPurchaseOrderNotFound})
+		case errors.Is(err, usecase.ErrPurchaseOrderNotDraft):
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrPurchaseOrderNotDraft})
+		case errors.Is(err, usecase.ErrPurchaseOrderNotReceivable):
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrPurchaseOrderNotReceivable})
+		case errors.Is(err, usecase.ErrPurchaseOrderNotClosable):
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrPurchaseOrderNotClosable})
+		default:
+			handleStockError(c, err, consts.ErrFailedUpdatePurchaseOrder)
+		}
+		return
+	}
+
+	utility.LogSuccess(successMessage, order.ID(), order.Status())
+	c.JSON(http.StatusOK, transformer.TransformPurchaseOrderEntityToResponse(order))
+}
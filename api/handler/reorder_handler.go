@@ -0,0 +1,106 @@
+package handler
+
+import (
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReorderHandler struct {
+	reorderUsecase usecase.ReorderUsecase
+}
+
+func NewReorderHandler(u usecase.ReorderUsecase) *ReorderHandler {
+	return &ReorderHandler{reorderUsecase: u}
+}
+
+// SetReorderPolicy handles creating or replacing the reorder policy of a product in a warehouse
+func (h *ReorderHandler) SetReorderPolicy(c *gin.Context) {
+	var req dto.SetReorderPolicyRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	policy, err := h.reorderUsecase.SetReorderPolicy(usecase.ReorderPolicyInput{
+		ProductID:           req.ProductID,
+		WarehouseID:         req.WarehouseID,
+		ReorderPoint:        req.ReorderPoint,
+		ReorderQuantity:     req.ReorderQuantity,
+		SafetyStock:         req.SafetyStock,
+		PreferredSupplierID: req.PreferredSupplierID,
+	})
+	if err != nil {
+		if err == usecase.ErrSupplierNotFound {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrSupplierNotFound})
+		} else {
+			handleStockError(c, err, consts.ErrFailedSetReorderPolicy)
+		}
+		return
+	}
+
+	utility.LogSuccess("reorder policy set successfully", policy.ProductID(), policy.WarehouseID())
+	c.JSON(http.StatusOK, transformer.TransformReorderPolicyEntityToResponse(policy))
+}
+
+// GetProductReorderPolicies retrieves the reorder policies of a product across warehouses
+func (h *ReorderHandler) GetProductReorderPolicies(c *gin.Context) {
+	productID, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	policies, err := h.reorderUsecase.GetProductReorderPolicies(productID)
+	if err != nil {
+		if err == usecase.ErrProductNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveReorderPolicy, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("reorder policies retrieved successfully", productID, len(policies))
+	c.JSON(http.StatusOK, transformer.TransformReorderPolicyEntitiesToResponse(policies))
+}
+
+// GetLowStockAlertList lists low-stock alerts filtered by status and warehouse with pagination
+func (h *ReorderHandler) GetLowStockAlertList(c *gin.Context) {
+	queryParams := dto.LowStockAlertListQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	alerts, total, err := h.reorderUsecase.ListLowStockAlerts(usecase.LowStockAlertFilter{
+		Status:      entity.LowStockAlertStatus(queryParams.Status),
+		WarehouseID: queryParams.WarehouseID,
+		Limit:       queryParams.Limit,
+		Offset:      queryParams.Offset,
+	})
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveLowStockAlert, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("low-stock alerts retrieved successfully", len(alerts))
+	c.JSON(http.StatusOK, dto.LowStockAlertListResponse{
+		LowStockAlerts:     transformer.TransformLowStockAlertEntitiesToResponse(alerts),
+		PaginationResponse: helper_handler.BuildPagination(c, total, queryParams.Limit, queryParams.Offset),
+	})
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReservationHandler struct {
+	reservationUsecase usecase.ReservationUsecase
+}
+
+func NewReservationHandler(u usecase.ReservationUsecase) *ReservationHandler {
+	return &ReservationHandler{reservationUsecase: u}
+}
+
+// CreateReservation handles reserving stock for an order
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	var req dto.CreateReservationRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	reservation, err := h.reservationUsecase.Reserve(usecase.ReservationInput{
+		ProductID:   req.ProductID,
+		WarehouseID: req.WarehouseID,
+		Quantity:    req.Quantity,
+		Reference:   req.Reference,
+		TTL:         time.Duration(req.TTLSeconds) * time.Second,
+	})
+	if err != nil {
+		handleStockError(c, err, consts.ErrFailedCreateReservation)
+		return
+	}
+
+	utility.LogSuccess("reservation created successfully", reservation.ID(), reservation.ProductID(), reservation.Quantity())
+	c.JSON(http.StatusCreated, transformer.TransformReservationEntityToResponse(reservation))
+}
+
+// GetReservation retrieves a reservation by its ID
+func (h *ReservationHandler) GetReservation(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidReservationID, http.StatusBadRequest)
+		return
+	}
+
+	reservation, err := h.reservationUsecase.GetReservationByID(id)
+	if err != nil {
+		if err == usecase.ErrReservationNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrReservationNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveReservation, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("reservation retrieved successfully", reservation.ID())
+	c.JSON(http.StatusOK, transformer.TransformReservationEntityToResponse(reservation))
+}
+
+// ConfirmReservation consumes a pending reservation. Reservations of serialized products name the
+// issued units in the body.
+func (h *ReservationHandler) ConfirmReservation(c *gin.Context) {
+	var req dto.ConfirmReservationRequest
+
+	if c.Request.ContentLength != 0 {
+		validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+		if validationErrors != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+			return
+		}
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+			return
+		}
+	}
+
+	h.transitionReservation(c, "reservation confirmed successfully", func(id uint) (*entity.Reservation, error) {
+		return h.reservationUsecase.ConfirmReservation(id, req.Serials, helper_handler.GetActor(c))
+	})
+}
+
+// ReleaseReservation gives the quantity of a pending reservation back
+func (h *ReservationHandler) ReleaseReservation(c *gin.Context) {
+	h.transitionReservation(c, "reservation released successfully", h.reservationUsecase.ReleaseReservation)
+}
+
+// transitionReservation parses the reservation ID, runs a state transition and writes the response
+func (h *ReservationHandler) transitionReservation(c *gin.Context, successMessage string, transition func(id uint) (*entity.Reservation, error)) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidReservationID, http.StatusBadRequest)
+		return
+	}
+
+	reservation, err := transition(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrReservationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrReservationNotFound})
+		case errors.Is(err, usecase.ErrReservationNotPending):
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrReservationNotPending})
+		case errors.Is(err, usecase.ErrReservationExpired):
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrReservationExpired})
+		default:
+			handleStockError(c, err, consts.ErrFailedUpdateReservation)
+		}
+		return
+	}
+
+	utility.LogSuccess(successMessage, reservation.ID(), reservation.Status())
+	c.JSON(http.StatusOK, transformer.TransformReservationEntityToResponse(reservation))
+}
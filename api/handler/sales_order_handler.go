@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SalesOrderHandler struct {
+	salesOrderUsecase usecase.SalesOrderUsecase
+}
+
+func NewSalesOrderHandler(u usecase.SalesOrderUsecase) *SalesOrderHandler {
+	return &SalesOrderHandler{salesOrderUsecase: u}
+}
+
+// CreateSalesOrder handles placing a sales order and allocating its stock
+func (h *SalesOrderHandler) CreateSalesOrder(c *gin.Context) {
+	var req dto.CreateSalesOrderRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	lines := make([]usecase.SalesOrderLineInput, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = usecase.SalesOrderLineInput{ProductID: line.ProductID, Quantity: line.Quantity, Unit: line.Unit}
+	}
+
+	order, err := h.salesOrderUsecase.CreateSalesOrder(usecase.SalesOrderInput{
+		WarehouseID:       req.WarehouseID,
+		CustomerReference: req.CustomerReference,
+		Lines:             lines,
+	})
+	if err != nil {
+		handleStockError(c, err, consts.ErrFailedCreateSalesOrder)
+		return
+	}
+
+	utility.LogSuccess("sales order created successfully", order.ID(), order.Status())
+	c.JSON(http.StatusCreated, transformer.TransformSalesOrderEntityToResponse(order))
+}
+
+// GetSalesOrder retrieves a sales order by its ID
+func (h *SalesOrderHandler) GetSalesOrder(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidSalesOrderID, http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.salesOrderUsecase.GetSalesOrderByID(id)
+	if err != nil {
+		if err == usecase.ErrSalesOrderNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrSalesOrderNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveSalesOrder, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("sales order retrieved successfully", order.ID())
+	c.JSON(http.StatusOK, transformer.TransformSalesOrderEntityToResponse(order))
+}
+
+// GetSalesOrderList lists sales orders filtered by status and warehouse with pagination
+func (h *SalesOrderHandler) GetSalesOrderList(c *gin.Context) {
+	queryParams := dto.SalesOrderListQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	orders, total, err := h.salesOrderUsecase.ListSalesOrders(usecase.SalesOrderFilter{
+		Status:      entity.SalesOrderStatus(queryParams.Status),
+		WarehouseID: queryParams.WarehouseID,
+		Limit:       queryParams.Limit,
+		Offset:      queryParams.Offset,
+	})
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveSalesOrder, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("sales orders retrieved successfully", len(orders))
+	c.JSON(http.StatusOK, dto.SalesOrderListResponse{
+		SalesOrders:        transformer.TransformSalesOrderEntitiesToResponse(orders),
+		PaginationResponse: helper_handler.BuildPagination(c, total, queryParams.Limit, queryParams.Offset),
+	})
+}
+
+// AllocateSalesOrder retries allocating the backordered quantity of an order
+func (h *SalesOrderHandler) AllocateSalesOrder(c *gin.Context) {
+	h.transitionSalesOrder(c, "sales order allocated", h.salesOrderUsecase.AllocateSalesOrder)
+}
+
+// PickSalesOrder starts picking a fully allocated order
+func (h *SalesOrderHandler) PickSalesOrder(c *gin.Context) {
+	h.transitionSalesOrder(c, "sales order picking started", h.salesOrderUsecase.PickSalesOrder)
+}
+
+// PackSalesOrder marks a picked order as packed
+func (h *SalesOrderHandler) PackSalesOrder(c *gin.Context) {
+	h.transitionSalesOrder(c, "sales order packed successfully", h.salesOrderUsecase.PackSalesOrder)
+}
+
+// ShipSalesOrder issues the stock of a packed order. Orders of serialized products name the shipped
+// units in the body.
+func (h *SalesOrderHandler) ShipSalesOrder(c *gin.Context) {
+	var req dto.ShipSalesOrderRequest
+
+	if c.Request.ContentLength != 0 {
+		validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+		if validationErrors != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+			return
+		}
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+			return
+		}
+	}
+
+	lines := make([]usecase.ShipmentLineInput, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = usecase.ShipmentLineInput{ProductID: line.ProductID, Serials: line.Serials}
+	}
+
+	h.transitionSalesOrder(c, "sales order shipped successfully", func(id uint) (*entity.SalesOrder, error) {
+		return h.salesOrderUsecase.ShipSalesOrder(id, lines, helper_handler.GetActor(c))
+	})
+}
+
+// CancelSalesOrder abandons an order that has not shipped
+func (h *SalesOrderHandler) CancelSalesOrder(c *gin.Context) {
+	h.transitionSalesOrder(c, "sales order cancelled successfully", h.salesOrderUsecase.CancelSalesOrder)
+}
+
+// transitionSalesOrder parses the sales order ID, runs a fulfilment step and writes the response
+func (h *SalesOrderHandler) transitionSalesOrder(c *gin.Context, successMessage string, transition func(id uint) (*entity.SalesOrder, error)) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidSalesOrderID, http.StatusBadRequest)
+		return
+	}
+
+	order, err := transition(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrSalesOrderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrSalesOrderNotFound})
+		case errors.Is(err, usecase.ErrSalesOrderInvalidTransition):
+			c.JSON(http.StatusConflict, gin.H{"errors": err.Error()})
+		default:
+			handleStockError(c, err, consts.ErrFailedUpdateSalesOrder)
+		}
+		return
+	}
+
+	utility.LogSuccess(successMessage, order.ID(), order.Status())
+	c.JSON(http.StatusOK, transformer.TransformSalesOrderEntityToResponse(order))
+}
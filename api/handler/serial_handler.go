@@ -0,0 +1,36 @@
+package handler
+
+import (
+	consts "inventory_management/api/handler/const"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SerialHandler struct {
+	serialUsecase usecase.SerialUsecase
+}
+
+func NewSerialHandler(u usecase.SerialUsecase) *SerialHandler {
+	return &SerialHandler{serialUsecase: u}
+}
+
+// GetSerial retrieves the current location and movement history of a serialized unit
+func (h *SerialHandler) GetSerial(c *gin.Context) {
+	history, err := h.serialUsecase.GetSerial(c.Param("serial"))
+	if err != nil {
+		if err == usecase.ErrSerialNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrSerialNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveSerial, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("serial retrieved successfully", history.Serial.SerialNumber(), history.Serial.Status())
+	c.JSON(http.StatusOK, transformer.TransformSerialEntityToResponse(history.Serial, history.Movements))
+}
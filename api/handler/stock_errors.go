@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleStockError maps the errors shared by the stock-changing use cases to HTTP responses.
+// Products and warehouses referenced from a request body are reported as unprocessable.
+func handleStockError(c *gin.Context, err error, failureMessage string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidInput):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+	case errors.Is(err, usecase.ErrProductNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrProductNotFound})
+	case errors.Is(err, usecase.ErrWarehouseNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrWarehouseNotFound})
+	case errors.Is(err, usecase.ErrLotNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrLotNotFound})
+	case errors.Is(err, usecase.ErrSerialNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrSerialNotFound})
+	case errors.Is(err, usecase.ErrProductNotStockable):
+		c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrProductNotStockable})
+	case errors.Is(err, usecase.ErrInsufficientStock):
+		c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrInsufficientStock})
+	default:
+		helper_handler.HandleErrorResponse(c, err, failureMessage, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockHandler struct {
+	stockUsecase usecase.StockUsecase
+}
+
+func NewStockHandler(u usecase.StockUsecase) *StockHandler {
+	return &StockHandler{stockUsecase: u}
+}
+
+// GetProductStock retrieves the stock levels of a product across warehouses, optionally in the unit
+// given by the unit query parameter
+func (h *StockHandler) GetProductStock(c *gin.Context) {
+	productID, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	stock, err := h.stockUsecase.GetProductStock(productID, c.Query("unit"))
+	if err != nil {
+		switch {
+		case err == usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveStock, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product stock retrieved successfully", productID, len(stock.StockLevels))
+	c.JSON(http.StatusOK, transformer.TransformProductStockToResponse(productID, stock.Unit, stock.StockLevels, stock.Conversions))
+}
+
+// GetWarehouseStock retrieves the stock levels of every product in a warehouse, optionally in the
+// unit given by the unit query parameter
+func (h *StockHandler) GetWarehouseStock(c *gin.Context) {
+	warehouseID, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidWarehouseID, http.StatusBadRequest)
+		return
+	}
+
+	stock, err := h.stockUsecase.GetWarehouseStock(warehouseID, c.Query("unit"))
+	if err != nil {
+		switch {
+		case err == usecase.ErrWarehouseNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrWarehouseNotFound})
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveStock, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("warehouse stock retrieved successfully", warehouseID, len(stock.StockLevels))
+	c.JSON(http.StatusOK, transformer.TransformWarehouseStockToResponse(warehouseID, stock.Unit, stock.StockLevels, stock.Conversions))
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockMovementHandler struct {
+	stockMovementUsecase usecase.StockMovementUsecase
+}
+
+func NewStockMovementHandler(u usecase.StockMovementUsecase) *StockMovementHandler {
+	return &StockMovementHandler{stockMovementUsecase: u}
+}
+
+// CreateStockMovement handles recording a quantity change in the ledger
+func (h *StockMovementHandler) CreateStockMovement(c *gin.Context) {
+	var req dto.CreateStockMovementRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	input := usecase.StockMovementInput{
+		ProductID:   req.ProductID,
+		WarehouseID: req.WarehouseID,
+		Type:        entity.MovementType(req.Type),
+		Quantity:    req.Quantity,
+		Unit:        req.Unit,
+		ReasonCode:  req.ReasonCode,
+		Reference:   req.Reference,
+		Actor:       helper_handler.GetActor(c),
+		Serials:     req.Serials,
+	}
+	if req.OccurredAt != nil {
+		input.OccurredAt = *req.OccurredAt
+	}
+	if req.LotNumber != "" {
+		input.Lot = &usecase.LotInput{Number: req.LotNumber, ManufacturedAt: req.ManufacturedAt}
+		if req.ExpiresAt != nil {
+			input.Lot.ExpiresAt = *req.ExpiresAt
+		}
+	}
+
+	movement, err := h.stockMovementUsecase.RecordMovement(input)
+	if err != nil {
+		handleStockError(c, err, consts.ErrFailedRecordMovement)
+		return
+	}
+
+	utility.LogSuccess("stock movement recorded successfully", movement.ID(), movement.ProductID(), movement.Quantity())
+	c.JSON(http.StatusCreated, transformer.TransformStockMovementEntityToResponse(movement))
+}
+
+// GetProductMovements lists the ledger of a product with filters and pagination
+func (h *StockMovementHandler) GetProductMovements(c *gin.Context) {
+	productID, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	queryParams := dto.StockMovementListQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	movements, total, err := h.stockMovementUsecase.ListProductMovements(usecase.StockMovementFilter{
+		ProductID:    productID,
+		WarehouseID:  queryParams.WarehouseID,
+		MovementType: entity.MovementType(queryParams.Type),
+		From:         queryParams.From,
+		To:           queryParams.To,
+		Limit:        queryParams.Limit,
+		Offset:       queryParams.Offset,
+	})
+	if err != nil {
+		if err == usecase.ErrProductNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveMovements, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("stock movements retrieved successfully", productID, len(movements))
+	c.JSON(http.StatusOK, dto.StockMovementListResponse{
+		Movements:          transformer.TransformStockMovementEntitiesToResponse(movements),
+		PaginationResponse: helper_handler.BuildPagination(c, total, queryParams.Limit, queryParams.Offset),
+	})
+}
+
+// GetProductStockReconciliation compares the stored stock of a product with its ledger
+func (h *StockMovementHandler) GetProductStockReconciliation(c *gin.Context) {
+	productID, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	reconciliations, err := h.stockMovementUsecase.ReconcileProductStock(productID)
+	if err != nil {
+		if err == usecase.ErrProductNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedReconcileStock, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("stock reconciled successfully", productID, len(reconciliations))
+	c.JSON(http.StatusOK, transformer.TransformStockReconciliationsToResponse(productID, reconciliations))
+}
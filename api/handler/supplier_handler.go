@@ -0,0 +1,89 @@
+package handler
+
+import (
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SupplierHandler struct {
+	supplierUsecase usecase.SupplierUsecase
+}
+
+func NewSupplierHandler(u usecase.SupplierUsecase) *SupplierHandler {
+	return &SupplierHandler{supplierUsecase: u}
+}
+
+// CreateSupplier handles the creation of a new supplier
+func (h *SupplierHandler) CreateSupplier(c *gin.Context) {
+	var req dto.CreateSupplierRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	supplier, err := h.supplierUsecase.CreateSupplier(req.Code, req.Name, req.ContactEmail)
+	if err != nil {
+		if err == usecase.ErrSupplierCodeTaken {
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrSupplierCodeTaken})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedCreateSupplier, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("supplier created successfully", supplier.ID(), supplier.Code())
+	c.JSON(http.StatusCreated, transformer.TransformSupplierEntityToResponse(supplier))
+}
+
+// GetSupplier retrieves a supplier by its ID
+func (h *SupplierHandler) GetSupplier(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidSupplierID, http.StatusBadRequest)
+		return
+	}
+
+	supplier, err := h.supplierUsecase.GetSupplierByID(id)
+	if err != nil {
+		if err == usecase.ErrSupplierNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrSupplierNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveSupplier, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("supplier retrieved successfully", supplier.ID(), supplier.Code())
+	c.JSON(http.StatusOK, transformer.TransformSupplierEntityToResponse(supplier))
+}
+
+// GetSupplierList lists every supplier
+func (h *SupplierHandler) GetSupplierList(c *gin.Context) {
+	suppliers, err := h.supplierUsecase.ListSuppliers()
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveSupplier, http.StatusInternalServerError)
+		return
+	}
+
+	supplierResponses := make([]*dto.SupplierResponse, len(suppliers))
+	for i, supplier := range suppliers {
+		supplierResponses[i] = transformer.TransformSupplierEntityToResponse(supplier)
+	}
+
+	utility.LogSuccess("supplier list retrieved successfully", len(suppliers), "suppliers")
+	c.JSON(http.StatusOK, gin.H{"suppliers": supplierResponses})
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferHandler struct {
+	transferUsecase usecase.TransferUsecase
+}
+
+func NewTransferHandler(u usecase.TransferUsecase) *TransferHandler {
+	return &TransferHandler{transferUsecase: u}
+}
+
+// CreateTransfer handles drafting a transfer between two warehouses
+func (h *TransferHandler) CreateTransfer(c *gin.Context) {
+	var req dto.CreateTransferRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	transfer, err := h.transferUsecase.CreateTransfer(usecase.TransferInput{
+		SourceWarehouseID:      req.SourceWarehouseID,
+		DestinationWarehouseID: req.DestinationWarehouseID,
+		Reference:              req.Reference,
+		Lines:                  transferLineInputs(req.Lines),
+	})
+	if err != nil {
+		handleStockError(c, err, consts.ErrFailedCreateTransfer)
+		return
+	}
+
+	utility.LogSuccess("transfer created successfully", transfer.ID(), transfer.SourceWarehouseID(), transfer.DestinationWarehouseID())
+	c.JSON(http.StatusCreated, transformer.TransformTransferEntityToResponse(transfer))
+}
+
+// GetTransfer retrieves a transfer by its ID
+func (h *TransferHandler) GetTransfer(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidTransferID, http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := h.transferUsecase.GetTransferByID(id)
+	if err != nil {
+		if err == usecase.ErrTransferNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrTransferNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveTransfer, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("transfer retrieved successfully", transfer.ID())
+	c.JSON(http.StatusOK, transformer.TransformTransferEntityToResponse(transfer))
+}
+
+// GetTransferList lists transfers filtered by status and warehouse with pagination
+func (h *TransferHandler) GetTransferList(c *gin.Context) {
+	queryParams := dto.TransferListQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	transfers, total, err := h.transferUsecase.ListTransfers(usecase.TransferFilter{
+		Status:      entity.TransferStatus(queryParams.Status),
+		WarehouseID: queryParams.WarehouseID,
+		Limit:       queryParams.Limit,
+		Offset:      queryParams.Offset,
+	})
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveTransfer, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("transfers retrieved successfully", len(transfers))
+	c.JSON(http.StatusOK, dto.TransferListResponse{
+		Transfers:          transformer.TransformTransferEntitiesToResponse(transfers),
+		PaginationResponse: helper_handler.BuildPagination(c, total, queryParams.Limit, queryParams.Offset),
+	})
+}
+
+// DispatchTransfer takes the stock of a draft transfer out of its source warehouse. Lines of serialized
+// products name the dispatched units in the body.
+func (h *TransferHandler) DispatchTransfer(c *gin.Context) {
+	var req dto.DispatchTransferRequest
+
+	if c.Request.ContentLength != 0 {
+		validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+		if validationErrors != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+			return
+		}
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+			return
+		}
+	}
+
+	lines := make([]usecase.ShipmentLineInput, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = usecase.ShipmentLineInput{ProductID: line.ProductID, Serials: line.Serials}
+	}
+
+	h.transitionTransfer(c, "transfer dispatched successfully", func(id uint) (*entity.Transfer, error) {
+		return h.transferUsecase.DispatchTransfer(id, lines, helper_handler.GetActor(c))
+	})
+}
+
+// ReceiveTransfer puts arrived stock into the destination warehouse. An empty body receives
+// everything still in transit.
+func (h *TransferHandler) ReceiveTransfer(c *gin.Context) {
+	var req dto.ReceiveTransferRequest
+
+	if c.Request.ContentLength != 0 {
+		validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+		if validationErrors != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+			return
+		}
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+			return
+		}
+	}
+
+	h.transitionTransfer(c, "transfer received successfully", func(id uint) (*entity.Transfer, error) {
+		return h.transferUsecase.ReceiveTransfer(id, receivedLineInputs(req.Lines), helper_handler.GetActor(c))
+	})
+}
+
+// CancelTransfer abandons a draft transfer
+func (h *TransferHandler) CancelTransfer(c *gin.Context) {
+	h.transitionTransfer(c, "transfer cancelled successfully", h.transferUsecase.CancelTransfer)
+}
+
+// CloseTransfer writes off whatever of a dispatched transfer has not arrived, with the reason code given
+// in the body
+func (h *TransferHandler) CloseTransfer(c *gin.Context) {
+	var req dto.CloseTransferRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	h.transitionTransfer(c, "transfer closed successfully", func(id uint) (*entity.Transfer, error) {
+		return h.transferUsecase.CloseTransfer(id, req.ReasonCode)
+	})
+}
+
+// transitionTransfer parses the transfer ID, runs a state transition and writes the response
+func (h *TransferHandler) transitionTransfer(c *gin.Context, successMessage string, transition func(id uint) (*entity.Transfer, error)) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidTransferID, http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := transition(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrTransferNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrTransferNotFound})
+		case errors.Is(err, usecase.ErrTransferNotDraft):
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrTransferNotDraft})
+		case errors.Is(err, usecase.ErrTransferNotInTransit):
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrTransferNotInTransit})
+		default:
+			handleStockError(c, err, consts.ErrFailedUpdateTransfer)
+		}
+		return
+	}
+
+	utility.LogSuccess(successMessage, transfer.ID(), transfer.Status())
+	c.JSON(http.StatusOK, transformer.TransformTransferEntityToResponse(transfer))
+}
+
+// transferLineInputs converts request lines to use case input
+func transferLineInputs(lines []dto.TransferLineRequest) []usecase.TransferLineInput {
+	inputs := make([]usecase.TransferLineInput, len(lines))
+	for i, line := range lines {
+		inputs[i] = usecase.TransferLineInput{ProductID: line.ProductID, Quantity: line.Quantity}
+	}
+	return inputs
+}
+
+// receivedLineInputs converts the lines of a receipt request to use case input
+func receivedLineInputs(lines []dto.ReceiveTransferLineRequest) []usecase.TransferLineInput {
+	inputs := make([]usecase.TransferLineInput, len(lines))
+	for i, line := range lines {
+		inputs[i] = usecase.TransferLineInput{ProductID: line.ProductID, Quantity: line.Quantity, Serials: line.Serials}
+	}
+	return inputs
+}
@@ -0,0 +1,60 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformCategoryEntityToResponse transforms an entity.Category to a dto.CategoryResponse
+func TransformCategoryEntityToResponse(c *entity.Category) *dto.CategoryResponse {
+	response := &dto.CategoryResponse{
+		ID:        c.ID(),
+		Name:      c.Name(),
+		CreatedAt: c.CreatedAt(),
+		UpdatedAt: c.UpdatedAt(),
+	}
+	if parentID := c.ParentID(); parentID != 0 {
+		response.ParentID = &parentID
+	}
+	return response
+}
+
+// TransformCategoryEntitiesToResponse transforms a slice of entity.Category
+func TransformCategoryEntitiesToResponse(categories []*entity.Category) []*dto.CategoryResponse {
+	responses := make([]*dto.CategoryResponse, len(categories))
+	for i, category := range categories {
+		responses[i] = TransformCategoryEntityToResponse(category)
+	}
+	return responses
+}
+
+// TransformCategoryDetailToResponse transforms a category with its ancestors and children to a dto.CategoryDetailResponse
+func TransformCategoryDetailToResponse(c *entity.Category, ancestors []*entity.Category, children []*entity.Category) *dto.CategoryDetailResponse {
+	return &dto.CategoryDetailResponse{
+		CategoryResponse: *TransformCategoryEntityToResponse(c),
+		Ancestors:        TransformCategoryEntitiesToResponse(ancestors),
+		Children:         TransformCategoryEntitiesToResponse(children),
+	}
+}
+
+// TransformCategoryTreeToResponse nests a list of categories, in which parents come before their
+// children, into trees below the root categories
+func TransformCategoryTreeToResponse(categories []*entity.Category) []*dto.CategoryTreeResponse {
+	roots := []*dto.CategoryTreeResponse{}
+	nodes := make(map[uint]*dto.CategoryTreeResponse, len(categories))
+	for _, category := range categories {
+		node := &dto.CategoryTreeResponse{
+			ID:       category.ID(),
+			Name:     category.Name(),
+			Children: []*dto.CategoryTreeResponse{},
+		}
+		nodes[category.ID()] = node
+
+		if parent, ok := nodes[category.ParentID()]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
@@ -0,0 +1,32 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+	"time"
+)
+
+// TransformLotEntityToResponse transforms an entity.Lot to a dto.LotResponse, flagging whether it expired by now
+func TransformLotEntityToResponse(l *entity.Lot, now time.Time) *dto.LotResponse {
+	return &dto.LotResponse{
+		ID:             l.ID(),
+		ProductID:      l.ProductID(),
+		WarehouseID:    l.WarehouseID(),
+		LotNumber:      l.LotNumber(),
+		ManufacturedAt: l.ManufacturedAt(),
+		ExpiresAt:      l.ExpiresAt(),
+		Expired:        l.IsExpired(now),
+		Quantity:       l.Quantity(),
+		CreatedAt:      l.CreatedAt(),
+		UpdatedAt:      l.UpdatedAt(),
+	}
+}
+
+// TransformLotEntitiesToResponse transforms a slice of entity.Lot
+func TransformLotEntitiesToResponse(lots []*entity.Lot, now time.Time) []*dto.LotResponse {
+	responses := make([]*dto.LotResponse, len(lots))
+	for i, lot := range lots {
+		responses[i] = TransformLotEntityToResponse(lot, now)
+	}
+	return responses
+}
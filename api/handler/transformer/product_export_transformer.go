@@ -0,0 +1,58 @@
+package transformer
+
+import (
+	"encoding/json"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+	"strconv"
+	"time"
+)
+
+// ProductExportHeader names the columns of a product catalogue export. The columns shared with
+// product imports carry the same names, so an export can be imported again.
+var ProductExportHeader = []string{
+	"id", dto.ImportFieldName, dto.ImportFieldSKU, "description", "brand", "status", "weight_grams",
+	"length_mm", "width_mm", "height_mm", "tags", "attributes", dto.ImportFieldSerialized,
+	dto.ImportFieldStockUnit, dto.ImportFieldCategoryID, "version", "created_at", "updated_at", "deleted_at",
+}
+
+// TransformProductEntityToExportRecord transforms an entity.Product to the cells of an export row, in
+// the order of ProductExportHeader. Tags and attributes are written as JSON, times as RFC 3339.
+func TransformProductEntityToExportRecord(p *entity.Product) []string {
+	record := []string{
+		strconv.FormatUint(uint64(p.ID()), 10),
+		p.Name(),
+		p.SKU(),
+		p.Description(),
+		p.Brand(),
+		string(p.Status()),
+		strconv.FormatInt(p.WeightGrams(), 10),
+		strconv.FormatInt(p.Dimensions().LengthMM, 10),
+		strconv.FormatInt(p.Dimensions().WidthMM, 10),
+		strconv.FormatInt(p.Dimensions().HeightMM, 10),
+		"",
+		"",
+		strconv.FormatBool(p.IsSerialized()),
+		p.StockUnit(),
+		"",
+		strconv.FormatInt(p.Version(), 10),
+		p.CreatedAt().UTC().Format(time.RFC3339),
+		p.UpdatedAt().UTC().Format(time.RFC3339),
+		"",
+	}
+	if tags := p.Tags(); len(tags) > 0 {
+		encoded, _ := json.Marshal(tags)
+		record[10] = string(encoded)
+	}
+	if attributes := p.Attributes(); len(attributes) > 0 {
+		encoded, _ := json.Marshal(attributes)
+		record[11] = string(encoded)
+	}
+	if categoryID := p.CategoryID(); categoryID != 0 {
+		record[14] = strconv.FormatUint(uint64(categoryID), 10)
+	}
+	if deletedAt := p.DeletedAt(); deletedAt != nil {
+		record[18] = deletedAt.UTC().Format(time.RFC3339)
+	}
+	return record
+}
@@ -0,0 +1,33 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformProductAuditRecordToResponse transforms an entity.ProductAuditRecord to a dto.ProductAuditRecordResponse
+func TransformProductAuditRecordToResponse(r *entity.ProductAuditRecord) *dto.ProductAuditRecordResponse {
+	changes := make([]*dto.FieldChangeResponse, len(r.Changes()))
+	for i, change := range r.Changes() {
+		changes[i] = &dto.FieldChangeResponse{Field: change.Field, Before: change.Before, After: change.After}
+	}
+	return &dto.ProductAuditRecordResponse{
+		ID:         r.ID(),
+		ProductID:  r.ProductID(),
+		Action:     string(r.Action()),
+		Actor:      r.Origin().Actor,
+		RequestID:  r.Origin().RequestID,
+		SourceIP:   r.Origin().SourceIP,
+		Changes:    changes,
+		OccurredAt: r.OccurredAt(),
+	}
+}
+
+// TransformProductAuditRecordsToResponse transforms a slice of entity.ProductAuditRecord
+func TransformProductAuditRecordsToResponse(records []*entity.ProductAuditRecord) []*dto.ProductAuditRecordResponse {
+	responses := make([]*dto.ProductAuditRecordResponse, len(records))
+	for i, record := range records {
+		responses[i] = TransformProductAuditRecordToResponse(record)
+	}
+	return responses
+}
@@ -0,0 +1,35 @@
+package transformer
+
+import (
+	"fmt"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformProductImportEntityToResponse transforms an entity.ProductImport to a dto.ProductImportResponse
+func TransformProductImportEntityToResponse(i *entity.ProductImport) *dto.ProductImportResponse {
+	rejectedRows := make([]dto.RejectedProductRowResponse, len(i.RejectedRows()))
+	for j, row := range i.RejectedRows() {
+		rejectedRows[j] = dto.RejectedProductRowResponse{Line: row.Line, SKU: row.SKU, Name: row.Name, Errors: row.Errors}
+	}
+	response := &dto.ProductImportResponse{
+		ID:           i.ID(),
+		FileName:     i.FileName(),
+		DryRun:       i.DryRun(),
+		Status:       "completed",
+		TotalRows:    i.TotalRows(),
+		Created:      i.Created(),
+		Updated:      i.Updated(),
+		Unchanged:    i.Unchanged(),
+		Rejected:     i.Rejected(),
+		RejectedRows: rejectedRows,
+		ReportURL:    fmt.Sprintf("/api/v1/products/imports/%d/report", i.ID()),
+		CreatedAt:    i.CreatedAt(),
+	}
+	if i.Failed() {
+		failedAtLine := i.FailedAtLine()
+		response.Status = "failed"
+		response.FailedAtLine = &failedAtLine
+	}
+	return response
+}
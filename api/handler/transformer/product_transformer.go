@@ -7,11 +7,87 @@ import (
 
 // TransformProductEntityToResponse transforms an entity.Product to a dto.ProductResponse
 func TransformProductEntityToResponse(p *entity.Product) *dto.ProductResponse {
-	return &dto.ProductResponse{
-		ID:        p.ID(),
-		Name:      p.Name(),
-		SKU:       p.SKU(),
-		CreatedAt: p.CreatedAt(), // Assuming the entity has these methods
-		UpdatedAt: p.UpdatedAt(),
+	response := &dto.ProductResponse{
+		ID:          p.ID(),
+		Name:        p.Name(),
+		SKU:         p.SKU(),
+		Description: p.Description(),
+		Brand:       p.Brand(),
+		Status:      string(p.Status()),
+		WeightGrams: p.WeightGrams(),
+		Dimensions: dto.DimensionsResponse{
+			LengthMM: p.Dimensions().LengthMM,
+			WidthMM:  p.Dimensions().WidthMM,
+			HeightMM: p.Dimensions().HeightMM,
+		},
+		Tags:       p.Tags(),
+		Attributes: p.Attributes(),
+		Serialized: p.IsSerialized(),
+		StockUnit:  p.StockUnit(),
+		CreatedAt:  p.CreatedAt(), // Assuming the entity has these methods
+		UpdatedAt:  p.UpdatedAt(),
+		DeletedAt:  p.DeletedAt(),
+		Version:    p.Version(),
 	}
+	if categoryID := p.CategoryID(); categoryID != 0 {
+		response.CategoryID = &categoryID
+	}
+	return response
+}
+
+// TransformProductVariantToResponse transforms an entity.ProductVariant to a dto.ProductResponse
+// carrying its parent and option values
+func TransformProductVariantToResponse(v *entity.ProductVariant) *dto.ProductResponse {
+	response := TransformProductEntityToResponse(v.Product())
+	parentID := v.ParentID()
+	response.ParentID = &parentID
+	response.Options = make([]*dto.VariantOptionResponse, len(v.Options()))
+	for i, option := range v.Options() {
+		response.Options[i] = &dto.VariantOptionResponse{Name: option.Name(), Value: option.Value()}
+	}
+	return response
+}
+
+// TransformProductWithVariantsToResponse transforms a product to a dto.ProductResponse listing its
+// variants, or describing the variant it is when variantOf is set
+func TransformProductWithVariantsToResponse(p *entity.Product, variantOf *entity.ProductVariant, variants []*entity.ProductVariant) *dto.ProductResponse {
+	if variantOf != nil {
+		return TransformProductVariantToResponse(variantOf)
+	}
+	response := TransformProductEntityToResponse(p)
+	if len(variants) > 0 {
+		response.Variants = make([]*dto.ProductResponse, len(variants))
+		for i, variant := range variants {
+			response.Variants[i] = TransformProductVariantToResponse(variant)
+		}
+	}
+	return response
+}
+
+// TransformSKUAliasesToResponse transforms the former SKUs of a product to dto.SKUAliasResponses
+func TransformSKUAliasesToResponse(aliases []*entity.SKUAlias) []*dto.SKUAliasResponse {
+	responses := make([]*dto.SKUAliasResponse, len(aliases))
+	for i, alias := range aliases {
+		responses[i] = &dto.SKUAliasResponse{SKU: alias.SKU(), RetiredAt: alias.CreatedAt()}
+	}
+	return responses
+}
+
+// TransformBarcodeToResponse transforms an entity.Barcode to a dto.BarcodeResponse
+func TransformBarcodeToResponse(b *entity.Barcode) *dto.BarcodeResponse {
+	return &dto.BarcodeResponse{
+		Code:      b.Code(),
+		Type:      string(b.Type()),
+		GTIN:      b.GTIN(),
+		CreatedAt: b.CreatedAt(),
+	}
+}
+
+// TransformBarcodesToResponse transforms the barcodes of a product to dto.BarcodeResponses
+func TransformBarcodesToResponse(barcodes []*entity.Barcode) []*dto.BarcodeResponse {
+	responses := make([]*dto.BarcodeResponse, len(barcodes))
+	for i, barcode := range barcodes {
+		responses[i] = TransformBarcodeToResponse(barcode)
+	}
+	return responses
 }
@@ -0,0 +1,45 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformPurchaseOrderEntityToResponse transforms an entity.PurchaseOrder to a dto.PurchaseOrderResponse
+func TransformPurchaseOrderEntityToResponse(o *entity.PurchaseOrder) *dto.PurchaseOrderResponse {
+	lines := make([]*dto.PurchaseOrderLineResponse, len(o.Lines()))
+	for i, line := range o.Lines() {
+		lines[i] = &dto.PurchaseOrderLineResponse{
+			ProductID:        line.ProductID(),
+			Quantity:         line.Quantity(),
+			ReceivedQuantity: line.ReceivedQuantity(),
+			Outstanding:      line.Outstanding(),
+			UnitCost:         line.UnitCost(),
+			LineTotal:        line.LineTotal(),
+		}
+	}
+
+	return &dto.PurchaseOrderResponse{
+		ID:          o.ID(),
+		SupplierID:  o.SupplierID(),
+		WarehouseID: o.WarehouseID(),
+		Status:      string(o.Status()),
+		Reference:   o.Reference(),
+		Currency:    o.Currency(),
+		Total:       o.Total(),
+		Lines:       lines,
+		SubmittedAt: o.SubmittedAt(),
+		ClosedAt:    o.ClosedAt(),
+		CreatedAt:   o.CreatedAt(),
+		UpdatedAt:   o.UpdatedAt(),
+	}
+}
+
+// TransformPurchaseOrderEntitiesToResponse transforms a slice of entity.PurchaseOrder
+func TransformPurchaseOrderEntitiesToResponse(orders []*entity.PurchaseOrder) []*dto.PurchaseOrderResponse {
+	responses := make([]*dto.PurchaseOrderResponse, len(orders))
+	for i, order := range orders {
+		responses[i] = TransformPurchaseOrderEntityToResponse(order)
+	}
+	return responses
+}
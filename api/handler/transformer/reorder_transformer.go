@@ -0,0 +1,63 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformReorderPolicyEntityToResponse transforms an entity.ReorderPolicy to a dto.ReorderPolicyResponse
+func TransformReorderPolicyEntityToResponse(p *entity.ReorderPolicy) *dto.ReorderPolicyResponse {
+	response := &dto.ReorderPolicyResponse{
+		ID:              p.ID(),
+		ProductID:       p.ProductID(),
+		WarehouseID:     p.WarehouseID(),
+		ReorderPoint:    p.ReorderPoint(),
+		ReorderQuantity: p.ReorderQuantity(),
+		SafetyStock:     p.SafetyStock(),
+		CreatedAt:       p.CreatedAt(),
+		UpdatedAt:       p.UpdatedAt(),
+	}
+	if supplierID := p.PreferredSupplierID(); supplierID != 0 {
+		response.PreferredSupplierID = &supplierID
+	}
+	return response
+}
+
+// TransformReorderPolicyEntitiesToResponse transforms a slice of entity.ReorderPolicy
+func TransformReorderPolicyEntitiesToResponse(policies []*entity.ReorderPolicy) []*dto.ReorderPolicyResponse {
+	responses := make([]*dto.ReorderPolicyResponse, len(policies))
+	for i, policy := range policies {
+		responses[i] = TransformReorderPolicyEntityToResponse(policy)
+	}
+	return responses
+}
+
+// TransformLowStockAlertEntityToResponse transforms an entity.LowStockAlert to a dto.LowStockAlertResponse
+func TransformLowStockAlertEntityToResponse(a *entity.LowStockAlert) *dto.LowStockAlertResponse {
+	response := &dto.LowStockAlertResponse{
+		ID:                a.ID(),
+		ProductID:         a.ProductID(),
+		WarehouseID:       a.WarehouseID(),
+		Available:         a.Available(),
+		ReorderPoint:      a.ReorderPoint(),
+		SafetyStock:       a.SafetyStock(),
+		BelowSafetyStock:  a.BelowSafetyStock(),
+		SuggestedQuantity: a.SuggestedQuantity(),
+		Status:            string(a.Status()),
+		RaisedAt:          a.RaisedAt(),
+		ResolvedAt:        a.ResolvedAt(),
+	}
+	if purchaseOrderID := a.PurchaseOrderID(); purchaseOrderID != 0 {
+		response.PurchaseOrderID = &purchaseOrderID
+	}
+	return response
+}
+
+// TransformLowStockAlertEntitiesToResponse transforms a slice of entity.LowStockAlert
+func TransformLowStockAlertEntitiesToResponse(alerts []*entity.LowStockAlert) []*dto.LowStockAlertResponse {
+	responses := make([]*dto.LowStockAlertResponse, len(alerts))
+	for i, alert := range alerts {
+		responses[i] = TransformLowStockAlertEntityToResponse(alert)
+	}
+	return responses
+}
@@ -0,0 +1,21 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformReservationEntityToResponse transforms an entity.Reservation to a dto.ReservationResponse
+func TransformReservationEntityToResponse(r *entity.Reservation) *dto.ReservationResponse {
+	return &dto.ReservationResponse{
+		ID:          r.ID(),
+		ProductID:   r.ProductID(),
+		WarehouseID: r.WarehouseID(),
+		Quantity:    r.Quantity(),
+		Status:      string(r.Status()),
+		Reference:   r.Reference(),
+		ExpiresAt:   r.ExpiresAt(),
+		CreatedAt:   r.CreatedAt(),
+		UpdatedAt:   r.UpdatedAt(),
+	}
+}
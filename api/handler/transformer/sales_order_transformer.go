@@ -0,0 +1,40 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformSalesOrderEntityToResponse transforms an entity.SalesOrder to a dto.SalesOrderResponse
+func TransformSalesOrderEntityToResponse(o *entity.SalesOrder) *dto.SalesOrderResponse {
+	lines := make([]*dto.SalesOrderLineResponse, len(o.Lines()))
+	for i, line := range o.Lines() {
+		lines[i] = &dto.SalesOrderLineResponse{
+			ProductID:         line.ProductID(),
+			Quantity:          line.Quantity(),
+			AllocatedQuantity: line.AllocatedQuantity(),
+			Backordered:       line.Backordered(),
+		}
+	}
+
+	return &dto.SalesOrderResponse{
+		ID:                o.ID(),
+		WarehouseID:       o.WarehouseID(),
+		CustomerReference: o.CustomerReference(),
+		Status:            string(o.Status()),
+		Backordered:       o.Backordered(),
+		Lines:             lines,
+		ShippedAt:         o.ShippedAt(),
+		CreatedAt:         o.CreatedAt(),
+		UpdatedAt:         o.UpdatedAt(),
+	}
+}
+
+// TransformSalesOrderEntitiesToResponse transforms a slice of entity.SalesOrder
+func TransformSalesOrderEntitiesToResponse(orders []*entity.SalesOrder) []*dto.SalesOrderResponse {
+	responses := make([]*dto.SalesOrderResponse, len(orders))
+	for i, order := range orders {
+		responses[i] = TransformSalesOrderEntityToResponse(order)
+	}
+	return responses
+}
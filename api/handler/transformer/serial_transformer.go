@@ -0,0 +1,23 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformSerialEntityToResponse transforms an entity.Serial and the movements that moved it to a dto.SerialResponse
+func TransformSerialEntityToResponse(s *entity.Serial, movements []*entity.StockMovement) *dto.SerialResponse {
+	response := &dto.SerialResponse{
+		ID:           s.ID(),
+		ProductID:    s.ProductID(),
+		SerialNumber: s.SerialNumber(),
+		Status:       string(s.Status()),
+		History:      TransformStockMovementEntitiesToResponse(movements),
+		CreatedAt:    s.CreatedAt(),
+		UpdatedAt:    s.UpdatedAt(),
+	}
+	if warehouseID := s.WarehouseID(); warehouseID != 0 {
+		response.WarehouseID = &warehouseID
+	}
+	return response
+}
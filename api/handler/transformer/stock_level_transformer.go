@@ -0,0 +1,62 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformStockLevelEntityToResponse transforms an entity.StockLevel to a dto.StockLevelResponse.
+// A nil conversion keeps the quantities in stock units.
+func TransformStockLevelEntityToResponse(s *entity.StockLevel, conversion *entity.ProductUnit) *dto.StockLevelResponse {
+	return &dto.StockLevelResponse{
+		ProductID:   s.ProductID(),
+		WarehouseID: s.WarehouseID(),
+		OnHand:      convertQuantity(s.OnHand(), conversion),
+		Reserved:    convertQuantity(s.Reserved(), conversion),
+		Available:   convertQuantity(s.Available(), conversion),
+		UpdatedAt:   s.UpdatedAt(),
+	}
+}
+
+// TransformProductStockToResponse transforms the stock levels of a product to a dto.ProductStockResponse
+func TransformProductStockToResponse(productID uint, unit string, stockLevels []*entity.StockLevel, conversions map[uint]*entity.ProductUnit) *dto.ProductStockResponse {
+	responses, totals := transformStockLevels(stockLevels, conversions)
+	return &dto.ProductStockResponse{
+		ProductID:   productID,
+		Unit:        unit,
+		Totals:      totals,
+		StockLevels: responses,
+	}
+}
+
+// TransformWarehouseStockToResponse transforms the stock levels of a warehouse to a dto.WarehouseStockResponse
+func TransformWarehouseStockToResponse(warehouseID uint, unit string, stockLevels []*entity.StockLevel, conversions map[uint]*entity.ProductUnit) *dto.WarehouseStockResponse {
+	responses, totals := transformStockLevels(stockLevels, conversions)
+	return &dto.WarehouseStockResponse{
+		WarehouseID: warehouseID,
+		Unit:        unit,
+		Totals:      totals,
+		StockLevels: responses,
+	}
+}
+
+// transformStockLevels transforms a slice of stock levels and sums their quantities
+func transformStockLevels(stockLevels []*entity.StockLevel, conversions map[uint]*entity.ProductUnit) ([]*dto.StockLevelResponse, dto.StockTotalsResponse) {
+	responses := make([]*dto.StockLevelResponse, len(stockLevels))
+	totals := dto.StockTotalsResponse{}
+	for i, stockLevel := range stockLevels {
+		responses[i] = TransformStockLevelEntityToResponse(stockLevel, conversions[stockLevel.ProductID()])
+		totals.OnHand += responses[i].OnHand
+		totals.Reserved += responses[i].Reserved
+		totals.Available += responses[i].Available
+	}
+	return responses, totals
+}
+
+// convertQuantity expresses a quantity in stock units in the unit of the conversion
+func convertQuantity(quantity int64, conversion *entity.ProductUnit) float64 {
+	if conversion == nil {
+		return float64(quantity)
+	}
+	return conversion.FromStockUnits(quantity)
+}
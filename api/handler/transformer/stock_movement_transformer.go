@@ -0,0 +1,52 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformStockMovementEntityToResponse transforms an entity.StockMovement to a dto.StockMovementResponse
+func TransformStockMovementEntityToResponse(m *entity.StockMovement) *dto.StockMovementResponse {
+	return &dto.StockMovementResponse{
+		ID:           m.ID(),
+		ProductID:    m.ProductID(),
+		WarehouseID:  m.WarehouseID(),
+		Type:         string(m.Type()),
+		Quantity:     m.Quantity(),
+		BalanceAfter: m.BalanceAfter(),
+		ReasonCode:   m.ReasonCode(),
+		Reference:    m.Reference(),
+		Actor:        m.Actor(),
+		OccurredAt:   m.OccurredAt(),
+		CreatedAt:    m.CreatedAt(),
+	}
+}
+
+// TransformStockMovementEntitiesToResponse transforms a slice of entity.StockMovement
+func TransformStockMovementEntitiesToResponse(movements []*entity.StockMovement) []*dto.StockMovementResponse {
+	responses := make([]*dto.StockMovementResponse, len(movements))
+	for i, movement := range movements {
+		responses[i] = TransformStockMovementEntityToResponse(movement)
+	}
+	return responses
+}
+
+// TransformStockReconciliationsToResponse transforms the reconciliations of a product to a dto.ProductStockReconciliationResponse
+func TransformStockReconciliationsToResponse(productID uint, reconciliations []*entity.StockReconciliation) *dto.ProductStockReconciliationResponse {
+	response := &dto.ProductStockReconciliationResponse{
+		ProductID:       productID,
+		InSync:          true,
+		Reconciliations: make([]*dto.StockReconciliationResponse, len(reconciliations)),
+	}
+	for i, reconciliation := range reconciliations {
+		response.Reconciliations[i] = &dto.StockReconciliationResponse{
+			WarehouseID:   reconciliation.WarehouseID(),
+			OnHand:        reconciliation.OnHand(),
+			LedgerBalance: reconciliation.LedgerBalance(),
+			Discrepancy:   reconciliation.Discrepancy(),
+			InSync:        reconciliation.InSync(),
+		}
+		response.InSync = response.InSync && reconciliation.InSync()
+	}
+	return response
+}
@@ -0,0 +1,18 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformSupplierEntityToResponse transforms an entity.Supplier to a dto.SupplierResponse
+func TransformSupplierEntityToResponse(s *entity.Supplier) *dto.SupplierResponse {
+	return &dto.SupplierResponse{
+		ID:           s.ID(),
+		Code:         s.Code(),
+		Name:         s.Name(),
+		ContactEmail: s.ContactEmail(),
+		CreatedAt:    s.CreatedAt(),
+		UpdatedAt:    s.UpdatedAt(),
+	}
+}
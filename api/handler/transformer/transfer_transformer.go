@@ -0,0 +1,64 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformTransferEntityToResponse transforms an entity.Transfer to a dto.TransferResponse
+func TransformTransferEntityToResponse(t *entity.Transfer) *dto.TransferResponse {
+	lines := make([]*dto.TransferLineResponse, len(t.Lines()))
+	for i, line := range t.Lines() {
+		inTransit, writtenOff := int64(0), int64(0)
+		switch {
+		case t.InTransitStatus():
+			inTransit = line.Outstanding()
+		case t.Status() == entity.TransferStatusClosed:
+			writtenOff = line.Outstanding()
+		}
+		lots := make([]*dto.TransferLotResponse, len(line.Lots()))
+		for j, lot := range line.Lots() {
+			lots[j] = &dto.TransferLotResponse{
+				LotNumber:        lot.LotNumber(),
+				ManufacturedAt:   lot.ManufacturedAt(),
+				ExpiresAt:        lot.ExpiresAt(),
+				Quantity:         lot.Quantity(),
+				ReceivedQuantity: lot.ReceivedQuantity(),
+			}
+		}
+		lines[i] = &dto.TransferLineResponse{
+			ProductID:        line.ProductID(),
+			Quantity:         line.Quantity(),
+			ReceivedQuantity: line.ReceivedQuantity(),
+			InTransit:        inTransit,
+			WrittenOff:       writtenOff,
+			Lots:             lots,
+		}
+	}
+
+	return &dto.TransferResponse{
+		ID:                     t.ID(),
+		SourceWarehouseID:      t.SourceWarehouseID(),
+		DestinationWarehouseID: t.DestinationWarehouseID(),
+		Status:                 string(t.Status()),
+		Reference:              t.Reference(),
+		InTransit:              t.InTransit(),
+		WrittenOff:             t.WrittenOff(),
+		Lines:                  lines,
+		DispatchedAt:           t.DispatchedAt(),
+		ReceivedAt:             t.ReceivedAt(),
+		ClosedAt:               t.ClosedAt(),
+		CloseReasonCode:        t.CloseReasonCode(),
+		CreatedAt:              t.CreatedAt(),
+		UpdatedAt:              t.UpdatedAt(),
+	}
+}
+
+// TransformTransferEntitiesToResponse transforms a slice of entity.Transfer
+func TransformTransferEntitiesToResponse(transfers []*entity.Transfer) []*dto.TransferResponse {
+	responses := make([]*dto.TransferResponse, len(transfers))
+	for i, transfer := range transfers {
+		responses[i] = TransformTransferEntityToResponse(transfer)
+	}
+	return responses
+}
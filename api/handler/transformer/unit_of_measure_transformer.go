@@ -0,0 +1,48 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformUnitOfMeasureEntityToResponse transforms an entity.UnitOfMeasure to a dto.UnitOfMeasureResponse
+func TransformUnitOfMeasureEntityToResponse(u *entity.UnitOfMeasure) *dto.UnitOfMeasureResponse {
+	return &dto.UnitOfMeasureResponse{
+		ID:        u.ID(),
+		Code:      u.Code(),
+		Name:      u.Name(),
+		CreatedAt: u.CreatedAt(),
+		UpdatedAt: u.UpdatedAt(),
+	}
+}
+
+// TransformUnitOfMeasureEntitiesToResponse transforms a slice of entity.UnitOfMeasure
+func TransformUnitOfMeasureEntitiesToResponse(units []*entity.UnitOfMeasure) []*dto.UnitOfMeasureResponse {
+	responses := make([]*dto.UnitOfMeasureResponse, len(units))
+	for i, unit := range units {
+		responses[i] = TransformUnitOfMeasureEntityToResponse(unit)
+	}
+	return responses
+}
+
+// TransformProductUnitEntityToResponse transforms an entity.ProductUnit to a dto.ProductUnitResponse
+func TransformProductUnitEntityToResponse(u *entity.ProductUnit) *dto.ProductUnitResponse {
+	return &dto.ProductUnitResponse{
+		UnitCode:  u.UnitCode(),
+		Factor:    u.Factor(),
+		UpdatedAt: u.UpdatedAt(),
+	}
+}
+
+// TransformProductUnitsToResponse transforms the stock unit and conversions of a product to a dto.ProductUnitsResponse
+func TransformProductUnitsToResponse(p *entity.Product, conversions []*entity.ProductUnit) *dto.ProductUnitsResponse {
+	response := &dto.ProductUnitsResponse{
+		ProductID:   p.ID(),
+		StockUnit:   p.StockUnit(),
+		Conversions: make([]*dto.ProductUnitResponse, len(conversions)),
+	}
+	for i, conversion := range conversions {
+		response.Conversions[i] = TransformProductUnitEntityToResponse(conversion)
+	}
+	return response
+}
@@ -0,0 +1,17 @@
+package transformer
+
+import (
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformWarehouseEntityToResponse transforms an entity.Warehouse to a dto.WarehouseResponse
+func TransformWarehouseEntityToResponse(w *entity.Warehouse) *dto.WarehouseResponse {
+	return &dto.WarehouseResponse{
+		ID:        w.ID(),
+		Code:      w.Code(),
+		Name:      w.Name(),
+		CreatedAt: w.CreatedAt(),
+		UpdatedAt: w.UpdatedAt(),
+	}
+}
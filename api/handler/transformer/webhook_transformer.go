@@ -0,0 +1,65 @@
+package transformer
+
+import (
+	"encoding/json"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+)
+
+// TransformWebhookEntityToResponse transforms an entity.WebhookSubscription to a dto.WebhookResponse
+// without its secret
+func TransformWebhookEntityToResponse(s *entity.WebhookSubscription) *dto.WebhookResponse {
+	eventTypes := make([]string, len(s.EventTypes()))
+	for i, eventType := range s.EventTypes() {
+		eventTypes[i] = string(eventType)
+	}
+	return &dto.WebhookResponse{
+		ID:         s.ID(),
+		URL:        s.URL(),
+		EventTypes: eventTypes,
+		CreatedAt:  s.CreatedAt(),
+		UpdatedAt:  s.UpdatedAt(),
+	}
+}
+
+// TransformWebhookEntitiesToResponse transforms a slice of entity.WebhookSubscription
+func TransformWebhookEntitiesToResponse(subscriptions []*entity.WebhookSubscription) []*dto.WebhookResponse {
+	responses := make([]*dto.WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = TransformWebhookEntityToResponse(subscription)
+	}
+	return responses
+}
+
+// TransformWebhookDeliveryEntityToResponse transforms an entity.WebhookDelivery to a dto.WebhookDeliveryResponse
+func TransformWebhookDeliveryEntityToResponse(d *entity.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	response := &dto.WebhookDeliveryResponse{
+		ID:          d.ID(),
+		WebhookID:   d.SubscriptionID(),
+		EventID:     d.EventID(),
+		EventType:   string(d.EventType()),
+		Status:      string(d.Status()),
+		Attempts:    d.Attempts(),
+		LastError:   d.LastError(),
+		DeliveredAt: d.DeliveredAt(),
+		CreatedAt:   d.CreatedAt(),
+		Payload:     json.RawMessage(d.Payload()),
+	}
+	if d.Status() == entity.WebhookDeliveryPending {
+		nextAttemptAt := d.NextAttemptAt()
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	if statusCode := d.LastStatusCode(); statusCode != 0 {
+		response.LastStatusCode = &statusCode
+	}
+	return response
+}
+
+// TransformWebhookDeliveryEntitiesToResponse transforms a slice of entity.WebhookDelivery
+func TransformWebhookDeliveryEntitiesToResponse(deliveries []*entity.WebhookDelivery) []*dto.WebhookDeliveryResponse {
+	responses := make([]*dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = TransformWebhookDeliveryEntityToResponse(delivery)
+	}
+	return responses
+}
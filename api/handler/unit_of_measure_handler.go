@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UnitOfMeasureHandler struct {
+	unitUsecase usecase.UnitOfMeasureUsecase
+}
+
+func NewUnitOfMeasureHandler(u usecase.UnitOfMeasureUsecase) *UnitOfMeasureHandler {
+	return &UnitOfMeasureHandler{unitUsecase: u}
+}
+
+// CreateUnit handles adding a unit to the catalogue
+func (h *UnitOfMeasureHandler) CreateUnit(c *gin.Context) {
+	var req dto.CreateUnitOfMeasureRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	unit, err := h.unitUsecase.CreateUnit(req.Code, req.Name)
+	if err != nil {
+		switch {
+		case err == usecase.ErrUnitCodeTaken:
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrUnitCodeTaken})
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedCreateUnit, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("unit of measure created successfully", unit.ID(), unit.Code())
+	c.JSON(http.StatusCreated, transformer.TransformUnitOfMeasureEntityToResponse(unit))
+}
+
+// GetUnitList lists the whole unit catalogue
+func (h *UnitOfMeasureHandler) GetUnitList(c *gin.Context) {
+	units, err := h.unitUsecase.ListUnits()
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveUnits, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("units of measure retrieved successfully", len(units))
+	c.JSON(http.StatusOK, transformer.TransformUnitOfMeasureEntitiesToResponse(units))
+}
+
+// GetProductUnits retrieves the stock unit of a product and its unit conversions
+func (h *UnitOfMeasureHandler) GetProductUnits(c *gin.Context) {
+	productID, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	units, err := h.unitUsecase.GetProductUnits(productID)
+	if err != nil {
+		if err == usecase.ErrProductNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveUnits, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product units retrieved successfully", productID, len(units.Conversions))
+	c.JSON(http.StatusOK, transformer.TransformProductUnitsToResponse(units.Product, units.Conversions))
+}
+
+// SetProductUnit creates or replaces how many stock units of a product one of a unit holds
+func (h *UnitOfMeasureHandler) SetProductUnit(c *gin.Context) {
+	productID, err := helper_handler.ParseIDFromParam(c)
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidProductID, http.StatusBadRequest)
+		return
+	}
+
+	var req dto.SetProductUnitRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	conversion, err := h.unitUsecase.SetProductUnit(productID, req.UnitCode, req.Factor)
+	if err != nil {
+		switch {
+		case err == usecase.ErrProductNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrProductNotFound})
+		case err == usecase.ErrUnitOfMeasureNotFound:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": consts.ErrUnitOfMeasureNotFound})
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		default:
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedSetProductUnit, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("product unit conversion set successfully", productID, conversion.UnitCode(), conversion.Factor())
+	c.JSON(http.StatusOK, transformer.TransformProductUnitEntityToResponse(conversion))
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WarehouseHandler struct {
+	warehouseUsecase usecase.WarehouseUsecase
+}
+
+func NewWarehouseHandler(u usecase.WarehouseUsecase) *WarehouseHandler {
+	return &WarehouseHandler{warehouseUsecase: u}
+}
+
+// CreateWarehouse handles the creation of a new warehouse
+func (h *WarehouseHandler) CreateWarehouse(c *gin.Context) {
+	var req dto.CreateWarehouseRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	warehouse, err := h.warehouseUsecase.CreateWarehouse(req.Code, req.Name)
+	if err != nil {
+		if err == usecase.ErrWarehouseCodeTaken {
+			c.JSON(http.StatusConflict, gin.H{"errors": consts.ErrWarehouseCodeTaken})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedCreateWarehouse, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("warehouse created successfully", warehouse.ID(), warehouse.Code())
+	c.JSON(http.StatusCreated, transformer.TransformWarehouseEntityToResponse(warehouse))
+}
+
+// GetWarehouse retrieves a warehouse by its ID
+func (h *WarehouseHandler) GetWarehouse(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidWarehouseID, http.StatusBadRequest)
+		return
+	}
+
+	warehouse, err := h.warehouseUsecase.GetWarehouseByID(id)
+	if err != nil {
+		if err == usecase.ErrWarehouseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrWarehouseNotFound})
+		} else {
+			helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveWarehouse, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utility.LogSuccess("warehouse retrieved successfully", warehouse.ID(), warehouse.Code())
+	c.JSON(http.StatusOK, transformer.TransformWarehouseEntityToResponse(warehouse))
+}
+
+// GetWarehouseList lists every warehouse
+func (h *WarehouseHandler) GetWarehouseList(c *gin.Context) {
+	warehouses, err := h.warehouseUsecase.ListWarehouses()
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveWarehouse, http.StatusInternalServerError)
+		return
+	}
+
+	warehouseResponses := make([]*dto.WarehouseResponse, len(warehouses))
+	for i, warehouse := range warehouses {
+		warehouseResponses[i] = transformer.TransformWarehouseEntityToResponse(warehouse)
+	}
+
+	utility.LogSuccess("warehouse list retrieved successfully", len(warehouses), "warehouses")
+	c.JSON(http.StatusOK, gin.H{"warehouses": warehouseResponses})
+}
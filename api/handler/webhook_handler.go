@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	helper_handler "inventory_management/api/handler/helper"
+	"inventory_management/api/handler/transformer"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/utility"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookUsecase usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(u usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{webhookUsecase: u}
+}
+
+// CreateWebhook subscribes a URL to events. The response is the only place the secret is shown.
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req dto.WebhookRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	subscription, err := h.webhookUsecase.CreateSubscription(webhookInput(req))
+	if err != nil {
+		handleWebhookError(c, err, consts.ErrFailedCreateWebhook)
+		return
+	}
+
+	response := transformer.TransformWebhookEntityToResponse(subscription)
+	response.Secret = subscription.Secret()
+
+	utility.LogSuccess("webhook created successfully", subscription.ID(), subscription.URL())
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetWebhook retrieves a webhook subscription by its ID
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidWebhookID, http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := h.webhookUsecase.GetSubscription(id)
+	if err != nil {
+		handleWebhookError(c, err, consts.ErrFailedRetrieveWebhook)
+		return
+	}
+
+	utility.LogSuccess("webhook retrieved successfully", subscription.ID(), subscription.URL())
+	c.JSON(http.StatusOK, transformer.TransformWebhookEntityToResponse(subscription))
+}
+
+// GetWebhookList lists every webhook subscription
+func (h *WebhookHandler) GetWebhookList(c *gin.Context) {
+	subscriptions, err := h.webhookUsecase.ListSubscriptions()
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrFailedRetrieveWebhook, http.StatusInternalServerError)
+		return
+	}
+
+	utility.LogSuccess("webhook list retrieved successfully", len(subscriptions), "webhooks")
+	c.JSON(http.StatusOK, gin.H{"webhooks": transformer.TransformWebhookEntitiesToResponse(subscriptions)})
+}
+
+// UpdateWebhook replaces the URL and event filter of a webhook subscription and optionally its secret
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidWebhookID, http.StatusBadRequest)
+		return
+	}
+
+	var req dto.WebhookRequest
+
+	validationErrors, err := helper_handler.ReadAndValidateRequestBody(c, &req)
+	if validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": err.Error()})
+		return
+	}
+
+	subscription, err := h.webhookUsecase.UpdateSubscription(id, webhookInput(req))
+	if err != nil {
+		handleWebhookError(c, err, consts.ErrFailedUpdateWebhook)
+		return
+	}
+
+	utility.LogSuccess("webhook updated successfully", subscription.ID(), subscription.URL())
+	c.JSON(http.StatusOK, transformer.TransformWebhookEntityToResponse(subscription))
+}
+
+// DeleteWebhook removes a webhook subscription and its delivery log
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidWebhookID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.webhookUsecase.DeleteSubscription(id); err != nil {
+		handleWebhookError(c, err, consts.ErrFailedDeleteWebhook)
+		return
+	}
+
+	utility.LogSuccess("webhook deleted successfully", id)
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries lists the deliveries of a webhook, newest first, filtered by status with pagination
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	id, err := helper_handler.ParseUintParam(c, "id")
+	if err != nil {
+		helper_handler.HandleErrorResponse(c, err, consts.ErrInvalidWebhookID, http.StatusBadRequest)
+		return
+	}
+
+	queryParams := dto.WebhookDeliveryListQueryParams{}
+	if validationErrors := queryParams.Validate(c.Request.URL.Query()); validationErrors != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": validationErrors})
+		return
+	}
+
+	deliveries, total, err := h.webhookUsecase.ListDeliveries(usecase.WebhookDeliveryFilter{
+		SubscriptionID: id,
+		Status:         entity.WebhookDeliveryStatus(queryParams.Status),
+		Limit:          queryParams.Limit,
+		Offset:         queryParams.Offset,
+	})
+	if err != nil {
+		handleWebhookError(c, err, consts.ErrFailedRetrieveWebhookDeliveries)
+		return
+	}
+
+	utility.LogSuccess("webhook deliveries retrieved successfully", id, len(deliveries))
+	c.JSON(http.StatusOK, dto.WebhookDeliveryListResponse{
+		Deliveries:         transformer.TransformWebhookDeliveryEntitiesToResponse(deliveries),
+		PaginationResponse: helper_handler.BuildPagination(c, total, queryParams.Limit, queryParams.Offset),
+	})
+}
+
+// webhookInput converts a webhook request to the use case input
+func webhookInput(req dto.WebhookRequest) usecase.WebhookSubscriptionInput {
+	eventTypes := make([]entity.EventType, len(req.EventTypes))
+	for i, eventType := range req.EventTypes {
+		eventTypes[i] = entity.EventType(eventType)
+	}
+	return usecase.WebhookSubscriptionInput{URL: req.URL, Secret: req.Secret, EventTypes: eventTypes}
+}
+
+// handleWebhookError maps webhook use case errors to HTTP responses
+func handleWebhookError(c *gin.Context, err error, failureMessage string) {
+	switch {
+	case errors.Is(err, usecase.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"errors": consts.ErrWebhookNotFound})
+	case errors.Is(err, usecase.ErrInvalidInput):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+	default:
+		helper_handler.HandleErrorResponse(c, err, failureMessage, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	consts "inventory_management/api/handler/const"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Admin marks requests sending the configured admin token in the X-Admin-Token header as made by an
+// administrator, see helper_handler.IsAdmin. Without a configured token no request is.
+func Admin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		given := c.GetHeader(consts.HeaderAdminToken)
+		if token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+			c.Set(consts.ContextKeyAdmin, true)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	consts "inventory_management/api/handler/const"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxRequestIDLength is the longest request ID taken from a client, longer ones are replaced
+const maxRequestIDLength = 100
+
+// RequestID makes sure every request carries an ID in the X-Request-ID header so that changes can be
+// traced back to it. An ID sent by the client is kept, otherwise a random one is generated. The ID is
+// echoed in the response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(consts.HeaderRequestID)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+			c.Request.Header.Set(consts.HeaderRequestID, id)
+		}
+		c.Header(consts.HeaderRequestID, id)
+		c.Next()
+	}
+}
+
+// newRequestID returns 16 random bytes in hex, or the current time when no random bytes are available
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,46 @@
+// /cmd/api/config.go
+package main
+
+import (
+	"fmt"
+	"inventory_management/internal/event"
+	"inventory_management/internal/usecase"
+	"os"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// durationFromEnv reads a number of seconds from an environment variable, falling back to the
+// default when the variable is missing or not a positive number
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		if value != "" {
+			log.WithFields(log.Fields{"key": key, "value": value}).Warn("Invalid duration, using default")
+		}
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// stringFromEnv reads an environment variable, falling back to the default when it is missing
+func stringFromEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// newEventPublisher creates the publisher the outbox relay delivers domain events to
+func newEventPublisher(kind string) (usecase.EventPublisher, error) {
+	switch kind {
+	case "stdout":
+		return event.NewStdoutPublisher(), nil
+	case "memory":
+		return event.NewInMemoryPublisher(), nil
+	}
+	return nil, fmt.Errorf("unknown event publisher %q", kind)
+}
@@ -3,8 +3,12 @@ package main
 import (
 	"context"
 	"inventory_management/api/handler"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/event"
 	"inventory_management/internal/repository"
 	"inventory_management/internal/usecase"
+	"inventory_management/internal/webhook"
+	"inventory_management/internal/worker"
 	"inventory_management/pkg/db"
 	"os"
 	"os/signal"
@@ -38,13 +42,62 @@ func main() {
 		log.Fatal("Failed to initialize the database.")
 	}
 
-	// Initialize repository, use case, and handler
-	productRepo := repository.NewPostgresProductRepository(db)
-	productUsecase := usecase.NewProductUsecase(productRepo)
-	productHandler := handler.NewProductHandler(productUsecase)
+	// Choose how the SKUs of new products are generated
+	skuGenerator, err := entity.NewSKUGenerator(stringFromEnv("SKU_STRATEGY", entity.SKUStrategyRandom), os.Getenv("SKU_TEMPLATE"))
+	if err != nil {
+		log.Fatalf("Invalid SKU generation settings: %s", err)
+	}
+
+	// Choose where the domain events written to the outbox are delivered
+	eventPublisher, err := newEventPublisher(stringFromEnv("EVENT_PUBLISHER", "stdout"))
+	if err != nil {
+		log.Fatalf("Invalid event publishing settings: %s", err)
+	}
+
+	// Initialize repositories and the unit of work used for transactional changes
+	repos := repository.NewRepositories(db, repository.WithSKUGenerator(skuGenerator))
+	uow := repository.NewUnitOfWork(db, repository.WithSKUGenerator(skuGenerator))
+
+	// Initialize use cases
+	productUsecase := usecase.NewProductUsecase(repos, uow)
+	productImportUsecase := usecase.NewProductImportUsecase(repos, uow)
+	warehouseUsecase := usecase.NewWarehouseUsecase(repos.Warehouses)
+	stockUsecase := usecase.NewStockUsecase(repos.Products, repos.Warehouses, repos.StockLevels, repos.ProductUnits)
+	stockMovementUsecase := usecase.NewStockMovementUsecase(repos, uow)
+	reservationUsecase := usecase.NewReservationUsecase(repos, uow, durationFromEnv("RESERVATION_TTL", 15*time.Minute))
+	transferUsecase := usecase.NewTransferUsecase(repos, uow)
+	supplierUsecase := usecase.NewSupplierUsecase(repos.Suppliers)
+	purchaseOrderUsecase := usecase.NewPurchaseOrderUsecase(repos, uow)
+	salesOrderUsecase := usecase.NewSalesOrderUsecase(repos, uow)
+	lotUsecase := usecase.NewLotUsecase(repos)
+	serialUsecase := usecase.NewSerialUsecase(repos)
+	unitUsecase := usecase.NewUnitOfMeasureUsecase(repos)
+	categoryUsecase := usecase.NewCategoryUsecase(repos, uow)
+	variantUsecase := usecase.NewProductVariantUsecase(repos, uow)
+	reorderUsecase := usecase.NewReorderUsecase(repos, uow, stringFromEnv("REORDER_PURCHASE_ORDER_CURRENCY", "USD"))
+	webhookUsecase := usecase.NewWebhookUsecase(repos, uow, webhook.NewHTTPSender(durationFromEnv("WEBHOOK_TIMEOUT", 10*time.Second)), entity.DefaultWebhookRetryPolicy)
+	eventRelayUsecase := usecase.NewEventRelayUsecase(uow, event.NewFanOutPublisher(eventPublisher, webhookUsecase), 100)
 
 	// Setup the router by calling the new SetupRouter function
-	router := SetupRouter(productHandler)
+	router := SetupRouter(Handlers{
+		Product:       handler.NewProductHandler(productUsecase),
+		ProductImport: handler.NewProductImportHandler(productImportUsecase),
+		Warehouse:     handler.NewWarehouseHandler(warehouseUsecase),
+		Stock:         handler.NewStockHandler(stockUsecase),
+		Movement:      handler.NewStockMovementHandler(stockMovementUsecase),
+		Reservation:   handler.NewReservationHandler(reservationUsecase),
+		Transfer:      handler.NewTransferHandler(transferUsecase),
+		Supplier:      handler.NewSupplierHandler(supplierUsecase),
+		PurchaseOrder: handler.NewPurchaseOrderHandler(purchaseOrderUsecase),
+		SalesOrder:    handler.NewSalesOrderHandler(salesOrderUsecase),
+		Reorder:       handler.NewReorderHandler(reorderUsecase),
+		Lot:           handler.NewLotHandler(lotUsecase),
+		Serial:        handler.NewSerialHandler(serialUsecase),
+		Unit:          handler.NewUnitOfMeasureHandler(unitUsecase),
+		Category:      handler.NewCategoryHandler(categoryUsecase),
+		Variant:       handler.NewProductVariantHandler(variantUsecase),
+		Webhook:       handler.NewWebhookHandler(webhookUsecase),
+	}, os.Getenv("ADMIN_TOKEN"))
 
 	// Create the HTTP server with the Gin router as its handler
 	srv := &http.Server{
@@ -61,6 +114,50 @@ func main() {
 	}()
 	log.Println("Server running on port 8080")
 
+	// Start the background worker releasing stock held by expired reservations
+	reservationExpiryWorker := worker.NewPeriodicWorker(
+		"reservation-expiry",
+		durationFromEnv("RESERVATION_EXPIRY_INTERVAL", 30*time.Second),
+		func(now time.Time) error {
+			_, err := reservationUsecase.ExpireReservations(now)
+			return err
+		},
+	)
+	reservationExpiryWorker.Start()
+
+	// Start the background worker raising and resolving low-stock alerts
+	lowStockWorker := worker.NewPeriodicWorker(
+		"low-stock-evaluator",
+		durationFromEnv("LOW_STOCK_EVALUATION_INTERVAL", 5*time.Minute),
+		func(now time.Time) error {
+			_, err := reorderUsecase.EvaluateLowStock(now)
+			return err
+		},
+	)
+	lowStockWorker.Start()
+
+	// Start the background worker delivering the domain events written to the outbox
+	outboxRelayWorker := worker.NewPeriodicWorker(
+		"outbox-relay",
+		durationFromEnv("OUTBOX_RELAY_INTERVAL", 5*time.Second),
+		func(now time.Time) error {
+			_, err := eventRelayUsecase.RelayEvents(now)
+			return err
+		},
+	)
+	outboxRelayWorker.Start()
+
+	// Start the background worker posting due webhook deliveries and retrying failed ones
+	webhookDispatchWorker := worker.NewPeriodicWorker(
+		"webhook-dispatcher",
+		durationFromEnv("WEBHOOK_DISPATCH_INTERVAL", 5*time.Second),
+		func(now time.Time) error {
+			_, err := webhookUsecase.DispatchDeliveries(now)
+			return err
+		},
+	)
+	webhookDispatchWorker.Start()
+
 	// Create a channel to listen for interrupt signals
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM) // Listen for SIGINT and SIGTERM
@@ -75,6 +172,12 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Stop the background workers before the database they use goes away
+	reservationExpiryWorker.Stop()
+	lowStockWorker.Stop()
+	outboxRelayWorker.Stop()
+	webhookDispatchWorker.Stop()
+
 	// Close the database connection
 	if err := sqlDB.Close(); err != nil {
 		log.WithFields(log.Fields{
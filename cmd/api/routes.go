@@ -3,21 +3,133 @@ package main
 
 import (
 	"inventory_management/api/handler"
+	"inventory_management/api/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-// SetupRouter defines all the application routes and returns the Gin router
-func SetupRouter(productHandler *handler.ProductHandler) *gin.Engine {
+// Handlers groups the HTTP handlers mounted by the router
+type Handlers struct {
+	Product       *handler.ProductHandler
+	ProductImport *handler.ProductImportHandler
+	Warehouse     *handler.WarehouseHandler
+	Stock         *handler.StockHandler
+	Movement      *handler.StockMovementHandler
+	Reservation   *handler.ReservationHandler
+	Transfer      *handler.TransferHandler
+	Supplier      *handler.SupplierHandler
+	PurchaseOrder *handler.PurchaseOrderHandler
+	SalesOrder    *handler.SalesOrderHandler
+	Reorder       *handler.ReorderHandler
+	Lot           *handler.LotHandler
+	Serial        *handler.SerialHandler
+	Unit          *handler.UnitOfMeasureHandler
+	Category      *handler.CategoryHandler
+	Variant       *handler.ProductVariantHandler
+	Webhook       *handler.WebhookHandler
+}
+
+// SetupRouter defines all the application routes and returns the Gin router. Requests sending adminToken
+// in the X-Admin-Token header are made by an administrator.
+func SetupRouter(h Handlers, adminToken string) *gin.Engine {
 	router := gin.Default()
+	router.Use(middleware.RequestID(), middleware.Admin(adminToken))
 
 	// Define Routes with route grouping
 	api := router.Group("/api/v1")
 	{
-		api.POST("/products", productHandler.CreateProduct)
-		api.GET("/products/:id", productHandler.GetProduct)
-		api.PUT("/products/:id", productHandler.UpdateProductName) // Add the route for updating the product name
+		api.POST("/products", h.Product.CreateProduct)
+		api.GET("/products", h.Product.GetProductList)
+		api.GET("/products/by-sku/:sku", h.Product.GetProductBySKU)
+		api.GET("/products/by-barcode/:code", h.Product.GetProductByBarcode)
+		api.POST("/products/import", h.ProductImport.ImportProducts)
+		api.GET("/products/export", h.Product.ExportProducts)
+		api.GET("/products/imports/:id", h.ProductImport.GetProductImport)
+		api.GET("/products/imports/:id/report", h.ProductImport.GetProductImportReport)
+		api.GET("/products/:id", h.Product.GetProduct)
+		api.PUT("/products/:id", h.Product.UpdateProductName) // Add the route for updating the product name
+		api.PATCH("/products/:id", h.Product.PatchProduct)
+		api.DELETE("/products/:id", h.Product.DeleteProduct)
+		api.POST("/products/:id/restore", h.Product.RestoreProduct)
+		api.GET("/products/:id/history", h.Product.GetProductHistory)
+		api.GET("/products/:id/snapshot", h.Product.GetProductSnapshot)
+		api.GET("/products/:id/stock", h.Stock.GetProductStock)
+		api.GET("/products/:id/stock/reconciliation", h.Movement.GetProductStockReconciliation)
+		api.GET("/products/:id/stock-movements", h.Movement.GetProductMovements)
+		api.GET("/products/:id/reorder-policies", h.Reorder.GetProductReorderPolicies)
+		api.GET("/products/:id/lots", h.Lot.GetProductLots)
+		api.GET("/products/:id/units", h.Unit.GetProductUnits)
+		api.PUT("/products/:id/units", h.Unit.SetProductUnit)
+		api.PUT("/products/:id/category", h.Product.AssignProductCategory)
+		api.PUT("/products/:id/sku", h.Product.ChangeProductSKU)
+		api.GET("/products/:id/barcodes", h.Product.GetProductBarcodes)
+		api.POST("/products/:id/barcodes", h.Product.AddProductBarcode)
+		api.DELETE("/products/:id/barcodes/:code", h.Product.RemoveProductBarcode)
+		api.GET("/products/:id/label", h.Product.GetProductLabel)
+		api.POST("/products/:id/variants/generate", h.Variant.GenerateVariants)
+
+		api.POST("/categories", h.Category.CreateCategory)
+		api.GET("/categories", h.Category.GetCategoryList)
+		api.GET("/categories/:id", h.Category.GetCategory)
+		api.PUT("/categories/:id", h.Category.UpdateCategory)
+		api.DELETE("/categories/:id", h.Category.DeleteCategory)
+
+		api.POST("/units", h.Unit.CreateUnit)
+		api.GET("/units", h.Unit.GetUnitList)
+
+		api.POST("/warehouses", h.Warehouse.CreateWarehouse)
+		api.GET("/warehouses", h.Warehouse.GetWarehouseList)
+		api.GET("/warehouses/:id", h.Warehouse.GetWarehouse)
+		api.GET("/warehouses/:id/stock", h.Stock.GetWarehouseStock)
+
+		api.POST("/stock-movements", h.Movement.CreateStockMovement)
+
+		api.GET("/lots/expiring", h.Lot.GetExpiringLots)
+
+		api.GET("/serials/:serial", h.Serial.GetSerial)
+
+		api.POST("/reservations", h.Reservation.CreateReservation)
+		api.GET("/reservations/:id", h.Reservation.GetReservation)
+		api.POST("/reservations/:id/confirm", h.Reservation.ConfirmReservation)
+		api.POST("/reservations/:id/release", h.Reservation.ReleaseReservation)
+
+		api.POST("/transfers", h.Transfer.CreateTransfer)
+		api.GET("/transfers", h.Transfer.GetTransferList)
+		api.GET("/transfers/:id", h.Transfer.GetTransfer)
+		api.POST("/transfers/:id/dispatch", h.Transfer.DispatchTransfer)
+		api.POST("/transfers/:id/receive", h.Transfer.ReceiveTransfer)
+		api.POST("/transfers/:id/cancel", h.Transfer.CancelTransfer)
+		api.POST("/transfers/:id/close", h.Transfer.CloseTransfer)
+
+		api.POST("/suppliers", h.Supplier.CreateSupplier)
+		api.GET("/suppliers", h.Supplier.GetSupplierList)
+		api.GET("/suppliers/:id", h.Supplier.GetSupplier)
+
+		api.POST("/purchase-orders", h.PurchaseOrder.CreatePurchaseOrder)
+		api.GET("/purchase-orders", h.PurchaseOrder.GetPurchaseOrderList)
+		api.GET("/purchase-orders/:id", h.PurchaseOrder.GetPurchaseOrder)
+		api.POST("/purchase-orders/:id/submit", h.PurchaseOrder.SubmitPurchaseOrder)
+		api.POST("/purchase-orders/:id/receive", h.PurchaseOrder.ReceivePurchaseOrder)
+		api.POST("/purchase-orders/:id/close", h.PurchaseOrder.ClosePurchaseOrder)
+
+		api.POST("/sales-orders", h.SalesOrder.CreateSalesOrder)
+		api.GET("/sales-orders", h.SalesOrder.GetSalesOrderList)
+		api.GET("/sales-orders/:id", h.SalesOrder.GetSalesOrder)
+		api.POST("/sales-orders/:id/allocate", h.SalesOrder.AllocateSalesOrder)
+		api.POST("/sales-orders/:id/pick", h.SalesOrder.PickSalesOrder)
+		api.POST("/sales-orders/:id/pack", h.SalesOrder.PackSalesOrder)
+		api.POST("/sales-orders/:id/ship", h.SalesOrder.ShipSalesOrder)
+		api.POST("/sales-orders/:id/cancel", h.SalesOrder.CancelSalesOrder)
+
+		api.PUT("/reorder-policies", h.Reorder.SetReorderPolicy)
+		api.GET("/alerts/low-stock", h.Reorder.GetLowStockAlertList)
 
+		api.POST("/webhooks", h.Webhook.CreateWebhook)
+		api.GET("/webhooks", h.Webhook.GetWebhookList)
+		api.GET("/webhooks/:id", h.Webhook.GetWebhook)
+		api.PUT("/webhooks/:id", h.Webhook.UpdateWebhook)
+		api.DELETE("/webhooks/:id", h.Webhook.DeleteWebhook)
+		api.GET("/webhooks/:id/deliveries", h.Webhook.GetWebhookDeliveries)
 	}
 
 	return router
@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// BarcodeType is the GS1 symbology family a barcode number belongs to
+type BarcodeType string
+
+// Supported barcode types, told apart by the number of digits
+const (
+	BarcodeTypeEAN8   BarcodeType = "EAN-8"
+	BarcodeTypeUPCA   BarcodeType = "UPC-A"
+	BarcodeTypeEAN13  BarcodeType = "EAN-13"
+	BarcodeTypeGTIN14 BarcodeType = "GTIN-14"
+)
+
+// gtinLength is the length every barcode is padded to so that the same trade item scanned as UPC-A
+// or EAN-13 is found under one number
+const gtinLength = 14
+
+// Barcode validation errors
+var (
+	ErrInvalidBarcode         = errors.New("barcode must be 8, 12, 13 or 14 digits")
+	ErrInvalidBarcodeChecksum = errors.New("barcode check digit does not match")
+	ErrInvalidBarcodeProduct  = errors.New("barcode requires a product")
+)
+
+// Barcode is a GTIN printed on a product. A product may carry several, e.g. the UPC-A of its US
+// packaging and the EAN-13 of its European packaging.
+type Barcode struct {
+	productID   uint        // Product the barcode identifies
+	code        string      // Digits as printed on the label
+	barcodeType BarcodeType // Symbology family derived from the number of digits
+	createdAt   time.Time   // Unexported CreatedAt field
+}
+
+// NewBarcode validates a barcode number and assigns it to a product
+func NewBarcode(productID uint, code string) (*Barcode, error) {
+	barcode := &Barcode{}
+	if err := barcode.MakeBarcode(productID, code, time.Now()); err != nil {
+		return nil, err
+	}
+	return barcode, nil
+}
+
+// MakeBarcode sets all attributes of the Barcode from parameters
+func (b *Barcode) MakeBarcode(productID uint, code string, createdAt time.Time) error {
+	if productID == 0 {
+		return ErrInvalidBarcodeProduct
+	}
+	code = strings.TrimSpace(code)
+	barcodeType, err := ValidateBarcode(code)
+	if err != nil {
+		return err
+	}
+	b.productID = productID
+	b.code = code
+	b.barcodeType = barcodeType
+	b.createdAt = createdAt
+	return nil
+}
+
+// ProductID returns the product the barcode identifies
+func (b *Barcode) ProductID() uint {
+	return b.productID
+}
+
+// Code returns the digits as printed on the label
+func (b *Barcode) Code() string {
+	return b.code
+}
+
+// Type returns the symbology family of the barcode
+func (b *Barcode) Type() BarcodeType {
+	return b.barcodeType
+}
+
+// GTIN returns the barcode padded to 14 digits, the number it is stored and looked up by
+func (b *Barcode) GTIN() string {
+	return padGTIN(b.code)
+}
+
+// CreatedAt returns when the barcode was assigned
+func (b *Barcode) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+// ValidateBarcode checks the length and GS1 check digit of a barcode number and returns its type
+func ValidateBarcode(code string) (BarcodeType, error) {
+	var barcodeType BarcodeType
+	switch len(code) {
+	case 8:
+		barcodeType = BarcodeTypeEAN8
+	case 12:
+		barcodeType = BarcodeTypeUPCA
+	case 13:
+		barcodeType = BarcodeTypeEAN13
+	case 14:
+		barcodeType = BarcodeTypeGTIN14
+	default:
+		return "", ErrInvalidBarcode
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidBarcode
+		}
+	}
+	if gs1CheckDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return "", ErrInvalidBarcodeChecksum
+	}
+	return barcodeType, nil
+}
+
+// NormalizeBarcode validates a scanned barcode and returns the 14 digit GTIN it is stored under
+func NormalizeBarcode(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if _, err := ValidateBarcode(code); err != nil {
+		return "", err
+	}
+	return padGTIN(code), nil
+}
+
+// padGTIN left pads a valid barcode number with zeros to 14 digits
+func padGTIN(code string) string {
+	return strings.Repeat("0", gtinLength-len(code)) + code
+}
+
+// gs1CheckDigit computes the GS1 mod 10 check digit of the digits preceding it. Weights alternate
+// 3 and 1 starting with 3 from the rightmost digit.
+func gs1CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		digit := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Category validation and tree errors
+var (
+	ErrEmptyCategoryName   = errors.New("category name cannot be empty")
+	ErrInvalidCategoryPath = errors.New("invalid category path")
+	ErrCategoryCycle       = errors.New("a category cannot be moved below itself or one of its descendants")
+)
+
+// Category is a node of the product category tree, e.g. Headphones below Electronics > Audio.
+// Its path lists the IDs of its ancestors from the root, like "/1/4/", so a whole subtree can be
+// found by path prefix.
+type Category struct {
+	id        uint      // Unexported ID field
+	parentID  uint      // Parent category, zero for a root category
+	name      string    // Unexported Name field
+	path      string    // Materialized path of the ancestor IDs, "/" for a root category
+	createdAt time.Time // Unexported CreatedAt field
+	updatedAt time.Time // Unexported UpdatedAt field
+}
+
+// NewCategory creates a Category below parent, or a root category when parent is nil
+func NewCategory(name string, parent *Category) (*Category, error) {
+	parentID, path := uint(0), "/"
+	if parent != nil {
+		parentID, path = parent.id, parent.SubtreePath()
+	}
+
+	currentTime := time.Now()
+	category := &Category{}
+	if err := category.MakeCategory(0, parentID, name, path, currentTime, currentTime); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// MakeCategory sets all attributes of the Category from parameters
+func (c *Category) MakeCategory(id uint, parentID uint, name string, path string, createdAt, updatedAt time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyCategoryName
+	}
+	if !strings.HasPrefix(path, "/") || !strings.HasSuffix(path, "/") || (parentID == 0) != (path == "/") {
+		return ErrInvalidCategoryPath
+	}
+	c.id = id
+	c.parentID = parentID
+	c.name = name
+	c.path = path
+	c.createdAt = createdAt
+	c.updatedAt = updatedAt
+	return nil
+}
+
+// Rename changes the name of the category
+func (c *Category) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyCategoryName
+	}
+	c.name = name
+	return nil
+}
+
+// MoveTo moves the category below parent, or to the root when parent is nil. The caller rewrites the
+// paths of the descendants from the old to the new SubtreePath.
+func (c *Category) MoveTo(parent *Category) error {
+	if parent == nil {
+		c.parentID, c.path = 0, "/"
+		return nil
+	}
+	if parent.id == c.id || c.IsAncestorOf(parent) {
+		return ErrCategoryCycle
+	}
+	c.parentID, c.path = parent.id, parent.SubtreePath()
+	return nil
+}
+
+// IsAncestorOf reports whether other lies anywhere below the category
+func (c *Category) IsAncestorOf(other *Category) bool {
+	return strings.HasPrefix(other.path, c.SubtreePath())
+}
+
+// SubtreePath returns the path every descendant of the category starts with
+func (c *Category) SubtreePath() string {
+	return c.path + strconv.FormatUint(uint64(c.id), 10) + "/"
+}
+
+// AncestorIDs returns the IDs of the ancestors of the category, starting at the root
+func (c *Category) AncestorIDs() []uint {
+	segments := strings.Split(strings.Trim(c.path, "/"), "/")
+	ids := make([]uint, 0, len(segments))
+	for _, segment := range segments {
+		if id, err := strconv.ParseUint(segment, 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+// Depth returns how many ancestors the category has, zero for a root category
+func (c *Category) Depth() int {
+	return strings.Count(c.path, "/") - 1
+}
+
+// ID returns the ID of the category
+func (c *Category) ID() uint {
+	return c.id
+}
+
+// ParentID returns the parent of the category, or zero for a root category
+func (c *Category) ParentID() uint {
+	return c.parentID
+}
+
+// Name returns the Name of the category
+func (c *Category) Name() string {
+	return c.name
+}
+
+// Path returns the materialized path of the ancestors of the category
+func (c *Category) Path() string {
+	return c.path
+}
+
+// CreatedAt returns the creation timestamp of the category
+func (c *Category) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the category
+func (c *Category) UpdatedAt() time.Time {
+	return c.updatedAt
+}
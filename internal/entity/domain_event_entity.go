@@ -0,0 +1,207 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// EventType names a domain event that services downstream can react to
+type EventType string
+
+// Supported domain events
+const (
+	EventProductCreated  EventType = "ProductCreated"
+	EventProductRenamed  EventType = "ProductRenamed"
+	EventProductUpdated  EventType = "ProductUpdated"
+	EventProductDeleted  EventType = "ProductDeleted"
+	EventProductRestored EventType = "ProductRestored"
+	EventStockAdjusted   EventType = "StockAdjusted"
+)
+
+// Aggregates the domain events are about
+const (
+	AggregateProduct    = "product"
+	AggregateStockLevel = "stock_level"
+)
+
+// Domain event validation errors
+var (
+	ErrInvalidEventType      = errors.New("invalid event type")
+	ErrInvalidEventAggregate = errors.New("event requires an aggregate")
+)
+
+// IsValid reports whether the event type is one of the supported events
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventProductCreated, EventProductRenamed, EventProductUpdated, EventProductDeleted, EventProductRestored, EventStockAdjusted:
+		return true
+	}
+	return false
+}
+
+// DomainEvent is a change worth telling other services about. It is written to the outbox in the
+// transaction that made the change and delivered later, at least once, by the relay.
+type DomainEvent struct {
+	id            uint                   // Unexported ID field, also the delivery order
+	eventType     EventType              // What happened
+	aggregateType string                 // Kind of thing it happened to
+	aggregateID   uint                   // Thing it happened to
+	payload       map[string]interface{} // Details of the event
+	occurredAt    time.Time              // When it happened
+	attempts      int                    // Failed deliveries so far
+	lastError     string                 // Why the last delivery failed
+	publishedAt   *time.Time             // When the event was delivered, nil while pending
+}
+
+// NewDomainEvent creates a pending event
+func NewDomainEvent(eventType EventType, aggregateType string, aggregateID uint, payload map[string]interface{}, occurredAt time.Time) (*DomainEvent, error) {
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	e := &DomainEvent{}
+	if err := e.MakeDomainEvent(0, eventType, aggregateType, aggregateID, payload, occurredAt, 0, "", nil); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// NewProductEvent creates the event announcing the change an audit record describes. An update touching
+// nothing but the name is announced as a rename.
+func NewProductEvent(record *ProductAuditRecord) (*DomainEvent, error) {
+	state := record.State()
+	payload := map[string]interface{}{
+		"product_id": record.ProductID(),
+		"sku":        state.SKU,
+		"name":       state.Name,
+		"status":     string(state.Status),
+		"version":    state.Version,
+		"actor":      record.Origin().Actor,
+		"request_id": record.Origin().RequestID,
+	}
+
+	var eventType EventType
+	switch record.Action() {
+	case AuditActionCreated:
+		eventType = EventProductCreated
+	case AuditActionDeleted:
+		eventType = EventProductDeleted
+	case AuditActionRestored:
+		eventType = EventProductRestored
+	default:
+		changes := record.Changes()
+		if len(changes) == 1 && changes[0].Field == "name" {
+			eventType = EventProductRenamed
+			payload["previous_name"] = changes[0].Before
+			break
+		}
+		eventType = EventProductUpdated
+		changed := make([]map[string]interface{}, len(changes))
+		for i, change := range changes {
+			changed[i] = map[string]interface{}{"field": change.Field, "before": change.Before, "after": change.After}
+		}
+		payload["changes"] = changed
+	}
+	return NewDomainEvent(eventType, AggregateProduct, record.ProductID(), payload, record.OccurredAt())
+}
+
+// NewStockAdjustedEvent creates the event announcing that a movement changed a stock level
+func NewStockAdjustedEvent(movement *StockMovement, stockLevel *StockLevel) (*DomainEvent, error) {
+	payload := map[string]interface{}{
+		"product_id":    movement.ProductID(),
+		"warehouse_id":  movement.WarehouseID(),
+		"movement_id":   movement.ID(),
+		"movement_type": string(movement.Type()),
+		"quantity":      movement.Quantity(),
+		"reason_code":   movement.ReasonCode(),
+		"reference":     movement.Reference(),
+		"actor":         movement.Actor(),
+		"on_hand":       stockLevel.OnHand(),
+		"reserved":      stockLevel.Reserved(),
+	}
+	return NewDomainEvent(EventStockAdjusted, AggregateStockLevel, stockLevel.ID(), payload, movement.OccurredAt())
+}
+
+// MakeDomainEvent sets all attributes of the DomainEvent from parameters
+func (e *DomainEvent) MakeDomainEvent(id uint, eventType EventType, aggregateType string, aggregateID uint, payload map[string]interface{}, occurredAt time.Time, attempts int, lastError string, publishedAt *time.Time) error {
+	if !eventType.IsValid() {
+		return ErrInvalidEventType
+	}
+	if strings.TrimSpace(aggregateType) == "" || aggregateID == 0 {
+		return ErrInvalidEventAggregate
+	}
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+	e.id = id
+	e.eventType = eventType
+	e.aggregateType = aggregateType
+	e.aggregateID = aggregateID
+	e.payload = payload
+	e.occurredAt = occurredAt
+	e.attempts = attempts
+	e.lastError = lastError
+	e.publishedAt = publishedAt
+	return nil
+}
+
+// MarkPublished records that the event was delivered
+func (e *DomainEvent) MarkPublished(now time.Time) {
+	e.publishedAt = &now
+}
+
+// RecordFailure records a delivery that failed. The event stays pending and is retried.
+func (e *DomainEvent) RecordFailure(err error) {
+	e.attempts++
+	e.lastError = err.Error()
+}
+
+// ID returns the ID of the event
+func (e *DomainEvent) ID() uint {
+	return e.id
+}
+
+// Type returns what happened
+func (e *DomainEvent) Type() EventType {
+	return e.eventType
+}
+
+// AggregateType returns the kind of thing the event happened to
+func (e *DomainEvent) AggregateType() string {
+	return e.aggregateType
+}
+
+// AggregateID returns the thing the event happened to
+func (e *DomainEvent) AggregateID() uint {
+	return e.aggregateID
+}
+
+// Payload returns the details of the event
+func (e *DomainEvent) Payload() map[string]interface{} {
+	return e.payload
+}
+
+// OccurredAt returns when the event happened
+func (e *DomainEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// Attempts returns the number of failed deliveries
+func (e *DomainEvent) Attempts() int {
+	return e.attempts
+}
+
+// LastError returns why the last delivery failed, empty when none did
+func (e *DomainEvent) LastError() string {
+	return e.lastError
+}
+
+// PublishedAt returns when the event was delivered, nil while it is pending
+func (e *DomainEvent) PublishedAt() *time.Time {
+	return e.publishedAt
+}
+
+// IsPublished reports whether the event was delivered
+func (e *DomainEvent) IsPublished() bool {
+	return e.publishedAt != nil
+}
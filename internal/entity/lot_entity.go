@@ -0,0 +1,192 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Lot validation and quantity errors
+var (
+	ErrInvalidLotProduct    = errors.New("lot requires a product")
+	ErrInvalidLotWarehouse  = errors.New("lot requires a warehouse")
+	ErrEmptyLotNumber       = errors.New("lot number cannot be empty")
+	ErrMissingLotExpiry     = errors.New("lot requires an expiry date")
+	ErrLotExpiresBeforeMade = errors.New("lot cannot expire before it was manufactured")
+	ErrNegativeLotQuantity  = errors.New("lot quantity cannot be negative")
+	ErrInvalidLotQuantity   = errors.New("quantity to receive into or consume from a lot must be positive")
+	ErrLotQuantityExceeded  = errors.New("cannot consume more than the lot holds")
+	ErrLotExpiryMismatch    = errors.New("lot was already received with a different expiry date")
+)
+
+// Lot is a batch of one product in one warehouse sharing a lot number and an expiry date. The quantity
+// of every lot counts towards the on-hand quantity of the stock level; stock received without a lot
+// number is untracked and never expires.
+type Lot struct {
+	id             uint       // Unexported ID field
+	productID      uint       // Unexported ProductID field
+	warehouseID    uint       // Unexported WarehouseID field
+	lotNumber      string     // Supplier or production batch number
+	manufacturedAt *time.Time // When the batch was produced, if known
+	expiresAt      time.Time  // First moment the batch may no longer be allocated
+	quantity       int64      // Quantity of the batch still on hand
+	createdAt      time.Time  // Unexported CreatedAt field
+	updatedAt      time.Time  // Unexported UpdatedAt field
+}
+
+// NewLot creates an empty Lot of a product in a warehouse
+func NewLot(productID uint, warehouseID uint, lotNumber string, manufacturedAt *time.Time, expiresAt time.Time) (*Lot, error) {
+	if expiresAt.IsZero() {
+		return nil, ErrMissingLotExpiry
+	}
+
+	currentTime := time.Now()
+	lot := &Lot{}
+	if err := lot.MakeLot(0, productID, warehouseID, lotNumber, manufacturedAt, expiresAt, 0, currentTime, currentTime); err != nil {
+		return nil, err
+	}
+	return lot, nil
+}
+
+// MakeLot sets all attributes of the Lot from parameters
+func (l *Lot) MakeLot(id uint, productID uint, warehouseID uint, lotNumber string, manufacturedAt *time.Time, expiresAt time.Time, quantity int64, createdAt, updatedAt time.Time) error {
+	if productID == 0 {
+		return ErrInvalidLotProduct
+	}
+	if warehouseID == 0 {
+		return ErrInvalidLotWarehouse
+	}
+	lotNumber = strings.TrimSpace(lotNumber)
+	if lotNumber == "" {
+		return ErrEmptyLotNumber
+	}
+	if manufacturedAt != nil && expiresAt.Before(*manufacturedAt) {
+		return ErrLotExpiresBeforeMade
+	}
+	if quantity < 0 {
+		return ErrNegativeLotQuantity
+	}
+	l.id = id
+	l.productID = productID
+	l.warehouseID = warehouseID
+	l.lotNumber = lotNumber
+	l.manufacturedAt = manufacturedAt
+	l.expiresAt = expiresAt
+	l.quantity = quantity
+	l.createdAt = createdAt
+	l.updatedAt = updatedAt
+	return nil
+}
+
+// Receive adds quantity to the lot. An expiry date, when given, must match the one the lot was created with.
+func (l *Lot) Receive(quantity int64, expiresAt time.Time) error {
+	if quantity <= 0 {
+		return ErrInvalidLotQuantity
+	}
+	if !expiresAt.IsZero() && !expiresAt.Equal(l.expiresAt) {
+		return ErrLotExpiryMismatch
+	}
+	l.quantity += quantity
+	return nil
+}
+
+// Consume takes quantity out of the lot
+func (l *Lot) Consume(quantity int64) error {
+	if quantity <= 0 {
+		return ErrInvalidLotQuantity
+	}
+	if quantity > l.quantity {
+		return ErrLotQuantityExceeded
+	}
+	l.quantity -= quantity
+	return nil
+}
+
+// IsExpired reports whether the lot may no longer be allocated at the given time
+func (l *Lot) IsExpired(now time.Time) bool {
+	return !now.Before(l.expiresAt)
+}
+
+// ConsumeFirstExpiring takes quantity out of the lots of one stock level, first-expiring first (FEFO).
+// Expired lots are skipped, and whatever the lots cannot cover comes out of the untracked quantity.
+// Nothing is consumed when the unexpired lots and the untracked quantity together fall short.
+// It returns the lots whose quantity changed.
+func ConsumeFirstExpiring(lots []*Lot, untracked int64, quantity int64, now time.Time) ([]*Lot, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidLotQuantity
+	}
+
+	usable := make([]*Lot, 0, len(lots))
+	covered := untracked
+	for _, lot := range lots {
+		if lot.quantity > 0 && !lot.IsExpired(now) {
+			usable = append(usable, lot)
+			covered += lot.quantity
+		}
+	}
+	if covered < quantity {
+		return nil, ErrInsufficientStock
+	}
+
+	sort.SliceStable(usable, func(i, j int) bool {
+		return usable[i].expiresAt.Before(usable[j].expiresAt)
+	})
+
+	var consumed []*Lot
+	for _, lot := range usable {
+		if quantity == 0 {
+			break
+		}
+		take := min(lot.quantity, quantity)
+		lot.quantity -= take
+		quantity -= take
+		consumed = append(consumed, lot)
+	}
+	return consumed, nil
+}
+
+// ID returns the ID of the lot
+func (l *Lot) ID() uint {
+	return l.id
+}
+
+// ProductID returns the product of the lot
+func (l *Lot) ProductID() uint {
+	return l.productID
+}
+
+// WarehouseID returns the warehouse holding the lot
+func (l *Lot) WarehouseID() uint {
+	return l.warehouseID
+}
+
+// LotNumber returns the batch number of the lot
+func (l *Lot) LotNumber() string {
+	return l.lotNumber
+}
+
+// ManufacturedAt returns when the batch was produced, or nil when unknown
+func (l *Lot) ManufacturedAt() *time.Time {
+	return l.manufacturedAt
+}
+
+// ExpiresAt returns when the lot expires
+func (l *Lot) ExpiresAt() time.Time {
+	return l.expiresAt
+}
+
+// Quantity returns the quantity of the lot still on hand
+func (l *Lot) Quantity() int64 {
+	return l.quantity
+}
+
+// CreatedAt returns the creation timestamp of the lot
+func (l *Lot) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the lot
+func (l *Lot) UpdatedAt() time.Time {
+	return l.updatedAt
+}
@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// LowStockAlertStatus describes whether a low-stock alert still needs attention
+type LowStockAlertStatus string
+
+// Supported low-stock alert statuses
+const (
+	LowStockAlertStatusOpen     LowStockAlertStatus = "open"
+	LowStockAlertStatusResolved LowStockAlertStatus = "resolved"
+)
+
+// Low-stock alert validation and state errors
+var (
+	ErrInvalidLowStockAlertStatus = errors.New("invalid low-stock alert status")
+	ErrLowStockAlertResolved      = errors.New("low-stock alert is already resolved")
+)
+
+// LowStockAlert records that the available stock of a product in a warehouse fell below its reorder point
+type LowStockAlert struct {
+	id                uint                // Unexported ID field
+	productID         uint                // Unexported ProductID field
+	warehouseID       uint                // Unexported WarehouseID field
+	available         int64               // Available quantity when the alert was raised
+	reorderPoint      int64               // Reorder point in force when the alert was raised
+	safetyStock       int64               // Safety stock in force when the alert was raised
+	suggestedQuantity int64               // Quantity the policy suggests ordering
+	purchaseOrderID   uint                // Purchase order drafted for the alert, zero when none
+	status            LowStockAlertStatus // Unexported Status field
+	raisedAt          time.Time           // When the stock fell below the reorder point
+	resolvedAt        *time.Time          // When the stock recovered
+}
+
+// NewLowStockAlert raises an open alert from a policy and the available quantity that breached it
+func NewLowStockAlert(policy *ReorderPolicy, available int64, now time.Time) *LowStockAlert {
+	return &LowStockAlert{
+		productID:         policy.ProductID(),
+		warehouseID:       policy.WarehouseID(),
+		available:         available,
+		reorderPoint:      policy.ReorderPoint(),
+		safetyStock:       policy.SafetyStock(),
+		suggestedQuantity: policy.ReorderQuantity(),
+		status:            LowStockAlertStatusOpen,
+		raisedAt:          now,
+	}
+}
+
+// MakeLowStockAlert sets all attributes of the LowStockAlert from parameters
+func (a *LowStockAlert) MakeLowStockAlert(id uint, productID uint, warehouseID uint, available int64, reorderPoint int64, safetyStock int64, suggestedQuantity int64, purchaseOrderID uint, status LowStockAlertStatus, raisedAt time.Time, resolvedAt *time.Time) error {
+	if productID == 0 {
+		return ErrInvalidStockLevelProduct
+	}
+	if warehouseID == 0 {
+		return ErrInvalidStockLevelWarehouse
+	}
+	if status != LowStockAlertStatusOpen && status != LowStockAlertStatusResolved {
+		return ErrInvalidLowStockAlertStatus
+	}
+	a.id = id
+	a.productID = productID
+	a.warehouseID = warehouseID
+	a.available = available
+	a.reorderPoint = reorderPoint
+	a.safetyStock = safetyStock
+	a.suggestedQuantity = suggestedQuantity
+	a.purchaseOrderID = purchaseOrderID
+	a.status = status
+	a.raisedAt = raisedAt
+	a.resolvedAt = resolvedAt
+	return nil
+}
+
+// LinkPurchaseOrder records the purchase order drafted to replenish the stock
+func (a *LowStockAlert) LinkPurchaseOrder(purchaseOrderID uint) {
+	a.purchaseOrderID = purchaseOrderID
+}
+
+// Resolve closes the alert once the stock recovered
+func (a *LowStockAlert) Resolve(now time.Time) error {
+	if a.status != LowStockAlertStatusOpen {
+		return ErrLowStockAlertResolved
+	}
+	a.status = LowStockAlertStatusResolved
+	a.resolvedAt = &now
+	return nil
+}
+
+// BelowSafetyStock reports whether the stock had already eaten into the safety stock
+func (a *LowStockAlert) BelowSafetyStock() bool {
+	return a.available < a.safetyStock
+}
+
+// ID returns the ID of the alert
+func (a *LowStockAlert) ID() uint {
+	return a.id
+}
+
+// ProductID returns the product running low
+func (a *LowStockAlert) ProductID() uint {
+	return a.productID
+}
+
+// WarehouseID returns the warehouse running low
+func (a *LowStockAlert) WarehouseID() uint {
+	return a.warehouseID
+}
+
+// Available returns the available quantity when the alert was raised
+func (a *LowStockAlert) Available() int64 {
+	return a.available
+}
+
+// ReorderPoint returns the reorder point in force when the alert was raised
+func (a *LowStockAlert) ReorderPoint() int64 {
+	return a.reorderPoint
+}
+
+// SafetyStock returns the safety stock in force when the alert was raised
+func (a *LowStockAlert) SafetyStock() int64 {
+	return a.safetyStock
+}
+
+// SuggestedQuantity returns the quantity the policy suggests ordering
+func (a *LowStockAlert) SuggestedQuantity() int64 {
+	return a.suggestedQuantity
+}
+
+// PurchaseOrderID returns the purchase order drafted for the alert, or zero when none
+func (a *LowStockAlert) PurchaseOrderID() uint {
+	return a.purchaseOrderID
+}
+
+// Status returns the status of the alert
+func (a *LowStockAlert) Status() LowStockAlertStatus {
+	return a.status
+}
+
+// RaisedAt returns when the stock fell below the reorder point
+func (a *LowStockAlert) RaisedAt() time.Time {
+	return a.raisedAt
+}
+
+// ResolvedAt returns when the stock recovered, or nil while the alert is open
+func (a *LowStockAlert) ResolvedAt() *time.Time {
+	return a.resolvedAt
+}
@@ -0,0 +1,285 @@
+package entity
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// AuditAction names the kind of change an audit record describes
+type AuditAction string
+
+// Supported audit actions
+const (
+	AuditActionCreated  AuditAction = "created"
+	AuditActionUpdated  AuditAction = "updated"
+	AuditActionDeleted  AuditAction = "deleted"
+	AuditActionRestored AuditAction = "restored"
+)
+
+// Product audit validation errors
+var (
+	ErrInvalidAuditAction  = errors.New("invalid audit action")
+	ErrInvalidAuditProduct = errors.New("audit record requires a product")
+	ErrEmptyAuditActor     = errors.New("audit record actor cannot be empty")
+)
+
+// IsValid reports whether the audit action is one of the supported actions
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditActionCreated, AuditActionUpdated, AuditActionDeleted, AuditActionRestored:
+		return true
+	}
+	return false
+}
+
+// ChangeOrigin identifies who made a change and the request it came with
+type ChangeOrigin struct {
+	Actor     string
+	RequestID string // Empty when the change was not made through a request
+	SourceIP  string // Empty when the change was not made through a request
+}
+
+// ProductState is what a product looked like at one point in time. CreatedAt, UpdatedAt and Version
+// are bookkeeping and never show up as changes.
+type ProductState struct {
+	Name        string
+	SKU         string
+	Description string
+	Brand       string
+	Status      ProductStatus
+	WeightGrams int64
+	Dimensions  Dimensions
+	Tags        []string
+	Attributes  map[string]string
+	Serialized  bool
+	StockUnit   string
+	CategoryID  uint // Zero while the product is uncategorised
+	DeletedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Version     int64
+}
+
+// FieldChange is the value of one product field before and after a change. Before is nil for the
+// fields of a created product.
+type FieldChange struct {
+	Field  string
+	Before interface{}
+	After  interface{}
+}
+
+// ProductStateOf captures the current state of a product
+func ProductStateOf(p *Product) ProductState {
+	attributes := p.Attributes()
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	return ProductState{
+		Name:        p.Name(),
+		SKU:         p.SKU(),
+		Description: p.Description(),
+		Brand:       p.Brand(),
+		Status:      p.Status(),
+		WeightGrams: p.WeightGrams(),
+		Dimensions:  p.Dimensions(),
+		Tags:        p.Tags(),
+		Attributes:  attributes,
+		Serialized:  p.IsSerialized(),
+		StockUnit:   p.StockUnit(),
+		CategoryID:  p.CategoryID(),
+		DeletedAt:   p.DeletedAt(),
+		CreatedAt:   p.CreatedAt(),
+		UpdatedAt:   p.UpdatedAt(),
+		Version:     p.Version(),
+	}
+}
+
+// Product rebuilds the product with the given ID in this state
+func (s ProductState) Product(id uint) (*Product, error) {
+	p := &Product{}
+	if err := p.MakeProduct(id, s.Name, s.SKU, s.CreatedAt, s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	p.SetSerialized(s.Serialized)
+	p.SetStockUnit(s.StockUnit)
+	p.SetCategory(s.CategoryID)
+	if err := p.SetDescription(s.Description); err != nil {
+		return nil, err
+	}
+	if err := p.SetBrand(s.Brand); err != nil {
+		return nil, err
+	}
+	if s.Status != "" {
+		if err := p.SetStatus(s.Status); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.SetWeightGrams(s.WeightGrams); err != nil {
+		return nil, err
+	}
+	if err := p.SetDimensions(s.Dimensions); err != nil {
+		return nil, err
+	}
+	if err := p.SetTags(s.Tags); err != nil {
+		return nil, err
+	}
+	if err := p.SetAttributes(s.Attributes); err != nil {
+		return nil, err
+	}
+	if s.DeletedAt != nil {
+		p.MarkDeleted(*s.DeletedAt)
+	}
+	p.SetVersion(s.Version)
+	return p, nil
+}
+
+// Diff lists the fields whose values differ between this state and after, in a fixed order
+func (s ProductState) Diff(after ProductState) []FieldChange {
+	beforeFields, afterFields := s.fields(), after.fields()
+	changes := []FieldChange{}
+	for i, field := range beforeFields {
+		if !reflect.DeepEqual(field.value, afterFields[i].value) {
+			changes = append(changes, FieldChange{Field: field.name, Before: field.value, After: afterFields[i].value})
+		}
+	}
+	return changes
+}
+
+// fieldValue is one audited field of a product state in the form it is recorded in
+type fieldValue struct {
+	name  string
+	value interface{}
+}
+
+// fields returns the audited fields of the state. Absent values are nil and times are UTC so that
+// equal states compare equal wherever they were read from.
+func (s ProductState) fields() []fieldValue {
+	var categoryID interface{}
+	if s.CategoryID != 0 {
+		categoryID = s.CategoryID
+	}
+	var deletedAt interface{}
+	if s.DeletedAt != nil {
+		deletedAt = s.DeletedAt.UTC().Format(time.RFC3339Nano)
+	}
+	tags := s.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	attributes := s.Attributes
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	return []fieldValue{
+		{"name", s.Name},
+		{"sku", s.SKU},
+		{"description", s.Description},
+		{"brand", s.Brand},
+		{"status", string(s.Status)},
+		{"weight_grams", s.WeightGrams},
+		{"length_mm", s.Dimensions.LengthMM},
+		{"width_mm", s.Dimensions.WidthMM},
+		{"height_mm", s.Dimensions.HeightMM},
+		{"tags", tags},
+		{"attributes", attributes},
+		{"serialized", s.Serialized},
+		{"stock_unit", s.StockUnit},
+		{"category_id", categoryID},
+		{"deleted_at", deletedAt},
+	}
+}
+
+// ProductAuditRecord is an immutable entry of the audit trail recording one change of a product
+type ProductAuditRecord struct {
+	id         uint          // Unexported ID field
+	productID  uint          // Product that changed
+	action     AuditAction   // Kind of change
+	origin     ChangeOrigin  // Who made the change and from where
+	changes    []FieldChange // Fields the change touched
+	state      ProductState  // State of the product right after the change
+	occurredAt time.Time     // When the change was made
+}
+
+// NewProductAuditRecord creates the audit record of a change that took a product from before to after.
+// before is nil when the product was created, and every field is then recorded as changed.
+func NewProductAuditRecord(productID uint, action AuditAction, before *ProductState, after ProductState, origin ChangeOrigin, occurredAt time.Time) (*ProductAuditRecord, error) {
+	var changes []FieldChange
+	if before != nil {
+		changes = before.Diff(after)
+	} else {
+		for _, field := range after.fields() {
+			changes = append(changes, FieldChange{Field: field.name, After: field.value})
+		}
+	}
+
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	record := &ProductAuditRecord{}
+	if err := record.MakeProductAuditRecord(0, productID, action, origin, changes, after, occurredAt); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// MakeProductAuditRecord sets all attributes of the ProductAuditRecord from parameters
+func (r *ProductAuditRecord) MakeProductAuditRecord(id uint, productID uint, action AuditAction, origin ChangeOrigin, changes []FieldChange, state ProductState, occurredAt time.Time) error {
+	if productID == 0 {
+		return ErrInvalidAuditProduct
+	}
+	if !action.IsValid() {
+		return ErrInvalidAuditAction
+	}
+	if strings.TrimSpace(origin.Actor) == "" {
+		return ErrEmptyAuditActor
+	}
+	if changes == nil {
+		changes = []FieldChange{}
+	}
+	r.id = id
+	r.productID = productID
+	r.action = action
+	r.origin = origin
+	r.changes = changes
+	r.state = state
+	r.occurredAt = occurredAt
+	return nil
+}
+
+// ID returns the ID of the audit record
+func (r *ProductAuditRecord) ID() uint {
+	return r.id
+}
+
+// ProductID returns the product that changed
+func (r *ProductAuditRecord) ProductID() uint {
+	return r.productID
+}
+
+// Action returns the kind of change
+func (r *ProductAuditRecord) Action() AuditAction {
+	return r.action
+}
+
+// Origin returns who made the change and the request it came with
+func (r *ProductAuditRecord) Origin() ChangeOrigin {
+	return r.origin
+}
+
+// Changes returns the fields the change touched with their values before and after it
+func (r *ProductAuditRecord) Changes() []FieldChange {
+	return append([]FieldChange{}, r.changes...)
+}
+
+// State returns the state of the product right after the change
+func (r *ProductAuditRecord) State() ProductState {
+	return r.state
+}
+
+// OccurredAt returns when the change was made
+func (r *ProductAuditRecord) OccurredAt() time.Time {
+	return r.occurredAt
+}
@@ -2,23 +2,84 @@ package entity
 
 import (
 	"crypto/rand"
-	"encoding/binary"
 	"errors"
-	"fmt"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Define constant for error message
 const ErrEmptyName = "name cannot be empty"
 
+// MaxSKULength is the longest SKU a product can have
+const MaxSKULength = 100
+
+// ErrInvalidSKU is returned when a SKU does not have the format products are looked up by
+var ErrInvalidSKU = errors.New("sku must be up to 100 letters, digits, '.', '_' or '-' and start with a letter or digit")
+
+// Limits on the descriptive attributes of a product
+const (
+	MaxDescriptionLength    = 5000
+	MaxBrandLength          = 100
+	MaxTags                 = 50
+	MaxTagLength            = 50
+	MaxAttributes           = 50
+	MaxAttributeNameLength  = 50
+	MaxAttributeValueLength = 255
+)
+
+// ProductStatus describes whether a product is being sold
+type ProductStatus string
+
+// Supported product statuses
+const (
+	ProductStatusDraft        ProductStatus = "draft"
+	ProductStatusActive       ProductStatus = "active"
+	ProductStatusDiscontinued ProductStatus = "discontinued"
+	ProductStatusArchived     ProductStatus = "archived"
+)
+
+// Product attribute validation errors
+var (
+	ErrDescriptionTooLong    = errors.New("description must be at most 5000 characters")
+	ErrBrandTooLong          = errors.New("brand must be at most 100 characters")
+	ErrInvalidProductStatus  = errors.New("status must be one of draft, active, discontinued or archived")
+	ErrInvalidWeight         = errors.New("weight must not be negative")
+	ErrInvalidDimensions     = errors.New("dimensions must not be negative")
+	ErrTooManyTags           = errors.New("a product can have at most 50 tags")
+	ErrInvalidTag            = errors.New("tags must be between 1 and 50 characters")
+	ErrTooManyAttributes     = errors.New("a product can have at most 50 attributes")
+	ErrInvalidAttributeName  = errors.New("attribute names must be up to 50 letters, digits, '_' or '-' and start with a letter")
+	ErrAttributeValueTooLong = errors.New("attribute values must be at most 255 characters")
+)
+
+// Dimensions is the size of a packed product in millimetres. Zero means unknown.
+type Dimensions struct {
+	LengthMM int64
+	WidthMM  int64
+	HeightMM int64
+}
+
 // Product represents the business logic of a product
 type Product struct {
-	id        uint      // Unexported ID field
-	name      string    // Unexported Name field
-	sku       string    // Unexported SKU field
-	createdAt time.Time // Unexported CreatedAt field
-	updatedAt time.Time // Unexported UpdatedAt field
+	id          uint              // Unexported ID field
+	name        string            // Unexported Name field
+	sku         string            // Unexported SKU field
+	description string            // Free text shown to customers
+	brand       string            // Brand the product is sold under
+	status      ProductStatus     // Whether the product is being sold
+	weightGrams int64             // Packed weight in grams, zero when unknown
+	dimensions  Dimensions        // Packed size in millimetres
+	tags        []string          // Normalised, unique tags
+	attributes  map[string]string // Custom attributes by name
+	serialized  bool              // Whether every unit is tracked by its serial number
+	stockUnit   string            // Unit stock levels and the ledger are kept in
+	categoryID  uint              // Category the product is filed under, zero when uncategorised
+	createdAt   time.Time         // Unexported CreatedAt field
+	updatedAt   time.Time         // Unexported UpdatedAt field
+	deletedAt   *time.Time        // When the product was soft-deleted, nil while it is not
+	version     int64             // Incremented on every stored change, zero until the product is first saved
 }
 
 // NewProduct creates a new Product instance and initializes the Name, SKU, and timestamps
@@ -28,20 +89,7 @@ func NewProduct(name string) (*Product, error) {
 
 // generateSKUWithCustomGenerator generates an SKU with a custom random function
 func generateSKUWithCustomGenerator(name string, randomNumberGenerator func([]byte) (int, error)) (string, error) {
-	// Get the first 3 letters of the name, convert to uppercase
-	namePart := strings.ToUpper(name)
-	if len(namePart) > 3 {
-		namePart = namePart[:3] // Take the first 3 characters
-	}
-
-	// Generate a secure random 5-digit number
-	randomPart, err := generateSecureFiveDigitNumber(randomNumberGenerator)
-	if err != nil {
-		return "", err
-	}
-
-	// Combine the name part and the random part to form the SKU
-	return "SKU-" + namePart + "-" + randomPart, nil
+	return NewRandomSKUGenerator(randomNumberGenerator).GenerateSKU(SKUInput{Name: name, Now: time.Now()}, nil)
 }
 
 // NewProductWithCustomGenerator creates a new Product with a custom random number generator (for testing)
@@ -60,6 +108,8 @@ func NewProductWithCustomGenerator(name string, randomNumberGenerator func([]byt
 		id:        0, // Assign default ID (can change as needed)
 		name:      name,
 		sku:       sku, // Generate SKU when creating the product
+		status:    ProductStatusActive,
+		stockUnit: DefaultStockUnit,
 		createdAt: currentTime,
 		updatedAt: currentTime,
 	}
@@ -67,6 +117,30 @@ func NewProductWithCustomGenerator(name string, randomNumberGenerator func([]byt
 	return product, nil
 }
 
+// NewProductWithSKU creates a new Product with the given SKU. An empty SKU leaves it to the SKU
+// generator of the repository when the product is first saved.
+func NewProductWithSKU(name string, sku string) (*Product, error) {
+	if name == "" {
+		return nil, errors.New(ErrEmptyName) // Use constant for empty name check
+	}
+	sku = strings.TrimSpace(sku)
+	if sku != "" {
+		if err := ValidateSKU(sku); err != nil {
+			return nil, err
+		}
+	}
+
+	currentTime := time.Now()
+	return &Product{
+		name:      name,
+		sku:       sku,
+		status:    ProductStatusActive,
+		stockUnit: DefaultStockUnit,
+		createdAt: currentTime,
+		updatedAt: currentTime,
+	}, nil
+}
+
 // MakeProduct sets all attributes of the Product from parameters
 func (p *Product) MakeProduct(id uint, name string, sku string, createdAt, updatedAt time.Time) error {
 	if name == "" {
@@ -80,26 +154,6 @@ func (p *Product) MakeProduct(id uint, name string, sku string, createdAt, updat
 	return nil
 }
 
-// generateSecureFiveDigitNumber generates a cryptographically secure 5-digit number
-func generateSecureFiveDigitNumber(randomNumberGenerator func([]byte) (int, error)) (string, error) {
-	// Create a byte slice for random bytes
-	b := make([]byte, 8) // 8 bytes for a 64-bit unsigned int
-
-	// Read cryptographically secure random bytes
-	_, err := randomNumberGenerator(b)
-	if err != nil {
-		return "", err
-	}
-
-	// Convert the bytes to a large integer
-	randomInt := binary.BigEndian.Uint64(b)
-
-	// Take the last 5 digits of the random integer
-	randomString := fmt.Sprintf("%05d", randomInt%100000)
-
-	return randomString, nil
-}
-
 // ID returns the ID of the product
 func (p *Product) ID() uint {
 	return p.id // Getter for ID
@@ -115,6 +169,73 @@ func (p *Product) SKU() string {
 	return p.sku // Getter for SKU
 }
 
+// Description returns the description of the product
+func (p *Product) Description() string {
+	return p.description
+}
+
+// Brand returns the brand the product is sold under
+func (p *Product) Brand() string {
+	return p.brand
+}
+
+// Status returns whether the product is being sold
+func (p *Product) Status() ProductStatus {
+	if p.status == "" { // Made without SetStatus
+		return ProductStatusActive
+	}
+	return p.status
+}
+
+// WeightGrams returns the packed weight of the product in grams, or zero when it is unknown
+func (p *Product) WeightGrams() int64 {
+	return p.weightGrams
+}
+
+// Dimensions returns the packed size of the product
+func (p *Product) Dimensions() Dimensions {
+	return p.dimensions
+}
+
+// AcceptsStock reports whether new stock may be brought in for the product. Discontinued, archived
+// and deleted products only sell off what is left.
+func (p *Product) AcceptsStock() bool {
+	status := p.Status()
+	return status != ProductStatusDiscontinued && status != ProductStatusArchived && !p.IsDeleted()
+}
+
+// Tags returns the tags of the product
+func (p *Product) Tags() []string {
+	return append([]string{}, p.tags...)
+}
+
+// Attributes returns a copy of the custom attributes of the product
+func (p *Product) Attributes() map[string]string {
+	attributes := make(map[string]string, len(p.attributes))
+	for name, value := range p.attributes {
+		attributes[name] = value
+	}
+	return attributes
+}
+
+// IsSerialized reports whether every unit of the product is tracked by its serial number
+func (p *Product) IsSerialized() bool {
+	return p.serialized
+}
+
+// StockUnit returns the unit stock levels and the ledger of the product are kept in
+func (p *Product) StockUnit() string {
+	if p.stockUnit == "" { // Made without SetStockUnit
+		return DefaultStockUnit
+	}
+	return p.stockUnit
+}
+
+// CategoryID returns the category the product is filed under, or zero when it has none
+func (p *Product) CategoryID() uint {
+	return p.categoryID
+}
+
 // CreatedAt returns the creation timestamp of the product
 func (p *Product) CreatedAt() time.Time {
 	return p.createdAt
@@ -125,6 +246,36 @@ func (p *Product) UpdatedAt() time.Time {
 	return p.updatedAt
 }
 
+// DeletedAt returns when the product was soft-deleted, or nil when it is not deleted
+func (p *Product) DeletedAt() *time.Time {
+	return p.deletedAt
+}
+
+// IsDeleted reports whether the product was soft-deleted
+func (p *Product) IsDeleted() bool {
+	return p.deletedAt != nil
+}
+
+// MarkDeleted records that the product was soft-deleted at the given time
+func (p *Product) MarkDeleted(at time.Time) {
+	p.deletedAt = &at
+}
+
+// Restore brings back a soft-deleted product
+func (p *Product) Restore() {
+	p.deletedAt = nil
+}
+
+// Version returns the stored version of the product. Updates are only written while it is still current.
+func (p *Product) Version() int64 {
+	return p.version
+}
+
+// SetVersion sets the version the product was read at
+func (p *Product) SetVersion(version int64) {
+	p.version = version
+}
+
 // SetName sets the Name of the product
 func (p *Product) SetName(name string) error {
 	if name == "" {
@@ -133,3 +284,178 @@ func (p *Product) SetName(name string) error {
 	p.name = name // Set the unexported Name
 	return nil
 }
+
+// SetSKU replaces the SKU of the product. The caller keeps the previous SKU as an alias.
+func (p *Product) SetSKU(sku string) error {
+	sku = strings.TrimSpace(sku)
+	if err := ValidateSKU(sku); err != nil {
+		return err
+	}
+	p.sku = sku
+	return nil
+}
+
+// SetDescription replaces the description of the product. An empty description removes it.
+func (p *Product) SetDescription(description string) error {
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return ErrDescriptionTooLong
+	}
+	p.description = description
+	return nil
+}
+
+// SetBrand replaces the brand of the product. An empty brand removes it.
+func (p *Product) SetBrand(brand string) error {
+	brand = strings.TrimSpace(brand)
+	if utf8.RuneCountInString(brand) > MaxBrandLength {
+		return ErrBrandTooLong
+	}
+	p.brand = brand
+	return nil
+}
+
+// SetStatus sets whether the product is being sold
+func (p *Product) SetStatus(status ProductStatus) error {
+	switch status {
+	case ProductStatusDraft, ProductStatusActive, ProductStatusDiscontinued, ProductStatusArchived:
+	default:
+		return ErrInvalidProductStatus
+	}
+	p.status = status
+	return nil
+}
+
+// SetWeightGrams sets the packed weight of the product. Zero marks it as unknown.
+func (p *Product) SetWeightGrams(grams int64) error {
+	if grams < 0 {
+		return ErrInvalidWeight
+	}
+	p.weightGrams = grams
+	return nil
+}
+
+// SetDimensions sets the packed size of the product. Zero marks a side as unknown.
+func (p *Product) SetDimensions(dimensions Dimensions) error {
+	if dimensions.LengthMM < 0 || dimensions.WidthMM < 0 || dimensions.HeightMM < 0 {
+		return ErrInvalidDimensions
+	}
+	p.dimensions = dimensions
+	return nil
+}
+
+// SetTags replaces the tags of the product. Tags are trimmed and lowercased, and duplicates are
+// dropped keeping the first occurrence.
+func (p *Product) SetTags(tags []string) error {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+			return ErrInvalidTag
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxTags {
+		return ErrTooManyTags
+	}
+	p.tags = normalized
+	return nil
+}
+
+// SetAttributes replaces the custom attributes of the product
+func (p *Product) SetAttributes(attributes map[string]string) error {
+	if len(attributes) > MaxAttributes {
+		return ErrTooManyAttributes
+	}
+	replaced := make(map[string]string, len(attributes))
+	for name, value := range attributes {
+		if err := validateAttribute(name, value); err != nil {
+			return err
+		}
+		replaced[name] = value
+	}
+	p.attributes = replaced
+	return nil
+}
+
+// SetAttribute adds or replaces one custom attribute of the product
+func (p *Product) SetAttribute(name string, value string) error {
+	if err := validateAttribute(name, value); err != nil {
+		return err
+	}
+	if _, exists := p.attributes[name]; !exists && len(p.attributes) >= MaxAttributes {
+		return ErrTooManyAttributes
+	}
+	if p.attributes == nil {
+		p.attributes = make(map[string]string)
+	}
+	p.attributes[name] = value
+	return nil
+}
+
+// RemoveAttribute removes a custom attribute from the product. Removing a missing attribute does nothing.
+func (p *Product) RemoveAttribute(name string) {
+	delete(p.attributes, name)
+}
+
+// SetSerialized marks whether every unit of the product is tracked by its serial number
+func (p *Product) SetSerialized(serialized bool) {
+	p.serialized = serialized
+}
+
+// SetStockUnit sets the unit stock levels and the ledger of the product are kept in. An empty code
+// selects the default unit.
+func (p *Product) SetStockUnit(code string) {
+	p.stockUnit = NormalizeUnitCode(code)
+	if p.stockUnit == "" {
+		p.stockUnit = DefaultStockUnit
+	}
+}
+
+// SetCategory files the product under a category. Zero removes it from its category.
+func (p *Product) SetCategory(categoryID uint) {
+	p.categoryID = categoryID
+}
+
+// validateAttribute checks the name and value of a custom attribute
+func validateAttribute(name string, value string) error {
+	if name == "" || utf8.RuneCountInString(name) > MaxAttributeNameLength {
+		return ErrInvalidAttributeName
+	}
+	for i, r := range name {
+		if unicode.IsLetter(r) {
+			continue
+		}
+		if i > 0 && (unicode.IsDigit(r) || r == '_' || r == '-') {
+			continue
+		}
+		return ErrInvalidAttributeName
+	}
+	if utf8.RuneCountInString(value) > MaxAttributeValueLength {
+		return ErrAttributeValueTooLong
+	}
+	return nil
+}
+
+// ValidateSKU checks that a SKU can be stored and used in lookup URLs: up to MaxSKULength letters,
+// digits, '.', '_' or '-', starting with a letter or digit
+func ValidateSKU(sku string) error {
+	if sku == "" || utf8.RuneCountInString(sku) > MaxSKULength {
+		return ErrInvalidSKU
+	}
+	for i, r := range sku {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			continue
+		}
+		if i > 0 && (r == '.' || r == '_' || r == '-') {
+			continue
+		}
+		return ErrInvalidSKU
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidProductImportCounts is returned when the counts of an import do not add up
+var ErrInvalidProductImportCounts = errors.New("product import counts and lines cannot be negative")
+
+// RejectedProductRow is a row of an import file that was not imported, with the reason for every field
+// it failed on. Line is the line of the row in the file.
+type RejectedProductRow struct {
+	Line   int
+	SKU    string
+	Name   string
+	Errors map[string]string // Messages keyed by the request field they concern
+}
+
+// ProductImport is the outcome of importing a file of products: how many rows created or updated a
+// product and which rows were rejected. A dry run reports what an import would do without storing
+// any product. An import that failed part way reports the rows imported before the failure.
+type ProductImport struct {
+	id           uint                 // Unexported ID field
+	fileName     string               // Name of the uploaded file
+	dryRun       bool                 // Set when no product was stored
+	created      int                  // Rows that created a product
+	updated      int                  // Rows that changed the product carrying their SKU
+	unchanged    int                  // Rows that matched the product carrying their SKU as it was
+	rejectedRows []RejectedProductRow // Rows that were not imported, in file order
+	failedAtLine int                  // Line the rows left unimported start at, zero when no batch failed
+	createdAt    time.Time            // Unexported CreatedAt field
+}
+
+// NewProductImport starts the report of importing a file
+func NewProductImport(fileName string, dryRun bool, now time.Time) *ProductImport {
+	return &ProductImport{fileName: fileName, dryRun: dryRun, rejectedRows: []RejectedProductRow{}, createdAt: now}
+}
+
+// MakeProductImport sets all attributes of the ProductImport from parameters
+func (i *ProductImport) MakeProductImport(id uint, fileName string, dryRun bool, created int, updated int, unchanged int, rejectedRows []RejectedProductRow, failedAtLine int, createdAt time.Time) error {
+	if created < 0 || updated < 0 || unchanged < 0 || failedAtLine < 0 {
+		return ErrInvalidProductImportCounts
+	}
+	if rejectedRows == nil {
+		rejectedRows = []RejectedProductRow{}
+	}
+	i.id = id
+	i.fileName = fileName
+	i.dryRun = dryRun
+	i.created = created
+	i.updated = updated
+	i.unchanged = unchanged
+	i.rejectedRows = rejectedRows
+	i.failedAtLine = failedAtLine
+	i.createdAt = createdAt
+	return nil
+}
+
+// RecordCreated counts a row that created a product
+func (i *ProductImport) RecordCreated() {
+	i.created++
+}
+
+// RecordUpdated counts a row that changed an existing product
+func (i *ProductImport) RecordUpdated() {
+	i.updated++
+}
+
+// RecordUnchanged counts a row that left an existing product as it was
+func (i *ProductImport) RecordUnchanged() {
+	i.unchanged++
+}
+
+// RecordRejected adds a row that was not imported to the report
+func (i *ProductImport) RecordRejected(row RejectedProductRow) {
+	i.rejectedRows = append(i.rejectedRows, row)
+}
+
+// MarkFailed records that the rows from the given line on were not imported, because the batch
+// starting there failed
+func (i *ProductImport) MarkFailed(line int) {
+	i.failedAtLine = line
+}
+
+// ID returns the ID of the import
+func (i *ProductImport) ID() uint {
+	return i.id
+}
+
+// FileName returns the name of the uploaded file
+func (i *ProductImport) FileName() string {
+	return i.fileName
+}
+
+// DryRun reports whether the import left the products untouched
+func (i *ProductImport) DryRun() bool {
+	return i.dryRun
+}
+
+// TotalRows returns the number of product rows read from the file
+func (i *ProductImport) TotalRows() int {
+	return i.created + i.updated + i.unchanged + len(i.rejectedRows)
+}
+
+// Created returns the number of rows that created a product
+func (i *ProductImport) Created() int {
+	return i.created
+}
+
+// Updated returns the number of rows that changed an existing product
+func (i *ProductImport) Updated() int {
+	return i.updated
+}
+
+// Unchanged returns the number of rows that left an existing product as it was
+func (i *ProductImport) Unchanged() int {
+	return i.unchanged
+}
+
+// Rejected returns the number of rows that were not imported
+func (i *ProductImport) Rejected() int {
+	return len(i.rejectedRows)
+}
+
+// RejectedRows returns the rows that were not imported, in file order
+func (i *ProductImport) RejectedRows() []RejectedProductRow {
+	return append([]RejectedProductRow{}, i.rejectedRows...)
+}
+
+// Failed reports whether the import stopped before every row was tried
+func (i *ProductImport) Failed() bool {
+	return i.failedAtLine > 0
+}
+
+// FailedAtLine returns the line the rows left unimported start at, zero when no batch failed
+func (i *ProductImport) FailedAtLine() int {
+	return i.failedAtLine
+}
+
+// CreatedAt returns when the file was imported
+func (i *ProductImport) CreatedAt() time.Time {
+	return i.createdAt
+}
@@ -0,0 +1,231 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+// MaxVariantsPerProduct caps how many variants the option axes of one product may combine into
+const MaxVariantsPerProduct = 250
+
+// Variant validation errors
+var (
+	ErrEmptyOptionName       = errors.New("option name cannot be empty")
+	ErrEmptyOptionValue      = errors.New("option value cannot be empty")
+	ErrNoOptionValues        = errors.New("option axis requires at least one value")
+	ErrDuplicateOptionValue  = errors.New("option value appears more than once on the axis")
+	ErrNoOptionAxes          = errors.New("variants require at least one option axis")
+	ErrDuplicateOptionAxis   = errors.New("option axis appears more than once")
+	ErrTooManyVariants       = errors.New("option axes combine into too many variants")
+	ErrInvalidVariantParent  = errors.New("variant requires a saved parent product")
+	ErrVariantOfVariant      = errors.New("a variant cannot have variants of its own")
+	ErrVariantAxesMismatch   = errors.New("option axes differ from those of the existing variants")
+	ErrInvalidVariantOptions = errors.New("variant requires one value for every option axis")
+	ErrInvalidVariantProduct = errors.New("variant requires a product")
+)
+
+// OptionAxis is one dimension a product comes in, like size, with the values it comes in
+type OptionAxis struct {
+	name   string   // Unexported Name field, e.g. "Size"
+	values []string // Values in the order they were given, e.g. "S", "M", "L"
+}
+
+// NewOptionAxis creates an OptionAxis, trimming the name and values. Values must be distinct
+// regardless of case.
+func NewOptionAxis(name string, values []string) (*OptionAxis, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyOptionName
+	}
+	if len(values) == 0 {
+		return nil, ErrNoOptionValues
+	}
+
+	seen := make(map[string]bool, len(values))
+	trimmed := make([]string, len(values))
+	for i, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, ErrEmptyOptionValue
+		}
+		if seen[strings.ToLower(value)] {
+			return nil, ErrDuplicateOptionValue
+		}
+		seen[strings.ToLower(value)] = true
+		trimmed[i] = value
+	}
+	return &OptionAxis{name: name, values: trimmed}, nil
+}
+
+// Name returns the name of the option axis
+func (a *OptionAxis) Name() string {
+	return a.name
+}
+
+// Values returns the values of the option axis in the order they were given
+func (a *OptionAxis) Values() []string {
+	return a.values
+}
+
+// VariantOption is the value a variant takes on one option axis, like size M
+type VariantOption struct {
+	name  string // Name of the option axis
+	value string // Value on the axis
+}
+
+// MakeVariantOption creates a VariantOption, trimming the name and value
+func MakeVariantOption(name string, value string) (VariantOption, error) {
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if name == "" {
+		return VariantOption{}, ErrEmptyOptionName
+	}
+	if value == "" {
+		return VariantOption{}, ErrEmptyOptionValue
+	}
+	return VariantOption{name: name, value: value}, nil
+}
+
+// Name returns the name of the option axis
+func (o VariantOption) Name() string {
+	return o.name
+}
+
+// Value returns the value on the option axis
+func (o VariantOption) Value() string {
+	return o.value
+}
+
+// CombineOptionAxes returns every combination of one value per axis, in the order of the axes with
+// the last axis varying fastest
+func CombineOptionAxes(axes []*OptionAxis) ([][]VariantOption, error) {
+	if len(axes) == 0 {
+		return nil, ErrNoOptionAxes
+	}
+
+	total := 1
+	seen := make(map[string]bool, len(axes))
+	for _, axis := range axes {
+		if seen[strings.ToLower(axis.name)] {
+			return nil, ErrDuplicateOptionAxis
+		}
+		seen[strings.ToLower(axis.name)] = true
+		total *= len(axis.values)
+		if total > MaxVariantsPerProduct {
+			return nil, ErrTooManyVariants
+		}
+	}
+
+	combinations := make([][]VariantOption, 0, total)
+	combination := make([]VariantOption, len(axes))
+	var combine func(depth int)
+	combine = func(depth int) {
+		if depth == len(axes) {
+			combinations = append(combinations, append([]VariantOption(nil), combination...))
+			return
+		}
+		for _, value := range axes[depth].values {
+			combination[depth] = VariantOption{name: axes[depth].name, value: value}
+			combine(depth + 1)
+		}
+	}
+	combine(0)
+	return combinations, nil
+}
+
+// VariantKey identifies a combination of option values regardless of case, so that regenerating
+// variants recognises the combinations that already exist
+func VariantKey(options []VariantOption) string {
+	parts := make([]string, len(options))
+	for i, option := range options {
+		parts[i] = strings.ToLower(option.name) + "=" + strings.ToLower(option.value)
+	}
+	return strings.Join(parts, ";")
+}
+
+// ProductVariant is a product sold as one combination of the option axes of its parent, like the
+// medium red shirt of a shirt. The variant is a product of its own with its own SKU and stock.
+type ProductVariant struct {
+	parentID uint            // Product the variant belongs to
+	product  *Product        // Product holding the SKU and stock of the variant
+	options  []VariantOption // Value on every option axis of the parent, in axis order
+}
+
+// NewProductVariant creates the product of one combination of options of parent. It is named after
+// the parent and its option values and inherits how the parent is stocked. Its SKU is generated
+// when it is saved.
+func NewProductVariant(parent *Product, options []VariantOption) (*ProductVariant, error) {
+	if parent == nil || parent.ID() == 0 {
+		return nil, ErrInvalidVariantParent
+	}
+	if len(options) == 0 {
+		return nil, ErrInvalidVariantOptions
+	}
+
+	values := make([]string, len(options))
+	for i, option := range options {
+		values[i] = option.value
+	}
+	product, err := NewProductWithSKU(parent.Name()+" - "+strings.Join(values, " / "), "")
+	if err != nil {
+		return nil, err
+	}
+	product.SetSerialized(parent.IsSerialized())
+	product.SetStockUnit(parent.StockUnit())
+	product.SetCategory(parent.CategoryID())
+
+	variant := &ProductVariant{}
+	if err := variant.MakeProductVariant(parent.ID(), product, options); err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+// MakeProductVariant sets all attributes of the ProductVariant from parameters
+func (v *ProductVariant) MakeProductVariant(parentID uint, product *Product, options []VariantOption) error {
+	if parentID == 0 {
+		return ErrInvalidVariantParent
+	}
+	if product == nil {
+		return ErrInvalidVariantProduct
+	}
+	if len(options) == 0 {
+		return ErrInvalidVariantOptions
+	}
+	v.parentID = parentID
+	v.product = product
+	v.options = options
+	return nil
+}
+
+// ParentID returns the product the variant belongs to
+func (v *ProductVariant) ParentID() uint {
+	return v.parentID
+}
+
+// Product returns the product holding the SKU and stock of the variant
+func (v *ProductVariant) Product() *Product {
+	return v.product
+}
+
+// Options returns the value of the variant on every option axis, in axis order
+func (v *ProductVariant) Options() []VariantOption {
+	return v.options
+}
+
+// Key returns the VariantKey of the options of the variant
+func (v *ProductVariant) Key() string {
+	return VariantKey(v.options)
+}
+
+// MatchesAxes reports whether the variant takes one value on each of the axes, in the same order
+func (v *ProductVariant) MatchesAxes(axes []*OptionAxis) bool {
+	if len(v.options) != len(axes) {
+		return false
+	}
+	for i, axis := range axes {
+		if !strings.EqualFold(v.options[i].name, axis.name) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,311 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// PurchaseOrderStatus describes where a purchase order is in its lifecycle
+type PurchaseOrderStatus string
+
+// Supported purchase order statuses
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderStatusSubmitted         PurchaseOrderStatus = "submitted"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderStatusClosed            PurchaseOrderStatus = "closed"
+)
+
+// Purchase order validation and state errors
+var (
+	ErrInvalidPurchaseOrderSupplier  = errors.New("purchase order requires a supplier")
+	ErrInvalidPurchaseOrderWarehouse = errors.New("purchase order requires a receiving warehouse")
+	ErrInvalidPurchaseOrderStatus    = errors.New("invalid purchase order status")
+	ErrInvalidCurrency               = errors.New("currency must be a three-letter ISO 4217 code")
+	ErrInvalidPurchaseLineProduct    = errors.New("purchase order line requires a product")
+	ErrInvalidPurchaseQuantity       = errors.New("purchase order quantity must be positive")
+	ErrNegativeUnitCost              = errors.New("unit cost cannot be negative")
+	ErrInvalidPurchaseReceived       = errors.New("received quantity must be between 0 and the ordered quantity")
+	ErrDuplicatePurchaseLine         = errors.New("product appears on more than one purchase order line")
+	ErrEmptyPurchaseOrder            = errors.New("purchase order has no lines")
+	ErrPurchaseOrderNotDraft         = errors.New("purchase order is no longer a draft")
+	ErrPurchaseOrderNotReceivable    = errors.New("purchase order is not open for receiving")
+	ErrPurchaseOrderNotClosable      = errors.New("purchase order cannot be closed before goods are received")
+	ErrPurchaseLineNotFound          = errors.New("product is not on the purchase order")
+	ErrReceiveExceedsOrdered         = errors.New("received quantity exceeds the outstanding quantity")
+)
+
+// PurchaseOrderLine is the ordered quantity and agreed unit cost of one product
+type PurchaseOrderLine struct {
+	id               uint  // Unexported ID field
+	productID        uint  // Unexported ProductID field
+	quantity         int64 // Ordered quantity
+	receivedQuantity int64 // Quantity received so far
+	unitCost         int64 // Cost per unit in minor currency units, e.g. cents
+}
+
+// MakePurchaseOrderLine sets all attributes of the PurchaseOrderLine from parameters
+func (l *PurchaseOrderLine) MakePurchaseOrderLine(id uint, productID uint, quantity int64, receivedQuantity int64, unitCost int64) error {
+	if productID == 0 {
+		return ErrInvalidPurchaseLineProduct
+	}
+	if quantity <= 0 {
+		return ErrInvalidPurchaseQuantity
+	}
+	if receivedQuantity < 0 || receivedQuantity > quantity {
+		return ErrInvalidPurchaseReceived
+	}
+	if unitCost < 0 {
+		return ErrNegativeUnitCost
+	}
+	l.id = id
+	l.productID = productID
+	l.quantity = quantity
+	l.receivedQuantity = receivedQuantity
+	l.unitCost = unitCost
+	return nil
+}
+
+// ID returns the ID of the purchase order line
+func (l *PurchaseOrderLine) ID() uint {
+	return l.id
+}
+
+// ProductID returns the ordered product
+func (l *PurchaseOrderLine) ProductID() uint {
+	return l.productID
+}
+
+// Quantity returns the ordered quantity
+func (l *PurchaseOrderLine) Quantity() int64 {
+	return l.quantity
+}
+
+// ReceivedQuantity returns the quantity received so far
+func (l *PurchaseOrderLine) ReceivedQuantity() int64 {
+	return l.receivedQuantity
+}
+
+// Outstanding returns the quantity still to be delivered
+func (l *PurchaseOrderLine) Outstanding() int64 {
+	return l.quantity - l.receivedQuantity
+}
+
+// UnitCost returns the cost per unit in minor currency units
+func (l *PurchaseOrderLine) UnitCost() int64 {
+	return l.unitCost
+}
+
+// LineTotal returns the cost of the ordered quantity in minor currency units
+func (l *PurchaseOrderLine) LineTotal() int64 {
+	return l.quantity * l.unitCost
+}
+
+// PurchaseOrder is an order for stock placed with a supplier and received into one warehouse
+type PurchaseOrder struct {
+	id          uint                 // Unexported ID field
+	supplierID  uint                 // Supplier the order is placed with
+	warehouseID uint                 // Warehouse the goods are received into
+	status      PurchaseOrderStatus  // Unexported Status field
+	reference   string               // Caller's reference, e.g. the supplier's quote number
+	currency    string               // ISO 4217 code the unit costs are expressed in
+	lines       []*PurchaseOrderLine // Ordered products
+	submittedAt *time.Time           // When the order was sent to the supplier
+	closedAt    *time.Time           // When the order was closed
+	createdAt   time.Time            // Unexported CreatedAt field
+	updatedAt   time.Time            // Unexported UpdatedAt field
+}
+
+// NewPurchaseOrder creates a draft PurchaseOrder without lines
+func NewPurchaseOrder(supplierID uint, warehouseID uint, reference string, currency string) (*PurchaseOrder, error) {
+	order := &PurchaseOrder{}
+	now := time.Now()
+	if err := order.MakePurchaseOrder(0, supplierID, warehouseID, PurchaseOrderStatusDraft, reference, currency, nil, nil, nil, now, now); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// MakePurchaseOrder sets all attributes of the PurchaseOrder from parameters
+func (o *PurchaseOrder) MakePurchaseOrder(id uint, supplierID uint, warehouseID uint, status PurchaseOrderStatus, reference string, currency string, lines []*PurchaseOrderLine, submittedAt, closedAt *time.Time, createdAt, updatedAt time.Time) error {
+	if supplierID == 0 {
+		return ErrInvalidPurchaseOrderSupplier
+	}
+	if warehouseID == 0 {
+		return ErrInvalidPurchaseOrderWarehouse
+	}
+	switch status {
+	case PurchaseOrderStatusDraft, PurchaseOrderStatusSubmitted, PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived, PurchaseOrderStatusClosed:
+	default:
+		return ErrInvalidPurchaseOrderStatus
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if len(currency) != 3 {
+		return ErrInvalidCurrency
+	}
+	o.id = id
+	o.supplierID = supplierID
+	o.warehouseID = warehouseID
+	o.status = status
+	o.reference = reference
+	o.currency = currency
+	o.lines = lines
+	o.submittedAt = submittedAt
+	o.closedAt = closedAt
+	o.createdAt = createdAt
+	o.updatedAt = updatedAt
+	return nil
+}
+
+// AddLine adds a product to a draft purchase order
+func (o *PurchaseOrder) AddLine(productID uint, quantity int64, unitCost int64) error {
+	if o.status != PurchaseOrderStatusDraft {
+		return ErrPurchaseOrderNotDraft
+	}
+	if o.findLine(productID) != nil {
+		return ErrDuplicatePurchaseLine
+	}
+
+	line := &PurchaseOrderLine{}
+	if err := line.MakePurchaseOrderLine(0, productID, quantity, 0, unitCost); err != nil {
+		return err
+	}
+	o.lines = append(o.lines, line)
+	return nil
+}
+
+// Submit sends a draft purchase order to the supplier, after which it can be received
+func (o *PurchaseOrder) Submit(now time.Time) error {
+	if o.status != PurchaseOrderStatusDraft {
+		return ErrPurchaseOrderNotDraft
+	}
+	if len(o.lines) == 0 {
+		return ErrEmptyPurchaseOrder
+	}
+	o.status = PurchaseOrderStatusSubmitted
+	o.submittedAt = &now
+	return nil
+}
+
+// Receive books delivered quantities per product. The order is received once nothing is outstanding
+// and partially received otherwise. Nothing changes when any of the quantities is rejected.
+func (o *PurchaseOrder) Receive(quantities map[uint]int64) error {
+	if o.status != PurchaseOrderStatusSubmitted && o.status != PurchaseOrderStatusPartiallyReceived {
+		return ErrPurchaseOrderNotReceivable
+	}
+	if len(quantities) == 0 {
+		return ErrInvalidPurchaseQuantity
+	}
+	for productID, quantity := range quantities {
+		line := o.findLine(productID)
+		if line == nil {
+			return ErrPurchaseLineNotFound
+		}
+		if quantity <= 0 {
+			return ErrInvalidPurchaseQuantity
+		}
+		if quantity > line.Outstanding() {
+			return ErrReceiveExceedsOrdered
+		}
+	}
+
+	for productID, quantity := range quantities {
+		o.findLine(productID).receivedQuantity += quantity
+	}
+
+	o.status = PurchaseOrderStatusReceived
+	for _, line := range o.lines {
+		if line.Outstanding() > 0 {
+			o.status = PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+	return nil
+}
+
+// Close finishes a purchase order once goods arrived. A partially received order can be closed
+// to give up on the outstanding quantity.
+func (o *PurchaseOrder) Close(now time.Time) error {
+	if o.status != PurchaseOrderStatusReceived && o.status != PurchaseOrderStatusPartiallyReceived {
+		return ErrPurchaseOrderNotClosable
+	}
+	o.status = PurchaseOrderStatusClosed
+	o.closedAt = &now
+	return nil
+}
+
+// Total returns the cost of every ordered line in minor currency units
+func (o *PurchaseOrder) Total() int64 {
+	var total int64
+	for _, line := range o.lines {
+		total += line.LineTotal()
+	}
+	return total
+}
+
+// findLine returns the line of a product or nil when the product is not on the order
+func (o *PurchaseOrder) findLine(productID uint) *PurchaseOrderLine {
+	for _, line := range o.lines {
+		if line.productID == productID {
+			return line
+		}
+	}
+	return nil
+}
+
+// ID returns the ID of the purchase order
+func (o *PurchaseOrder) ID() uint {
+	return o.id
+}
+
+// SupplierID returns the supplier the order is placed with
+func (o *PurchaseOrder) SupplierID() uint {
+	return o.supplierID
+}
+
+// WarehouseID returns the warehouse the goods are received into
+func (o *PurchaseOrder) WarehouseID() uint {
+	return o.warehouseID
+}
+
+// Status returns the status of the purchase order
+func (o *PurchaseOrder) Status() PurchaseOrderStatus {
+	return o.status
+}
+
+// Reference returns the caller's reference of the purchase order
+func (o *PurchaseOrder) Reference() string {
+	return o.reference
+}
+
+// Currency returns the ISO 4217 code the unit costs are expressed in
+func (o *PurchaseOrder) Currency() string {
+	return o.currency
+}
+
+// Lines returns the ordered products
+func (o *PurchaseOrder) Lines() []*PurchaseOrderLine {
+	return o.lines
+}
+
+// SubmittedAt returns when the order was sent to the supplier, or nil while it is a draft
+func (o *PurchaseOrder) SubmittedAt() *time.Time {
+	return o.submittedAt
+}
+
+// ClosedAt returns when the order was closed, or nil while it is open
+func (o *PurchaseOrder) ClosedAt() *time.Time {
+	return o.closedAt
+}
+
+// CreatedAt returns the creation timestamp of the purchase order
+func (o *PurchaseOrder) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the purchase order
+func (o *PurchaseOrder) UpdatedAt() time.Time {
+	return o.updatedAt
+}
@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// Reorder policy validation errors
+var (
+	ErrNegativeReorderPoint         = errors.New("reorder point cannot be negative")
+	ErrInvalidReorderQuantity       = errors.New("reorder quantity must be positive")
+	ErrNegativeSafetyStock          = errors.New("safety stock cannot be negative")
+	ErrSafetyStockAboveReorderPoint = errors.New("safety stock cannot exceed the reorder point")
+)
+
+// ReorderPolicy tells when the stock of a product in a warehouse runs low and how much to order
+type ReorderPolicy struct {
+	id                  uint      // Unexported ID field
+	productID           uint      // Unexported ProductID field
+	warehouseID         uint      // Unexported WarehouseID field
+	reorderPoint        int64     // Available quantity below which stock is replenished
+	reorderQuantity     int64     // Quantity to order when replenishing
+	safetyStock         int64     // Buffer kept against demand spikes, at most the reorder point
+	preferredSupplierID uint      // Supplier to draft purchase orders with, zero when none
+	createdAt           time.Time // Unexported CreatedAt field
+	updatedAt           time.Time // Unexported UpdatedAt field
+}
+
+// NewReorderPolicy creates a ReorderPolicy for a product in a warehouse
+func NewReorderPolicy(productID uint, warehouseID uint, reorderPoint int64, reorderQuantity int64, safetyStock int64, preferredSupplierID uint) (*ReorderPolicy, error) {
+	currentTime := time.Now()
+
+	policy := &ReorderPolicy{}
+	if err := policy.MakeReorderPolicy(0, productID, warehouseID, reorderPoint, reorderQuantity, safetyStock, preferredSupplierID, currentTime, currentTime); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// MakeReorderPolicy sets all attributes of the ReorderPolicy from parameters
+func (p *ReorderPolicy) MakeReorderPolicy(id uint, productID uint, warehouseID uint, reorderPoint int64, reorderQuantity int64, safetyStock int64, preferredSupplierID uint, createdAt, updatedAt time.Time) error {
+	if productID == 0 {
+		return ErrInvalidStockLevelProduct
+	}
+	if warehouseID == 0 {
+		return ErrInvalidStockLevelWarehouse
+	}
+	if err := validateReorderQuantities(reorderPoint, reorderQuantity, safetyStock); err != nil {
+		return err
+	}
+	p.id = id
+	p.productID = productID
+	p.warehouseID = warehouseID
+	p.reorderPoint = reorderPoint
+	p.reorderQuantity = reorderQuantity
+	p.safetyStock = safetyStock
+	p.preferredSupplierID = preferredSupplierID
+	p.createdAt = createdAt
+	p.updatedAt = updatedAt
+	return nil
+}
+
+// Update replaces the thresholds and preferred supplier of the policy
+func (p *ReorderPolicy) Update(reorderPoint int64, reorderQuantity int64, safetyStock int64, preferredSupplierID uint) error {
+	if err := validateReorderQuantities(reorderPoint, reorderQuantity, safetyStock); err != nil {
+		return err
+	}
+	p.reorderPoint = reorderPoint
+	p.reorderQuantity = reorderQuantity
+	p.safetyStock = safetyStock
+	p.preferredSupplierID = preferredSupplierID
+	return nil
+}
+
+// validateReorderQuantities checks the invariants every reorder policy must satisfy
+func validateReorderQuantities(reorderPoint int64, reorderQuantity int64, safetyStock int64) error {
+	if reorderPoint < 0 {
+		return ErrNegativeReorderPoint
+	}
+	if reorderQuantity <= 0 {
+		return ErrInvalidReorderQuantity
+	}
+	if safetyStock < 0 {
+		return ErrNegativeSafetyStock
+	}
+	if safetyStock > reorderPoint {
+		return ErrSafetyStockAboveReorderPoint
+	}
+	return nil
+}
+
+// IsBelowReorderPoint reports whether the available quantity calls for replenishment
+func (p *ReorderPolicy) IsBelowReorderPoint(available int64) bool {
+	return available < p.reorderPoint
+}
+
+// ID returns the ID of the reorder policy
+func (p *ReorderPolicy) ID() uint {
+	return p.id
+}
+
+// ProductID returns the product the policy applies to
+func (p *ReorderPolicy) ProductID() uint {
+	return p.productID
+}
+
+// WarehouseID returns the warehouse the policy applies to
+func (p *ReorderPolicy) WarehouseID() uint {
+	return p.warehouseID
+}
+
+// ReorderPoint returns the available quantity below which stock is replenished
+func (p *ReorderPolicy) ReorderPoint() int64 {
+	return p.reorderPoint
+}
+
+// ReorderQuantity returns the quantity to order when replenishing
+func (p *ReorderPolicy) ReorderQuantity() int64 {
+	return p.reorderQuantity
+}
+
+// SafetyStock returns the buffer kept against demand spikes
+func (p *ReorderPolicy) SafetyStock() int64 {
+	return p.safetyStock
+}
+
+// PreferredSupplierID returns the supplier to draft purchase orders with, or zero when none
+func (p *ReorderPolicy) PreferredSupplierID() uint {
+	return p.preferredSupplierID
+}
+
+// CreatedAt returns the creation timestamp of the reorder policy
+func (p *ReorderPolicy) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the reorder policy
+func (p *ReorderPolicy) UpdatedAt() time.Time {
+	return p.updatedAt
+}
@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ReservationStatus describes where a reservation is in its lifecycle
+type ReservationStatus string
+
+// Supported reservation statuses
+const (
+	ReservationStatusPending   ReservationStatus = "pending"
+	ReservationStatusConfirmed ReservationStatus = "confirmed"
+	ReservationStatusReleased  ReservationStatus = "released"
+	ReservationStatusExpired   ReservationStatus = "expired"
+)
+
+// Reservation validation and state errors
+var (
+	ErrInvalidReservationQuantity = errors.New("reservation quantity must be positive")
+	ErrInvalidReservationTTL      = errors.New("reservation time to live must be positive")
+	ErrInvalidReservationStatus   = errors.New("invalid reservation status")
+	ErrReservationNotPending      = errors.New("reservation is no longer pending")
+	ErrReservationExpired         = errors.New("reservation has expired")
+	ErrReservationNotExpired      = errors.New("reservation has not expired yet")
+)
+
+// Reservation holds quantity of a product in a warehouse for an order until it is confirmed,
+// released or expires
+type Reservation struct {
+	id          uint              // Unexported ID field
+	productID   uint              // Unexported ProductID field
+	warehouseID uint              // Unexported WarehouseID field
+	quantity    int64             // Reserved quantity
+	status      ReservationStatus // Unexported Status field
+	reference   string            // Caller's reference, e.g. an order number
+	expiresAt   time.Time         // When a pending reservation stops holding stock
+	createdAt   time.Time         // Unexported CreatedAt field
+	updatedAt   time.Time         // Unexported UpdatedAt field
+}
+
+// NewReservation creates a pending Reservation that expires ttl after now
+func NewReservation(productID uint, warehouseID uint, quantity int64, reference string, ttl time.Duration, now time.Time) (*Reservation, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidReservationTTL
+	}
+
+	reservation := &Reservation{}
+	if err := reservation.MakeReservation(0, productID, warehouseID, quantity, ReservationStatusPending, reference, now.Add(ttl), now, now); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// MakeReservation sets all attributes of the Reservation from parameters
+func (r *Reservation) MakeReservation(id uint, productID uint, warehouseID uint, quantity int64, status ReservationStatus, reference string, expiresAt, createdAt, updatedAt time.Time) error {
+	if productID == 0 {
+		return ErrInvalidStockLevelProduct
+	}
+	if warehouseID == 0 {
+		return ErrInvalidStockLevelWarehouse
+	}
+	if quantity <= 0 {
+		return ErrInvalidReservationQuantity
+	}
+	switch status {
+	case ReservationStatusPending, ReservationStatusConfirmed, ReservationStatusReleased, ReservationStatusExpired:
+	default:
+		return ErrInvalidReservationStatus
+	}
+	r.id = id
+	r.productID = productID
+	r.warehouseID = warehouseID
+	r.quantity = quantity
+	r.status = status
+	r.reference = reference
+	r.expiresAt = expiresAt
+	r.createdAt = createdAt
+	r.updatedAt = updatedAt
+	return nil
+}
+
+// IsExpired reports whether the reservation's time to live has elapsed at now
+func (r *Reservation) IsExpired(now time.Time) bool {
+	return !now.Before(r.expiresAt)
+}
+
+// Confirm marks a pending, unexpired reservation as consumed
+func (r *Reservation) Confirm(now time.Time) error {
+	if r.status != ReservationStatusPending {
+		return ErrReservationNotPending
+	}
+	if r.IsExpired(now) {
+		return ErrReservationExpired
+	}
+	r.status = ReservationStatusConfirmed
+	return nil
+}
+
+// Release gives the reserved quantity back before the reservation expires
+func (r *Reservation) Release() error {
+	if r.status != ReservationStatusPending {
+		return ErrReservationNotPending
+	}
+	r.status = ReservationStatusReleased
+	return nil
+}
+
+// Expire marks a pending reservation whose time to live has elapsed as expired
+func (r *Reservation) Expire(now time.Time) error {
+	if r.status != ReservationStatusPending {
+		return ErrReservationNotPending
+	}
+	if !r.IsExpired(now) {
+		return ErrReservationNotExpired
+	}
+	r.status = ReservationStatusExpired
+	return nil
+}
+
+// ID returns the ID of the reservation
+func (r *Reservation) ID() uint {
+	return r.id
+}
+
+// ProductID returns the reserved product
+func (r *Reservation) ProductID() uint {
+	return r.productID
+}
+
+// WarehouseID returns the warehouse holding the reserved stock
+func (r *Reservation) WarehouseID() uint {
+	return r.warehouseID
+}
+
+// Quantity returns the reserved quantity
+func (r *Reservation) Quantity() int64 {
+	return r.quantity
+}
+
+// Status returns the status of the reservation
+func (r *Reservation) Status() ReservationStatus {
+	return r.status
+}
+
+// Reference returns the caller's reference of the reservation
+func (r *Reservation) Reference() string {
+	return r.reference
+}
+
+// ExpiresAt returns when a pending reservation stops holding stock
+func (r *Reservation) ExpiresAt() time.Time {
+	return r.expiresAt
+}
+
+// CreatedAt returns the creation timestamp of the reservation
+func (r *Reservation) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the reservation
+func (r *Reservation) UpdatedAt() time.Time {
+	return r.updatedAt
+}
@@ -0,0 +1,283 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// SalesOrderStatus describes where a sales order is in its fulfilment
+type SalesOrderStatus string
+
+// Supported sales order statuses
+const (
+	SalesOrderStatusPending     SalesOrderStatus = "pending"
+	SalesOrderStatusBackordered SalesOrderStatus = "backordered"
+	SalesOrderStatusAllocated   SalesOrderStatus = "allocated"
+	SalesOrderStatusPicking     SalesOrderStatus = "picking"
+	SalesOrderStatusPacked      SalesOrderStatus = "packed"
+	SalesOrderStatusShipped     SalesOrderStatus = "shipped"
+	SalesOrderStatusCancelled   SalesOrderStatus = "cancelled"
+)
+
+// Sales order validation and state errors
+var (
+	ErrInvalidSalesOrderWarehouse = errors.New("sales order requires a fulfilling warehouse")
+	ErrInvalidSalesOrderStatus    = errors.New("invalid sales order status")
+	ErrInvalidSalesLineProduct    = errors.New("sales order line requires a product")
+	ErrInvalidSalesQuantity       = errors.New("sales order quantity must be positive")
+	ErrInvalidAllocatedQuantity   = errors.New("allocated quantity must be between 0 and the ordered quantity")
+	ErrDuplicateSalesLine         = errors.New("product appears on more than one sales order line")
+	ErrEmptySalesOrder            = errors.New("sales order has no lines")
+	ErrSalesOrderNotPending       = errors.New("sales order lines can only change while it is pending")
+	ErrSalesOrderNotAllocatable   = errors.New("sales order is not waiting for stock")
+	ErrAllocationExceedsOrdered   = errors.New("allocation exceeds the backordered quantity")
+	ErrSalesOrderNotAllocated     = errors.New("sales order is not fully allocated")
+	ErrSalesOrderNotPicking       = errors.New("sales order is not being picked")
+	ErrSalesOrderNotPacked        = errors.New("sales order is not packed")
+	ErrSalesOrderNotCancellable   = errors.New("sales order has already shipped or been cancelled")
+)
+
+// SalesOrderLine is the ordered quantity of one product and how much of it is allocated
+type SalesOrderLine struct {
+	id                uint  // Unexported ID field
+	productID         uint  // Unexported ProductID field
+	quantity          int64 // Ordered quantity
+	allocatedQuantity int64 // Quantity reserved in the fulfilling warehouse
+}
+
+// MakeSalesOrderLine sets all attributes of the SalesOrderLine from parameters
+func (l *SalesOrderLine) MakeSalesOrderLine(id uint, productID uint, quantity int64, allocatedQuantity int64) error {
+	if productID == 0 {
+		return ErrInvalidSalesLineProduct
+	}
+	if quantity <= 0 {
+		return ErrInvalidSalesQuantity
+	}
+	if allocatedQuantity < 0 || allocatedQuantity > quantity {
+		return ErrInvalidAllocatedQuantity
+	}
+	l.id = id
+	l.productID = productID
+	l.quantity = quantity
+	l.allocatedQuantity = allocatedQuantity
+	return nil
+}
+
+// ID returns the ID of the sales order line
+func (l *SalesOrderLine) ID() uint {
+	return l.id
+}
+
+// ProductID returns the ordered product
+func (l *SalesOrderLine) ProductID() uint {
+	return l.productID
+}
+
+// Quantity returns the ordered quantity
+func (l *SalesOrderLine) Quantity() int64 {
+	return l.quantity
+}
+
+// AllocatedQuantity returns the quantity reserved for the line
+func (l *SalesOrderLine) AllocatedQuantity() int64 {
+	return l.allocatedQuantity
+}
+
+// Backordered returns the quantity still waiting for stock
+func (l *SalesOrderLine) Backordered() int64 {
+	return l.quantity - l.allocatedQuantity
+}
+
+// SalesOrder is a customer order fulfilled from one warehouse. Lines allocate stock as it becomes
+// available, and the order moves through picking and packing before it ships.
+type SalesOrder struct {
+	id                uint              // Unexported ID field
+	warehouseID       uint              // Warehouse the order ships from
+	customerReference string            // Caller's reference, e.g. the storefront order number
+	status            SalesOrderStatus  // Unexported Status field
+	lines             []*SalesOrderLine // Ordered products
+	shippedAt         *time.Time        // When the order left the warehouse
+	createdAt         time.Time         // Unexported CreatedAt field
+	updatedAt         time.Time         // Unexported UpdatedAt field
+}
+
+// NewSalesOrder creates a pending SalesOrder without lines
+func NewSalesOrder(warehouseID uint, customerReference string) (*SalesOrder, error) {
+	order := &SalesOrder{}
+	now := time.Now()
+	if err := order.MakeSalesOrder(0, warehouseID, customerReference, SalesOrderStatusPending, nil, nil, now, now); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// MakeSalesOrder sets all attributes of the SalesOrder from parameters
+func (o *SalesOrder) MakeSalesOrder(id uint, warehouseID uint, customerReference string, status SalesOrderStatus, lines []*SalesOrderLine, shippedAt *time.Time, createdAt, updatedAt time.Time) error {
+	if warehouseID == 0 {
+		return ErrInvalidSalesOrderWarehouse
+	}
+	switch status {
+	case SalesOrderStatusPending, SalesOrderStatusBackordered, SalesOrderStatusAllocated, SalesOrderStatusPicking,
+		SalesOrderStatusPacked, SalesOrderStatusShipped, SalesOrderStatusCancelled:
+	default:
+		return ErrInvalidSalesOrderStatus
+	}
+	o.id = id
+	o.warehouseID = warehouseID
+	o.customerReference = customerReference
+	o.status = status
+	o.lines = lines
+	o.shippedAt = shippedAt
+	o.createdAt = createdAt
+	o.updatedAt = updatedAt
+	return nil
+}
+
+// AddLine adds a product to a pending sales order
+func (o *SalesOrder) AddLine(productID uint, quantity int64) error {
+	if o.status != SalesOrderStatusPending {
+		return ErrSalesOrderNotPending
+	}
+	if o.findLine(productID) != nil {
+		return ErrDuplicateSalesLine
+	}
+
+	line := &SalesOrderLine{}
+	if err := line.MakeSalesOrderLine(0, productID, quantity, 0); err != nil {
+		return err
+	}
+	o.lines = append(o.lines, line)
+	return nil
+}
+
+// Allocate books quantities per product as reserved for the order. Products that could not get any
+// stock may be left out. The order is allocated once nothing is backordered and backordered otherwise.
+func (o *SalesOrder) Allocate(quantities map[uint]int64) error {
+	if o.status != SalesOrderStatusPending && o.status != SalesOrderStatusBackordered {
+		return ErrSalesOrderNotAllocatable
+	}
+	if len(o.lines) == 0 {
+		return ErrEmptySalesOrder
+	}
+	for productID, quantity := range quantities {
+		line := o.findLine(productID)
+		if line == nil {
+			return ErrInvalidSalesLineProduct
+		}
+		if quantity < 0 {
+			return ErrInvalidSalesQuantity
+		}
+		if quantity > line.Backordered() {
+			return ErrAllocationExceedsOrdered
+		}
+	}
+
+	for productID, quantity := range quantities {
+		o.findLine(productID).allocatedQuantity += quantity
+	}
+
+	o.status = SalesOrderStatusAllocated
+	for _, line := range o.lines {
+		if line.Backordered() > 0 {
+			o.status = SalesOrderStatusBackordered
+			break
+		}
+	}
+	return nil
+}
+
+// Pick starts picking a fully allocated order
+func (o *SalesOrder) Pick() error {
+	if o.status != SalesOrderStatusAllocated {
+		return ErrSalesOrderNotAllocated
+	}
+	o.status = SalesOrderStatusPicking
+	return nil
+}
+
+// Pack marks a picked order as packed and ready to ship
+func (o *SalesOrder) Pack() error {
+	if o.status != SalesOrderStatusPicking {
+		return ErrSalesOrderNotPicking
+	}
+	o.status = SalesOrderStatusPacked
+	return nil
+}
+
+// Ship marks a packed order as having left the warehouse
+func (o *SalesOrder) Ship(now time.Time) error {
+	if o.status != SalesOrderStatusPacked {
+		return ErrSalesOrderNotPacked
+	}
+	o.status = SalesOrderStatusShipped
+	o.shippedAt = &now
+	return nil
+}
+
+// Cancel abandons an order that has not shipped. The caller releases the allocated stock.
+func (o *SalesOrder) Cancel() error {
+	if o.status == SalesOrderStatusShipped || o.status == SalesOrderStatusCancelled {
+		return ErrSalesOrderNotCancellable
+	}
+	o.status = SalesOrderStatusCancelled
+	return nil
+}
+
+// Backordered returns the quantity of every line still waiting for stock
+func (o *SalesOrder) Backordered() int64 {
+	var backordered int64
+	for _, line := range o.lines {
+		backordered += line.Backordered()
+	}
+	return backordered
+}
+
+// findLine returns the line of a product or nil when the product is not on the order
+func (o *SalesOrder) findLine(productID uint) *SalesOrderLine {
+	for _, line := range o.lines {
+		if line.productID == productID {
+			return line
+		}
+	}
+	return nil
+}
+
+// ID returns the ID of the sales order
+func (o *SalesOrder) ID() uint {
+	return o.id
+}
+
+// WarehouseID returns the warehouse the order ships from
+func (o *SalesOrder) WarehouseID() uint {
+	return o.warehouseID
+}
+
+// CustomerReference returns the caller's reference of the sales order
+func (o *SalesOrder) CustomerReference() string {
+	return o.customerReference
+}
+
+// Status returns the status of the sales order
+func (o *SalesOrder) Status() SalesOrderStatus {
+	return o.status
+}
+
+// Lines returns the ordered products
+func (o *SalesOrder) Lines() []*SalesOrderLine {
+	return o.lines
+}
+
+// ShippedAt returns when the order shipped, or nil until then
+func (o *SalesOrder) ShippedAt() *time.Time {
+	return o.shippedAt
+}
+
+// CreatedAt returns the creation timestamp of the sales order
+func (o *SalesOrder) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the sales order
+func (o *SalesOrder) UpdatedAt() time.Time {
+	return o.updatedAt
+}
@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// SerialStatus describes where a serialized unit currently is
+type SerialStatus string
+
+// Supported serial statuses
+const (
+	SerialStatusInStock   SerialStatus = "in_stock"
+	SerialStatusInTransit SerialStatus = "in_transit"
+	SerialStatusIssued    SerialStatus = "issued"
+)
+
+// Serial validation and state errors
+var (
+	ErrInvalidSerialProduct   = errors.New("serial requires a product")
+	ErrEmptySerialNumber      = errors.New("serial number cannot be empty")
+	ErrInvalidSerialStatus    = errors.New("invalid serial status")
+	ErrSerialMissingWarehouse = errors.New("a serial in stock requires a warehouse")
+	ErrProductNotSerialized   = errors.New("serial numbers can only be given for serialized products")
+	ErrSerialCountMismatch    = errors.New("number of serials must match the moved quantity")
+	ErrDuplicateSerial        = errors.New("serial number appears more than once")
+	ErrSerialProductMismatch  = errors.New("serial number belongs to another product")
+	ErrSerialAlreadyInStock   = errors.New("serial is already in stock")
+	ErrSerialNotInTransit     = errors.New("serial is not in transit")
+	ErrSerialInTransit        = errors.New("serial is in transit and arrives with a transfer receipt")
+	ErrSerialNotInWarehouse   = errors.New("serial is not in stock in this warehouse")
+)
+
+// Serial is one unit of a serialized product, identified by its serial number
+type Serial struct {
+	id           uint         // Unexported ID field
+	productID    uint         // Unexported ProductID field
+	serialNumber string       // Manufacturer serial number, unique across products
+	status       SerialStatus // Unexported Status field
+	warehouseID  uint         // Warehouse holding the unit, zero while in transit or issued
+	createdAt    time.Time    // Unexported CreatedAt field
+	updatedAt    time.Time    // Unexported UpdatedAt field
+}
+
+// NewSerial creates a Serial for a unit received for the first time into a warehouse
+func NewSerial(productID uint, serialNumber string, warehouseID uint) (*Serial, error) {
+	currentTime := time.Now()
+	serial := &Serial{}
+	if err := serial.MakeSerial(0, productID, serialNumber, SerialStatusInStock, warehouseID, currentTime, currentTime); err != nil {
+		return nil, err
+	}
+	return serial, nil
+}
+
+// MakeSerial sets all attributes of the Serial from parameters
+func (s *Serial) MakeSerial(id uint, productID uint, serialNumber string, status SerialStatus, warehouseID uint, createdAt, updatedAt time.Time) error {
+	if productID == 0 {
+		return ErrInvalidSerialProduct
+	}
+	serialNumber = strings.TrimSpace(serialNumber)
+	if serialNumber == "" {
+		return ErrEmptySerialNumber
+	}
+	switch status {
+	case SerialStatusInStock:
+		if warehouseID == 0 {
+			return ErrSerialMissingWarehouse
+		}
+	case SerialStatusInTransit, SerialStatusIssued:
+		warehouseID = 0
+	default:
+		return ErrInvalidSerialStatus
+	}
+	s.id = id
+	s.productID = productID
+	s.serialNumber = serialNumber
+	s.status = status
+	s.warehouseID = warehouseID
+	s.createdAt = createdAt
+	s.updatedAt = updatedAt
+	return nil
+}
+
+// Receive books a unit that left the business, e.g. a customer return, back into a warehouse
+func (s *Serial) Receive(warehouseID uint) error {
+	switch s.status {
+	case SerialStatusInStock:
+		return ErrSerialAlreadyInStock
+	case SerialStatusInTransit:
+		return ErrSerialInTransit
+	}
+	s.status = SerialStatusInStock
+	s.warehouseID = warehouseID
+	return nil
+}
+
+// Arrive books a unit in transit into the receiving warehouse
+func (s *Serial) Arrive(warehouseID uint) error {
+	if s.status != SerialStatusInTransit {
+		return ErrSerialNotInTransit
+	}
+	s.status = SerialStatusInStock
+	s.warehouseID = warehouseID
+	return nil
+}
+
+// Dispatch sends a unit from its warehouse on its way to another warehouse
+func (s *Serial) Dispatch(warehouseID uint) error {
+	if s.status != SerialStatusInStock || s.warehouseID != warehouseID {
+		return ErrSerialNotInWarehouse
+	}
+	s.status = SerialStatusInTransit
+	s.warehouseID = 0
+	return nil
+}
+
+// Issue takes a unit out of its warehouse for good, e.g. when it is shipped or written off
+func (s *Serial) Issue(warehouseID uint) error {
+	if s.status != SerialStatusInStock || s.warehouseID != warehouseID {
+		return ErrSerialNotInWarehouse
+	}
+	s.status = SerialStatusIssued
+	s.warehouseID = 0
+	return nil
+}
+
+// WriteOff gives up on a unit in transit that never arrived, e.g. when its transfer is closed
+func (s *Serial) WriteOff() error {
+	if s.status != SerialStatusInTransit {
+		return ErrSerialNotInTransit
+	}
+	s.status = SerialStatusIssued
+	return nil
+}
+
+// ID returns the ID of the serial
+func (s *Serial) ID() uint {
+	return s.id
+}
+
+// ProductID returns the product of the unit
+func (s *Serial) ProductID() uint {
+	return s.productID
+}
+
+// SerialNumber returns the serial number of the unit
+func (s *Serial) SerialNumber() string {
+	return s.serialNumber
+}
+
+// Status returns where the unit currently is
+func (s *Serial) Status() SerialStatus {
+	return s.status
+}
+
+// WarehouseID returns the warehouse holding the unit, or zero while it is in transit or issued
+func (s *Serial) WarehouseID() uint {
+	return s.warehouseID
+}
+
+// CreatedAt returns when the unit was first received
+func (s *Serial) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// UpdatedAt returns when the unit last moved
+func (s *Serial) UpdatedAt() time.Time {
+	return s.updatedAt
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidSKUAliasProduct is returned when a SKU alias does not point at a product
+var ErrInvalidSKUAliasProduct = errors.New("sku alias requires a product")
+
+// SKUAlias is a former SKU of a product. Lookups by the alias still find the product after its SKU
+// was reassigned.
+type SKUAlias struct {
+	sku       string    // Former SKU, unique across SKUs and aliases
+	productID uint      // Product the alias resolves to
+	createdAt time.Time // When the SKU was retired
+}
+
+// NewSKUAlias creates an alias for a SKU a product no longer carries
+func NewSKUAlias(sku string, productID uint) (*SKUAlias, error) {
+	alias := &SKUAlias{}
+	if err := alias.MakeSKUAlias(sku, productID, time.Now()); err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
+
+// MakeSKUAlias sets all attributes of the SKUAlias from parameters
+func (a *SKUAlias) MakeSKUAlias(sku string, productID uint, createdAt time.Time) error {
+	if sku == "" {
+		return ErrInvalidSKU
+	}
+	if productID == 0 {
+		return ErrInvalidSKUAliasProduct
+	}
+	a.sku = sku
+	a.productID = productID
+	a.createdAt = createdAt
+	return nil
+}
+
+// SKU returns the former SKU
+func (a *SKUAlias) SKU() string {
+	return a.sku
+}
+
+// ProductID returns the product the alias resolves to
+func (a *SKUAlias) ProductID() uint {
+	return a.productID
+}
+
+// CreatedAt returns when the SKU was retired
+func (a *SKUAlias) CreatedAt() time.Time {
+	return a.createdAt
+}
@@ -0,0 +1,253 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Built-in SKU generation strategies
+const (
+	SKUStrategyRandom     = "random"     // SKU-<name>-<5 random digits>, the historical format
+	SKUStrategySequential = "sequential" // SKU-<name>-<counter per name prefix>
+	SKUStrategyCategory   = "category"   // <category>-<counter per category>
+	SKUStrategyTemplate   = "template"   // Any template, e.g. {CAT}-{YYYY}-{SEQ:6}
+)
+
+// SKU template errors
+var (
+	ErrUnknownSKUStrategy     = errors.New("unknown SKU strategy")
+	ErrInvalidSKUTemplate     = errors.New("invalid SKU template")
+	ErrSKUTemplateNotUnique   = errors.New("SKU template needs a {SEQ} or {RAND} placeholder to tell products apart")
+	ErrSKUSequenceUnavailable = errors.New("SKU template uses {SEQ} but no sequence is available")
+)
+
+// SKUInput describes the product a SKU is generated for
+type SKUInput struct {
+	Name         string
+	CategoryName string // Empty when the product is uncategorised
+	Now          time.Time
+}
+
+// SKUSequence hands out increasing numbers per key, starting at 1
+type SKUSequence interface {
+	Next(key string) (int64, error)
+}
+
+// SKUGenerator creates the SKU of a new product. Generating again for the same input must be able
+// to produce another SKU, so that a SKU that turns out to be taken can be retried.
+type SKUGenerator interface {
+	GenerateSKU(input SKUInput, sequence SKUSequence) (string, error)
+}
+
+// NewSKUGenerator returns the generator of a built-in strategy. The template is only used by the
+// template strategy.
+func NewSKUGenerator(strategy string, template string) (SKUGenerator, error) {
+	switch strategy {
+	case SKUStrategyRandom:
+		return NewRandomSKUGenerator(rand.Read), nil
+	case SKUStrategySequential:
+		return NewTemplateSKUGenerator("SKU-{NAME:3}-{SEQ:6}")
+	case SKUStrategyCategory:
+		return NewTemplateSKUGenerator("{CAT}-{SEQ:6}")
+	case SKUStrategyTemplate:
+		return NewTemplateSKUGenerator(template)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownSKUStrategy, strategy)
+}
+
+// NewRandomSKUGenerator returns the historical SKU-<first 3 letters>-<5 random digits> generator
+// drawing digits from randomNumberGenerator
+func NewRandomSKUGenerator(randomNumberGenerator func([]byte) (int, error)) SKUGenerator {
+	generator, _ := NewTemplateSKUGenerator("SKU-{NAME:3}-{RAND:5}")
+	generator.(*templateSKUGenerator).random = randomNumberGenerator
+	return generator
+}
+
+// skuPlaceholder matches the placeholders of a SKU template, like {NAME:3} or {YYYY}
+var skuPlaceholder = regexp.MustCompile(`\{([A-Z]+)(?::(\d+))?\}`)
+
+// skuLiteral matches the text a SKU template may contain between placeholders. It is limited to
+// characters ValidateSKU accepts.
+var skuLiteral = regexp.MustCompile(`^[A-Za-z0-9._-]*$`)
+
+// skuTemplatePart is literal text or one placeholder of a SKU template
+type skuTemplatePart struct {
+	literal string // Text copied as is when token is empty
+	token   string // NAME, CAT, YYYY, YY, MM, DD, SEQ or RAND
+	width   int    // Letters of NAME and CAT, digits of SEQ and RAND
+}
+
+// templateSKUGenerator renders a template of literal text and placeholders:
+//
+//	{NAME:n}  first n letters and digits of the product name, upper-cased (default 3)
+//	{CAT:n}   first n letters and digits of the category name, GEN when uncategorised (default 3)
+//	{YYYY} {YY} {MM} {DD}  date the product is created
+//	{SEQ:n}   next number of a counter kept per rendered prefix, zero-padded to n digits (default 6)
+//	{RAND:n}  n random digits (default 5)
+type templateSKUGenerator struct {
+	parts  []skuTemplatePart
+	random func([]byte) (int, error)
+}
+
+// NewTemplateSKUGenerator parses a SKU template such as {CAT}-{YYYY}-{SEQ:6}
+func NewTemplateSKUGenerator(template string) (SKUGenerator, error) {
+	generator := &templateSKUGenerator{random: rand.Read}
+	unique := false
+
+	rest := template
+	for rest != "" {
+		loc := skuPlaceholder.FindStringSubmatchIndex(rest)
+		literal := rest
+		if loc != nil {
+			literal = rest[:loc[0]]
+		}
+		if !skuLiteral.MatchString(literal) {
+			return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidSKUTemplate, literal)
+		}
+		if literal != "" {
+			generator.parts = append(generator.parts, skuTemplatePart{literal: literal})
+		}
+		if loc == nil {
+			break
+		}
+
+		part := skuTemplatePart{token: rest[loc[2]:loc[3]]}
+		if loc[4] >= 0 {
+			part.width, _ = strconv.Atoi(rest[loc[4]:loc[5]])
+		}
+		if err := part.validate(); err != nil {
+			return nil, err
+		}
+		unique = unique || part.token == "SEQ" || part.token == "RAND"
+		generator.parts = append(generator.parts, part)
+		rest = rest[loc[1]:]
+	}
+
+	if !unique {
+		return nil, ErrSKUTemplateNotUnique
+	}
+	return generator, nil
+}
+
+// validate checks the token and applies its default width
+func (p *skuTemplatePart) validate() error {
+	defaults := map[string]int{"NAME": 3, "CAT": 3, "SEQ": 6, "RAND": 5}
+	switch p.token {
+	case "YYYY", "YY", "MM", "DD":
+		if p.width != 0 {
+			return fmt.Errorf("%w: {%s} takes no width", ErrInvalidSKUTemplate, p.token)
+		}
+		return nil
+	case "NAME", "CAT", "SEQ", "RAND":
+		if p.width == 0 {
+			p.width = defaults[p.token]
+		}
+		if p.width > 18 {
+			return fmt.Errorf("%w: {%s:%d} is too wide", ErrInvalidSKUTemplate, p.token, p.width)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidSKUTemplate, p.token)
+}
+
+// GenerateSKU renders the template for a product
+func (g *templateSKUGenerator) GenerateSKU(input SKUInput, sequence SKUSequence) (string, error) {
+	// The counter key is the SKU with the counter and random digits left as placeholders, so every
+	// distinct prefix counts on its own
+	var sku, key strings.Builder
+	var seq *skuTemplatePart
+	for i := range g.parts {
+		part := &g.parts[i]
+		switch part.token {
+		case "SEQ":
+			seq = part
+			key.WriteString("{SEQ}")
+			sku.WriteString("{SEQ}")
+			continue
+		case "RAND":
+			digits, err := randomDigits(part.width, g.random)
+			if err != nil {
+				return "", err
+			}
+			key.WriteString("{RAND}")
+			sku.WriteString(digits)
+			continue
+		}
+		text := part.render(input)
+		key.WriteString(text)
+		sku.WriteString(text)
+	}
+
+	if seq == nil {
+		return sku.String(), nil
+	}
+	if sequence == nil {
+		return "", ErrSKUSequenceUnavailable
+	}
+	next, err := sequence.Next(key.String())
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(sku.String(), "{SEQ}", fmt.Sprintf("%0*d", seq.width, next), 1), nil
+}
+
+// render returns the text of a literal or of a placeholder that does not draw numbers
+func (p *skuTemplatePart) render(input SKUInput) string {
+	switch p.token {
+	case "":
+		return p.literal
+	case "NAME":
+		return skuCode(input.Name, p.width, "")
+	case "CAT":
+		return skuCode(input.CategoryName, p.width, "GEN")
+	case "YYYY":
+		return input.Now.Format("2006")
+	case "YY":
+		return input.Now.Format("06")
+	case "MM":
+		return input.Now.Format("01")
+	case "DD":
+		return input.Now.Format("02")
+	}
+	return ""
+}
+
+// skuCode returns the first letters and digits of text upper-cased, or fallback when it has none.
+// It works on runes so names in any script are cut between characters.
+func skuCode(text string, width int, fallback string) string {
+	code := make([]rune, 0, width)
+	for _, r := range text {
+		if len(code) == width {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			code = append(code, unicode.ToUpper(r))
+		}
+	}
+	if len(code) == 0 {
+		return fallback
+	}
+	return string(code)
+}
+
+// randomDigits returns n cryptographically secure random digits
+func randomDigits(n int, randomNumberGenerator func([]byte) (int, error)) (string, error) {
+	// Create a byte slice for random bytes
+	b := make([]byte, 8) // 8 bytes for a 64-bit unsigned int
+	if _, err := randomNumberGenerator(b); err != nil {
+		return "", err
+	}
+
+	modulus := uint64(1)
+	for i := 0; i < n; i++ {
+		modulus *= 10
+	}
+	return fmt.Sprintf("%0*d", n, binary.BigEndian.Uint64(b)%modulus), nil
+}
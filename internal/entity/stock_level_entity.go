@@ -0,0 +1,208 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// Stock level validation errors
+var (
+	ErrInvalidStockLevelProduct   = errors.New("stock level requires a product")
+	ErrInvalidStockLevelWarehouse = errors.New("stock level requires a warehouse")
+	ErrNegativeOnHand             = errors.New("on-hand quantity cannot be negative")
+	ErrNegativeReserved           = errors.New("reserved quantity cannot be negative")
+	ErrReservedExceedsOnHand      = errors.New("reserved quantity cannot exceed on-hand quantity")
+	ErrInsufficientStock          = errors.New("insufficient stock")
+	ErrMovementLevelMismatch      = errors.New("stock movement does not belong to this stock level")
+	ErrInvalidReserveQuantity     = errors.New("quantity to reserve or release must be positive")
+	ErrReleaseExceedsReserved     = errors.New("cannot release more than the reserved quantity")
+)
+
+// StockLevel is the aggregate holding the quantity of one product in one warehouse
+type StockLevel struct {
+	id          uint      // Unexported ID field
+	productID   uint      // Unexported ProductID field
+	warehouseID uint      // Unexported WarehouseID field
+	onHand      int64     // Physically present quantity
+	reserved    int64     // Quantity promised but not yet issued
+	expired     int64     // Quantity held in expired lots, which cannot be promised
+	updatedAt   time.Time // Unexported UpdatedAt field
+}
+
+// NewStockLevel creates an empty StockLevel for a product in a warehouse
+func NewStockLevel(productID uint, warehouseID uint) (*StockLevel, error) {
+	stockLevel := &StockLevel{}
+	if err := stockLevel.MakeStockLevel(0, productID, warehouseID, 0, 0, time.Now()); err != nil {
+		return nil, err
+	}
+	return stockLevel, nil
+}
+
+// MakeStockLevel sets all attributes of the StockLevel from parameters
+func (s *StockLevel) MakeStockLevel(id uint, productID uint, warehouseID uint, onHand int64, reserved int64, updatedAt time.Time) error {
+	if productID == 0 {
+		return ErrInvalidStockLevelProduct
+	}
+	if warehouseID == 0 {
+		return ErrInvalidStockLevelWarehouse
+	}
+	if err := validateQuantities(onHand, reserved); err != nil {
+		return err
+	}
+	s.id = id
+	s.productID = productID
+	s.warehouseID = warehouseID
+	s.onHand = onHand
+	s.reserved = reserved
+	s.updatedAt = updatedAt
+	return nil
+}
+
+// validateQuantities checks the invariants every stock level must satisfy
+func validateQuantities(onHand int64, reserved int64) error {
+	if onHand < 0 {
+		return ErrNegativeOnHand
+	}
+	if reserved < 0 {
+		return ErrNegativeReserved
+	}
+	if reserved > onHand {
+		return ErrReservedExceedsOnHand
+	}
+	return nil
+}
+
+// ID returns the ID of the stock level
+func (s *StockLevel) ID() uint {
+	return s.id
+}
+
+// ProductID returns the product the stock level belongs to
+func (s *StockLevel) ProductID() uint {
+	return s.productID
+}
+
+// WarehouseID returns the warehouse the stock level belongs to
+func (s *StockLevel) WarehouseID() uint {
+	return s.warehouseID
+}
+
+// OnHand returns the quantity physically present in the warehouse
+func (s *StockLevel) OnHand() int64 {
+	return s.onHand
+}
+
+// Reserved returns the quantity promised to pending demand
+func (s *StockLevel) Reserved() int64 {
+	return s.reserved
+}
+
+// Expired returns the quantity held in expired lots
+func (s *StockLevel) Expired() int64 {
+	return s.expired
+}
+
+// Available returns the quantity that can still be promised
+func (s *StockLevel) Available() int64 {
+	return s.onHand - s.reserved - s.expired
+}
+
+// ExcludeExpired keeps the quantity held in expired lots from being promised. The expired quantity is
+// not stored with the stock level, so callers set it after loading the level from its lots.
+func (s *StockLevel) ExcludeExpired(quantity int64) {
+	s.expired = max(quantity, 0)
+}
+
+// UpdatedAt returns the last updated timestamp of the stock level
+func (s *StockLevel) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// ApplyMovement changes the on-hand quantity by the movement's quantity and stamps the
+// resulting balance on the movement. Stock that is reserved cannot be taken out.
+func (s *StockLevel) ApplyMovement(movement *StockMovement) error {
+	if movement.ProductID() != s.productID || movement.WarehouseID() != s.warehouseID {
+		return ErrMovementLevelMismatch
+	}
+
+	onHand := s.onHand + movement.Quantity()
+	if onHand < s.reserved {
+		return ErrInsufficientStock
+	}
+
+	s.onHand = onHand
+	movement.balanceAfter = onHand
+	return nil
+}
+
+// Reserve sets aside quantity of the available stock for pending demand
+func (s *StockLevel) Reserve(quantity int64) error {
+	if quantity <= 0 {
+		return ErrInvalidReserveQuantity
+	}
+	if quantity > s.Available() {
+		return ErrInsufficientStock
+	}
+	s.reserved += quantity
+	return nil
+}
+
+// ReleaseReservation makes previously reserved quantity available again
+func (s *StockLevel) ReleaseReservation(quantity int64) error {
+	if quantity <= 0 {
+		return ErrInvalidReserveQuantity
+	}
+	if quantity > s.reserved {
+		return ErrReleaseExceedsReserved
+	}
+	s.reserved -= quantity
+	return nil
+}
+
+// StockReconciliation compares the on-hand quantity of a stock level with the balance replayed from its ledger
+type StockReconciliation struct {
+	productID     uint
+	warehouseID   uint
+	onHand        int64
+	ledgerBalance int64
+}
+
+// NewStockReconciliation creates a StockReconciliation from a stored on-hand quantity and a ledger balance
+func NewStockReconciliation(productID uint, warehouseID uint, onHand int64, ledgerBalance int64) *StockReconciliation {
+	return &StockReconciliation{
+		productID:     productID,
+		warehouseID:   warehouseID,
+		onHand:        onHand,
+		ledgerBalance: ledgerBalance,
+	}
+}
+
+// ProductID returns the reconciled product
+func (r *StockReconciliation) ProductID() uint {
+	return r.productID
+}
+
+// WarehouseID returns the reconciled warehouse
+func (r *StockReconciliation) WarehouseID() uint {
+	return r.warehouseID
+}
+
+// OnHand returns the stored on-hand quantity
+func (r *StockReconciliation) OnHand() int64 {
+	return r.onHand
+}
+
+// LedgerBalance returns the sum of every movement recorded in the ledger
+func (r *StockReconciliation) LedgerBalance() int64 {
+	return r.ledgerBalance
+}
+
+// Discrepancy returns how far the stored on-hand quantity drifted from the ledger
+func (r *StockReconciliation) Discrepancy() int64 {
+	return r.onHand - r.ledgerBalance
+}
+
+// InSync reports whether the stored on-hand quantity matches the ledger
+func (r *StockReconciliation) InSync() bool {
+	return r.Discrepancy() == 0
+}
@@ -0,0 +1,179 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MovementType classifies why the on-hand quantity of a stock level changed
+type MovementType string
+
+// Supported movement types
+const (
+	MovementTypeReceipt     MovementType = "receipt"
+	MovementTypeIssue       MovementType = "issue"
+	MovementTypeAdjustment  MovementType = "adjustment"
+	MovementTypeTransferIn  MovementType = "transfer_in"
+	MovementTypeTransferOut MovementType = "transfer_out"
+)
+
+// Stock movement validation errors
+var (
+	ErrInvalidMovementType      = errors.New("invalid stock movement type")
+	ErrInvalidMovementQuantity  = errors.New("stock movement quantity must be positive, or non-zero for adjustments")
+	ErrInvalidMovementProduct   = errors.New("stock movement requires a product")
+	ErrInvalidMovementWarehouse = errors.New("stock movement requires a warehouse")
+	ErrEmptyReasonCode          = errors.New("stock movement reason code cannot be empty")
+	ErrEmptyActor               = errors.New("stock movement actor cannot be empty")
+)
+
+// IsValid reports whether the movement type is one of the supported types
+func (t MovementType) IsValid() bool {
+	switch t {
+	case MovementTypeReceipt, MovementTypeIssue, MovementTypeAdjustment, MovementTypeTransferIn, MovementTypeTransferOut:
+		return true
+	}
+	return false
+}
+
+// isOutbound reports whether movements of this type take stock out of a warehouse
+func (t MovementType) isOutbound() bool {
+	return t == MovementTypeIssue || t == MovementTypeTransferOut
+}
+
+// StockMovement is an immutable ledger entry recording one change of on-hand quantity
+type StockMovement struct {
+	id           uint         // Unexported ID field
+	productID    uint         // Unexported ProductID field
+	warehouseID  uint         // Unexported WarehouseID field
+	movementType MovementType // Unexported MovementType field
+	quantity     int64        // Signed change applied to the on-hand quantity
+	balanceAfter int64        // On-hand quantity right after the movement was applied
+	reasonCode   string       // Unexported ReasonCode field
+	reference    string       // Reference document, e.g. a goods receipt number
+	actor        string       // Who performed the movement
+	occurredAt   time.Time    // When the movement physically happened
+	createdAt    time.Time    // When the movement was recorded
+}
+
+// NewStockMovement creates a new StockMovement. The quantity is the moved amount for receipts,
+// issues and transfers, and the signed correction for adjustments.
+func NewStockMovement(productID uint, warehouseID uint, movementType MovementType, quantity int64, reasonCode string, reference string, actor string, occurredAt time.Time) (*StockMovement, error) {
+	if !movementType.IsValid() {
+		return nil, ErrInvalidMovementType
+	}
+	if quantity == 0 || (movementType != MovementTypeAdjustment && quantity < 0) {
+		return nil, ErrInvalidMovementQuantity
+	}
+
+	// Outbound movements are stored as negative changes so the ledger sums to the balance
+	if movementType.isOutbound() {
+		quantity = -quantity
+	}
+
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	movement := &StockMovement{}
+	if err := movement.MakeStockMovement(0, productID, warehouseID, movementType, quantity, 0, reasonCode, reference, actor, occurredAt, time.Now()); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// MakeStockMovement sets all attributes of the StockMovement from parameters
+func (m *StockMovement) MakeStockMovement(id uint, productID uint, warehouseID uint, movementType MovementType, quantity int64, balanceAfter int64, reasonCode string, reference string, actor string, occurredAt, createdAt time.Time) error {
+	if productID == 0 {
+		return ErrInvalidMovementProduct
+	}
+	if warehouseID == 0 {
+		return ErrInvalidMovementWarehouse
+	}
+	if !movementType.IsValid() {
+		return ErrInvalidMovementType
+	}
+	if quantity == 0 {
+		return ErrInvalidMovementQuantity
+	}
+	if strings.TrimSpace(reasonCode) == "" {
+		return ErrEmptyReasonCode
+	}
+	if strings.TrimSpace(actor) == "" {
+		return ErrEmptyActor
+	}
+	m.id = id
+	m.productID = productID
+	m.warehouseID = warehouseID
+	m.movementType = movementType
+	m.quantity = quantity
+	m.balanceAfter = balanceAfter
+	m.reasonCode = strings.ToUpper(strings.TrimSpace(reasonCode))
+	m.reference = reference
+	m.actor = actor
+	m.occurredAt = occurredAt
+	m.createdAt = createdAt
+	return nil
+}
+
+// ID returns the ID of the movement
+func (m *StockMovement) ID() uint {
+	return m.id
+}
+
+// ProductID returns the product that moved
+func (m *StockMovement) ProductID() uint {
+	return m.productID
+}
+
+// WarehouseID returns the warehouse where the movement happened
+func (m *StockMovement) WarehouseID() uint {
+	return m.warehouseID
+}
+
+// Type returns the movement type
+func (m *StockMovement) Type() MovementType {
+	return m.movementType
+}
+
+// Quantity returns the signed change applied to the on-hand quantity
+func (m *StockMovement) Quantity() int64 {
+	return m.quantity
+}
+
+// AddsStock reports whether the movement brings new stock into the business: a receipt or an upward
+// adjustment. Transfers only move stock between warehouses.
+func (m *StockMovement) AddsStock() bool {
+	return m.movementType == MovementTypeReceipt || (m.movementType == MovementTypeAdjustment && m.quantity > 0)
+}
+
+// BalanceAfter returns the on-hand quantity right after the movement was applied
+func (m *StockMovement) BalanceAfter() int64 {
+	return m.balanceAfter
+}
+
+// ReasonCode returns the reason code of the movement
+func (m *StockMovement) ReasonCode() string {
+	return m.reasonCode
+}
+
+// Reference returns the reference document of the movement
+func (m *StockMovement) Reference() string {
+	return m.reference
+}
+
+// Actor returns who performed the movement
+func (m *StockMovement) Actor() string {
+	return m.actor
+}
+
+// OccurredAt returns when the movement physically happened
+func (m *StockMovement) OccurredAt() time.Time {
+	return m.occurredAt
+}
+
+// CreatedAt returns when the movement was recorded
+func (m *StockMovement) CreatedAt() time.Time {
+	return m.createdAt
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Supplier validation errors
+var (
+	ErrEmptySupplierCode = errors.New("supplier code cannot be empty")
+	ErrEmptySupplierName = errors.New("supplier name cannot be empty")
+)
+
+// Supplier represents a vendor that stock is purchased from
+type Supplier struct {
+	id           uint      // Unexported ID field
+	code         string    // Unexported Code field, unique short identifier
+	name         string    // Unexported Name field
+	contactEmail string    // Where purchase orders are sent, may be empty
+	createdAt    time.Time // Unexported CreatedAt field
+	updatedAt    time.Time // Unexported UpdatedAt field
+}
+
+// NewSupplier creates a new Supplier instance with a normalized code and timestamps
+func NewSupplier(code string, name string, contactEmail string) (*Supplier, error) {
+	currentTime := time.Now()
+
+	supplier := &Supplier{}
+	if err := supplier.MakeSupplier(0, code, name, contactEmail, currentTime, currentTime); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// MakeSupplier sets all attributes of the Supplier from parameters
+func (s *Supplier) MakeSupplier(id uint, code string, name string, contactEmail string, createdAt, updatedAt time.Time) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return ErrEmptySupplierCode
+	}
+	if strings.TrimSpace(name) == "" {
+		return ErrEmptySupplierName
+	}
+	s.id = id
+	s.code = code
+	s.name = name
+	s.contactEmail = strings.TrimSpace(contactEmail)
+	s.createdAt = createdAt
+	s.updatedAt = updatedAt
+	return nil
+}
+
+// ID returns the ID of the supplier
+func (s *Supplier) ID() uint {
+	return s.id
+}
+
+// Code returns the unique code of the supplier
+func (s *Supplier) Code() string {
+	return s.code
+}
+
+// Name returns the Name of the supplier
+func (s *Supplier) Name() string {
+	return s.name
+}
+
+// ContactEmail returns where purchase orders are sent
+func (s *Supplier) ContactEmail() string {
+	return s.contactEmail
+}
+
+// CreatedAt returns the creation timestamp of the supplier
+func (s *Supplier) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the supplier
+func (s *Supplier) UpdatedAt() time.Time {
+	return s.updatedAt
+}
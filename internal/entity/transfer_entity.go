@@ -0,0 +1,482 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TransferStatus describes where a transfer is in its lifecycle
+type TransferStatus string
+
+// Supported transfer statuses
+const (
+	TransferStatusDraft             TransferStatus = "draft"
+	TransferStatusDispatched        TransferStatus = "dispatched"
+	TransferStatusPartiallyReceived TransferStatus = "partially_received"
+	TransferStatusReceived          TransferStatus = "received"
+	TransferStatusCancelled         TransferStatus = "cancelled"
+	TransferStatusClosed            TransferStatus = "closed"
+)
+
+// Transfer validation and state errors
+var (
+	ErrInvalidTransferSource      = errors.New("transfer requires a source warehouse")
+	ErrInvalidTransferDestination = errors.New("transfer requires a destination warehouse")
+	ErrSameTransferWarehouses     = errors.New("source and destination warehouses must differ")
+	ErrInvalidTransferStatus      = errors.New("invalid transfer status")
+	ErrInvalidTransferLineProduct = errors.New("transfer line requires a product")
+	ErrInvalidTransferQuantity    = errors.New("transfer quantity must be positive")
+	ErrInvalidReceivedQuantity    = errors.New("received quantity must be between 0 and the transferred quantity")
+	ErrDuplicateTransferLine      = errors.New("product appears on more than one transfer line")
+	ErrEmptyTransfer              = errors.New("transfer has no lines")
+	ErrTransferNotDraft           = errors.New("transfer is no longer a draft")
+	ErrTransferNotInTransit       = errors.New("transfer is not in transit")
+	ErrTransferLineNotFound       = errors.New("product is not on the transfer")
+	ErrReceiveExceedsInTransit    = errors.New("received quantity exceeds the quantity in transit")
+	ErrTransferLotsExceedLine     = errors.New("lots of a transfer line cannot exceed its quantity")
+	ErrEmptyCloseReasonCode       = errors.New("closing a transfer requires a reason code")
+)
+
+// TransferLot is the part of a transfer line taken out of one lot at the source warehouse. The lot is
+// recreated with the same number and dates at the destination as the stock arrives.
+type TransferLot struct {
+	id               uint       // Unexported ID field
+	lotNumber        string     // Number of the lot the stock was taken out of
+	manufacturedAt   *time.Time // When the batch was produced, if known
+	expiresAt        time.Time  // First moment the batch may no longer be allocated
+	quantity         int64      // Quantity taken out of the lot on dispatch
+	receivedQuantity int64      // Quantity put back into the lot at the destination so far
+}
+
+// MakeTransferLot sets all attributes of the TransferLot from parameters
+func (l *TransferLot) MakeTransferLot(id uint, lotNumber string, manufacturedAt *time.Time, expiresAt time.Time, quantity int64, receivedQuantity int64) error {
+	lotNumber = strings.TrimSpace(lotNumber)
+	if lotNumber == "" {
+		return ErrEmptyLotNumber
+	}
+	if expiresAt.IsZero() {
+		return ErrMissingLotExpiry
+	}
+	if quantity <= 0 {
+		return ErrInvalidTransferQuantity
+	}
+	if receivedQuantity < 0 || receivedQuantity > quantity {
+		return ErrInvalidReceivedQuantity
+	}
+	l.id = id
+	l.lotNumber = lotNumber
+	l.manufacturedAt = manufacturedAt
+	l.expiresAt = expiresAt
+	l.quantity = quantity
+	l.receivedQuantity = receivedQuantity
+	return nil
+}
+
+// ID returns the ID of the transfer lot
+func (l *TransferLot) ID() uint {
+	return l.id
+}
+
+// LotNumber returns the number of the lot the stock was taken out of
+func (l *TransferLot) LotNumber() string {
+	return l.lotNumber
+}
+
+// ManufacturedAt returns when the batch was produced, or nil when unknown
+func (l *TransferLot) ManufacturedAt() *time.Time {
+	return l.manufacturedAt
+}
+
+// ExpiresAt returns the first moment the batch may no longer be allocated
+func (l *TransferLot) ExpiresAt() time.Time {
+	return l.expiresAt
+}
+
+// Quantity returns the quantity taken out of the lot on dispatch
+func (l *TransferLot) Quantity() int64 {
+	return l.quantity
+}
+
+// ReceivedQuantity returns the quantity put back into the lot at the destination so far
+func (l *TransferLot) ReceivedQuantity() int64 {
+	return l.receivedQuantity
+}
+
+// Outstanding returns the quantity of the lot not yet received at the destination
+func (l *TransferLot) Outstanding() int64 {
+	return l.quantity - l.receivedQuantity
+}
+
+// ArrivingLot is the part of a receipt that arrives from one dispatched lot
+type ArrivingLot struct {
+	Lot      *TransferLot
+	Quantity int64
+}
+
+// TransferLine is the quantity of one product moved by a transfer. The part of it taken out of lots
+// is recorded per lot; the rest was untracked stock.
+type TransferLine struct {
+	id               uint           // Unexported ID field
+	productID        uint           // Unexported ProductID field
+	quantity         int64          // Quantity taken out of the source warehouse on dispatch
+	receivedQuantity int64          // Quantity put into the destination warehouse so far
+	lots             []*TransferLot // Lots the quantity was taken out of on dispatch
+}
+
+// MakeTransferLine sets all attributes of the TransferLine from parameters
+func (l *TransferLine) MakeTransferLine(id uint, productID uint, quantity int64, receivedQuantity int64, lots []*TransferLot) error {
+	if productID == 0 {
+		return ErrInvalidTransferLineProduct
+	}
+	if quantity <= 0 {
+		return ErrInvalidTransferQuantity
+	}
+	if receivedQuantity < 0 || receivedQuantity > quantity {
+		return ErrInvalidReceivedQuantity
+	}
+	var lotQuantity int64
+	for _, lot := range lots {
+		lotQuantity += lot.quantity
+	}
+	if lotQuantity > quantity {
+		return ErrTransferLotsExceedLine
+	}
+	l.id = id
+	l.productID = productID
+	l.quantity = quantity
+	l.receivedQuantity = receivedQuantity
+	l.lots = lots
+	return nil
+}
+
+// ID returns the ID of the transfer line
+func (l *TransferLine) ID() uint {
+	return l.id
+}
+
+// ProductID returns the transferred product
+func (l *TransferLine) ProductID() uint {
+	return l.productID
+}
+
+// Quantity returns the transferred quantity
+func (l *TransferLine) Quantity() int64 {
+	return l.quantity
+}
+
+// ReceivedQuantity returns the quantity received at the destination so far
+func (l *TransferLine) ReceivedQuantity() int64 {
+	return l.receivedQuantity
+}
+
+// Outstanding returns the quantity not yet received at the destination. Once the transfer is closed
+// this is the quantity written off.
+func (l *TransferLine) Outstanding() int64 {
+	return l.quantity - l.receivedQuantity
+}
+
+// Lots returns the lots the quantity was taken out of on dispatch
+func (l *TransferLine) Lots() []*TransferLot {
+	return l.lots
+}
+
+// ArrivingLots returns which lots the next quantity received for the line comes out of. Outstanding
+// lots arrive first-expiring first, whatever they do not cover is untracked stock.
+func (l *TransferLine) ArrivingLots(quantity int64) []ArrivingLot {
+	outstanding := make([]*TransferLot, 0, len(l.lots))
+	for _, lot := range l.lots {
+		if lot.Outstanding() > 0 {
+			outstanding = append(outstanding, lot)
+		}
+	}
+	sort.SliceStable(outstanding, func(i, j int) bool {
+		return outstanding[i].expiresAt.Before(outstanding[j].expiresAt)
+	})
+
+	var arriving []ArrivingLot
+	for _, lot := range outstanding {
+		if quantity <= 0 {
+			break
+		}
+		take := min(lot.Outstanding(), quantity)
+		arriving = append(arriving, ArrivingLot{Lot: lot, Quantity: take})
+		quantity -= take
+	}
+	return arriving
+}
+
+// Transfer is the document moving stock from one warehouse to another. Stock leaves the source
+// warehouse when the transfer is dispatched and is in transit until the destination receives it.
+type Transfer struct {
+	id                     uint            // Unexported ID field
+	sourceWarehouseID      uint            // Warehouse the stock leaves
+	destinationWarehouseID uint            // Warehouse the stock arrives at
+	status                 TransferStatus  // Unexported Status field
+	reference              string          // Caller's reference, e.g. a delivery note number
+	lines                  []*TransferLine // Products moved by the transfer
+	dispatchedAt           *time.Time      // When the stock left the source warehouse
+	receivedAt             *time.Time      // When the last outstanding quantity arrived
+	closedAt               *time.Time      // When the outstanding quantity was written off
+	closeReasonCode        string          // Why the outstanding quantity was written off
+	createdAt              time.Time       // Unexported CreatedAt field
+	updatedAt              time.Time       // Unexported UpdatedAt field
+}
+
+// NewTransfer creates a draft Transfer between two warehouses without lines
+func NewTransfer(sourceWarehouseID uint, destinationWarehouseID uint, reference string) (*Transfer, error) {
+	transfer := &Transfer{}
+	now := time.Now()
+	if err := transfer.MakeTransfer(0, sourceWarehouseID, destinationWarehouseID, TransferStatusDraft, reference, nil, nil, nil, nil, "", now, now); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// MakeTransfer sets all attributes of the Transfer from parameters
+func (t *Transfer) MakeTransfer(id uint, sourceWarehouseID uint, destinationWarehouseID uint, status TransferStatus, reference string, lines []*TransferLine, dispatchedAt, receivedAt, closedAt *time.Time, closeReasonCode string, createdAt, updatedAt time.Time) error {
+	if sourceWarehouseID == 0 {
+		return ErrInvalidTransferSource
+	}
+	if destinationWarehouseID == 0 {
+		return ErrInvalidTransferDestination
+	}
+	if sourceWarehouseID == destinationWarehouseID {
+		return ErrSameTransferWarehouses
+	}
+	switch status {
+	case TransferStatusDraft, TransferStatusDispatched, TransferStatusPartiallyReceived, TransferStatusReceived, TransferStatusCancelled, TransferStatusClosed:
+	default:
+		return ErrInvalidTransferStatus
+	}
+	t.id = id
+	t.sourceWarehouseID = sourceWarehouseID
+	t.destinationWarehouseID = destinationWarehouseID
+	t.status = status
+	t.reference = reference
+	t.lines = lines
+	t.dispatchedAt = dispatchedAt
+	t.receivedAt = receivedAt
+	t.closedAt = closedAt
+	t.closeReasonCode = closeReasonCode
+	t.createdAt = createdAt
+	t.updatedAt = updatedAt
+	return nil
+}
+
+// AddLine adds a product to a draft transfer
+func (t *Transfer) AddLine(productID uint, quantity int64) error {
+	if t.status != TransferStatusDraft {
+		return ErrTransferNotDraft
+	}
+	if t.findLine(productID) != nil {
+		return ErrDuplicateTransferLine
+	}
+
+	line := &TransferLine{}
+	if err := line.MakeTransferLine(0, productID, quantity, 0, nil); err != nil {
+		return err
+	}
+	t.lines = append(t.lines, line)
+	return nil
+}
+
+// Dispatch marks a draft transfer as having left the source warehouse
+func (t *Transfer) Dispatch(now time.Time) error {
+	if t.status != TransferStatusDraft {
+		return ErrTransferNotDraft
+	}
+	if len(t.lines) == 0 {
+		return ErrEmptyTransfer
+	}
+	t.status = TransferStatusDispatched
+	t.dispatchedAt = &now
+	return nil
+}
+
+// AddDispatchedLot records that part of the line of a product was taken out of a lot on dispatch, so
+// the lot can be recreated at the destination
+func (t *Transfer) AddDispatchedLot(productID uint, lotNumber string, manufacturedAt *time.Time, expiresAt time.Time, quantity int64) error {
+	if t.status != TransferStatusDispatched {
+		return ErrTransferNotInTransit
+	}
+	line := t.findLine(productID)
+	if line == nil {
+		return ErrTransferLineNotFound
+	}
+
+	lot := &TransferLot{}
+	if err := lot.MakeTransferLot(0, lotNumber, manufacturedAt, expiresAt, quantity, 0); err != nil {
+		return err
+	}
+	lots := append(line.lots, lot)
+	return line.MakeTransferLine(line.id, line.productID, line.quantity, line.receivedQuantity, lots)
+}
+
+// Receive books quantities per product as arrived at the destination warehouse, taking them out of the
+// dispatched lots as ArrivingLots does. The transfer is
+// received once nothing is outstanding and partially received otherwise. Nothing changes when any
+// of the quantities is rejected.
+func (t *Transfer) Receive(quantities map[uint]int64, now time.Time) error {
+	if !t.InTransitStatus() {
+		return ErrTransferNotInTransit
+	}
+	if len(quantities) == 0 {
+		return ErrInvalidTransferQuantity
+	}
+	for productID, quantity := range quantities {
+		line := t.findLine(productID)
+		if line == nil {
+			return ErrTransferLineNotFound
+		}
+		if quantity <= 0 {
+			return ErrInvalidTransferQuantity
+		}
+		if quantity > line.Outstanding() {
+			return ErrReceiveExceedsInTransit
+		}
+	}
+
+	for productID, quantity := range quantities {
+		line := t.findLine(productID)
+		for _, arriving := range line.ArrivingLots(quantity) {
+			arriving.Lot.receivedQuantity += arriving.Quantity
+		}
+		line.receivedQuantity += quantity
+	}
+
+	t.status = TransferStatusReceived
+	for _, line := range t.lines {
+		if line.Outstanding() > 0 {
+			t.status = TransferStatusPartiallyReceived
+			return nil
+		}
+	}
+	t.receivedAt = &now
+	return nil
+}
+
+// Cancel abandons a transfer before any stock left the source warehouse
+func (t *Transfer) Cancel() error {
+	if t.status != TransferStatusDraft {
+		return ErrTransferNotDraft
+	}
+	t.status = TransferStatusCancelled
+	return nil
+}
+
+// Close writes off whatever of a dispatched transfer has not arrived, e.g. stock lost or damaged on the
+// way, so the transfer no longer waits for it. The reason code says why.
+func (t *Transfer) Close(reasonCode string, now time.Time) error {
+	if !t.InTransitStatus() {
+		return ErrTransferNotInTransit
+	}
+	reasonCode = strings.ToUpper(strings.TrimSpace(reasonCode))
+	if reasonCode == "" {
+		return ErrEmptyCloseReasonCode
+	}
+	t.status = TransferStatusClosed
+	t.closedAt = &now
+	t.closeReasonCode = reasonCode
+	return nil
+}
+
+// WrittenOff returns the quantity that never arrived and was written off when the transfer was closed
+func (t *Transfer) WrittenOff() int64 {
+	if t.status != TransferStatusClosed {
+		return 0
+	}
+	var writtenOff int64
+	for _, line := range t.lines {
+		writtenOff += line.Outstanding()
+	}
+	return writtenOff
+}
+
+// InTransitStatus reports whether stock of the transfer may still be on its way
+func (t *Transfer) InTransitStatus() bool {
+	return t.status == TransferStatusDispatched || t.status == TransferStatusPartiallyReceived
+}
+
+// InTransit returns the quantity that left the source warehouse but has not arrived yet
+func (t *Transfer) InTransit() int64 {
+	if !t.InTransitStatus() {
+		return 0
+	}
+	var inTransit int64
+	for _, line := range t.lines {
+		inTransit += line.Outstanding()
+	}
+	return inTransit
+}
+
+// findLine returns the line of a product or nil when the product is not on the transfer
+func (t *Transfer) findLine(productID uint) *TransferLine {
+	for _, line := range t.lines {
+		if line.productID == productID {
+			return line
+		}
+	}
+	return nil
+}
+
+// ID returns the ID of the transfer
+func (t *Transfer) ID() uint {
+	return t.id
+}
+
+// SourceWarehouseID returns the warehouse the stock leaves
+func (t *Transfer) SourceWarehouseID() uint {
+	return t.sourceWarehouseID
+}
+
+// DestinationWarehouseID returns the warehouse the stock arrives at
+func (t *Transfer) DestinationWarehouseID() uint {
+	return t.destinationWarehouseID
+}
+
+// Status returns the status of the transfer
+func (t *Transfer) Status() TransferStatus {
+	return t.status
+}
+
+// Reference returns the caller's reference of the transfer
+func (t *Transfer) Reference() string {
+	return t.reference
+}
+
+// Lines returns the products moved by the transfer
+func (t *Transfer) Lines() []*TransferLine {
+	return t.lines
+}
+
+// DispatchedAt returns when the transfer was dispatched, or nil while it is a draft
+func (t *Transfer) DispatchedAt() *time.Time {
+	return t.dispatchedAt
+}
+
+// ReceivedAt returns when the transfer was fully received, or nil until then
+func (t *Transfer) ReceivedAt() *time.Time {
+	return t.receivedAt
+}
+
+// ClosedAt returns when the transfer was closed, or nil unless it was
+func (t *Transfer) ClosedAt() *time.Time {
+	return t.closedAt
+}
+
+// CloseReasonCode returns why the outstanding quantity of a closed transfer was written off
+func (t *Transfer) CloseReasonCode() string {
+	return t.closeReasonCode
+}
+
+// CreatedAt returns the creation timestamp of the transfer
+func (t *Transfer) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the transfer
+func (t *Transfer) UpdatedAt() time.Time {
+	return t.updatedAt
+}
@@ -0,0 +1,185 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// DefaultStockUnit is the unit products are stocked in unless another one is chosen
+const DefaultStockUnit = "EA"
+
+// Unit of measure validation and conversion errors
+var (
+	ErrEmptyUnitCode           = errors.New("unit code cannot be empty")
+	ErrEmptyUnitName           = errors.New("unit name cannot be empty")
+	ErrInvalidProductUnit      = errors.New("unit conversion requires a product")
+	ErrInvalidConversionFactor = errors.New("conversion factor must be a positive number of stock units")
+	ErrConversionForStockUnit  = errors.New("the stock unit of a product converts to itself")
+	ErrUnitNotConvertible      = errors.New("quantity cannot be converted to the stock unit of the product")
+	ErrConvertedQuantityTooBig = errors.New("converted quantity is too large")
+)
+
+// UnitOfMeasure is an entry in the catalogue of units quantities can be expressed in, e.g. EA or CASE
+type UnitOfMeasure struct {
+	id        uint      // Unexported ID field
+	code      string    // Unexported Code field, unique short identifier
+	name      string    // Unexported Name field
+	createdAt time.Time // Unexported CreatedAt field
+	updatedAt time.Time // Unexported UpdatedAt field
+}
+
+// NewUnitOfMeasure creates a new UnitOfMeasure with a normalized code and timestamps
+func NewUnitOfMeasure(code string, name string) (*UnitOfMeasure, error) {
+	currentTime := time.Now()
+
+	unit := &UnitOfMeasure{}
+	if err := unit.MakeUnitOfMeasure(0, code, name, currentTime, currentTime); err != nil {
+		return nil, err
+	}
+	return unit, nil
+}
+
+// MakeUnitOfMeasure sets all attributes of the UnitOfMeasure from parameters
+func (u *UnitOfMeasure) MakeUnitOfMeasure(id uint, code string, name string, createdAt, updatedAt time.Time) error {
+	code = NormalizeUnitCode(code)
+	if code == "" {
+		return ErrEmptyUnitCode
+	}
+	if strings.TrimSpace(name) == "" {
+		return ErrEmptyUnitName
+	}
+	u.id = id
+	u.code = code
+	u.name = strings.TrimSpace(name)
+	u.createdAt = createdAt
+	u.updatedAt = updatedAt
+	return nil
+}
+
+// NormalizeUnitCode returns the form unit codes are stored and compared in
+func NormalizeUnitCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ID returns the ID of the unit
+func (u *UnitOfMeasure) ID() uint {
+	return u.id
+}
+
+// Code returns the unique code of the unit
+func (u *UnitOfMeasure) Code() string {
+	return u.code
+}
+
+// Name returns the Name of the unit
+func (u *UnitOfMeasure) Name() string {
+	return u.name
+}
+
+// CreatedAt returns the creation timestamp of the unit
+func (u *UnitOfMeasure) CreatedAt() time.Time {
+	return u.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the unit
+func (u *UnitOfMeasure) UpdatedAt() time.Time {
+	return u.updatedAt
+}
+
+// ProductUnit converts quantities of a product between another unit and its stock unit, e.g. a case
+// of 24 each
+type ProductUnit struct {
+	id        uint      // Unexported ID field
+	productID uint      // Unexported ProductID field
+	unitCode  string    // Unit the product is bought or sold in
+	factor    int64     // Number of stock units in one of this unit
+	createdAt time.Time // Unexported CreatedAt field
+	updatedAt time.Time // Unexported UpdatedAt field
+}
+
+// NewProductUnit creates a conversion between a unit and the stock unit of a product
+func NewProductUnit(productID uint, unitCode string, factor int64) (*ProductUnit, error) {
+	currentTime := time.Now()
+
+	unit := &ProductUnit{}
+	if err := unit.MakeProductUnit(0, productID, unitCode, factor, currentTime, currentTime); err != nil {
+		return nil, err
+	}
+	return unit, nil
+}
+
+// MakeProductUnit sets all attributes of the ProductUnit from parameters
+func (u *ProductUnit) MakeProductUnit(id uint, productID uint, unitCode string, factor int64, createdAt, updatedAt time.Time) error {
+	if productID == 0 {
+		return ErrInvalidProductUnit
+	}
+	unitCode = NormalizeUnitCode(unitCode)
+	if unitCode == "" {
+		return ErrEmptyUnitCode
+	}
+	if factor <= 0 {
+		return ErrInvalidConversionFactor
+	}
+	u.id = id
+	u.productID = productID
+	u.unitCode = unitCode
+	u.factor = factor
+	u.createdAt = createdAt
+	u.updatedAt = updatedAt
+	return nil
+}
+
+// SetFactor changes how many stock units one of this unit holds
+func (u *ProductUnit) SetFactor(factor int64) error {
+	if factor <= 0 {
+		return ErrInvalidConversionFactor
+	}
+	u.factor = factor
+	return nil
+}
+
+// ToStockUnits converts a quantity in this unit to stock units
+func (u *ProductUnit) ToStockUnits(quantity int64) (int64, error) {
+	if quantity > math.MaxInt64/u.factor || quantity < math.MinInt64/u.factor {
+		return 0, ErrConvertedQuantityTooBig
+	}
+	return quantity * u.factor, nil
+}
+
+// FromStockUnits converts a quantity in stock units to this unit. Quantities that do not fill a whole
+// unit come out fractional, e.g. 30 each are 1.25 cases of 24.
+func (u *ProductUnit) FromStockUnits(quantity int64) float64 {
+	return float64(quantity) / float64(u.factor)
+}
+
+// ID returns the ID of the conversion
+func (u *ProductUnit) ID() uint {
+	return u.id
+}
+
+// ProductID returns the product the conversion belongs to
+func (u *ProductUnit) ProductID() uint {
+	return u.productID
+}
+
+// UnitCode returns the unit converted from
+func (u *ProductUnit) UnitCode() string {
+	return u.unitCode
+}
+
+// Factor returns the number of stock units in one of this unit
+func (u *ProductUnit) Factor() int64 {
+	return u.factor
+}
+
+// CreatedAt returns the creation timestamp of the conversion
+func (u *ProductUnit) CreatedAt() time.Time {
+	return u.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the conversion
+func (u *ProductUnit) UpdatedAt() time.Time {
+	return u.updatedAt
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Warehouse validation errors
+var (
+	ErrEmptyWarehouseCode = errors.New("warehouse code cannot be empty")
+	ErrEmptyWarehouseName = errors.New("warehouse name cannot be empty")
+)
+
+// Warehouse represents a physical location where stock is held
+type Warehouse struct {
+	id        uint      // Unexported ID field
+	code      string    // Unexported Code field, unique short identifier
+	name      string    // Unexported Name field
+	createdAt time.Time // Unexported CreatedAt field
+	updatedAt time.Time // Unexported UpdatedAt field
+}
+
+// NewWarehouse creates a new Warehouse instance with a normalized code and timestamps
+func NewWarehouse(code string, name string) (*Warehouse, error) {
+	currentTime := time.Now()
+
+	warehouse := &Warehouse{}
+	if err := warehouse.MakeWarehouse(0, code, name, currentTime, currentTime); err != nil {
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+// MakeWarehouse sets all attributes of the Warehouse from parameters
+func (w *Warehouse) MakeWarehouse(id uint, code string, name string, createdAt, updatedAt time.Time) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return ErrEmptyWarehouseCode
+	}
+	if strings.TrimSpace(name) == "" {
+		return ErrEmptyWarehouseName
+	}
+	w.id = id
+	w.code = code
+	w.name = name
+	w.createdAt = createdAt
+	w.updatedAt = updatedAt
+	return nil
+}
+
+// ID returns the ID of the warehouse
+func (w *Warehouse) ID() uint {
+	return w.id
+}
+
+// Code returns the unique code of the warehouse
+func (w *Warehouse) Code() string {
+	return w.code
+}
+
+// Name returns the Name of the warehouse
+func (w *Warehouse) Name() string {
+	return w.name
+}
+
+// CreatedAt returns the creation timestamp of the warehouse
+func (w *Warehouse) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// UpdatedAt returns the last updated timestamp of the warehouse
+func (w *Warehouse) UpdatedAt() time.Time {
+	return w.updatedAt
+}
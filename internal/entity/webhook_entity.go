@@ -0,0 +1,332 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// WebhookDeliveryStatus describes how far the delivery of an event to a webhook got
+type WebhookDeliveryStatus string
+
+// Supported webhook delivery statuses
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for its next attempt
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // The receiver accepted it
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"      // Every attempt failed, it is not retried
+)
+
+// minWebhookSecretLength keeps secrets long enough that signatures cannot be guessed
+const minWebhookSecretLength = 16
+
+// Webhook validation and state errors
+var (
+	ErrInvalidWebhookURL            = errors.New("webhook URL must be an absolute http or https URL")
+	ErrWebhookSecretTooShort        = fmt.Errorf("webhook secret must be at least %d characters long", minWebhookSecretLength)
+	ErrInvalidWebhookDeliveryStatus = errors.New("invalid webhook delivery status")
+	ErrWebhookDeliveryNotPending    = errors.New("webhook delivery is no longer pending")
+)
+
+// IsValid reports whether the status is one of the supported statuses
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliverySucceeded, WebhookDeliveryDead:
+		return true
+	}
+	return false
+}
+
+// WebhookSubscription asks for the events of some types to be posted to a URL, signed with a secret
+// shared with the receiver
+type WebhookSubscription struct {
+	id         uint        // Unexported ID field
+	url        string      // Where the events are posted
+	secret     string      // Key of the HMAC signature of every request
+	eventTypes []EventType // Events to post, empty for every event
+	createdAt  time.Time   // Unexported CreatedAt field
+	updatedAt  time.Time   // Unexported UpdatedAt field
+}
+
+// NewWebhookSubscription creates a subscription. An empty secret is replaced by a random one.
+func NewWebhookSubscription(rawURL string, secret string, eventTypes []EventType) (*WebhookSubscription, error) {
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+	s := &WebhookSubscription{}
+	if err := s.MakeWebhookSubscription(0, rawURL, secret, eventTypes, time.Now(), time.Now()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// MakeWebhookSubscription sets all attributes of the WebhookSubscription from parameters
+func (s *WebhookSubscription) MakeWebhookSubscription(id uint, rawURL string, secret string, eventTypes []EventType, createdAt time.Time, updatedAt time.Time) error {
+	if err := validateWebhookURL(rawURL); err != nil {
+		return err
+	}
+	if len(secret) < minWebhookSecretLength {
+		return ErrWebhookSecretTooShort
+	}
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			return ErrInvalidEventType
+		}
+	}
+	if eventTypes == nil {
+		eventTypes = []EventType{}
+	}
+	s.id = id
+	s.url = rawURL
+	s.secret = secret
+	s.eventTypes = eventTypes
+	s.createdAt = createdAt
+	s.updatedAt = updatedAt
+	return nil
+}
+
+// Update points the subscription at a new URL and event filter. An empty secret keeps the current one.
+func (s *WebhookSubscription) Update(rawURL string, secret string, eventTypes []EventType) error {
+	if secret == "" {
+		secret = s.secret
+	}
+	return s.MakeWebhookSubscription(s.id, rawURL, secret, eventTypes, s.createdAt, time.Now())
+}
+
+// Accepts reports whether events of the type are posted to the subscription
+func (s *WebhookSubscription) Accepts(eventType EventType) bool {
+	if len(s.eventTypes) == 0 {
+		return true
+	}
+	for _, accepted := range s.eventTypes {
+		if accepted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ID returns the ID of the subscription
+func (s *WebhookSubscription) ID() uint {
+	return s.id
+}
+
+// URL returns where the events are posted
+func (s *WebhookSubscription) URL() string {
+	return s.url
+}
+
+// Secret returns the key the requests are signed with
+func (s *WebhookSubscription) Secret() string {
+	return s.secret
+}
+
+// EventTypes returns the events posted to the subscription, empty for every event
+func (s *WebhookSubscription) EventTypes() []EventType {
+	return append([]EventType{}, s.eventTypes...)
+}
+
+// CreatedAt returns the creation time of the subscription
+func (s *WebhookSubscription) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// UpdatedAt returns the last update time of the subscription
+func (s *WebhookSubscription) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// validateWebhookURL accepts absolute http and https URLs only
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// generateWebhookSecret returns 32 random bytes as hex
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// WebhookRetryPolicy decides how often and how long failed webhook deliveries are retried
+type WebhookRetryPolicy struct {
+	MaxAttempts int           // Attempts before a delivery is given up and dead-lettered
+	BaseDelay   time.Duration // Wait after the first failure, doubled after every further failure
+	MaxDelay    time.Duration // Longest wait between two attempts
+}
+
+// DefaultWebhookRetryPolicy retries a delivery for about a day before giving up
+var DefaultWebhookRetryPolicy = WebhookRetryPolicy{MaxAttempts: 10, BaseDelay: 30 * time.Second, MaxDelay: 6 * time.Hour}
+
+// Delay returns how long to wait after the given number of failed attempts
+func (p WebhookRetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// WebhookDelivery is one event posted to one webhook subscription, with the outcome of its attempts
+type WebhookDelivery struct {
+	id             uint                  // Unexported ID field
+	subscriptionID uint                  // Subscription the event is posted to
+	eventID        uint                  // Outbox event being delivered
+	eventType      EventType             // Type of the event being delivered
+	payload        string                // JSON body posted on every attempt
+	status         WebhookDeliveryStatus // Unexported Status field
+	attempts       int                   // Attempts made so far
+	nextAttemptAt  time.Time             // When a pending delivery is tried next
+	lastStatusCode int                   // HTTP status of the last attempt, zero when no response came back
+	lastError      string                // Why the last attempt failed
+	deliveredAt    *time.Time            // When the receiver accepted the event
+	createdAt      time.Time             // Unexported CreatedAt field
+}
+
+// NewWebhookDelivery creates a pending delivery of an event, due right away
+func NewWebhookDelivery(subscriptionID uint, event *DomainEvent, payload string, now time.Time) (*WebhookDelivery, error) {
+	d := &WebhookDelivery{}
+	if err := d.MakeWebhookDelivery(0, subscriptionID, event.ID(), event.Type(), payload, WebhookDeliveryPending, 0, now, 0, "", nil, now); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// MakeWebhookDelivery sets all attributes of the WebhookDelivery from parameters
+func (d *WebhookDelivery) MakeWebhookDelivery(id uint, subscriptionID uint, eventID uint, eventType EventType, payload string, status WebhookDeliveryStatus, attempts int, nextAttemptAt time.Time, lastStatusCode int, lastError string, deliveredAt *time.Time, createdAt time.Time) error {
+	if !eventType.IsValid() {
+		return ErrInvalidEventType
+	}
+	if !status.IsValid() {
+		return ErrInvalidWebhookDeliveryStatus
+	}
+	d.id = id
+	d.subscriptionID = subscriptionID
+	d.eventID = eventID
+	d.eventType = eventType
+	d.payload = payload
+	d.status = status
+	d.attempts = attempts
+	d.nextAttemptAt = nextAttemptAt
+	d.lastStatusCode = lastStatusCode
+	d.lastError = lastError
+	d.deliveredAt = deliveredAt
+	d.createdAt = createdAt
+	return nil
+}
+
+// Lease holds a pending delivery back from other dispatchers until the given time, while it is being
+// posted. A dispatcher that stops before recording the outcome leaves it to be tried again then.
+func (d *WebhookDelivery) Lease(until time.Time) error {
+	if d.status != WebhookDeliveryPending {
+		return ErrWebhookDeliveryNotPending
+	}
+	d.nextAttemptAt = until
+	return nil
+}
+
+// RecordSuccess records that the receiver accepted the event
+func (d *WebhookDelivery) RecordSuccess(statusCode int, now time.Time) error {
+	if d.status != WebhookDeliveryPending {
+		return ErrWebhookDeliveryNotPending
+	}
+	d.attempts++
+	d.status = WebhookDeliverySucceeded
+	d.lastStatusCode = statusCode
+	d.lastError = ""
+	d.deliveredAt = &now
+	return nil
+}
+
+// RecordFailure records a failed attempt and schedules the next one with exponential backoff. The
+// delivery is dead-lettered once the policy runs out of attempts. statusCode is zero when the receiver
+// could not be reached.
+func (d *WebhookDelivery) RecordFailure(statusCode int, reason string, now time.Time, policy WebhookRetryPolicy) error {
+	if d.status != WebhookDeliveryPending {
+		return ErrWebhookDeliveryNotPending
+	}
+	d.attempts++
+	d.lastStatusCode = statusCode
+	d.lastError = reason
+	if d.attempts >= policy.MaxAttempts {
+		d.status = WebhookDeliveryDead
+		return nil
+	}
+	d.nextAttemptAt = now.Add(policy.Delay(d.attempts))
+	return nil
+}
+
+// ID returns the ID of the delivery
+func (d *WebhookDelivery) ID() uint {
+	return d.id
+}
+
+// SubscriptionID returns the subscription the event is posted to
+func (d *WebhookDelivery) SubscriptionID() uint {
+	return d.subscriptionID
+}
+
+// EventID returns the outbox event being delivered
+func (d *WebhookDelivery) EventID() uint {
+	return d.eventID
+}
+
+// EventType returns the type of the event being delivered
+func (d *WebhookDelivery) EventType() EventType {
+	return d.eventType
+}
+
+// Payload returns the JSON body posted on every attempt
+func (d *WebhookDelivery) Payload() string {
+	return d.payload
+}
+
+// Status returns how far the delivery got
+func (d *WebhookDelivery) Status() WebhookDeliveryStatus {
+	return d.status
+}
+
+// Attempts returns the number of attempts made
+func (d *WebhookDelivery) Attempts() int {
+	return d.attempts
+}
+
+// NextAttemptAt returns when a pending delivery is tried next
+func (d *WebhookDelivery) NextAttemptAt() time.Time {
+	return d.nextAttemptAt
+}
+
+// LastStatusCode returns the HTTP status of the last attempt, zero when no response came back
+func (d *WebhookDelivery) LastStatusCode() int {
+	return d.lastStatusCode
+}
+
+// LastError returns why the last attempt failed, empty when it did not
+func (d *WebhookDelivery) LastError() string {
+	return d.lastError
+}
+
+// DeliveredAt returns when the receiver accepted the event, nil until it did
+func (d *WebhookDelivery) DeliveredAt() *time.Time {
+	return d.deliveredAt
+}
+
+// CreatedAt returns the creation time of the delivery
+func (d *WebhookDelivery) CreatedAt() time.Time {
+	return d.createdAt
+}
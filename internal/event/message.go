@@ -0,0 +1,34 @@
+package event
+
+import (
+	"encoding/json"
+	"inventory_management/internal/entity"
+	"time"
+)
+
+// Message is the wire format of a domain event handed to services downstream
+type Message struct {
+	ID            uint                   `json:"id"` // Stable across redeliveries, for consumers to skip duplicates
+	Type          entity.EventType       `json:"type"`
+	AggregateType string                 `json:"aggregate_type"`
+	AggregateID   uint                   `json:"aggregate_id"`
+	OccurredAt    time.Time              `json:"occurred_at"`
+	Payload       map[string]interface{} `json:"payload"`
+}
+
+// NewMessage converts a domain event to its wire format
+func NewMessage(e *entity.DomainEvent) Message {
+	return Message{
+		ID:            e.ID(),
+		Type:          e.Type(),
+		AggregateType: e.AggregateType(),
+		AggregateID:   e.AggregateID(),
+		OccurredAt:    e.OccurredAt().UTC(),
+		Payload:       e.Payload(),
+	}
+}
+
+// Encode renders a domain event as a JSON message
+func Encode(e *entity.DomainEvent) ([]byte, error) {
+	return json.Marshal(NewMessage(e))
+}
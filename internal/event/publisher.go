@@ -0,0 +1,114 @@
+package event
+
+import (
+	"inventory_management/internal/entity"
+	"io"
+	"os"
+	"sync"
+)
+
+// WriterPublisher writes every event as one line of JSON, for local runs and debugging
+type WriterPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterPublisher creates a publisher writing to w
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+// NewStdoutPublisher creates a publisher writing to the standard output
+func NewStdoutPublisher() *WriterPublisher {
+	return NewWriterPublisher(os.Stdout)
+}
+
+// Publish writes the event followed by a newline
+func (p *WriterPublisher) Publish(e *entity.DomainEvent) error {
+	encoded, err := Encode(e)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.w.Write(append(encoded, '\n'))
+	return err
+}
+
+// Handler reacts to an event delivered in process. An error fails the delivery and the event is retried.
+type Handler func(message Message) error
+
+// InMemoryPublisher delivers events to handlers in the same process and keeps every delivered event,
+// for tests and for wiring features together without a broker
+type InMemoryPublisher struct {
+	mu        sync.Mutex
+	handlers  map[entity.EventType][]Handler
+	published []Message
+}
+
+// NewInMemoryPublisher creates a publisher without handlers
+func NewInMemoryPublisher() *InMemoryPublisher {
+	return &InMemoryPublisher{handlers: map[entity.EventType][]Handler{}}
+}
+
+// Subscribe registers a handler for one type of event
+func (p *InMemoryPublisher) Subscribe(eventType entity.EventType, handler Handler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers[eventType] = append(p.handlers[eventType], handler)
+}
+
+// Publish calls the handlers of the event in the order they subscribed. The event counts as delivered
+// only when every handler succeeded.
+func (p *InMemoryPublisher) Publish(e *entity.DomainEvent) error {
+	message := NewMessage(e)
+
+	p.mu.Lock()
+	handlers := append([]Handler{}, p.handlers[message.Type]...)
+	p.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler(message); err != nil {
+			return err
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, message)
+	return nil
+}
+
+// Published returns the events delivered so far, in delivery order
+func (p *InMemoryPublisher) Published() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message{}, p.published...)
+}
+
+// Publisher delivers domain events somewhere
+type Publisher interface {
+	Publish(e *entity.DomainEvent) error
+}
+
+// FanOutPublisher hands every event to several publishers
+type FanOutPublisher struct {
+	publishers []Publisher
+}
+
+// NewFanOutPublisher creates a publisher delivering to each of publishers in order
+func NewFanOutPublisher(publishers ...Publisher) *FanOutPublisher {
+	return &FanOutPublisher{publishers: publishers}
+}
+
+// Publish hands the event to every publisher in turn and stops at the first failure. The publishers
+// before the failing one get the event again when the relay retries it.
+func (p *FanOutPublisher) Publish(e *entity.DomainEvent) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package label
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"inventory_management/internal/entity"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrLabelTooSmall is returned when the barcode does not fit the label at the requested size and DPI
+var ErrLabelTooSmall = errors.New("label is too small for its barcode")
+
+// quietZoneModules is the blank space kept on either side of the bars, in modules
+const quietZoneModules = 10
+
+// Size is the printed size of a label and the resolution of the printer
+type Size struct {
+	WidthMM  float64
+	HeightMM float64
+	DPI      int
+}
+
+// pixels converts a length in millimetres to printer dots
+func (s Size) pixels(mm float64) int {
+	return int(math.Round(mm / 25.4 * float64(s.DPI)))
+}
+
+// Label is a shelf label showing a product name and SKU above a barcode
+type Label struct {
+	name        string
+	sku         string
+	symbology   string // "EAN-13" or "Code 128"
+	barcodeText string // Human readable text printed under the bars
+	modules     []bool // Bars of the barcode, true for a dark module
+}
+
+// NewProductLabel lays out the label of a product. The bars encode its first EAN-13 or UPC-A barcode
+// when it has one and its SKU in Code 128 otherwise.
+func NewProductLabel(product *entity.Product, barcodes []*entity.Barcode) (*Label, error) {
+	l := &Label{name: product.Name(), sku: product.SKU()}
+	for _, barcode := range barcodes {
+		code := barcode.Code()
+		switch barcode.Type() {
+		case entity.BarcodeTypeUPCA:
+			code = "0" + code // UPC-A is an EAN-13 with a leading zero
+		case entity.BarcodeTypeEAN13:
+		default:
+			continue
+		}
+		modules, err := EncodeEAN13(code)
+		if err != nil {
+			return nil, err
+		}
+		l.symbology, l.barcodeText, l.modules = "EAN-13", code, modules
+		return l, nil
+	}
+
+	modules, err := EncodeCode128(product.SKU())
+	if err != nil {
+		return nil, err
+	}
+	l.symbology, l.barcodeText, l.modules = "Code 128", product.SKU(), modules
+	return l, nil
+}
+
+// Name returns the product name printed on the label
+func (l *Label) Name() string {
+	return l.name
+}
+
+// SKU returns the product SKU printed on the label
+func (l *Label) SKU() string {
+	return l.sku
+}
+
+// Symbology returns the barcode symbology, "EAN-13" or "Code 128"
+func (l *Label) Symbology() string {
+	return l.symbology
+}
+
+// BarcodeText returns the human readable text printed under the bars
+func (l *Label) BarcodeText() string {
+	return l.barcodeText
+}
+
+// Modules returns the bars of the barcode, true for a dark module
+func (l *Label) Modules() []bool {
+	return l.modules
+}
+
+// layout is the position of every element of a label in printer dots
+type layout struct {
+	width, height int
+	scale         int      // Pixels per font pixel
+	margin        int      // Blank border around the label
+	lines         []string // Text lines above the bars
+	barsX, barsY  int      // Top left corner of the bars
+	barsHeight    int
+	moduleWidth   int
+	captionY      int // Top of the human readable text under the bars
+}
+
+// layout places the text and bars of the label for a size
+func (l *Label) layout(size Size) (*layout, error) {
+	lo := &layout{width: size.pixels(size.WidthMM), height: size.pixels(size.HeightMM)}
+	lo.margin = int(math.Max(2, math.Round(float64(lo.height)*0.06)))
+	lo.scale = int(math.Max(1, float64(lo.height)*0.1/glyphHeight))
+
+	lo.lines = []string{l.name}
+	if l.barcodeText != l.sku {
+		lo.lines = append(lo.lines, l.sku)
+	}
+	maxChars := (lo.width - 2*lo.margin + lo.scale) / (glyphAdvance * lo.scale)
+	for i, line := range lo.lines {
+		lo.lines[i] = truncate(line, maxChars)
+	}
+
+	lo.moduleWidth = (lo.width - 2*lo.margin) / (len(l.modules) + 2*quietZoneModules)
+	lo.barsX = (lo.width - lo.moduleWidth*len(l.modules)) / 2
+	lo.barsY = lo.margin + len(lo.lines)*lineAdvance*lo.scale
+	lo.captionY = lo.height - lo.margin - glyphHeight*lo.scale
+	lo.barsHeight = lo.captionY - 2*lo.scale - lo.barsY
+	if lo.moduleWidth < 1 || lo.barsHeight < 4*lo.scale || maxChars < 1 {
+		return nil, ErrLabelTooSmall
+	}
+	return lo, nil
+}
+
+// WritePNG renders the label as a black and white PNG at the DPI of the size
+func (l *Label) WritePNG(w io.Writer, size Size) error {
+	lo, err := l.layout(size)
+	if err != nil {
+		return err
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, lo.width, lo.height), color.Palette{color.White, color.Black})
+	fill := func(x, y, width, height int) {
+		for py := y; py < y+height; py++ {
+			for px := x; px < x+width; px++ {
+				img.SetColorIndex(px, py, 1)
+			}
+		}
+	}
+	drawText := func(text string, x, y int) {
+		for _, r := range text {
+			g := glyph(r)
+			for gx := 0; gx < glyphWidth; gx++ {
+				for gy := 0; gy < glyphHeight; gy++ {
+					if glyphPixel(g, gx, gy) {
+						fill(x+gx*lo.scale, y+gy*lo.scale, lo.scale, lo.scale)
+					}
+				}
+			}
+			x += glyphAdvance * lo.scale
+		}
+	}
+
+	for i, line := range lo.lines {
+		drawText(line, lo.margin, lo.margin+i*lineAdvance*lo.scale)
+	}
+	for i, bar := range l.modules {
+		if bar {
+			fill(lo.barsX+i*lo.moduleWidth, lo.barsY, lo.moduleWidth, lo.barsHeight)
+		}
+	}
+	caption := truncate(l.barcodeText, (lo.width-2*lo.margin+lo.scale)/(glyphAdvance*lo.scale))
+	drawText(caption, (lo.width-textWidth(caption, lo.scale))/2, lo.captionY)
+
+	return png.Encode(w, img)
+}
+
+// WriteSVG renders the label as an SVG sized in millimetres. The DPI of the size decides how finely
+// the bars are laid out.
+func (l *Label) WriteSVG(w io.Writer, size Size) error {
+	lo, err := l.layout(size)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%gmm" height="%gmm" viewBox="0 0 %d %d">`+"\n",
+		size.WidthMM, size.HeightMM, lo.width, lo.height)
+	fmt.Fprintf(out, `<rect width="%d" height="%d" fill="#fff"/>`+"\n", lo.width, lo.height)
+
+	fontSize := lineAdvance * lo.scale
+	for i, line := range lo.lines {
+		fmt.Fprintf(out, `<text x="%d" y="%d" font-family="monospace" font-size="%d">%s</text>`+"\n",
+			lo.margin, lo.margin+i*fontSize+glyphHeight*lo.scale, fontSize, escapeXML(line))
+	}
+
+	// Adjacent dark modules are drawn as one bar
+	for i := 0; i < len(l.modules); {
+		if !l.modules[i] {
+			i++
+			continue
+		}
+		start := i
+		for i < len(l.modules) && l.modules[i] {
+			i++
+		}
+		fmt.Fprintf(out, `<rect x="%d" y="%d" width="%d" height="%d"/>`+"\n",
+			lo.barsX+start*lo.moduleWidth, lo.barsY, (i-start)*lo.moduleWidth, lo.barsHeight)
+	}
+
+	fmt.Fprintf(out, `<text x="%d" y="%d" font-family="monospace" font-size="%d" text-anchor="middle">%s</text>`+"\n",
+		lo.width/2, lo.captionY+glyphHeight*lo.scale, fontSize, escapeXML(l.barcodeText))
+	fmt.Fprintln(out, `</svg>`)
+	return out.Flush()
+}
+
+// truncate shortens text to at most maxChars characters, marking the cut with two dots
+func truncate(text string, maxChars int) string {
+	if utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+	runes := []rune(text)
+	if maxChars <= 2 {
+		return string(runes[:maxChars])
+	}
+	return string(runes[:maxChars-2]) + ".."
+}
+
+// textWidth returns the width of text drawn in the bitmap font
+func textWidth(text string, scale int) int {
+	return (utf8.RuneCountInString(text)*glyphAdvance - 1) * scale
+}
+
+// escapeXML escapes text for use in SVG character data
+func escapeXML(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(text)
+}
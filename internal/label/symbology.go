@@ -0,0 +1,131 @@
+package label
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+)
+
+// ErrNotEncodable is returned when text contains characters the barcode symbology cannot carry
+var ErrNotEncodable = errors.New("text cannot be encoded in the barcode symbology")
+
+// code128Patterns holds the bar and space widths of every Code 128 symbol value, starting with a bar.
+// Values 103 to 105 are the start symbols of code sets A to C and 106 is the stop symbol.
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+// code128StartB and code128Stop are the symbol values framing a Code 128 barcode in code set B
+const (
+	code128StartB = 104
+	code128Stop   = 106
+)
+
+// EncodeCode128 encodes printable ASCII text in Code 128 code set B and returns its modules, true
+// for a dark bar module
+func EncodeCode128(text string) ([]bool, error) {
+	if text == "" {
+		return nil, ErrNotEncodable
+	}
+
+	values := []int{code128StartB}
+	checksum := code128StartB
+	for i := 0; i < len(text); i++ {
+		if text[i] < 32 || text[i] > 126 {
+			return nil, ErrNotEncodable
+		}
+		value := int(text[i]) - 32
+		values = append(values, value)
+		checksum += value * (i + 1)
+	}
+	values = append(values, checksum%103, code128Stop)
+
+	var modules []bool
+	for _, value := range values {
+		modules = appendWidths(modules, code128Patterns[value])
+	}
+	return modules, nil
+}
+
+// ean13LeftOdd holds the left hand odd parity (L) codes of the digits. Right hand (R) codes are their
+// complement and left hand even parity (G) codes their mirror image.
+var ean13LeftOdd = [10]string{
+	"0001101", "0011001", "0010011", "0111101", "0100011", "0110001", "0101111", "0111011", "0110111", "0001011",
+}
+
+// ean13Parity holds for every first digit whether each left hand digit uses even parity
+var ean13Parity = [10]string{
+	"OOOOOO", "OOEOEE", "OOEEOE", "OOEEEO", "OEOOEE", "OEEOOE", "OEEEOO", "OEOEOE", "OEOEEO", "OEEOEO",
+}
+
+// EncodeEAN13 encodes a 13 digit EAN and returns its 95 modules, true for a dark bar module
+func EncodeEAN13(code string) ([]bool, error) {
+	barcodeType, err := entity.ValidateBarcode(code)
+	if err != nil || barcodeType != entity.BarcodeTypeEAN13 {
+		return nil, ErrNotEncodable
+	}
+
+	modules := appendBits(nil, "101")
+	parity := ean13Parity[code[0]-'0']
+	for i := 1; i <= 6; i++ {
+		pattern := ean13LeftOdd[code[i]-'0']
+		if parity[i-1] == 'E' {
+			pattern = reverse(complement(pattern))
+		}
+		modules = appendBits(modules, pattern)
+	}
+	modules = appendBits(modules, "01010")
+	for i := 7; i <= 12; i++ {
+		modules = appendBits(modules, complement(ean13LeftOdd[code[i]-'0']))
+	}
+	return appendBits(modules, "101"), nil
+}
+
+// appendWidths appends alternating bars and spaces of the given module widths, starting with a bar
+func appendWidths(modules []bool, widths string) []bool {
+	for i, width := range widths {
+		for j := 0; j < int(width-'0'); j++ {
+			modules = append(modules, i%2 == 0)
+		}
+	}
+	return modules
+}
+
+// appendBits appends one module per character, '1' for a bar
+func appendBits(modules []bool, bits string) []bool {
+	for _, bit := range bits {
+		modules = append(modules, bit == '1')
+	}
+	return modules
+}
+
+// complement swaps bars and spaces of a module pattern
+func complement(bits string) string {
+	swapped := []byte(bits)
+	for i, bit := range swapped {
+		if bit == '1' {
+			swapped[i] = '0'
+		} else {
+			swapped[i] = '1'
+		}
+	}
+	return string(swapped)
+}
+
+// reverse mirrors a module pattern
+func reverse(bits string) string {
+	mirrored := []byte(bits)
+	for i, j := 0, len(mirrored)-1; i < j; i, j = i+1, j-1 {
+		mirrored[i], mirrored[j] = mirrored[j], mirrored[i]
+	}
+	return string(mirrored)
+}
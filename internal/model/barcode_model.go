@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// ProductBarcode represents the structure of the product_barcodes table in the database
+type ProductBarcode struct {
+	GTIN      string    `gorm:"primaryKey;type:varchar(14)" json:"gtin"`
+	Code      string    `gorm:"type:varchar(14);not null" json:"code"`
+	ProductID uint      `gorm:"not null;index" json:"product_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Category represents the structure of the categories table in the database
+type Category struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ParentID  *uint     `json:"parent_id"`
+	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
+	Path      string    `gorm:"type:varchar(1000);not null;index" json:"path"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
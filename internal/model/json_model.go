@@ -0,0 +1,61 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StringList is a list of strings stored in a JSONB column
+type StringList []string
+
+// Value encodes the list as a JSON array. A nil list is stored as an empty array.
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	encoded, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan decodes a JSON array read from the database
+func (l *StringList) Scan(value interface{}) error {
+	return scanJSON(value, (*[]string)(l))
+}
+
+// StringMap is a map of strings stored in a JSONB column
+type StringMap map[string]string
+
+// Value encodes the map as a JSON object. A nil map is stored as an empty object.
+func (m StringMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	encoded, err := json.Marshal(map[string]string(m))
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan decodes a JSON object read from the database
+func (m *StringMap) Scan(value interface{}) error {
+	return scanJSON(value, (*map[string]string)(m))
+}
+
+// scanJSON decodes a JSON column that the driver returns as text or bytes. NULL leaves target empty.
+func scanJSON(value interface{}, target interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, target)
+	case string:
+		return json.Unmarshal([]byte(v), target)
+	default:
+		return fmt.Errorf("cannot scan %T into a JSON column", value)
+	}
+}
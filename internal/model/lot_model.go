@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Lot represents the structure of the lots table in the database
+type Lot struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID      uint       `gorm:"not null;uniqueIndex:idx_lots_product_warehouse_number" json:"product_id"`
+	WarehouseID    uint       `gorm:"not null;uniqueIndex:idx_lots_product_warehouse_number" json:"warehouse_id"`
+	LotNumber      string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_lots_product_warehouse_number" json:"lot_number"`
+	ManufacturedAt *time.Time `json:"manufactured_at"`
+	ExpiresAt      time.Time  `gorm:"not null;index" json:"expires_at"`
+	Quantity       int64      `gorm:"not null;default:0" json:"quantity"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OutboxEvent represents the structure of the outbox_events table in the database
+type OutboxEvent struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventType     string     `gorm:"type:varchar(50);not null" json:"event_type"`
+	AggregateType string     `gorm:"type:varchar(50);not null" json:"aggregate_type"`
+	AggregateID   uint       `gorm:"not null" json:"aggregate_id"`
+	Payload       JSONObject `gorm:"type:jsonb;not null" json:"payload"`
+	OccurredAt    time.Time  `gorm:"not null" json:"occurred_at"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `gorm:"type:text;not null;default:''" json:"last_error"`
+	PublishedAt   *time.Time `json:"published_at"` // Null while the event waits for the relay
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// JSONObject is a free-form JSON object stored in a JSONB column
+type JSONObject map[string]interface{}
+
+// Value encodes the object as JSON. A nil object is stored as an empty object.
+func (o JSONObject) Value() (driver.Value, error) {
+	if o == nil {
+		return "{}", nil
+	}
+	encoded, err := json.Marshal(map[string]interface{}(o))
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan decodes a JSON object read from the database. Numbers are kept as json.Number so that IDs and
+// quantities survive unchanged.
+func (o *JSONObject) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a JSON column", value)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	return decoder.Decode((*map[string]interface{})(o))
+}
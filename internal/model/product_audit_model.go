@@ -0,0 +1,100 @@
+package model
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ProductAuditRecord represents the structure of the product_audit_records table in the database
+type ProductAuditRecord struct {
+	ID         uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID  uint            `gorm:"not null;index:idx_product_audit_records_product" json:"product_id"`
+	Action     string          `gorm:"type:varchar(20);not null" json:"action"`
+	Actor      string          `gorm:"type:varchar(100);not null" json:"actor"`
+	RequestID  string          `gorm:"type:varchar(100);not null;default:''" json:"request_id"`
+	SourceIP   string          `gorm:"type:varchar(45);not null;default:''" json:"source_ip"`
+	Changes    AuditChanges    `gorm:"type:jsonb;not null" json:"changes"`
+	State      ProductSnapshot `gorm:"type:jsonb;not null" json:"state"` // The product right after the change
+	OccurredAt time.Time       `gorm:"not null;index:idx_product_audit_records_product" json:"occurred_at"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// AuditChange is the value of one product field before and after a change
+type AuditChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditChanges is the list of field changes of an audit record stored in a JSONB column
+type AuditChanges []AuditChange
+
+// Value encodes the changes as a JSON array. A nil list is stored as an empty array.
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	encoded, err := json.Marshal([]AuditChange(c))
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan decodes a JSON array read from the database. Numbers are kept as json.Number so that large
+// values survive unchanged.
+func (c *AuditChanges) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a JSON column", value)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	return decoder.Decode((*[]AuditChange)(c))
+}
+
+// ProductSnapshot is the full state of a product stored in a JSONB column
+type ProductSnapshot struct {
+	Name        string            `json:"name"`
+	SKU         string            `json:"sku"`
+	Description string            `json:"description"`
+	Brand       string            `json:"brand"`
+	Status      string            `json:"status"`
+	WeightGrams int64             `json:"weight_grams"`
+	LengthMM    int64             `json:"length_mm"`
+	WidthMM     int64             `json:"width_mm"`
+	HeightMM    int64             `json:"height_mm"`
+	Tags        []string          `json:"tags"`
+	Attributes  map[string]string `json:"attributes"`
+	Serialized  bool              `json:"serialized"`
+	StockUnit   string            `json:"stock_unit"`
+	CategoryID  uint              `json:"category_id"` // Zero while the product is uncategorised
+	DeletedAt   *time.Time        `json:"deleted_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Version     int64             `json:"version"`
+}
+
+// Value encodes the snapshot as a JSON object
+func (s ProductSnapshot) Value() (driver.Value, error) {
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan decodes a JSON object read from the database
+func (s *ProductSnapshot) Scan(value interface{}) error {
+	return scanJSON(value, s)
+}
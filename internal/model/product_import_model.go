@@ -0,0 +1,48 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// ProductImport represents the structure of the product_imports table in the database
+type ProductImport struct {
+	ID           uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	FileName     string       `gorm:"type:varchar(255);not null" json:"file_name"`
+	DryRun       bool         `gorm:"not null;default:false" json:"dry_run"`
+	Created      int          `gorm:"not null;default:0" json:"created"`
+	Updated      int          `gorm:"not null;default:0" json:"updated"`
+	Unchanged    int          `gorm:"not null;default:0" json:"unchanged"`
+	RejectedRows RejectedRows `gorm:"type:jsonb;not null;default:'[]'" json:"rejected_rows"`
+	FailedAtLine int          `gorm:"not null;default:0" json:"failed_at_line"`
+	CreatedAt    time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// RejectedRow is a row of an import file that was not imported
+type RejectedRow struct {
+	Line   int               `json:"line"`
+	SKU    string            `json:"sku"`
+	Name   string            `json:"name"`
+	Errors map[string]string `json:"errors"`
+}
+
+// RejectedRows is the list of rejected rows of an import stored in a JSONB column
+type RejectedRows []RejectedRow
+
+// Value encodes the rows as a JSON array. A nil list is stored as an empty array.
+func (r RejectedRows) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	encoded, err := json.Marshal([]RejectedRow(r))
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan decodes a JSON array read from the database
+func (r *RejectedRows) Scan(value interface{}) error {
+	return scanJSON(value, (*[]RejectedRow)(r))
+}
@@ -1,12 +1,30 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Product represents the structure of the products table in the database
 type Product struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
-	SKU       string    `gorm:"type:varchar(100);unique;not null" json:"sku"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string         `gorm:"type:varchar(255);not null" json:"name"`
+	SKU         string         `gorm:"type:varchar(100);unique;not null" json:"sku"`
+	Description string         `gorm:"type:text;not null;default:''" json:"description"`
+	Brand       string         `gorm:"type:varchar(100);not null;default:''" json:"brand"`
+	Status      string         `gorm:"type:varchar(20);not null;default:active" json:"status"`
+	WeightGrams int64          `gorm:"not null;default:0" json:"weight_grams"`
+	LengthMM    int64          `gorm:"column:length_mm;not null;default:0" json:"length_mm"`
+	WidthMM     int64          `gorm:"column:width_mm;not null;default:0" json:"width_mm"`
+	HeightMM    int64          `gorm:"column:height_mm;not null;default:0" json:"height_mm"`
+	Tags        StringList     `gorm:"type:jsonb;not null;default:'[]'" json:"tags"`
+	Attributes  StringMap      `gorm:"type:jsonb;not null;default:'{}'" json:"attributes"`
+	Serialized  bool           `gorm:"not null;default:false" json:"serialized"`
+	StockUnit   string         `gorm:"type:varchar(20);not null;default:EA" json:"stock_unit"`
+	CategoryID  *uint          `gorm:"index" json:"category_id"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`           // Soft-deleted products are left out of queries unless unscoped
+	Version     int64          `gorm:"not null;default:1" json:"version"` // Incremented on every update for optimistic locking
 }
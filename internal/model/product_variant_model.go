@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// ProductVariant represents the structure of the product_variants table in the database
+type ProductVariant struct {
+	ProductID  uint      `gorm:"primaryKey;autoIncrement:false" json:"product_id"`
+	ParentID   uint      `gorm:"not null;index" json:"parent_id"`
+	VariantKey string    `gorm:"type:varchar(1000);not null" json:"variant_key"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ProductVariantOption represents the structure of the product_variant_options table in the database
+type ProductVariantOption struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID uint   `gorm:"not null;index" json:"product_id"`
+	Position  int    `gorm:"not null" json:"position"`
+	Name      string `gorm:"type:varchar(50);not null" json:"name"`
+	Value     string `gorm:"type:varchar(50);not null" json:"value"`
+}
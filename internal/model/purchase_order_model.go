@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// PurchaseOrder represents the structure of the purchase_orders table in the database
+type PurchaseOrder struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	SupplierID  uint       `gorm:"not null;index" json:"supplier_id"`
+	WarehouseID uint       `gorm:"not null" json:"warehouse_id"`
+	Status      string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Reference   string     `gorm:"type:varchar(100)" json:"reference"`
+	Currency    string     `gorm:"type:char(3);not null" json:"currency"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// PurchaseOrderLine represents the structure of the purchase_order_lines table in the database
+type PurchaseOrderLine struct {
+	ID               uint  `gorm:"primaryKey;autoIncrement" json:"id"`
+	PurchaseOrderID  uint  `gorm:"not null;uniqueIndex:idx_purchase_order_lines_order_product" json:"purchase_order_id"`
+	ProductID        uint  `gorm:"not null;uniqueIndex:idx_purchase_order_lines_order_product" json:"product_id"`
+	Quantity         int64 `gorm:"not null" json:"quantity"`
+	ReceivedQuantity int64 `gorm:"not null;default:0" json:"received_quantity"`
+	UnitCost         int64 `gorm:"not null" json:"unit_cost"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// ReorderPolicy represents the structure of the reorder_policies table in the database
+type ReorderPolicy struct {
+	ID                  uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID           uint      `gorm:"not null;uniqueIndex:idx_reorder_policies_product_warehouse" json:"product_id"`
+	WarehouseID         uint      `gorm:"not null;uniqueIndex:idx_reorder_policies_product_warehouse" json:"warehouse_id"`
+	ReorderPoint        int64     `gorm:"not null" json:"reorder_point"`
+	ReorderQuantity     int64     `gorm:"not null" json:"reorder_quantity"`
+	SafetyStock         int64     `gorm:"not null;default:0" json:"safety_stock"`
+	PreferredSupplierID *uint     `json:"preferred_supplier_id"`
+	CreatedAt           time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// LowStockAlert represents the structure of the low_stock_alerts table in the database
+type LowStockAlert struct {
+	ID                uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID         uint       `gorm:"not null" json:"product_id"`
+	WarehouseID       uint       `gorm:"not null;index" json:"warehouse_id"`
+	Available         int64      `gorm:"not null" json:"available"`
+	ReorderPoint      int64      `gorm:"not null" json:"reorder_point"`
+	SafetyStock       int64      `gorm:"not null" json:"safety_stock"`
+	SuggestedQuantity int64      `gorm:"not null" json:"suggested_quantity"`
+	PurchaseOrderID   *uint      `json:"purchase_order_id"`
+	Status            string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	RaisedAt          time.Time  `gorm:"not null" json:"raised_at"`
+	ResolvedAt        *time.Time `json:"resolved_at"`
+}
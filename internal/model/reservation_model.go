@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Reservation represents the structure of the reservations table in the database
+type Reservation struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID   uint      `gorm:"not null" json:"product_id"`
+	WarehouseID uint      `gorm:"not null" json:"warehouse_id"`
+	Quantity    int64     `gorm:"not null" json:"quantity"`
+	Status      string    `gorm:"type:varchar(20);not null;index:idx_reservations_status_expires_at" json:"status"`
+	Reference   string    `gorm:"type:varchar(100)" json:"reference"`
+	ExpiresAt   time.Time `gorm:"not null;index:idx_reservations_status_expires_at" json:"expires_at"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
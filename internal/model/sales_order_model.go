@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// SalesOrder represents the structure of the sales_orders table in the database
+type SalesOrder struct {
+	ID                uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	WarehouseID       uint       `gorm:"not null" json:"warehouse_id"`
+	CustomerReference string     `gorm:"type:varchar(100)" json:"customer_reference"`
+	Status            string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	ShippedAt         *time.Time `json:"shipped_at"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// SalesOrderLine represents the structure of the sales_order_lines table in the database
+type SalesOrderLine struct {
+	ID                uint  `gorm:"primaryKey;autoIncrement" json:"id"`
+	SalesOrderID      uint  `gorm:"not null;uniqueIndex:idx_sales_order_lines_order_product" json:"sales_order_id"`
+	ProductID         uint  `gorm:"not null;uniqueIndex:idx_sales_order_lines_order_product" json:"product_id"`
+	Quantity          int64 `gorm:"not null" json:"quantity"`
+	AllocatedQuantity int64 `gorm:"not null;default:0" json:"allocated_quantity"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Serial represents the structure of the serials table in the database
+type Serial struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID    uint      `gorm:"not null;index" json:"product_id"`
+	SerialNumber string    `gorm:"type:varchar(100);unique;not null" json:"serial_number"`
+	Status       string    `gorm:"type:varchar(20);not null" json:"status"`
+	WarehouseID  *uint     `json:"warehouse_id"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// SerialMovement represents the structure of the serial_movements table, linking a unit to the
+// ledger entries that moved it
+type SerialMovement struct {
+	SerialID        uint `gorm:"primaryKey" json:"serial_id"`
+	StockMovementID uint `gorm:"primaryKey" json:"stock_movement_id"`
+}
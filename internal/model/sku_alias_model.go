@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// ProductSKUAlias represents the structure of the product_sku_aliases table in the database
+type ProductSKUAlias struct {
+	SKU       string    `gorm:"primaryKey;type:varchar(100)" json:"sku"`
+	ProductID uint      `gorm:"not null;index" json:"product_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,7 @@
+package model
+
+// SKUSequence represents the structure of the sku_sequences table in the database
+type SKUSequence struct {
+	Key       string `gorm:"type:varchar(100);primaryKey" json:"key"`
+	LastValue int64  `gorm:"not null" json:"last_value"`
+}
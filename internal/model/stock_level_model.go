@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// StockLevel represents the structure of the stock_levels table in the database
+type StockLevel struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID   uint      `gorm:"not null;uniqueIndex:idx_stock_levels_product_warehouse" json:"product_id"`
+	WarehouseID uint      `gorm:"not null;uniqueIndex:idx_stock_levels_product_warehouse" json:"warehouse_id"`
+	OnHand      int64     `gorm:"not null;default:0" json:"on_hand"`
+	Reserved    int64     `gorm:"not null;default:0" json:"reserved"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
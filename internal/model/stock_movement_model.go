@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// StockMovement represents the structure of the stock_movements table in the database
+type StockMovement struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID    uint      `gorm:"not null;index:idx_stock_movements_product_warehouse" json:"product_id"`
+	WarehouseID  uint      `gorm:"not null;index:idx_stock_movements_product_warehouse" json:"warehouse_id"`
+	MovementType string    `gorm:"type:varchar(20);not null" json:"movement_type"`
+	Quantity     int64     `gorm:"not null" json:"quantity"`
+	BalanceAfter int64     `gorm:"not null" json:"balance_after"`
+	ReasonCode   string    `gorm:"type:varchar(50);not null" json:"reason_code"`
+	Reference    string    `gorm:"type:varchar(100)" json:"reference"`
+	Actor        string    `gorm:"type:varchar(100);not null" json:"actor"`
+	OccurredAt   time.Time `gorm:"not null" json:"occurred_at"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
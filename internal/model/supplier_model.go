@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Supplier represents the structure of the suppliers table in the database
+type Supplier struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code         string    `gorm:"type:varchar(50);unique;not null" json:"code"`
+	Name         string    `gorm:"type:varchar(255);not null" json:"name"`
+	ContactEmail string    `gorm:"type:varchar(255)" json:"contact_email"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
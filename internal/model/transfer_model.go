@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Transfer represents the structure of the transfers table in the database
+type Transfer struct {
+	ID                     uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	SourceWarehouseID      uint       `gorm:"not null" json:"source_warehouse_id"`
+	DestinationWarehouseID uint       `gorm:"not null" json:"destination_warehouse_id"`
+	Status                 string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Reference              string     `gorm:"type:varchar(100)" json:"reference"`
+	DispatchedAt           *time.Time `json:"dispatched_at"`
+	ReceivedAt             *time.Time `json:"received_at"`
+	ClosedAt               *time.Time `json:"closed_at"`
+	CloseReasonCode        string     `gorm:"type:varchar(50)" json:"close_reason_code"`
+	CreatedAt              time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt              time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TransferLine represents the structure of the transfer_lines table in the database
+type TransferLine struct {
+	ID               uint  `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransferID       uint  `gorm:"not null;uniqueIndex:idx_transfer_lines_transfer_product" json:"transfer_id"`
+	ProductID        uint  `gorm:"not null;uniqueIndex:idx_transfer_lines_transfer_product" json:"product_id"`
+	Quantity         int64 `gorm:"not null" json:"quantity"`
+	ReceivedQuantity int64 `gorm:"not null;default:0" json:"received_quantity"`
+}
+
+// TransferLineLot represents the structure of the transfer_line_lots table in the database
+type TransferLineLot struct {
+	ID               uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransferLineID   uint       `gorm:"not null;uniqueIndex:idx_transfer_line_lots_line_number" json:"transfer_line_id"`
+	LotNumber        string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_transfer_line_lots_line_number" json:"lot_number"`
+	ManufacturedAt   *time.Time `json:"manufactured_at"`
+	ExpiresAt        time.Time  `gorm:"not null" json:"expires_at"`
+	Quantity         int64      `gorm:"not null" json:"quantity"`
+	ReceivedQuantity int64      `gorm:"not null;default:0" json:"received_quantity"`
+}
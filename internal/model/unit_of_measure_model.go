@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// UnitOfMeasure represents the structure of the units_of_measure table in the database
+type UnitOfMeasure struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string    `gorm:"type:varchar(20);unique;not null" json:"code"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName overrides the unit_of_measures table name gorm would derive
+func (UnitOfMeasure) TableName() string {
+	return "units_of_measure"
+}
+
+// ProductUnit represents the structure of the product_units table in the database
+type ProductUnit struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID uint      `gorm:"not null;uniqueIndex:idx_product_units_product_unit" json:"product_id"`
+	UnitCode  string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_product_units_product_unit" json:"unit_code"`
+	Factor    int64     `gorm:"not null" json:"factor"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// Warehouse represents the structure of the warehouses table in the database
+type Warehouse struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string    `gorm:"type:varchar(50);unique;not null" json:"code"`
+	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
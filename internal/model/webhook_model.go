@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// WebhookSubscription represents the structure of the webhook_subscriptions table in the database
+type WebhookSubscription struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	URL        string     `gorm:"type:varchar(2048);not null" json:"url"`
+	Secret     string     `gorm:"type:varchar(255);not null" json:"-"`
+	EventTypes StringList `gorm:"type:jsonb;not null;default:'[]'" json:"event_types"` // Empty for every event
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// WebhookDelivery represents the structure of the webhook_deliveries table in the database
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	SubscriptionID uint       `gorm:"not null;uniqueIndex:idx_webhook_deliveries_subscription_event" json:"subscription_id"`
+	EventID        uint       `gorm:"not null;uniqueIndex:idx_webhook_deliveries_subscription_event" json:"event_id"`
+	EventType      string     `gorm:"type:varchar(50);not null" json:"event_type"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(20);not null" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null" json:"next_attempt_at"`
+	LastStatusCode int        `gorm:"not null;default:0" json:"last_status_code"`
+	LastError      string     `gorm:"type:text;not null;default:''" json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
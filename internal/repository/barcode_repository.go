@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrBarcodeNotFound is returned when no product carries a barcode
+var ErrBarcodeNotFound = errors.New("barcode not found")
+
+// ErrBarcodeTaken is returned when a product already carries the barcode
+var ErrBarcodeTaken = errors.New("barcode already exists")
+
+type PostgresBarcodeRepository interface {
+	Save(b *entity.Barcode) error
+	FindByGTIN(gtin string) (*entity.Barcode, error)
+	FindByProductID(productID uint) ([]*entity.Barcode, error)
+	Delete(gtin string) error
+}
+
+type postgresBarcodeRepository struct {
+	DB DB
+}
+
+func NewPostgresBarcodeRepository(db DB) PostgresBarcodeRepository {
+	return &postgresBarcodeRepository{DB: db}
+}
+
+// Save assigns a barcode to its product
+func (r *postgresBarcodeRepository) Save(b *entity.Barcode) error {
+	modelBarcode := &model.ProductBarcode{GTIN: b.GTIN(), Code: b.Code(), ProductID: b.ProductID(), CreatedAt: b.CreatedAt()}
+	if err := r.DB.Create(modelBarcode).Error; err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return ErrBarcodeTaken
+		case isForeignKeyViolation(err, "product_barcodes_product_id_fkey"):
+			return ErrProductNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// FindByGTIN fetches the barcode stored under a 14 digit GTIN
+func (r *postgresBarcodeRepository) FindByGTIN(gtin string) (*entity.Barcode, error) {
+	var modelBarcode model.ProductBarcode
+	if err := r.DB.Where("gtin = ?", gtin).First(&modelBarcode).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBarcodeNotFound
+		}
+		return nil, err
+	}
+	return barcodeModelToEntity(&modelBarcode)
+}
+
+// FindByProductID returns the barcodes of a product in the order they were assigned
+func (r *postgresBarcodeRepository) FindByProductID(productID uint) ([]*entity.Barcode, error) {
+	var modelBarcodes []model.ProductBarcode
+	if err := r.DB.Where("product_id = ?", productID).Order("created_at asc, gtin asc").Find(&modelBarcodes).Error; err != nil {
+		return nil, err
+	}
+
+	barcodes := make([]*entity.Barcode, len(modelBarcodes))
+	for i := range modelBarcodes {
+		barcode, err := barcodeModelToEntity(&modelBarcodes[i])
+		if err != nil {
+			return nil, err
+		}
+		barcodes[i] = barcode
+	}
+	return barcodes, nil
+}
+
+// Delete removes the barcode stored under a 14 digit GTIN
+func (r *postgresBarcodeRepository) Delete(gtin string) error {
+	result := r.DB.Where("gtin = ?", gtin).Delete(&model.ProductBarcode{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBarcodeNotFound
+	}
+	return nil
+}
+
+// Convert model.ProductBarcode to entity.Barcode for returning from the database
+func barcodeModelToEntity(m *model.ProductBarcode) (*entity.Barcode, error) {
+	barcode := &entity.Barcode{}
+	if err := barcode.MakeBarcode(m.ProductID, m.Code, m.CreatedAt); err != nil {
+		return nil, err
+	}
+	return barcode, nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCategoryNotFound is returned when a category is not found in the database
+var ErrCategoryNotFound = errors.New("category not found")
+
+// ErrCategoryNameTaken is returned when a sibling category already uses the name
+var ErrCategoryNameTaken = errors.New("category name already exists below the parent")
+
+type PostgresCategoryRepository interface {
+	Save(c *entity.Category) error
+	FindByID(id uint) (*entity.Category, error)
+	FindForUpdate(id uint) (*entity.Category, error)
+	FindByIDs(ids []uint) ([]*entity.Category, error)
+	FindChildren(parentID uint) ([]*entity.Category, error)
+	ListCategories() ([]*entity.Category, error)
+	MoveSubtree(oldPath string, newPath string) error
+	CountChildren(id uint) (int64, error)
+	CountProducts(id uint) (int64, error)
+	Delete(id uint) error
+}
+
+type postgresCategoryRepository struct {
+	DB DB
+}
+
+func NewPostgresCategoryRepository(db DB) PostgresCategoryRepository {
+	return &postgresCategoryRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresCategoryRepository) Save(c *entity.Category) error {
+	modelCategory := categoryEntityToModel(c)
+
+	if err := r.DB.Save(modelCategory).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrCategoryNameTaken
+		}
+		return err
+	}
+
+	category, err := categoryModelToEntity(modelCategory)
+	if err != nil {
+		return err
+	}
+	*c = *category
+	return nil
+}
+
+// FindByID fetches a category from the database, converts model to entity, and returns it
+func (r *postgresCategoryRepository) FindByID(id uint) (*entity.Category, error) {
+	var modelCategory model.Category
+	if err := r.DB.First(&modelCategory, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return categoryModelToEntity(&modelCategory)
+}
+
+// FindForUpdate fetches a category and locks its row until the surrounding transaction ends.
+// It must be called inside a unit of work.
+func (r *postgresCategoryRepository) FindForUpdate(id uint) (*entity.Category, error) {
+	var modelCategory model.Category
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).First(&modelCategory, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return categoryModelToEntity(&modelCategory)
+}
+
+// FindByIDs fetches the given categories ordered from the root down. Unknown IDs are skipped.
+func (r *postgresCategoryRepository) FindByIDs(ids []uint) ([]*entity.Category, error) {
+	if len(ids) == 0 {
+		return []*entity.Category{}, nil
+	}
+	var modelCategories []model.Category
+	if err := r.DB.Where("id IN ?", ids).Order("path asc").Find(&modelCategories).Error; err != nil {
+		return nil, err
+	}
+	return categoryModelsToEntities(modelCategories)
+}
+
+// FindChildren returns the direct children of a category ordered by name
+func (r *postgresCategoryRepository) FindChildren(parentID uint) ([]*entity.Category, error) {
+	var modelCategories []model.Category
+	if err := r.DB.Where("parent_id = ?", parentID).Order("name asc").Find(&modelCategories).Error; err != nil {
+		return nil, err
+	}
+	return categoryModelsToEntities(modelCategories)
+}
+
+// ListCategories returns every category so that parents come before their children and siblings
+// are ordered by name
+func (r *postgresCategoryRepository) ListCategories() ([]*entity.Category, error) {
+	var modelCategories []model.Category
+	if err := r.DB.Order("path asc").Order("name asc").Find(&modelCategories).Error; err != nil {
+		return nil, err
+	}
+	return categoryModelsToEntities(modelCategories)
+}
+
+// MoveSubtree rewrites the paths of every category below oldPath to start with newPath instead.
+// It must be called inside a unit of work together with saving the moved category.
+func (r *postgresCategoryRepository) MoveSubtree(oldPath string, newPath string) error {
+	// Paths only hold digits and slashes, so the prefix needs no escaping for LIKE
+	return r.DB.Model(&model.Category{}).
+		Where("path LIKE ?", oldPath+"%").
+		Update("path", gorm.Expr("? || substr(path, ?)", newPath, len(oldPath)+1)).Error
+}
+
+// CountChildren returns how many direct children a category has
+func (r *postgresCategoryRepository) CountChildren(id uint) (int64, error) {
+	var count int64
+	err := r.DB.Model(&model.Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// CountProducts returns how many products are filed directly under a category. Soft-deleted
+// products count too since they still refer to it.
+func (r *postgresCategoryRepository) CountProducts(id uint) (int64, error) {
+	var count int64
+	err := r.DB.Unscoped().Model(&model.Product{}).Where("category_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// Delete removes a category
+func (r *postgresCategoryRepository) Delete(id uint) error {
+	result := r.DB.Where("id = ?", id).Delete(&model.Category{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
+// Convert entity.Category to model.Category for saving to the database
+func categoryEntityToModel(c *entity.Category) *model.Category {
+	return &model.Category{
+		ID:        c.ID(),
+		ParentID:  categoryIDToModel(c.ParentID()),
+		Name:      c.Name(),
+		Path:      c.Path(),
+		CreatedAt: c.CreatedAt(),
+		UpdatedAt: c.UpdatedAt(),
+	}
+}
+
+// Convert model.Category to entity.Category for returning from the database
+func categoryModelToEntity(m *model.Category) (*entity.Category, error) {
+	c := &entity.Category{}
+	if err := c.MakeCategory(m.ID, categoryIDFromModel(m.ParentID), m.Name, m.Path, m.CreatedAt, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Convert a slice of model.Category to entities, keeping their order
+func categoryModelsToEntities(modelCategories []model.Category) ([]*entity.Category, error) {
+	categories := make([]*entity.Category, len(modelCategories))
+	for i := range modelCategories {
+		category, err := categoryModelToEntity(&modelCategories[i])
+		if err != nil {
+			return nil, err
+		}
+		categories[i] = category
+	}
+	return categories, nil
+}
+
+// categoryIDToModel stores a missing category, zero in the entities, as NULL
+func categoryIDToModel(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// categoryIDFromModel reads a nullable category reference back as zero when it is NULL
+func categoryIDFromModel(id *uint) uint {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
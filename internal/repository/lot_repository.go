@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrLotNotFound is returned when a lot is not found in the database
+var ErrLotNotFound = errors.New("lot not found")
+
+// ExpiringLotFilter narrows down the lots returned from the expiring-soon report
+type ExpiringLotFilter struct {
+	ExpiresBefore time.Time // Lots expiring at or after this moment are left out
+	WarehouseID   uint      // Zero means every warehouse
+	Limit         int
+	Offset        int
+}
+
+type PostgresLotRepository interface {
+	Save(l *entity.Lot) error
+	FindByNumber(productID uint, warehouseID uint, lotNumber string) (*entity.Lot, error)
+	FindInStock(productID uint, warehouseID uint) ([]*entity.Lot, error)
+	FindByProductID(productID uint) ([]*entity.Lot, error)
+	SumExpiredQuantity(productID uint, warehouseID uint, now time.Time) (int64, error)
+	ListExpiringLots(filter ExpiringLotFilter) ([]*entity.Lot, int64, error)
+}
+
+type postgresLotRepository struct {
+	DB DB
+}
+
+func NewPostgresLotRepository(db DB) PostgresLotRepository {
+	return &postgresLotRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresLotRepository) Save(l *entity.Lot) error {
+	modelLot := lotEntityToModel(l)
+	if err := r.DB.Save(modelLot).Error; err != nil {
+		return err
+	}
+
+	lot, err := lotModelToEntity(modelLot)
+	if err != nil {
+		return err
+	}
+	*l = *lot
+	return nil
+}
+
+// FindByNumber fetches a lot of a product in a warehouse by its lot number
+func (r *postgresLotRepository) FindByNumber(productID uint, warehouseID uint, lotNumber string) (*entity.Lot, error) {
+	var modelLot model.Lot
+	err := r.DB.Where("product_id = ? AND warehouse_id = ? AND lot_number = ?", productID, warehouseID, lotNumber).
+		First(&modelLot).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLotNotFound
+		}
+		return nil, err
+	}
+	return lotModelToEntity(&modelLot)
+}
+
+// FindInStock returns the lots of a product in a warehouse that still hold stock, first-expiring first.
+// Callers lock the stock level of the lots beforehand so that concurrent movements cannot consume the same lot.
+func (r *postgresLotRepository) FindInStock(productID uint, warehouseID uint) ([]*entity.Lot, error) {
+	var modelLots []model.Lot
+	err := r.DB.Where("product_id = ? AND warehouse_id = ? AND quantity > 0", productID, warehouseID).
+		Order("expires_at asc, id asc").
+		Find(&modelLots).Error
+	if err != nil {
+		return nil, err
+	}
+	return lotModelsToEntities(modelLots)
+}
+
+// FindByProductID returns every lot of a product across all warehouses, first-expiring first
+func (r *postgresLotRepository) FindByProductID(productID uint) ([]*entity.Lot, error) {
+	var modelLots []model.Lot
+	if err := r.DB.Where("product_id = ?", productID).Order("expires_at asc, id asc").Find(&modelLots).Error; err != nil {
+		return nil, err
+	}
+	return lotModelsToEntities(modelLots)
+}
+
+// SumExpiredQuantity returns the quantity a product still holds in expired lots of a warehouse
+func (r *postgresLotRepository) SumExpiredQuantity(productID uint, warehouseID uint, now time.Time) (int64, error) {
+	var expired int64
+	err := r.DB.Model(&model.Lot{}).
+		Select("CAST(COALESCE(SUM(quantity), 0) AS BIGINT)").
+		Where("product_id = ? AND warehouse_id = ? AND expires_at <= ?", productID, warehouseID, now).
+		Scan(&expired).Error
+	return expired, err
+}
+
+// ListExpiringLots returns a page of lots holding stock that expire before the filter's cut-off,
+// first-expiring first, with the total number of matching rows
+func (r *postgresLotRepository) ListExpiringLots(filter ExpiringLotFilter) ([]*entity.Lot, int64, error) {
+	var total int64
+	if err := r.applyFilter(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var modelLots []model.Lot
+	err := r.applyFilter(filter).
+		Order("expires_at asc, id asc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&modelLots).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lots, err := lotModelsToEntities(modelLots)
+	if err != nil {
+		return nil, 0, err
+	}
+	return lots, total, nil
+}
+
+// applyFilter builds the query shared by the list and count queries
+func (r *postgresLotRepository) applyFilter(filter ExpiringLotFilter) *gorm.DB {
+	query := r.DB.Model(&model.Lot{}).Where("quantity > 0 AND expires_at < ?", filter.ExpiresBefore)
+	if filter.WarehouseID != 0 {
+		query = query.Where("warehouse_id = ?", filter.WarehouseID)
+	}
+	return query
+}
+
+// Convert entity.Lot to model.Lot for saving to the database
+func lotEntityToModel(l *entity.Lot) *model.Lot {
+	return &model.Lot{
+		ID:             l.ID(),
+		ProductID:      l.ProductID(),
+		WarehouseID:    l.WarehouseID(),
+		LotNumber:      l.LotNumber(),
+		ManufacturedAt: l.ManufacturedAt(),
+		ExpiresAt:      l.ExpiresAt(),
+		Quantity:       l.Quantity(),
+		CreatedAt:      l.CreatedAt(),
+		UpdatedAt:      l.UpdatedAt(),
+	}
+}
+
+// Convert a slice of model.Lot to entity.Lot
+func lotModelsToEntities(modelLots []model.Lot) ([]*entity.Lot, error) {
+	lots := make([]*entity.Lot, len(modelLots))
+	for i := range modelLots {
+		lot, err := lotModelToEntity(&modelLots[i])
+		if err != nil {
+			return nil, err
+		}
+		lots[i] = lot
+	}
+	return lots, nil
+}
+
+// Convert model.Lot to entity.Lot for returning from the database
+func lotModelToEntity(m *model.Lot) (*entity.Lot, error) {
+	l := &entity.Lot{}
+	if err := l.MakeLot(m.ID, m.ProductID, m.WarehouseID, m.LotNumber, m.ManufacturedAt, m.ExpiresAt, m.Quantity, m.CreatedAt, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
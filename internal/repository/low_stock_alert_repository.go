@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// LowStockAlertFilter narrows down the low-stock alerts returned from a listing
+type LowStockAlertFilter struct {
+	Status      entity.LowStockAlertStatus // Empty means every status
+	WarehouseID uint                       // Zero means every warehouse
+	Limit       int
+	Offset      int
+}
+
+type PostgresLowStockAlertRepository interface {
+	Save(a *entity.LowStockAlert) error
+	ListOpen() ([]*entity.LowStockAlert, error)
+	ListLowStockAlerts(filter LowStockAlertFilter) ([]*entity.LowStockAlert, int64, error)
+}
+
+type postgresLowStockAlertRepository struct {
+	DB DB
+}
+
+func NewPostgresLowStockAlertRepository(db DB) PostgresLowStockAlertRepository {
+	return &postgresLowStockAlertRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresLowStockAlertRepository) Save(a *entity.LowStockAlert) error {
+	modelAlert := lowStockAlertEntityToModel(a)
+	if err := r.DB.Save(modelAlert).Error; err != nil {
+		return err
+	}
+
+	alert, err := lowStockAlertModelToEntity(modelAlert)
+	if err != nil {
+		return err
+	}
+	*a = *alert
+	return nil
+}
+
+// ListOpen returns every alert that has not been resolved yet
+func (r *postgresLowStockAlertRepository) ListOpen() ([]*entity.LowStockAlert, error) {
+	var modelAlerts []model.LowStockAlert
+	if err := r.DB.Where("status = ?", string(entity.LowStockAlertStatusOpen)).Order("id asc").Find(&modelAlerts).Error; err != nil {
+		return nil, err
+	}
+	return lowStockAlertModelsToEntities(modelAlerts)
+}
+
+// ListLowStockAlerts returns a page of alerts, newest first, with the total number of matching rows
+func (r *postgresLowStockAlertRepository) ListLowStockAlerts(filter LowStockAlertFilter) ([]*entity.LowStockAlert, int64, error) {
+	var total int64
+	if err := r.applyFilter(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var modelAlerts []model.LowStockAlert
+	err := r.applyFilter(filter).
+		Order("id desc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&modelAlerts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	alerts, err := lowStockAlertModelsToEntities(modelAlerts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return alerts, total, nil
+}
+
+// applyFilter builds the query shared by the list and count queries
+func (r *postgresLowStockAlertRepository) applyFilter(filter LowStockAlertFilter) *gorm.DB {
+	query := r.DB.Model(&model.LowStockAlert{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.WarehouseID != 0 {
+		query = query.Where("warehouse_id = ?", filter.WarehouseID)
+	}
+	return query
+}
+
+// Convert entity.LowStockAlert to model.LowStockAlert for saving to the database
+func lowStockAlertEntityToModel(a *entity.LowStockAlert) *model.LowStockAlert {
+	modelAlert := &model.LowStockAlert{
+		ID:                a.ID(),
+		ProductID:         a.ProductID(),
+		WarehouseID:       a.WarehouseID(),
+		Available:         a.Available(),
+		ReorderPoint:      a.ReorderPoint(),
+		SafetyStock:       a.SafetyStock(),
+		SuggestedQuantity: a.SuggestedQuantity(),
+		Status:            string(a.Status()),
+		RaisedAt:          a.RaisedAt(),
+		ResolvedAt:        a.ResolvedAt(),
+	}
+	if purchaseOrderID := a.PurchaseOrderID(); purchaseOrderID != 0 {
+		modelAlert.PurchaseOrderID = &purchaseOrderID
+	}
+	return modelAlert
+}
+
+// Convert a slice of model.LowStockAlert to entity.LowStockAlert
+func lowStockAlertModelsToEntities(modelAlerts []model.LowStockAlert) ([]*entity.LowStockAlert, error) {
+	alerts := make([]*entity.LowStockAlert, len(modelAlerts))
+	for i := range modelAlerts {
+		alert, err := lowStockAlertModelToEntity(&modelAlerts[i])
+		if err != nil {
+			return nil, err
+		}
+		alerts[i] = alert
+	}
+	return alerts, nil
+}
+
+// Convert model.LowStockAlert to entity.LowStockAlert for returning from the database
+func lowStockAlertModelToEntity(m *model.LowStockAlert) (*entity.LowStockAlert, error) {
+	var purchaseOrderID uint
+	if m.PurchaseOrderID != nil {
+		purchaseOrderID = *m.PurchaseOrderID
+	}
+
+	a := &entity.LowStockAlert{}
+	if err := a.MakeLowStockAlert(
+		m.ID,
+		m.ProductID,
+		m.WarehouseID,
+		m.Available,
+		m.ReorderPoint,
+		m.SafetyStock,
+		m.SuggestedQuantity,
+		purchaseOrderID,
+		entity.LowStockAlertStatus(m.Status),
+		m.RaisedAt,
+		m.ResolvedAt,
+	); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm/clause"
+)
+
+// PostgresOutboxRepository stores the domain events waiting to be delivered by the relay
+type PostgresOutboxRepository interface {
+	Save(e *entity.DomainEvent) error
+	FindPendingForUpdate(limit int) ([]*entity.DomainEvent, error)
+}
+
+type postgresOutboxRepository struct {
+	DB DB
+}
+
+func NewPostgresOutboxRepository(db DB) PostgresOutboxRepository {
+	return &postgresOutboxRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresOutboxRepository) Save(e *entity.DomainEvent) error {
+	modelEvent := outboxEventEntityToModel(e)
+	if err := r.DB.Save(modelEvent).Error; err != nil {
+		return err
+	}
+
+	event, err := outboxEventModelToEntity(modelEvent)
+	if err != nil {
+		return err
+	}
+	*e = *event
+	return nil
+}
+
+// FindPendingForUpdate locks the oldest events that were not delivered yet, in the order they were
+// written. Events locked by another relay are skipped, so relays running side by side never deliver
+// the same batch. It must be called inside a unit of work.
+func (r *postgresOutboxRepository) FindPendingForUpdate(limit int) ([]*entity.DomainEvent, error) {
+	var modelEvents []model.OutboxEvent
+	err := r.DB.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("published_at IS NULL").
+		Order("id asc").
+		Limit(limit).
+		Find(&modelEvents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*entity.DomainEvent, len(modelEvents))
+	for i, modelEvent := range modelEvents {
+		event, err := outboxEventModelToEntity(&modelEvent)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = event
+	}
+	return events, nil
+}
+
+// outboxEventEntityToModel converts a domain event to its row
+func outboxEventEntityToModel(e *entity.DomainEvent) *model.OutboxEvent {
+	return &model.OutboxEvent{
+		ID:            e.ID(),
+		EventType:     string(e.Type()),
+		AggregateType: e.AggregateType(),
+		AggregateID:   e.AggregateID(),
+		Payload:       model.JSONObject(e.Payload()),
+		OccurredAt:    e.OccurredAt(),
+		Attempts:      e.Attempts(),
+		LastError:     e.LastError(),
+		PublishedAt:   e.PublishedAt(),
+	}
+}
+
+// outboxEventModelToEntity converts a row of the outbox to a domain event
+func outboxEventModelToEntity(m *model.OutboxEvent) (*entity.DomainEvent, error) {
+	e := &entity.DomainEvent{}
+	if err := e.MakeDomainEvent(
+		m.ID,
+		entity.EventType(m.EventType),
+		m.AggregateType,
+		m.AggregateID,
+		map[string]interface{}(m.Payload),
+		m.OccurredAt,
+		m.Attempts,
+		m.LastError,
+		m.PublishedAt,
+	); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
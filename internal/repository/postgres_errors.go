@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolationCode is the PostgreSQL error code raised when a unique constraint is violated
+const uniqueViolationCode = "23505"
+
+// foreignKeyViolationCode is the PostgreSQL error code raised when a referenced row does not exist
+const foreignKeyViolationCode = "23503"
+
+// isUniqueViolation reports whether err was caused by a unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// isForeignKeyViolation reports whether err was caused by violating the named foreign key constraint
+func isForeignKeyViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode && pgErr.ConstraintName == constraint
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrProductAuditRecordNotFound is returned when a product has no audit record at the time asked for
+var ErrProductAuditRecordNotFound = errors.New("product audit record not found")
+
+// ProductAuditFilter selects a page of the audit trail of one product
+type ProductAuditFilter struct {
+	ProductID uint
+	Limit     int
+	Offset    int
+}
+
+// PostgresProductAuditRepository stores the append-only audit trail of product changes
+type PostgresProductAuditRepository interface {
+	Save(r *entity.ProductAuditRecord) error
+	ListRecords(filter ProductAuditFilter) ([]*entity.ProductAuditRecord, int64, error)
+	FindLatestAt(productID uint, at time.Time) (*entity.ProductAuditRecord, error)
+}
+
+type postgresProductAuditRepository struct {
+	DB DB
+}
+
+func NewPostgresProductAuditRepository(db DB) PostgresProductAuditRepository {
+	return &postgresProductAuditRepository{DB: db}
+}
+
+// Save appends a record to the audit trail and updates the entity with the generated values
+func (r *postgresProductAuditRepository) Save(record *entity.ProductAuditRecord) error {
+	modelRecord := productAuditEntityToModel(record)
+	if err := r.DB.Create(modelRecord).Error; err != nil {
+		return err
+	}
+
+	saved, err := productAuditModelToEntity(modelRecord)
+	if err != nil {
+		return err
+	}
+	*record = *saved
+	return nil
+}
+
+// ListRecords returns a page of the audit trail of a product in the order the changes were made,
+// with the total number of records
+func (r *postgresProductAuditRepository) ListRecords(filter ProductAuditFilter) ([]*entity.ProductAuditRecord, int64, error) {
+	var total int64
+	if err := r.DB.Model(&model.ProductAuditRecord{}).Where("product_id = ?", filter.ProductID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var modelRecords []model.ProductAuditRecord
+	err := r.DB.Where("product_id = ?", filter.ProductID).
+		Order("occurred_at asc, id asc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&modelRecords).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	records := make([]*entity.ProductAuditRecord, len(modelRecords))
+	for i, modelRecord := range modelRecords {
+		record, err := productAuditModelToEntity(&modelRecord)
+		if err != nil {
+			return nil, 0, err
+		}
+		records[i] = record
+	}
+	return records, total, nil
+}
+
+// FindLatestAt fetches the last change of a product made at or before the given time
+func (r *postgresProductAuditRepository) FindLatestAt(productID uint, at time.Time) (*entity.ProductAuditRecord, error) {
+	var modelRecord model.ProductAuditRecord
+	err := r.DB.Where("product_id = ? AND occurred_at <= ?", productID, at).
+		Order("occurred_at desc, id desc").
+		First(&modelRecord).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductAuditRecordNotFound
+		}
+		return nil, err
+	}
+	return productAuditModelToEntity(&modelRecord)
+}
+
+// productAuditEntityToModel converts an audit record to its row
+func productAuditEntityToModel(record *entity.ProductAuditRecord) *model.ProductAuditRecord {
+	changes := make(model.AuditChanges, len(record.Changes()))
+	for i, change := range record.Changes() {
+		changes[i] = model.AuditChange{Field: change.Field, Before: change.Before, After: change.After}
+	}
+
+	state := record.State()
+	return &model.ProductAuditRecord{
+		ID:        record.ID(),
+		ProductID: record.ProductID(),
+		Action:    string(record.Action()),
+		Actor:     record.Origin().Actor,
+		RequestID: record.Origin().RequestID,
+		SourceIP:  record.Origin().SourceIP,
+		Changes:   changes,
+		State: model.ProductSnapshot{
+			Name:        state.Name,
+			SKU:         state.SKU,
+			Description: state.Description,
+			Brand:       state.Brand,
+			Status:      string(state.Status),
+			WeightGrams: state.WeightGrams,
+			LengthMM:    state.Dimensions.LengthMM,
+			WidthMM:     state.Dimensions.WidthMM,
+			HeightMM:    state.Dimensions.HeightMM,
+			Tags:        state.Tags,
+			Attributes:  state.Attributes,
+			Serialized:  state.Serialized,
+			StockUnit:   state.StockUnit,
+			CategoryID:  state.CategoryID,
+			DeletedAt:   state.DeletedAt,
+			CreatedAt:   state.CreatedAt,
+			UpdatedAt:   state.UpdatedAt,
+			Version:     state.Version,
+		},
+		OccurredAt: record.OccurredAt(),
+	}
+}
+
+// productAuditModelToEntity converts a row of the audit trail to an audit record
+func productAuditModelToEntity(modelRecord *model.ProductAuditRecord) (*entity.ProductAuditRecord, error) {
+	changes := make([]entity.FieldChange, len(modelRecord.Changes))
+	for i, change := range modelRecord.Changes {
+		changes[i] = entity.FieldChange{Field: change.Field, Before: change.Before, After: change.After}
+	}
+
+	snapshot := modelRecord.State
+	state := entity.ProductState{
+		Name:        snapshot.Name,
+		SKU:         snapshot.SKU,
+		Description: snapshot.Description,
+		Brand:       snapshot.Brand,
+		Status:      entity.ProductStatus(snapshot.Status),
+		WeightGrams: snapshot.WeightGrams,
+		Dimensions:  entity.Dimensions{LengthMM: snapshot.LengthMM, WidthMM: snapshot.WidthMM, HeightMM: snapshot.HeightMM},
+		Tags:        snapshot.Tags,
+		Attributes:  snapshot.Attributes,
+		Serialized:  snapshot.Serialized,
+		StockUnit:   snapshot.StockUnit,
+		CategoryID:  snapshot.CategoryID,
+		DeletedAt:   snapshot.DeletedAt,
+		CreatedAt:   snapshot.CreatedAt,
+		UpdatedAt:   snapshot.UpdatedAt,
+		Version:     snapshot.Version,
+	}
+
+	record := &entity.ProductAuditRecord{}
+	origin := entity.ChangeOrigin{Actor: modelRecord.Actor, RequestID: modelRecord.RequestID, SourceIP: modelRecord.SourceIP}
+	if err := record.MakeProductAuditRecord(
+		modelRecord.ID,
+		modelRecord.ProductID,
+		entity.AuditAction(modelRecord.Action),
+		origin,
+		changes,
+		state,
+		modelRecord.OccurredAt,
+	); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
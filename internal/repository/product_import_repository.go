@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrProductImportNotFound is returned when a product import is not found in the database
+var ErrProductImportNotFound = errors.New("product import not found")
+
+type PostgresProductImportRepository interface {
+	Save(i *entity.ProductImport) error
+	FindByID(id uint) (*entity.ProductImport, error)
+}
+
+type postgresProductImportRepository struct {
+	DB DB
+}
+
+func NewPostgresProductImportRepository(db DB) PostgresProductImportRepository {
+	return &postgresProductImportRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresProductImportRepository) Save(i *entity.ProductImport) error {
+	modelImport := productImportEntityToModel(i)
+	if err := r.DB.Save(modelImport).Error; err != nil {
+		return err
+	}
+
+	productImport, err := productImportModelToEntity(modelImport)
+	if err != nil {
+		return err
+	}
+	*i = *productImport
+	return nil
+}
+
+// FindByID fetches a product import from the database, converts model to entity, and returns it
+func (r *postgresProductImportRepository) FindByID(id uint) (*entity.ProductImport, error) {
+	var modelImport model.ProductImport
+	if err := r.DB.First(&modelImport, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductImportNotFound
+		}
+		return nil, err
+	}
+	return productImportModelToEntity(&modelImport)
+}
+
+// Convert entity.ProductImport to model.ProductImport for saving to the database
+func productImportEntityToModel(i *entity.ProductImport) *model.ProductImport {
+	rejectedRows := make(model.RejectedRows, len(i.RejectedRows()))
+	for j, row := range i.RejectedRows() {
+		rejectedRows[j] = model.RejectedRow{Line: row.Line, SKU: row.SKU, Name: row.Name, Errors: row.Errors}
+	}
+	return &model.ProductImport{
+		ID:           i.ID(),
+		FileName:     i.FileName(),
+		DryRun:       i.DryRun(),
+		Created:      i.Created(),
+		Updated:      i.Updated(),
+		Unchanged:    i.Unchanged(),
+		RejectedRows: rejectedRows,
+		FailedAtLine: i.FailedAtLine(),
+		CreatedAt:    i.CreatedAt(),
+	}
+}
+
+// Convert model.ProductImport to entity.ProductImport for returning from the database
+func productImportModelToEntity(m *model.ProductImport) (*entity.ProductImport, error) {
+	rejectedRows := make([]entity.RejectedProductRow, len(m.RejectedRows))
+	for j, row := range m.RejectedRows {
+		rejectedRows[j] = entity.RejectedProductRow{Line: row.Line, SKU: row.SKU, Name: row.Name, Errors: row.Errors}
+	}
+	i := &entity.ProductImport{}
+	if err := i.MakeProductImport(m.ID, m.FileName, m.DryRun, m.Created, m.Updated, m.Unchanged, rejectedRows, m.FailedAtLine, m.CreatedAt); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
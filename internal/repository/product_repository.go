@@ -1,11 +1,15 @@
 package repository
 
 import (
+	"crypto/rand"
+	"database/sql"
 	"errors"
 	"inventory_management/internal/entity"
 	"inventory_management/internal/model"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Define an interface for the methods we use from gorm.DB
@@ -18,46 +22,178 @@ type DB interface {
 	Limit(value int) *gorm.DB
 	Offset(value int) *gorm.DB
 	Find(dest interface{}, conds ...interface{}) *gorm.DB
+	Create(value interface{}) *gorm.DB
+	Clauses(conds ...clause.Expression) *gorm.DB
+	Transaction(fc func(tx *gorm.DB) error, opts ...*sql.TxOptions) error
+	Delete(value interface{}, conds ...interface{}) *gorm.DB
+	Unscoped() *gorm.DB
 }
 
 // ErrProductNotFound is returned when a product is not found in the database
 var ErrProductNotFound = errors.New("product not found")
 
+// ErrSKUTaken is returned when another product already uses the SKU, or when no free SKU was
+// generated within maxSKUAttempts
+var ErrSKUTaken = errors.New("sku already exists")
+
+// ErrProductVersionConflict is returned when a product was changed by someone else after it was read
+var ErrProductVersionConflict = errors.New("product version conflict")
+
+// maxSKUAttempts is how many SKUs Save generates for a new product before giving up
+const maxSKUAttempts = 10
+
+// ProductListFilter narrows down and orders the products returned from a listing
+type ProductListFilter struct {
+	SearchTerm     string // Matches part of the name, SKU or a barcode, empty means every product
+	CategoryID     uint   // Category whose subtree the products are filed under, zero means every category
+	SortBy         string
+	SortDirection  string
+	Limit          int
+	Offset         int
+	IncludeDeleted bool // Lists soft-deleted products as well
+}
+
+// PostgresProductRepository stores products. Soft-deleted products are only found by
+// FindByIDIncludingDeleted, FindBySKUIncludingDeleted and by listings that include them.
 type PostgresProductRepository interface {
 	Save(p *entity.Product) error
 	FindByID(id uint) (*entity.Product, error)
-	ListProducts(searchTerm string, sortBy string, sortDirection string, limit int, offset int) ([]*entity.Product, error)
+	FindByIDIncludingDeleted(id uint) (*entity.Product, error)
+	FindForUpdate(id uint) (*entity.Product, error)
+	FindForUpdateIncludingDeleted(id uint) (*entity.Product, error)
+	FindBySKU(sku string) (*entity.Product, error)
+	FindBySKUIncludingDeleted(sku string) (*entity.Product, error)
+	ListProducts(filter ProductListFilter) ([]*entity.Product, int64, error)
+	StreamProducts(filter ProductListFilter, fn func(p *entity.Product) error) error
+	Restore(id uint) error
 }
 
 type postgresProductRepository struct {
-	DB DB // Use the interface instead of the concrete gorm.DB type
+	DB           DB // Use the interface instead of the concrete gorm.DB type
+	skuGenerator entity.SKUGenerator
 }
 
-func NewPostgresProductRepository(db DB) PostgresProductRepository {
-	return &postgresProductRepository{DB: db}
+// ProductRepositoryOption configures a product repository
+type ProductRepositoryOption func(r *postgresProductRepository)
+
+// WithSKUGenerator sets how Save generates the SKU of new products that have none. The default is
+// the random strategy.
+func WithSKUGenerator(generator entity.SKUGenerator) ProductRepositoryOption {
+	return func(r *postgresProductRepository) {
+		r.skuGenerator = generator
+	}
 }
 
-// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func NewPostgresProductRepository(db DB, options ...ProductRepositoryOption) PostgresProductRepository {
+	r := &postgresProductRepository{DB: db, skuGenerator: entity.NewRandomSKUGenerator(rand.Read)}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values.
+// A new product without a SKU gets one from the SKU generator. Products without a name are left to
+// fail validation below. An existing product is only updated while it is still at the version it was
+// read at, otherwise ErrProductVersionConflict is returned.
 func (r *postgresProductRepository) Save(p *entity.Product) error {
 	modelProduct := entityToModel(p)
 
-	if err := r.DB.Save(modelProduct).Error; err != nil {
-		return err
+	var err error
+	switch {
+	case modelProduct.ID != 0:
+		err = r.update(modelProduct)
+	case modelProduct.SKU == "" && modelProduct.Name != "":
+		modelProduct.Version = 1
+		err = r.createWithGeneratedSKU(modelProduct)
+	default:
+		modelProduct.Version = 1
+		err = r.DB.Save(modelProduct).Error
+	}
+	if err != nil {
+		return translateProductSaveError(err)
 	}
 
-	if err := p.MakeProduct(
-		modelProduct.ID,
-		modelProduct.Name,
-		modelProduct.SKU,
-		modelProduct.CreatedAt,
-		modelProduct.UpdatedAt,
-	); err != nil {
+	saved, err := modelToEntity(modelProduct)
+	if err != nil {
 		return err
 	}
+	*p = *saved
+	return nil
+}
 
+// update writes every column of an existing product in a single conditional UPDATE that only matches
+// the version the product was read at, and moves the product to the next version
+func (r *postgresProductRepository) update(modelProduct *model.Product) error {
+	readVersion := modelProduct.Version
+	modelProduct.Version = readVersion + 1
+
+	result := r.DB.Model(modelProduct).Where("version = ?", readVersion).Select("*").Omit("id", "created_at").Updates(modelProduct)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProductVersionConflict
+	}
 	return nil
 }
 
+// createWithGeneratedSKU inserts a new product under generated SKUs until one is free. Taken SKUs are
+// skipped with ON CONFLICT DO NOTHING rather than failing, which would abort a surrounding transaction.
+func (r *postgresProductRepository) createWithGeneratedSKU(modelProduct *model.Product) error {
+	input := entity.SKUInput{Name: modelProduct.Name, Now: time.Now()}
+	if modelProduct.CategoryID != nil {
+		var category model.Category
+		if err := r.DB.First(&category, *modelProduct.CategoryID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCategoryNotFound
+			}
+			return err
+		}
+		input.CategoryName = category.Name
+	}
+
+	sequence := NewPostgresSKUSequence(r.DB)
+	for attempt := 0; attempt < maxSKUAttempts; attempt++ {
+		sku, err := r.skuGenerator.GenerateSKU(input, sequence)
+		if err != nil {
+			return err
+		}
+		modelProduct.SKU = sku
+
+		// Former SKUs still resolve to their products and are never handed out again
+		var aliases int64
+		if err := r.DB.Model(&model.ProductSKUAlias{}).Where("sku = ?", sku).Count(&aliases).Error; err != nil {
+			return err
+		}
+		if aliases > 0 {
+			continue
+		}
+
+		result := r.DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "sku"}}, DoNothing: true}).Create(modelProduct)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil
+		}
+	}
+	return ErrSKUTaken
+}
+
+// translateProductSaveError maps constraint violations of the products table to repository errors
+func translateProductSaveError(err error) error {
+	switch {
+	case isForeignKeyViolation(err, "products_stock_unit_fkey"):
+		return ErrUnitOfMeasureNotFound
+	case isForeignKeyViolation(err, "products_category_id_fkey"):
+		return ErrCategoryNotFound
+	case isUniqueViolation(err):
+		return ErrSKUTaken
+	}
+	return err
+}
+
 // FindByID fetches a product from the database, converts model to entity, and returns it
 func (r *postgresProductRepository) FindByID(id uint) (*entity.Product, error) {
 	var modelProduct model.Product
@@ -71,24 +207,102 @@ func (r *postgresProductRepository) FindByID(id uint) (*entity.Product, error) {
 	return modelToEntity(&modelProduct)
 }
 
-// New method to list products with search, sorting, and pagination
-func (r *postgresProductRepository) ListProducts(searchTerm string, sortBy string, sortDirection string, limit int, offset int) ([]*entity.Product, error) {
-	var modelProducts []model.Product
+// FindByIDIncludingDeleted fetches a product whether or not it was soft-deleted
+func (r *postgresProductRepository) FindByIDIncludingDeleted(id uint) (*entity.Product, error) {
+	var modelProduct model.Product
+	if err := r.DB.Unscoped().First(&modelProduct, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return modelToEntity(&modelProduct)
+}
 
-	query := r.DB.Model(&model.Product{})
+// FindForUpdate fetches a product and locks its row until the surrounding transaction ends.
+// It must be called inside a unit of work.
+func (r *postgresProductRepository) FindForUpdate(id uint) (*entity.Product, error) {
+	var modelProduct model.Product
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).First(&modelProduct, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return modelToEntity(&modelProduct)
+}
 
-	// Apply search filter if a search term is provided
-	if searchTerm != "" {
-		query = query.Where("name LIKE ? OR sku LIKE ?", "%"+searchTerm+"%", "%"+searchTerm+"%")
+// FindForUpdateIncludingDeleted fetches and locks a product whether or not it was soft-deleted.
+// It must be called inside a unit of work.
+func (r *postgresProductRepository) FindForUpdateIncludingDeleted(id uint) (*entity.Product, error) {
+	var modelProduct model.Product
+	if err := r.DB.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&modelProduct, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
 	}
+	return modelToEntity(&modelProduct)
+}
+
+// FindBySKU fetches the product currently carrying a SKU. Former SKUs are resolved through the
+// SKU alias repository.
+func (r *postgresProductRepository) FindBySKU(sku string) (*entity.Product, error) {
+	var modelProduct model.Product
+	if err := r.DB.Where("sku = ?", sku).First(&modelProduct).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return modelToEntity(&modelProduct)
+}
+
+// FindBySKUIncludingDeleted fetches the product carrying a SKU whether or not it was soft-deleted.
+// A deleted product keeps its SKU, so no other product can take it.
+func (r *postgresProductRepository) FindBySKUIncludingDeleted(sku string) (*entity.Product, error) {
+	var modelProduct model.Product
+	if err := r.DB.Unscoped().Where("sku = ?", sku).First(&modelProduct).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return modelToEntity(&modelProduct)
+}
+
+// Restore brings back a soft-deleted product and moves it to the next version
+func (r *postgresProductRepository) Restore(id uint) error {
+	result := r.DB.Unscoped().Model(&model.Product{}).Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+// ListProducts lists products with search, category, sorting, and pagination and returns the total number of matching rows
+func (r *postgresProductRepository) ListProducts(filter ProductListFilter) ([]*entity.Product, int64, error) {
+	var modelProducts []model.Product
+
+	// Count every row matching the filters, ignoring pagination
+	var total int64
+	if err := applyProductFilters(r.scoped(filter.IncludeDeleted).Model(&model.Product{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := applyProductFilters(r.scoped(filter.IncludeDeleted).Model(&model.Product{}), filter)
 
 	// Apply sorting
-	query = query.Order(sortBy + " " + sortDirection)
+	query = query.Order(filter.SortBy + " " + filter.SortDirection)
 
 	// Apply pagination
-	err := query.Limit(limit).Offset(offset).Find(&modelProducts).Error
+	err := query.Limit(filter.Limit).Offset(filter.Offset).Find(&modelProducts).Error
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// Convert modelProducts to entity.Products
@@ -96,22 +310,100 @@ func (r *postgresProductRepository) ListProducts(searchTerm string, sortBy strin
 	for i, modelProduct := range modelProducts {
 		entityProduct, err := modelToEntity(&modelProduct)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		entityProducts[i] = entityProduct
 	}
 
-	return entityProducts, nil
+	return entityProducts, total, nil
+}
+
+// StreamProducts calls fn with every product matching the search and category filters, sorted as the
+// filter asks or by ID when it names no column. Limit and offset are ignored. Rows are read off the
+// database cursor as fn consumes them, so the matching products are never held in memory together.
+// An error from fn stops the iteration and is returned.
+func (r *postgresProductRepository) StreamProducts(filter ProductListFilter, fn func(p *entity.Product) error) error {
+	query := applyProductFilters(r.scoped(filter.IncludeDeleted).Model(&model.Product{}), filter)
+	if filter.SortBy != "" {
+		// Products sharing a name are kept in a stable order
+		query = query.Order(filter.SortBy + " " + filter.SortDirection).Order("id asc")
+	} else {
+		query = query.Order("id " + filter.SortDirection)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var modelProduct model.Product
+		if err := query.ScanRows(rows, &modelProduct); err != nil {
+			return err
+		}
+		product, err := modelToEntity(&modelProduct)
+		if err != nil {
+			return err
+		}
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// scoped returns the database handle for product queries, leaving out soft-deleted products unless
+// includeDeleted is set
+func (r *postgresProductRepository) scoped(includeDeleted bool) DB {
+	if includeDeleted {
+		return r.DB.Unscoped()
+	}
+	return r.DB
+}
+
+// applyProductFilters applies the search and category filters shared by the list and count queries
+func applyProductFilters(query *gorm.DB, filter ProductListFilter) *gorm.DB {
+	if filter.SearchTerm != "" {
+		term := "%" + filter.SearchTerm + "%"
+		query = query.Where(
+			"name LIKE ? OR sku LIKE ? OR id IN (SELECT product_id FROM product_barcodes WHERE code LIKE ?)",
+			term, term, term,
+		)
+	}
+	if filter.CategoryID != 0 {
+		// The category itself and every category whose path starts below it
+		query = query.Where(
+			"category_id IN (SELECT c.id FROM categories c JOIN categories root ON root.id = ? "+
+				"WHERE c.id = root.id OR c.path LIKE root.path || root.id || '/%')",
+			filter.CategoryID,
+		)
+	}
+	return query
 }
 
 // Convert entity.Product to model.Product for saving to the database
 func entityToModel(entityProduct *entity.Product) *model.Product {
 	return &model.Product{
-		ID:        entityProduct.ID(),
-		Name:      entityProduct.Name(),
-		SKU:       entityProduct.SKU(),
-		CreatedAt: entityProduct.CreatedAt(),
-		UpdatedAt: entityProduct.UpdatedAt(),
+		ID:          entityProduct.ID(),
+		Name:        entityProduct.Name(),
+		SKU:         entityProduct.SKU(),
+		Description: entityProduct.Description(),
+		Brand:       entityProduct.Brand(),
+		Status:      string(entityProduct.Status()),
+		WeightGrams: entityProduct.WeightGrams(),
+		LengthMM:    entityProduct.Dimensions().LengthMM,
+		WidthMM:     entityProduct.Dimensions().WidthMM,
+		HeightMM:    entityProduct.Dimensions().HeightMM,
+		Tags:        entityProduct.Tags(),
+		Attributes:  entityProduct.Attributes(),
+		DeletedAt:   deletedAtToModel(entityProduct.DeletedAt()),
+		Version:     entityProduct.Version(),
+		Serialized:  entityProduct.IsSerialized(),
+		StockUnit:   entityProduct.StockUnit(),
+		CategoryID:  categoryIDToModel(entityProduct.CategoryID()),
+		CreatedAt:   entityProduct.CreatedAt(),
+		UpdatedAt:   entityProduct.UpdatedAt(),
 	}
 }
 
@@ -128,5 +420,44 @@ func modelToEntity(modelProduct *model.Product) (*entity.Product, error) {
 	); err != nil {
 		return nil, err
 	}
+	entityProduct.SetSerialized(modelProduct.Serialized)
+	entityProduct.SetStockUnit(modelProduct.StockUnit)
+	entityProduct.SetCategory(categoryIDFromModel(modelProduct.CategoryID))
+	if err := entityProduct.SetDescription(modelProduct.Description); err != nil {
+		return nil, err
+	}
+	if err := entityProduct.SetBrand(modelProduct.Brand); err != nil {
+		return nil, err
+	}
+	if modelProduct.Status != "" {
+		if err := entityProduct.SetStatus(entity.ProductStatus(modelProduct.Status)); err != nil {
+			return nil, err
+		}
+	}
+	if err := entityProduct.SetWeightGrams(modelProduct.WeightGrams); err != nil {
+		return nil, err
+	}
+	dimensions := entity.Dimensions{LengthMM: modelProduct.LengthMM, WidthMM: modelProduct.WidthMM, HeightMM: modelProduct.HeightMM}
+	if err := entityProduct.SetDimensions(dimensions); err != nil {
+		return nil, err
+	}
+	if err := entityProduct.SetTags(modelProduct.Tags); err != nil {
+		return nil, err
+	}
+	if err := entityProduct.SetAttributes(modelProduct.Attributes); err != nil {
+		return nil, err
+	}
+	if modelProduct.DeletedAt.Valid {
+		entityProduct.MarkDeleted(modelProduct.DeletedAt.Time)
+	}
+	entityProduct.SetVersion(modelProduct.Version)
 	return entityProduct, nil
 }
+
+// deletedAtToModel converts the deletion time of a product to its nullable column
+func deletedAtToModel(deletedAt *time.Time) gorm.DeletedAt {
+	if deletedAt == nil {
+		return gorm.DeletedAt{}
+	}
+	return gorm.DeletedAt{Time: *deletedAt, Valid: true}
+}
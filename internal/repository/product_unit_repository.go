@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrProductUnitNotFound is returned when a product has no conversion for a unit
+var ErrProductUnitNotFound = errors.New("product unit conversion not found")
+
+type PostgresProductUnitRepository interface {
+	Save(u *entity.ProductUnit) error
+	FindByProductAndUnit(productID uint, unitCode string) (*entity.ProductUnit, error)
+	FindByProductID(productID uint) ([]*entity.ProductUnit, error)
+}
+
+type postgresProductUnitRepository struct {
+	DB DB
+}
+
+func NewPostgresProductUnitRepository(db DB) PostgresProductUnitRepository {
+	return &postgresProductUnitRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresProductUnitRepository) Save(u *entity.ProductUnit) error {
+	modelUnit := productUnitEntityToModel(u)
+	if err := r.DB.Save(modelUnit).Error; err != nil {
+		return err
+	}
+
+	unit, err := productUnitModelToEntity(modelUnit)
+	if err != nil {
+		return err
+	}
+	*u = *unit
+	return nil
+}
+
+// FindByProductAndUnit fetches the conversion of a product for one unit
+func (r *postgresProductUnitRepository) FindByProductAndUnit(productID uint, unitCode string) (*entity.ProductUnit, error) {
+	var modelUnit model.ProductUnit
+	err := r.DB.Where("product_id = ? AND unit_code = ?", productID, entity.NormalizeUnitCode(unitCode)).
+		First(&modelUnit).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductUnitNotFound
+		}
+		return nil, err
+	}
+	return productUnitModelToEntity(&modelUnit)
+}
+
+// FindByProductID returns every conversion of a product ordered by size
+func (r *postgresProductUnitRepository) FindByProductID(productID uint) ([]*entity.ProductUnit, error) {
+	var modelUnits []model.ProductUnit
+	if err := r.DB.Where("product_id = ?", productID).Order("factor asc, unit_code asc").Find(&modelUnits).Error; err != nil {
+		return nil, err
+	}
+
+	units := make([]*entity.ProductUnit, len(modelUnits))
+	for i := range modelUnits {
+		unit, err := productUnitModelToEntity(&modelUnits[i])
+		if err != nil {
+			return nil, err
+		}
+		units[i] = unit
+	}
+	return units, nil
+}
+
+// Convert entity.ProductUnit to model.ProductUnit for saving to the database
+func productUnitEntityToModel(u *entity.ProductUnit) *model.ProductUnit {
+	return &model.ProductUnit{
+		ID:        u.ID(),
+		ProductID: u.ProductID(),
+		UnitCode:  u.UnitCode(),
+		Factor:    u.Factor(),
+		CreatedAt: u.CreatedAt(),
+		UpdatedAt: u.UpdatedAt(),
+	}
+}
+
+// Convert model.ProductUnit to entity.ProductUnit for returning from the database
+func productUnitModelToEntity(m *model.ProductUnit) (*entity.ProductUnit, error) {
+	u := &entity.ProductUnit{}
+	if err := u.MakeProductUnit(m.ID, m.ProductID, m.UnitCode, m.Factor, m.CreatedAt, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
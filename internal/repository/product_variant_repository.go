@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrProductVariantNotFound is returned when a product is not a variant of another product
+var ErrProductVariantNotFound = errors.New("product variant not found")
+
+// ErrProductVariantExists is returned when the parent already has a variant with the same options
+var ErrProductVariantExists = errors.New("product variant already exists")
+
+type PostgresProductVariantRepository interface {
+	Save(v *entity.ProductVariant) error
+	FindByProductID(productID uint) (*entity.ProductVariant, error)
+	FindByParentID(parentID uint) ([]*entity.ProductVariant, error)
+}
+
+type postgresProductVariantRepository struct {
+	DB       DB
+	products PostgresProductRepository // Saves the products of new variants
+}
+
+func NewPostgresProductVariantRepository(db DB, products PostgresProductRepository) PostgresProductVariantRepository {
+	return &postgresProductVariantRepository{DB: db, products: products}
+}
+
+// Save writes the product of a new variant, links it to its parent and records its options.
+// It must be called inside a unit of work so the product and its options are written atomically.
+func (r *postgresProductVariantRepository) Save(v *entity.ProductVariant) error {
+	if err := r.products.Save(v.Product()); err != nil {
+		return err
+	}
+
+	modelVariant := &model.ProductVariant{
+		ProductID:  v.Product().ID(),
+		ParentID:   v.ParentID(),
+		VariantKey: v.Key(),
+	}
+	if err := r.DB.Create(modelVariant).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrProductVariantExists
+		}
+		return err
+	}
+
+	modelOptions := variantOptionsToModels(v.Product().ID(), v.Options())
+	for i := range modelOptions {
+		if err := r.DB.Create(&modelOptions[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByProductID fetches the variant a product is, or ErrProductVariantNotFound when the product
+// is not a variant
+func (r *postgresProductVariantRepository) FindByProductID(productID uint) (*entity.ProductVariant, error) {
+	var modelVariant model.ProductVariant
+	if err := r.DB.Where("product_id = ?", productID).First(&modelVariant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductVariantNotFound
+		}
+		return nil, err
+	}
+	variants, err := r.withProductsAndOptions([]model.ProductVariant{modelVariant})
+	if err != nil {
+		return nil, err
+	}
+	return variants[0], nil
+}
+
+// FindByParentID returns the variants of a product in the order they were created
+func (r *postgresProductVariantRepository) FindByParentID(parentID uint) ([]*entity.ProductVariant, error) {
+	var modelVariants []model.ProductVariant
+	if err := r.DB.Where("parent_id = ?", parentID).Order("product_id asc").Find(&modelVariants).Error; err != nil {
+		return nil, err
+	}
+	if len(modelVariants) == 0 {
+		return []*entity.ProductVariant{}, nil
+	}
+	return r.withProductsAndOptions(modelVariants)
+}
+
+// withProductsAndOptions loads the products and options of variants and converts them to entities
+func (r *postgresProductVariantRepository) withProductsAndOptions(modelVariants []model.ProductVariant) ([]*entity.ProductVariant, error) {
+	ids := make([]uint, len(modelVariants))
+	for i, modelVariant := range modelVariants {
+		ids[i] = modelVariant.ProductID
+	}
+
+	// Soft-deleted variants stay listed so their option combinations are not generated again
+	var modelProducts []model.Product
+	if err := r.DB.Unscoped().Where("id IN ?", ids).Find(&modelProducts).Error; err != nil {
+		return nil, err
+	}
+	productsByID := make(map[uint]*model.Product, len(modelProducts))
+	for i := range modelProducts {
+		productsByID[modelProducts[i].ID] = &modelProducts[i]
+	}
+
+	var modelOptions []model.ProductVariantOption
+	if err := r.DB.Where("product_id IN ?", ids).Order("product_id asc").Order("position asc").Find(&modelOptions).Error; err != nil {
+		return nil, err
+	}
+	optionsByProduct := make(map[uint][]model.ProductVariantOption, len(modelVariants))
+	for _, modelOption := range modelOptions {
+		optionsByProduct[modelOption.ProductID] = append(optionsByProduct[modelOption.ProductID], modelOption)
+	}
+
+	variants := make([]*entity.ProductVariant, len(modelVariants))
+	for i, modelVariant := range modelVariants {
+		modelProduct, ok := productsByID[modelVariant.ProductID]
+		if !ok {
+			return nil, ErrProductNotFound
+		}
+		variant, err := productVariantModelToEntity(&modelVariant, modelProduct, optionsByProduct[modelVariant.ProductID])
+		if err != nil {
+			return nil, err
+		}
+		variants[i] = variant
+	}
+	return variants, nil
+}
+
+// Convert the options of a variant to model.ProductVariantOption for saving to the database
+func variantOptionsToModels(productID uint, options []entity.VariantOption) []model.ProductVariantOption {
+	modelOptions := make([]model.ProductVariantOption, len(options))
+	for i, option := range options {
+		modelOptions[i] = model.ProductVariantOption{
+			ProductID: productID,
+			Position:  i,
+			Name:      option.Name(),
+			Value:     option.Value(),
+		}
+	}
+	return modelOptions
+}
+
+// Convert model.ProductVariant with its product and options to entity.ProductVariant for returning from the database
+func productVariantModelToEntity(m *model.ProductVariant, modelProduct *model.Product, modelOptions []model.ProductVariantOption) (*entity.ProductVariant, error) {
+	product, err := modelToEntity(modelProduct)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]entity.VariantOption, len(modelOptions))
+	for i, modelOption := range modelOptions {
+		option, err := entity.MakeVariantOption(modelOption.Name, modelOption.Value)
+		if err != nil {
+			return nil, err
+		}
+		options[i] = option
+	}
+
+	variant := &entity.ProductVariant{}
+	if err := variant.MakeProductVariant(m.ParentID, product, options); err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrPurchaseOrderNotFound is returned when a purchase order is not found in the database
+var ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+
+// PurchaseOrderFilter narrows down the purchase orders returned from a listing
+type PurchaseOrderFilter struct {
+	Status     entity.PurchaseOrderStatus // Empty means every status
+	SupplierID uint                       // Zero means every supplier
+	Limit      int
+	Offset     int
+}
+
+type PostgresPurchaseOrderRepository interface {
+	Save(o *entity.PurchaseOrder) error
+	FindByID(id uint) (*entity.PurchaseOrder, error)
+	FindForUpdate(id uint) (*entity.PurchaseOrder, error)
+	ListPurchaseOrders(filter PurchaseOrderFilter) ([]*entity.PurchaseOrder, int64, error)
+}
+
+type postgresPurchaseOrderRepository struct {
+	DB DB
+}
+
+func NewPostgresPurchaseOrderRepository(db DB) PostgresPurchaseOrderRepository {
+	return &postgresPurchaseOrderRepository{DB: db}
+}
+
+// Save writes the purchase order and its lines and updates the entity with the generated values.
+// It must be called inside a unit of work so the header and lines are written atomically.
+func (r *postgresPurchaseOrderRepository) Save(o *entity.PurchaseOrder) error {
+	modelOrder := purchaseOrderEntityToModel(o)
+	if err := r.DB.Save(modelOrder).Error; err != nil {
+		return err
+	}
+
+	modelLines := purchaseOrderLineEntitiesToModels(modelOrder.ID, o.Lines())
+	for i := range modelLines {
+		if err := r.DB.Save(&modelLines[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	order, err := purchaseOrderModelToEntity(modelOrder, modelLines)
+	if err != nil {
+		return err
+	}
+	*o = *order
+	return nil
+}
+
+// FindByID fetches a purchase order with its lines from the database, converts model to entity, and returns it
+func (r *postgresPurchaseOrderRepository) FindByID(id uint) (*entity.PurchaseOrder, error) {
+	var modelOrder model.PurchaseOrder
+	if err := r.DB.First(&modelOrder, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return r.withLines(&modelOrder)
+}
+
+// FindForUpdate fetches a purchase order and locks its row until the surrounding transaction ends.
+// It must be called inside a unit of work.
+func (r *postgresPurchaseOrderRepository) FindForUpdate(id uint) (*entity.PurchaseOrder, error) {
+	var modelOrder model.PurchaseOrder
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).First(&modelOrder, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return r.withLines(&modelOrder)
+}
+
+// ListPurchaseOrders returns a page of purchase orders, newest first, with the total number of matching rows
+func (r *postgresPurchaseOrderRepository) ListPurchaseOrders(filter PurchaseOrderFilter) ([]*entity.PurchaseOrder, int64, error) {
+	var total int64
+	if err := r.applyFilter(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var modelOrders []model.PurchaseOrder
+	err := r.applyFilter(filter).
+		Order("id desc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&modelOrders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(modelOrders) == 0 {
+		return []*entity.PurchaseOrder{}, total, nil
+	}
+
+	ids := make([]uint, len(modelOrders))
+	for i, modelOrder := range modelOrders {
+		ids[i] = modelOrder.ID
+	}
+	var modelLines []model.PurchaseOrderLine
+	if err := r.DB.Where("purchase_order_id IN ?", ids).Order("id asc").Find(&modelLines).Error; err != nil {
+		return nil, 0, err
+	}
+	linesByOrder := make(map[uint][]model.PurchaseOrderLine, len(modelOrders))
+	for _, modelLine := range modelLines {
+		linesByOrder[modelLine.PurchaseOrderID] = append(linesByOrder[modelLine.PurchaseOrderID], modelLine)
+	}
+
+	orders := make([]*entity.PurchaseOrder, len(modelOrders))
+	for i := range modelOrders {
+		order, err := purchaseOrderModelToEntity(&modelOrders[i], linesByOrder[modelOrders[i].ID])
+		if err != nil {
+			return nil, 0, err
+		}
+		orders[i] = order
+	}
+	return orders, total, nil
+}
+
+// withLines loads the lines of a purchase order and converts both to an entity
+func (r *postgresPurchaseOrderRepository) withLines(modelOrder *model.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	var modelLines []model.PurchaseOrderLine
+	if err := r.DB.Where("purchase_order_id = ?", modelOrder.ID).Order("id asc").Find(&modelLines).Error; err != nil {
+		return nil, err
+	}
+	return purchaseOrderModelToEntity(modelOrder, modelLines)
+}
+
+// applyFilter builds the query shared by the list and count queries
+func (r *postgresPurchaseOrderRepository) applyFilter(filter PurchaseOrderFilter) *gorm.DB {
+	query := r.DB.Model(&model.PurchaseOrder{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.SupplierID != 0 {
+		query = query.Where("supplier_id = ?", filter.SupplierID)
+	}
+	return query
+}
+
+// Convert entity.PurchaseOrder to model.PurchaseOrder for saving to the database
+func purchaseOrderEntityToModel(o *entity.PurchaseOrder) *model.PurchaseOrder {
+	return &model.PurchaseOrder{
+		ID:          o.ID(),
+		SupplierID:  o.SupplierID(),
+		WarehouseID: o.WarehouseID(),
+		Status:      string(o.Status()),
+		Reference:   o.Reference(),
+		Currency:    o.Currency(),
+		SubmittedAt: o.SubmittedAt(),
+		ClosedAt:    o.ClosedAt(),
+		CreatedAt:   o.CreatedAt(),
+		UpdatedAt:   o.UpdatedAt(),
+	}
+}
+
+// Convert the lines of a purchase order to model.PurchaseOrderLine for saving to the database
+func purchaseOrderLineEntitiesToModels(purchaseOrderID uint, lines []*entity.PurchaseOrderLine) []model.PurchaseOrderLine {
+	modelLines := make([]model.PurchaseOrderLine, len(lines))
+	for i, line := range lines {
+		modelLines[i] = model.PurchaseOrderLine{
+			ID:               line.ID(),
+			PurchaseOrderID:  purchaseOrderID,
+			ProductID:        line.ProductID(),
+			Quantity:         line.Quantity(),
+			ReceivedQuantity: line.ReceivedQuantity(),
+			UnitCost:         line.UnitCost(),
+		}
+	}
+	return modelLines
+}
+
+// Convert model.PurchaseOrder and its lines to entity.PurchaseOrder for returning from the database
+func purchaseOrderModelToEntity(m *model.PurchaseOrder, modelLines []model.PurchaseOrderLine) (*entity.PurchaseOrder, error) {
+	lines := make([]*entity.PurchaseOrderLine, len(modelLines))
+	for i, modelLine := range modelLines {
+		line := &entity.PurchaseOrderLine{}
+		if err := line.MakePurchaseOrderLine(modelLine.ID, modelLine.ProductID, modelLine.Quantity, modelLine.ReceivedQuantity, modelLine.UnitCost); err != nil {
+			return nil, err
+		}
+		lines[i] = line
+	}
+
+	order := &entity.PurchaseOrder{}
+	if err := order.MakePurchaseOrder(
+		m.ID,
+		m.SupplierID,
+		m.WarehouseID,
+		entity.PurchaseOrderStatus(m.Status),
+		m.Reference,
+		m.Currency,
+		lines,
+		m.SubmittedAt,
+		m.ClosedAt,
+		m.CreatedAt,
+		m.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrReorderPolicyNotFound is returned when a product has no reorder policy in a warehouse
+var ErrReorderPolicyNotFound = errors.New("reorder policy not found")
+
+type PostgresReorderPolicyRepository interface {
+	Save(p *entity.ReorderPolicy) error
+	FindByProductAndWarehouse(productID uint, warehouseID uint) (*entity.ReorderPolicy, error)
+	FindByProductID(productID uint) ([]*entity.ReorderPolicy, error)
+	ListReorderPolicies() ([]*entity.ReorderPolicy, error)
+}
+
+type postgresReorderPolicyRepository struct {
+	DB DB
+}
+
+func NewPostgresReorderPolicyRepository(db DB) PostgresReorderPolicyRepository {
+	return &postgresReorderPolicyRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresReorderPolicyRepository) Save(p *entity.ReorderPolicy) error {
+	modelPolicy := reorderPolicyEntityToModel(p)
+	if err := r.DB.Save(modelPolicy).Error; err != nil {
+		return err
+	}
+
+	policy, err := reorderPolicyModelToEntity(modelPolicy)
+	if err != nil {
+		return err
+	}
+	*p = *policy
+	return nil
+}
+
+// FindByProductAndWarehouse fetches the reorder policy of a product in a warehouse
+func (r *postgresReorderPolicyRepository) FindByProductAndWarehouse(productID uint, warehouseID uint) (*entity.ReorderPolicy, error) {
+	var modelPolicy model.ReorderPolicy
+	err := r.DB.Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).First(&modelPolicy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReorderPolicyNotFound
+		}
+		return nil, err
+	}
+	return reorderPolicyModelToEntity(&modelPolicy)
+}
+
+// FindByProductID returns the reorder policies of a product across all warehouses
+func (r *postgresReorderPolicyRepository) FindByProductID(productID uint) ([]*entity.ReorderPolicy, error) {
+	var modelPolicies []model.ReorderPolicy
+	if err := r.DB.Where("product_id = ?", productID).Order("warehouse_id asc").Find(&modelPolicies).Error; err != nil {
+		return nil, err
+	}
+	return reorderPolicyModelsToEntities(modelPolicies)
+}
+
+// ListReorderPolicies returns every reorder policy grouped by warehouse
+func (r *postgresReorderPolicyRepository) ListReorderPolicies() ([]*entity.ReorderPolicy, error) {
+	var modelPolicies []model.ReorderPolicy
+	if err := r.DB.Order("warehouse_id asc, product_id asc").Find(&modelPolicies).Error; err != nil {
+		return nil, err
+	}
+	return reorderPolicyModelsToEntities(modelPolicies)
+}
+
+// Convert entity.ReorderPolicy to model.ReorderPolicy for saving to the database
+func reorderPolicyEntityToModel(p *entity.ReorderPolicy) *model.ReorderPolicy {
+	modelPolicy := &model.ReorderPolicy{
+		ID:              p.ID(),
+		ProductID:       p.ProductID(),
+		WarehouseID:     p.WarehouseID(),
+		ReorderPoint:    p.ReorderPoint(),
+		ReorderQuantity: p.ReorderQuantity(),
+		SafetyStock:     p.SafetyStock(),
+		CreatedAt:       p.CreatedAt(),
+		UpdatedAt:       p.UpdatedAt(),
+	}
+	if supplierID := p.PreferredSupplierID(); supplierID != 0 {
+		modelPolicy.PreferredSupplierID = &supplierID
+	}
+	return modelPolicy
+}
+
+// Convert a slice of model.ReorderPolicy to entity.ReorderPolicy
+func reorderPolicyModelsToEntities(modelPolicies []model.ReorderPolicy) ([]*entity.ReorderPolicy, error) {
+	policies := make([]*entity.ReorderPolicy, len(modelPolicies))
+	for i := range modelPolicies {
+		policy, err := reorderPolicyModelToEntity(&modelPolicies[i])
+		if err != nil {
+			return nil, err
+		}
+		policies[i] = policy
+	}
+	return policies, nil
+}
+
+// Convert model.ReorderPolicy to entity.ReorderPolicy for returning from the database
+func reorderPolicyModelToEntity(m *model.ReorderPolicy) (*entity.ReorderPolicy, error) {
+	var supplierID uint
+	if m.PreferredSupplierID != nil {
+		supplierID = *m.PreferredSupplierID
+	}
+
+	p := &entity.ReorderPolicy{}
+	if err := p.MakeReorderPolicy(m.ID, m.ProductID, m.WarehouseID, m.ReorderPoint, m.ReorderQuantity, m.SafetyStock, supplierID, m.CreatedAt, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrReservationNotFound is returned when a reservation is not found in the database
+var ErrReservationNotFound = errors.New("reservation not found")
+
+type PostgresReservationRepository interface {
+	Save(r *entity.Reservation) error
+	FindByID(id uint) (*entity.Reservation, error)
+	FindForUpdate(id uint) (*entity.Reservation, error)
+	FindExpiredIDs(now time.Time, limit int) ([]uint, error)
+}
+
+type postgresReservationRepository struct {
+	DB DB
+}
+
+func NewPostgresReservationRepository(db DB) PostgresReservationRepository {
+	return &postgresReservationRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresReservationRepository) Save(reservation *entity.Reservation) error {
+	modelReservation := reservationEntityToModel(reservation)
+	if err := r.DB.Save(modelReservation).Error; err != nil {
+		return err
+	}
+	return reservation.MakeReservation(
+		modelReservation.ID,
+		modelReservation.ProductID,
+		modelReservation.WarehouseID,
+		modelReservation.Quantity,
+		entity.ReservationStatus(modelReservation.Status),
+		modelReservation.Reference,
+		modelReservation.ExpiresAt,
+		modelReservation.CreatedAt,
+		modelReservation.UpdatedAt,
+	)
+}
+
+// FindByID fetches a reservation from the database, converts model to entity, and returns it
+func (r *postgresReservationRepository) FindByID(id uint) (*entity.Reservation, error) {
+	var modelReservation model.Reservation
+	if err := r.DB.First(&modelReservation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, err
+	}
+	return reservationModelToEntity(&modelReservation)
+}
+
+// FindForUpdate fetches a reservation and locks its row until the surrounding transaction ends.
+// It must be called inside a unit of work.
+func (r *postgresReservationRepository) FindForUpdate(id uint) (*entity.Reservation, error) {
+	var modelReservation model.Reservation
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).First(&modelReservation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, err
+	}
+	return reservationModelToEntity(&modelReservation)
+}
+
+// FindExpiredIDs returns the IDs of pending reservations whose time to live elapsed before now, oldest first
+func (r *postgresReservationRepository) FindExpiredIDs(now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.DB.Model(&model.Reservation{}).
+		Where("status = ? AND expires_at <= ?", string(entity.ReservationStatusPending), now).
+		Order("expires_at asc").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Convert entity.Reservation to model.Reservation for saving to the database
+func reservationEntityToModel(r *entity.Reservation) *model.Reservation {
+	return &model.Reservation{
+		ID:          r.ID(),
+		ProductID:   r.ProductID(),
+		WarehouseID: r.WarehouseID(),
+		Quantity:    r.Quantity(),
+		Status:      string(r.Status()),
+		Reference:   r.Reference(),
+		ExpiresAt:   r.ExpiresAt(),
+		CreatedAt:   r.CreatedAt(),
+		UpdatedAt:   r.UpdatedAt(),
+	}
+}
+
+// Convert model.Reservation to entity.Reservation for returning from the database
+func reservationModelToEntity(m *model.Reservation) (*entity.Reservation, error) {
+	reservation := &entity.Reservation{}
+	if err := reservation.MakeReservation(
+		m.ID,
+		m.ProductID,
+		m.WarehouseID,
+		m.Quantity,
+		entity.ReservationStatus(m.Status),
+		m.Reference,
+		m.ExpiresAt,
+		m.CreatedAt,
+		m.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSalesOrderNotFound is returned when a sales order is not found in the database
+var ErrSalesOrderNotFound = errors.New("sales order not found")
+
+// SalesOrderFilter narrows down the sales orders returned from a listing
+type SalesOrderFilter struct {
+	Status      entity.SalesOrderStatus // Empty means every status
+	WarehouseID uint                    // Zero means every warehouse
+	Limit       int
+	Offset      int
+}
+
+type PostgresSalesOrderRepository interface {
+	Save(o *entity.SalesOrder) error
+	FindByID(id uint) (*entity.SalesOrder, error)
+	FindForUpdate(id uint) (*entity.SalesOrder, error)
+	ListSalesOrders(filter SalesOrderFilter) ([]*entity.SalesOrder, int64, error)
+}
+
+type postgresSalesOrderRepository struct {
+	DB DB
+}
+
+func NewPostgresSalesOrderRepository(db DB) PostgresSalesOrderRepository {
+	return &postgresSalesOrderRepository{DB: db}
+}
+
+// Save writes the sales order and its lines and updates the entity with the generated values.
+// It must be called inside a unit of work so the header and lines are written atomically.
+func (r *postgresSalesOrderRepository) Save(o *entity.SalesOrder) error {
+	modelOrder := salesOrderEntityToModel(o)
+	if err := r.DB.Save(modelOrder).Error; err != nil {
+		return err
+	}
+
+	modelLines := salesOrderLineEntitiesToModels(modelOrder.ID, o.Lines())
+	for i := range modelLines {
+		if err := r.DB.Save(&modelLines[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	order, err := salesOrderModelToEntity(modelOrder, modelLines)
+	if err != nil {
+		return err
+	}
+	*o = *order
+	return nil
+}
+
+// FindByID fetches a sales order with its lines from the database, converts model to entity, and returns it
+func (r *postgresSalesOrderRepository) FindByID(id uint) (*entity.SalesOrder, error) {
+	var modelOrder model.SalesOrder
+	if err := r.DB.First(&modelOrder, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSalesOrderNotFound
+		}
+		return nil, err
+	}
+	return r.withLines(&modelOrder)
+}
+
+// FindForUpdate fetches a sales order and locks its row until the surrounding transaction ends.
+// It must be called inside a unit of work.
+func (r *postgresSalesOrderRepository) FindForUpdate(id uint) (*entity.SalesOrder, error) {
+	var modelOrder model.SalesOrder
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).First(&modelOrder, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSalesOrderNotFound
+		}
+		return nil, err
+	}
+	return r.withLines(&modelOrder)
+}
+
+// ListSalesOrders returns a page of sales orders, newest first, with the total number of matching rows
+func (r *postgresSalesOrderRepository) ListSalesOrders(filter SalesOrderFilter) ([]*entity.SalesOrder, int64, error) {
+	var total int64
+	if err := r.applyFilter(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var modelOrders []model.SalesOrder
+	err := r.applyFilter(filter).
+		Order("id desc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&modelOrders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(modelOrders) == 0 {
+		return []*entity.SalesOrder{}, total, nil
+	}
+
+	ids := make([]uint, len(modelOrders))
+	for i, modelOrder := range modelOrders {
+		ids[i] = modelOrder.ID
+	}
+	var modelLines []model.SalesOrderLine
+	if err := r.DB.Where("sales_order_id IN ?", ids).Order("id asc").Find(&modelLines).Error; err != nil {
+		return nil, 0, err
+	}
+	linesByOrder := make(map[uint][]model.SalesOrderLine, len(modelOrders))
+	for _, modelLine := range modelLines {
+		linesByOrder[modelLine.SalesOrderID] = append(linesByOrder[modelLine.SalesOrderID], modelLine)
+	}
+
+	orders := make([]*entity.SalesOrder, len(modelOrders))
+	for i := range modelOrders {
+		order, err := salesOrderModelToEntity(&modelOrders[i], linesByOrder[modelOrders[i].ID])
+		if err != nil {
+			return nil, 0, err
+		}
+		orders[i] = order
+	}
+	return orders, total, nil
+}
+
+// withLines loads the lines of a sales order and converts both to an entity
+func (r *postgresSalesOrderRepository) withLines(modelOrder *model.SalesOrder) (*entity.SalesOrder, error) {
+	var modelLines []model.SalesOrderLine
+	if err := r.DB.Where("sales_order_id = ?", modelOrder.ID).Order("id asc").Find(&modelLines).Error; err != nil {
+		return nil, err
+	}
+	return salesOrderModelToEntity(modelOrder, modelLines)
+}
+
+// applyFilter builds the query shared by the list and count queries
+func (r *postgresSalesOrderRepository) applyFilter(filter SalesOrderFilter) *gorm.DB {
+	query := r.DB.Model(&model.SalesOrder{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.WarehouseID != 0 {
+		query = query.Where("warehouse_id = ?", filter.WarehouseID)
+	}
+	return query
+}
+
+// Convert entity.SalesOrder to model.SalesOrder for saving to the database
+func salesOrderEntityToModel(o *entity.SalesOrder) *model.SalesOrder {
+	return &model.SalesOrder{
+		ID:                o.ID(),
+		WarehouseID:       o.WarehouseID(),
+		CustomerReference: o.CustomerReference(),
+		Status:            string(o.Status()),
+		ShippedAt:         o.ShippedAt(),
+		CreatedAt:         o.CreatedAt(),
+		UpdatedAt:         o.UpdatedAt(),
+	}
+}
+
+// Convert the lines of a sales order to model.SalesOrderLine for saving to the database
+func salesOrderLineEntitiesToModels(salesOrderID uint, lines []*entity.SalesOrderLine) []model.SalesOrderLine {
+	modelLines := make([]model.SalesOrderLine, len(lines))
+	for i, line := range lines {
+		modelLines[i] = model.SalesOrderLine{
+			ID:                line.ID(),
+			SalesOrderID:      salesOrderID,
+			ProductID:         line.ProductID(),
+			Quantity:          line.Quantity(),
+			AllocatedQuantity: line.AllocatedQuantity(),
+		}
+	}
+	return modelLines
+}
+
+// Convert model.SalesOrder and its lines to entity.SalesOrder for returning from the database
+func salesOrderModelToEntity(m *model.SalesOrder, modelLines []model.SalesOrderLine) (*entity.SalesOrder, error) {
+	lines := make([]*entity.SalesOrderLine, len(modelLines))
+	for i, modelLine := range modelLines {
+		line := &entity.SalesOrderLine{}
+		if err := line.MakeSalesOrderLine(modelLine.ID, modelLine.ProductID, modelLine.Quantity, modelLine.AllocatedQuantity); err != nil {
+			return nil, err
+		}
+		lines[i] = line
+	}
+
+	order := &entity.SalesOrder{}
+	if err := order.MakeSalesOrder(
+		m.ID,
+		m.WarehouseID,
+		m.CustomerReference,
+		entity.SalesOrderStatus(m.Status),
+		lines,
+		m.ShippedAt,
+		m.CreatedAt,
+		m.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
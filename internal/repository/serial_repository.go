@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSerialNotFound is returned when a serial is not found in the database
+var ErrSerialNotFound = errors.New("serial not found")
+
+type PostgresSerialRepository interface {
+	Save(s *entity.Serial) error
+	FindBySerialNumber(serialNumber string) (*entity.Serial, error)
+	FindForUpdate(serialNumber string) (*entity.Serial, error)
+	FindInTransitForUpdate(productID uint, reference string) ([]*entity.Serial, error)
+	LinkMovement(movementID uint, serials []*entity.Serial) error
+	FindHistory(serialID uint) ([]*entity.StockMovement, error)
+}
+
+type postgresSerialRepository struct {
+	DB DB
+}
+
+func NewPostgresSerialRepository(db DB) PostgresSerialRepository {
+	return &postgresSerialRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresSerialRepository) Save(s *entity.Serial) error {
+	modelSerial := serialEntityToModel(s)
+	if err := r.DB.Save(modelSerial).Error; err != nil {
+		return err
+	}
+
+	serial, err := serialModelToEntity(modelSerial)
+	if err != nil {
+		return err
+	}
+	*s = *serial
+	return nil
+}
+
+// FindBySerialNumber fetches a serial by its serial number
+func (r *postgresSerialRepository) FindBySerialNumber(serialNumber string) (*entity.Serial, error) {
+	var modelSerial model.Serial
+	if err := r.DB.Where("serial_number = ?", serialNumber).First(&modelSerial).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSerialNotFound
+		}
+		return nil, err
+	}
+	return serialModelToEntity(&modelSerial)
+}
+
+// FindForUpdate fetches a serial and locks its row until the surrounding transaction ends.
+// It must be called inside a unit of work.
+func (r *postgresSerialRepository) FindForUpdate(serialNumber string) (*entity.Serial, error) {
+	var modelSerial model.Serial
+	err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("serial_number = ?", serialNumber).
+		First(&modelSerial).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSerialNotFound
+		}
+		return nil, err
+	}
+	return serialModelToEntity(&modelSerial)
+}
+
+// FindInTransitForUpdate fetches the units of a product still in transit since a dispatch with the
+// given reference and locks them until the surrounding transaction ends. Units that arrived and left
+// again on a later dispatch are not included. It must be called inside a unit of work.
+func (r *postgresSerialRepository) FindInTransitForUpdate(productID uint, reference string) ([]*entity.Serial, error) {
+	var modelSerials []model.Serial
+	err := r.DB.Model(&model.Serial{}).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "serials"}}).
+		Joins("JOIN serial_movements ON serial_movements.serial_id = serials.id").
+		Joins("JOIN stock_movements ON stock_movements.id = serial_movements.stock_movement_id").
+		Where("serials.product_id = ? AND serials.status = ?", productID, string(entity.SerialStatusInTransit)).
+		Where("stock_movements.movement_type = ? AND stock_movements.reference = ?", string(entity.MovementTypeTransferOut), reference).
+		Where("NOT EXISTS (SELECT 1 FROM serial_movements later WHERE later.serial_id = serials.id AND later.stock_movement_id > stock_movements.id)").
+		Order("serials.serial_number asc").
+		Find(&modelSerials).Error
+	if err != nil {
+		return nil, err
+	}
+
+	serials := make([]*entity.Serial, len(modelSerials))
+	for i := range modelSerials {
+		serial, err := serialModelToEntity(&modelSerials[i])
+		if err != nil {
+			return nil, err
+		}
+		serials[i] = serial
+	}
+	return serials, nil
+}
+
+// LinkMovement records that a ledger entry moved the given serials
+func (r *postgresSerialRepository) LinkMovement(movementID uint, serials []*entity.Serial) error {
+	if len(serials) == 0 {
+		return nil
+	}
+	links := make([]model.SerialMovement, len(serials))
+	for i, serial := range serials {
+		links[i] = model.SerialMovement{SerialID: serial.ID(), StockMovementID: movementID}
+	}
+	return r.DB.Create(&links).Error
+}
+
+// FindHistory returns the ledger entries that moved a serial, in the order they were applied
+func (r *postgresSerialRepository) FindHistory(serialID uint) ([]*entity.StockMovement, error) {
+	var modelMovements []model.StockMovement
+	err := r.DB.Model(&model.StockMovement{}).
+		Joins("JOIN serial_movements ON serial_movements.stock_movement_id = stock_movements.id").
+		Where("serial_movements.serial_id = ?", serialID).
+		Order("stock_movements.occurred_at asc, stock_movements.id asc").
+		Find(&modelMovements).Error
+	if err != nil {
+		return nil, err
+	}
+
+	movements := make([]*entity.StockMovement, len(modelMovements))
+	for i := range modelMovements {
+		movement, err := stockMovementModelToEntity(&modelMovements[i])
+		if err != nil {
+			return nil, err
+		}
+		movements[i] = movement
+	}
+	return movements, nil
+}
+
+// Convert entity.Serial to model.Serial for saving to the database
+func serialEntityToModel(s *entity.Serial) *model.Serial {
+	modelSerial := &model.Serial{
+		ID:           s.ID(),
+		ProductID:    s.ProductID(),
+		SerialNumber: s.SerialNumber(),
+		Status:       string(s.Status()),
+		CreatedAt:    s.CreatedAt(),
+		UpdatedAt:    s.UpdatedAt(),
+	}
+	if warehouseID := s.WarehouseID(); warehouseID != 0 {
+		modelSerial.WarehouseID = &warehouseID
+	}
+	return modelSerial
+}
+
+// Convert model.Serial to entity.Serial for returning from the database
+func serialModelToEntity(m *model.Serial) (*entity.Serial, error) {
+	var warehouseID uint
+	if m.WarehouseID != nil {
+		warehouseID = *m.WarehouseID
+	}
+
+	s := &entity.Serial{}
+	if err := s.MakeSerial(m.ID, m.ProductID, m.SerialNumber, entity.SerialStatus(m.Status), warehouseID, m.CreatedAt, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
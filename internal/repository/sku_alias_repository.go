@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrSKUAliasNotFound is returned when no product formerly carried a SKU
+var ErrSKUAliasNotFound = errors.New("sku alias not found")
+
+type PostgresSKUAliasRepository interface {
+	Save(a *entity.SKUAlias) error
+	FindBySKU(sku string) (*entity.SKUAlias, error)
+	FindByProductID(productID uint) ([]*entity.SKUAlias, error)
+	Delete(sku string) error
+}
+
+type postgresSKUAliasRepository struct {
+	DB DB
+}
+
+func NewPostgresSKUAliasRepository(db DB) PostgresSKUAliasRepository {
+	return &postgresSKUAliasRepository{DB: db}
+}
+
+// Save records a former SKU of a product
+func (r *postgresSKUAliasRepository) Save(a *entity.SKUAlias) error {
+	modelAlias := &model.ProductSKUAlias{SKU: a.SKU(), ProductID: a.ProductID(), CreatedAt: a.CreatedAt()}
+	if err := r.DB.Create(modelAlias).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrSKUTaken
+		}
+		return err
+	}
+	return nil
+}
+
+// FindBySKU fetches the alias of a former SKU
+func (r *postgresSKUAliasRepository) FindBySKU(sku string) (*entity.SKUAlias, error) {
+	var modelAlias model.ProductSKUAlias
+	if err := r.DB.Where("sku = ?", sku).First(&modelAlias).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSKUAliasNotFound
+		}
+		return nil, err
+	}
+	return skuAliasModelToEntity(&modelAlias)
+}
+
+// FindByProductID returns the former SKUs of a product, most recently retired first
+func (r *postgresSKUAliasRepository) FindByProductID(productID uint) ([]*entity.SKUAlias, error) {
+	var modelAliases []model.ProductSKUAlias
+	if err := r.DB.Where("product_id = ?", productID).Order("created_at desc, sku asc").Find(&modelAliases).Error; err != nil {
+		return nil, err
+	}
+
+	aliases := make([]*entity.SKUAlias, len(modelAliases))
+	for i := range modelAliases {
+		alias, err := skuAliasModelToEntity(&modelAliases[i])
+		if err != nil {
+			return nil, err
+		}
+		aliases[i] = alias
+	}
+	return aliases, nil
+}
+
+// Delete removes the alias of a SKU, e.g. when a product takes back a former SKU
+func (r *postgresSKUAliasRepository) Delete(sku string) error {
+	return r.DB.Where("sku = ?", sku).Delete(&model.ProductSKUAlias{}).Error
+}
+
+// Convert model.ProductSKUAlias to entity.SKUAlias for returning from the database
+func skuAliasModelToEntity(m *model.ProductSKUAlias) (*entity.SKUAlias, error) {
+	alias := &entity.SKUAlias{}
+	if err := alias.MakeSKUAlias(m.SKU, m.ProductID, m.CreatedAt); err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresSKUSequence struct {
+	DB DB
+}
+
+// NewPostgresSKUSequence creates the counters SKU templates draw {SEQ} numbers from
+func NewPostgresSKUSequence(db DB) entity.SKUSequence {
+	return &postgresSKUSequence{DB: db}
+}
+
+// Next increments the counter of a key, creating it at 1, and returns its new value. Inside a unit
+// of work the counter row stays locked until the transaction ends, so numbers are never handed out twice.
+func (s *postgresSKUSequence) Next(key string) (int64, error) {
+	sequence := model.SKUSequence{Key: key, LastValue: 1}
+	err := s.DB.Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"last_value": gorm.Expr("sku_sequences.last_value + 1")}),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "last_value"}}},
+	).Create(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+	return sequence.LastValue, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm/clause"
+)
+
+type PostgresStockLevelRepository interface {
+	FindByProductID(productID uint) ([]*entity.StockLevel, error)
+	FindByWarehouseID(warehouseID uint) ([]*entity.StockLevel, error)
+	FindForUpdate(productID uint, warehouseID uint) (*entity.StockLevel, error)
+	UpdateQuantities(s *entity.StockLevel) error
+}
+
+type postgresStockLevelRepository struct {
+	DB DB
+}
+
+func NewPostgresStockLevelRepository(db DB) PostgresStockLevelRepository {
+	return &postgresStockLevelRepository{DB: db}
+}
+
+// FindByProductID returns the stock levels of a product across all warehouses
+func (r *postgresStockLevelRepository) FindByProductID(productID uint) ([]*entity.StockLevel, error) {
+	var modelStockLevels []model.StockLevel
+	if err := r.DB.Where("product_id = ?", productID).Order("warehouse_id asc").Find(&modelStockLevels).Error; err != nil {
+		return nil, err
+	}
+	return stockLevelModelsToEntities(modelStockLevels)
+}
+
+// FindByWarehouseID returns the stock levels of every product held in a warehouse
+func (r *postgresStockLevelRepository) FindByWarehouseID(warehouseID uint) ([]*entity.StockLevel, error) {
+	var modelStockLevels []model.StockLevel
+	if err := r.DB.Where("warehouse_id = ?", warehouseID).Order("product_id asc").Find(&modelStockLevels).Error; err != nil {
+		return nil, err
+	}
+	return stockLevelModelsToEntities(modelStockLevels)
+}
+
+// FindForUpdate returns the stock level of a product in a warehouse and locks its row until the
+// surrounding transaction ends, creating an empty level first when none exists yet.
+// It must be called inside a unit of work.
+func (r *postgresStockLevelRepository) FindForUpdate(productID uint, warehouseID uint) (*entity.StockLevel, error) {
+	// Insert-if-missing first so that concurrent callers all end up locking the same row
+	emptyLevel := &model.StockLevel{ProductID: productID, WarehouseID: warehouseID}
+	if err := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(emptyLevel).Error; err != nil {
+		return nil, err
+	}
+
+	var modelStockLevel model.StockLevel
+	err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).
+		First(&modelStockLevel).Error
+	if err != nil {
+		return nil, err
+	}
+	return stockLevelModelToEntity(&modelStockLevel)
+}
+
+// UpdateQuantities writes the on-hand and reserved quantities of a stock level returned by FindForUpdate
+func (r *postgresStockLevelRepository) UpdateQuantities(s *entity.StockLevel) error {
+	return r.DB.Model(&model.StockLevel{ID: s.ID()}).Updates(map[string]interface{}{
+		"on_hand":  s.OnHand(),
+		"reserved": s.Reserved(),
+	}).Error
+}
+
+// Convert a slice of model.StockLevel to entity.StockLevel
+func stockLevelModelsToEntities(modelStockLevels []model.StockLevel) ([]*entity.StockLevel, error) {
+	stockLevels := make([]*entity.StockLevel, len(modelStockLevels))
+	for i, modelStockLevel := range modelStockLevels {
+		stockLevel, err := stockLevelModelToEntity(&modelStockLevel)
+		if err != nil {
+			return nil, err
+		}
+		stockLevels[i] = stockLevel
+	}
+	return stockLevels, nil
+}
+
+// Convert model.StockLevel to entity.StockLevel for returning from the database
+func stockLevelModelToEntity(m *model.StockLevel) (*entity.StockLevel, error) {
+	s := &entity.StockLevel{}
+	if err := s.MakeStockLevel(m.ID, m.ProductID, m.WarehouseID, m.OnHand, m.Reserved, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
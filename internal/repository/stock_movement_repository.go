@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockMovementFilter narrows down the movements returned from the ledger
+type StockMovementFilter struct {
+	ProductID    uint
+	WarehouseID  uint                // Zero means every warehouse
+	MovementType entity.MovementType // Empty means every type
+	From         *time.Time          // Inclusive lower bound on OccurredAt
+	To           *time.Time          // Exclusive upper bound on OccurredAt
+	Limit        int
+	Offset       int
+}
+
+type PostgresStockMovementRepository interface {
+	Save(m *entity.StockMovement) error
+	ListMovements(filter StockMovementFilter) ([]*entity.StockMovement, int64, error)
+	SumQuantitiesByWarehouse(productID uint) (map[uint]int64, error)
+}
+
+type postgresStockMovementRepository struct {
+	DB DB
+}
+
+func NewPostgresStockMovementRepository(db DB) PostgresStockMovementRepository {
+	return &postgresStockMovementRepository{DB: db}
+}
+
+// Save appends a movement to the ledger and updates the entity with the generated values
+func (r *postgresStockMovementRepository) Save(m *entity.StockMovement) error {
+	modelMovement := stockMovementEntityToModel(m)
+	if err := r.DB.Create(modelMovement).Error; err != nil {
+		return err
+	}
+
+	return m.MakeStockMovement(
+		modelMovement.ID,
+		modelMovement.ProductID,
+		modelMovement.WarehouseID,
+		entity.MovementType(modelMovement.MovementType),
+		modelMovement.Quantity,
+		modelMovement.BalanceAfter,
+		modelMovement.ReasonCode,
+		modelMovement.Reference,
+		modelMovement.Actor,
+		modelMovement.OccurredAt,
+		modelMovement.CreatedAt,
+	)
+}
+
+// ListMovements returns a page of movements in the order they were applied, with the total number of matching rows
+func (r *postgresStockMovementRepository) ListMovements(filter StockMovementFilter) ([]*entity.StockMovement, int64, error) {
+	var total int64
+	if err := r.applyFilter(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var modelMovements []model.StockMovement
+	err := r.applyFilter(filter).
+		Order("occurred_at asc, id asc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&modelMovements).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	movements := make([]*entity.StockMovement, len(modelMovements))
+	for i, modelMovement := range modelMovements {
+		movement, err := stockMovementModelToEntity(&modelMovement)
+		if err != nil {
+			return nil, 0, err
+		}
+		movements[i] = movement
+	}
+	return movements, total, nil
+}
+
+// SumQuantitiesByWarehouse replays the ledger of a product and returns its balance per warehouse
+func (r *postgresStockMovementRepository) SumQuantitiesByWarehouse(productID uint) (map[uint]int64, error) {
+	var rows []struct {
+		WarehouseID uint
+		Balance     int64
+	}
+	err := r.DB.Model(&model.StockMovement{}).
+		Select("warehouse_id, CAST(SUM(quantity) AS BIGINT) AS balance").
+		Where("product_id = ?", productID).
+		Group("warehouse_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		balances[row.WarehouseID] = row.Balance
+	}
+	return balances, nil
+}
+
+// applyFilter builds the query shared by the list and count queries
+func (r *postgresStockMovementRepository) applyFilter(filter StockMovementFilter) *gorm.DB {
+	query := r.DB.Model(&model.StockMovement{}).Where("product_id = ?", filter.ProductID)
+	if filter.WarehouseID != 0 {
+		query = query.Where("warehouse_id = ?", filter.WarehouseID)
+	}
+	if filter.MovementType != "" {
+		query = query.Where("movement_type = ?", string(filter.MovementType))
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", *filter.To)
+	}
+	return query
+}
+
+// Convert entity.StockMovement to model.StockMovement for saving to the database
+func stockMovementEntityToModel(m *entity.StockMovement) *model.StockMovement {
+	return &model.StockMovement{
+		ID:           m.ID(),
+		ProductID:    m.ProductID(),
+		WarehouseID:  m.WarehouseID(),
+		MovementType: string(m.Type()),
+		Quantity:     m.Quantity(),
+		BalanceAfter: m.BalanceAfter(),
+		ReasonCode:   m.ReasonCode(),
+		Reference:    m.Reference(),
+		Actor:        m.Actor(),
+		OccurredAt:   m.OccurredAt(),
+		CreatedAt:    m.CreatedAt(),
+	}
+}
+
+// Convert model.StockMovement to entity.StockMovement for returning from the database
+func stockMovementModelToEntity(m *model.StockMovement) (*entity.StockMovement, error) {
+	movement := &entity.StockMovement{}
+	if err := movement.MakeStockMovement(
+		m.ID,
+		m.ProductID,
+		m.WarehouseID,
+		entity.MovementType(m.MovementType),
+		m.Quantity,
+		m.BalanceAfter,
+		m.ReasonCode,
+		m.Reference,
+		m.Actor,
+		m.OccurredAt,
+		m.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrSupplierNotFound is returned when a supplier is not found in the database
+var ErrSupplierNotFound = errors.New("supplier not found")
+
+// ErrSupplierCodeTaken is returned when another supplier already uses the code
+var ErrSupplierCodeTaken = errors.New("supplier code already exists")
+
+type PostgresSupplierRepository interface {
+	Save(s *entity.Supplier) error
+	FindByID(id uint) (*entity.Supplier, error)
+	ListSuppliers() ([]*entity.Supplier, error)
+}
+
+type postgresSupplierRepository struct {
+	DB DB
+}
+
+func NewPostgresSupplierRepository(db DB) PostgresSupplierRepository {
+	return &postgresSupplierRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresSupplierRepository) Save(s *entity.Supplier) error {
+	modelSupplier := supplierEntityToModel(s)
+
+	if err := r.DB.Save(modelSupplier).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrSupplierCodeTaken
+		}
+		return err
+	}
+
+	return s.MakeSupplier(
+		modelSupplier.ID,
+		modelSupplier.Code,
+		modelSupplier.Name,
+		modelSupplier.ContactEmail,
+		modelSupplier.CreatedAt,
+		modelSupplier.UpdatedAt,
+	)
+}
+
+// FindByID fetches a supplier from the database, converts model to entity, and returns it
+func (r *postgresSupplierRepository) FindByID(id uint) (*entity.Supplier, error) {
+	var modelSupplier model.Supplier
+	err := r.DB.First(&modelSupplier, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, err
+	}
+	return supplierModelToEntity(&modelSupplier)
+}
+
+// ListSuppliers returns every supplier ordered by code
+func (r *postgresSupplierRepository) ListSuppliers() ([]*entity.Supplier, error) {
+	var modelSuppliers []model.Supplier
+	if err := r.DB.Order("code asc").Find(&modelSuppliers).Error; err != nil {
+		return nil, err
+	}
+
+	entitySuppliers := make([]*entity.Supplier, len(modelSuppliers))
+	for i, modelSupplier := range modelSuppliers {
+		entitySupplier, err := supplierModelToEntity(&modelSupplier)
+		if err != nil {
+			return nil, err
+		}
+		entitySuppliers[i] = entitySupplier
+	}
+	return entitySuppliers, nil
+}
+
+// Convert entity.Supplier to model.Supplier for saving to the database
+func supplierEntityToModel(s *entity.Supplier) *model.Supplier {
+	return &model.Supplier{
+		ID:           s.ID(),
+		Code:         s.Code(),
+		Name:         s.Name(),
+		ContactEmail: s.ContactEmail(),
+		CreatedAt:    s.CreatedAt(),
+		UpdatedAt:    s.UpdatedAt(),
+	}
+}
+
+// Convert model.Supplier to entity.Supplier for returning from the database
+func supplierModelToEntity(m *model.Supplier) (*entity.Supplier, error) {
+	s := &entity.Supplier{}
+	if err := s.MakeSupplier(m.ID, m.Code, m.Name, m.ContactEmail, m.CreatedAt, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrTransferNotFound is returned when a transfer is not found in the database
+var ErrTransferNotFound = errors.New("transfer not found")
+
+// TransferFilter narrows down the transfers returned from a listing
+type TransferFilter struct {
+	Status      entity.TransferStatus // Empty means every status
+	WarehouseID uint                  // Zero means every warehouse, otherwise either end of the transfer
+	Limit       int
+	Offset      int
+}
+
+type PostgresTransferRepository interface {
+	Save(t *entity.Transfer) error
+	FindByID(id uint) (*entity.Transfer, error)
+	FindForUpdate(id uint) (*entity.Transfer, error)
+	ListTransfers(filter TransferFilter) ([]*entity.Transfer, int64, error)
+}
+
+type postgresTransferRepository struct {
+	DB DB
+}
+
+func NewPostgresTransferRepository(db DB) PostgresTransferRepository {
+	return &postgresTransferRepository{DB: db}
+}
+
+// Save writes the transfer, its lines and their lots and updates the entity with the generated values.
+// It must be called inside a unit of work so the header and lines are written atomically.
+func (r *postgresTransferRepository) Save(t *entity.Transfer) error {
+	modelTransfer := transferEntityToModel(t)
+	if err := r.DB.Save(modelTransfer).Error; err != nil {
+		return err
+	}
+
+	modelLines := transferLineEntitiesToModels(modelTransfer.ID, t.Lines())
+	lotsByLine := make(map[uint][]model.TransferLineLot, len(modelLines))
+	for i := range modelLines {
+		if err := r.DB.Save(&modelLines[i]).Error; err != nil {
+			return err
+		}
+
+		modelLots := transferLotEntitiesToModels(modelLines[i].ID, t.Lines()[i].Lots())
+		for j := range modelLots {
+			if err := r.DB.Save(&modelLots[j]).Error; err != nil {
+				return err
+			}
+		}
+		lotsByLine[modelLines[i].ID] = modelLots
+	}
+
+	transfer, err := transferModelToEntity(modelTransfer, modelLines, lotsByLine)
+	if err != nil {
+		return err
+	}
+	*t = *transfer
+	return nil
+}
+
+// FindByID fetches a transfer with its lines from the database, converts model to entity, and returns it
+func (r *postgresTransferRepository) FindByID(id uint) (*entity.Transfer, error) {
+	var modelTransfer model.Transfer
+	if err := r.DB.First(&modelTransfer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransferNotFound
+		}
+		return nil, err
+	}
+	return r.withLines(&modelTransfer)
+}
+
+// FindForUpdate fetches a transfer and locks its row until the surrounding transaction ends.
+// It must be called inside a unit of work.
+func (r *postgresTransferRepository) FindForUpdate(id uint) (*entity.Transfer, error) {
+	var modelTransfer model.Transfer
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).First(&modelTransfer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransferNotFound
+		}
+		return nil, err
+	}
+	return r.withLines(&modelTransfer)
+}
+
+// ListTransfers returns a page of transfers, newest first, with the total number of matching rows
+func (r *postgresTransferRepository) ListTransfers(filter TransferFilter) ([]*entity.Transfer, int64, error) {
+	var total int64
+	if err := r.applyFilter(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var modelTransfers []model.Transfer
+	err := r.applyFilter(filter).
+		Order("id desc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&modelTransfers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(modelTransfers) == 0 {
+		return []*entity.Transfer{}, total, nil
+	}
+
+	ids := make([]uint, len(modelTransfers))
+	for i, modelTransfer := range modelTransfers {
+		ids[i] = modelTransfer.ID
+	}
+	var modelLines []model.TransferLine
+	if err := r.DB.Where("transfer_id IN ?", ids).Order("id asc").Find(&modelLines).Error; err != nil {
+		return nil, 0, err
+	}
+	linesByTransfer := make(map[uint][]model.TransferLine, len(modelTransfers))
+	for _, modelLine := range modelLines {
+		linesByTransfer[modelLine.TransferID] = append(linesByTransfer[modelLine.TransferID], modelLine)
+	}
+	lotsByLine, err := r.findLots(modelLines)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	transfers := make([]*entity.Transfer, len(modelTransfers))
+	for i := range modelTransfers {
+		transfer, err := transferModelToEntity(&modelTransfers[i], linesByTransfer[modelTransfers[i].ID], lotsByLine)
+		if err != nil {
+			return nil, 0, err
+		}
+		transfers[i] = transfer
+	}
+	return transfers, total, nil
+}
+
+// withLines loads the lines of a transfer with their lots and converts them to an entity
+func (r *postgresTransferRepository) withLines(modelTransfer *model.Transfer) (*entity.Transfer, error) {
+	var modelLines []model.TransferLine
+	if err := r.DB.Where("transfer_id = ?", modelTransfer.ID).Order("id asc").Find(&modelLines).Error; err != nil {
+		return nil, err
+	}
+	lotsByLine, err := r.findLots(modelLines)
+	if err != nil {
+		return nil, err
+	}
+	return transferModelToEntity(modelTransfer, modelLines, lotsByLine)
+}
+
+// findLots loads the lots of the given transfer lines, keyed by line ID
+func (r *postgresTransferRepository) findLots(modelLines []model.TransferLine) (map[uint][]model.TransferLineLot, error) {
+	lotsByLine := make(map[uint][]model.TransferLineLot, len(modelLines))
+	if len(modelLines) == 0 {
+		return lotsByLine, nil
+	}
+
+	ids := make([]uint, len(modelLines))
+	for i, modelLine := range modelLines {
+		ids[i] = modelLine.ID
+	}
+	var modelLots []model.TransferLineLot
+	if err := r.DB.Where("transfer_line_id IN ?", ids).Order("id asc").Find(&modelLots).Error; err != nil {
+		return nil, err
+	}
+	for _, modelLot := range modelLots {
+		lotsByLine[modelLot.TransferLineID] = append(lotsByLine[modelLot.TransferLineID], modelLot)
+	}
+	return lotsByLine, nil
+}
+
+// applyFilter builds the query shared by the list and count queries
+func (r *postgresTransferRepository) applyFilter(filter TransferFilter) *gorm.DB {
+	query := r.DB.Model(&model.Transfer{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.WarehouseID != 0 {
+		query = query.Where("source_warehouse_id = ? OR destination_warehouse_id = ?", filter.WarehouseID, filter.WarehouseID)
+	}
+	return query
+}
+
+// Convert entity.Transfer to model.Transfer for saving to the database
+func transferEntityToModel(t *entity.Transfer) *model.Transfer {
+	return &model.Transfer{
+		ID:                     t.ID(),
+		SourceWarehouseID:      t.SourceWarehouseID(),
+		DestinationWarehouseID: t.DestinationWarehouseID(),
+		Status:                 string(t.Status()),
+		Reference:              t.Reference(),
+		DispatchedAt:           t.DispatchedAt(),
+		ReceivedAt:             t.ReceivedAt(),
+		ClosedAt:               t.ClosedAt(),
+		CloseReasonCode:        t.CloseReasonCode(),
+		CreatedAt:              t.CreatedAt(),
+		UpdatedAt:              t.UpdatedAt(),
+	}
+}
+
+// Convert the lines of a transfer to model.TransferLine for saving to the database
+func transferLineEntitiesToModels(transferID uint, lines []*entity.TransferLine) []model.TransferLine {
+	modelLines := make([]model.TransferLine, len(lines))
+	for i, line := range lines {
+		modelLines[i] = model.TransferLine{
+			ID:               line.ID(),
+			TransferID:       transferID,
+			ProductID:        line.ProductID(),
+			Quantity:         line.Quantity(),
+			ReceivedQuantity: line.ReceivedQuantity(),
+		}
+	}
+	return modelLines
+}
+
+// Convert the lots of a transfer line to model.TransferLineLot for saving to the database
+func transferLotEntitiesToModels(transferLineID uint, lots []*entity.TransferLot) []model.TransferLineLot {
+	modelLots := make([]model.TransferLineLot, len(lots))
+	for i, lot := range lots {
+		modelLots[i] = model.TransferLineLot{
+			ID:               lot.ID(),
+			TransferLineID:   transferLineID,
+			LotNumber:        lot.LotNumber(),
+			ManufacturedAt:   lot.ManufacturedAt(),
+			ExpiresAt:        lot.ExpiresAt(),
+			Quantity:         lot.Quantity(),
+			ReceivedQuantity: lot.ReceivedQuantity(),
+		}
+	}
+	return modelLots
+}
+
+// Convert model.Transfer, its lines and their lots, keyed by line ID, to entity.Transfer for returning
+// from the database
+func transferModelToEntity(m *model.Transfer, modelLines []model.TransferLine, lotsByLine map[uint][]model.TransferLineLot) (*entity.Transfer, error) {
+	lines := make([]*entity.TransferLine, len(modelLines))
+	for i, modelLine := range modelLines {
+		lots := make([]*entity.TransferLot, len(lotsByLine[modelLine.ID]))
+		for j, modelLot := range lotsByLine[modelLine.ID] {
+			lot := &entity.TransferLot{}
+			if err := lot.MakeTransferLot(modelLot.ID, modelLot.LotNumber, modelLot.ManufacturedAt, modelLot.ExpiresAt, modelLot.Quantity, modelLot.ReceivedQuantity); err != nil {
+				return nil, err
+			}
+			lots[j] = lot
+		}
+
+		line := &entity.TransferLine{}
+		if err := line.MakeTransferLine(modelLine.ID, modelLine.ProductID, modelLine.Quantity, modelLine.ReceivedQuantity, lots); err != nil {
+			return nil, err
+		}
+		lines[i] = line
+	}
+
+	transfer := &entity.Transfer{}
+	if err := transfer.MakeTransfer(
+		m.ID,
+		m.SourceWarehouseID,
+		m.DestinationWarehouseID,
+		entity.TransferStatus(m.Status),
+		m.Reference,
+		lines,
+		m.DispatchedAt,
+		m.ReceivedAt,
+		m.ClosedAt,
+		m.CloseReasonCode,
+		m.CreatedAt,
+		m.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
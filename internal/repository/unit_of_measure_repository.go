@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnitOfMeasureNotFound is returned when a unit is not in the catalogue
+var ErrUnitOfMeasureNotFound = errors.New("unit of measure not found")
+
+// ErrUnitCodeTaken is returned when another unit already uses the code
+var ErrUnitCodeTaken = errors.New("unit code already exists")
+
+type PostgresUnitOfMeasureRepository interface {
+	Save(u *entity.UnitOfMeasure) error
+	FindByCode(code string) (*entity.UnitOfMeasure, error)
+	ListUnits() ([]*entity.UnitOfMeasure, error)
+}
+
+type postgresUnitOfMeasureRepository struct {
+	DB DB
+}
+
+func NewPostgresUnitOfMeasureRepository(db DB) PostgresUnitOfMeasureRepository {
+	return &postgresUnitOfMeasureRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresUnitOfMeasureRepository) Save(u *entity.UnitOfMeasure) error {
+	modelUnit := unitOfMeasureEntityToModel(u)
+
+	if err := r.DB.Save(modelUnit).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrUnitCodeTaken
+		}
+		return err
+	}
+
+	return u.MakeUnitOfMeasure(modelUnit.ID, modelUnit.Code, modelUnit.Name, modelUnit.CreatedAt, modelUnit.UpdatedAt)
+}
+
+// FindByCode fetches a unit by its code
+func (r *postgresUnitOfMeasureRepository) FindByCode(code string) (*entity.UnitOfMeasure, error) {
+	var modelUnit model.UnitOfMeasure
+	if err := r.DB.Where("code = ?", entity.NormalizeUnitCode(code)).First(&modelUnit).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUnitOfMeasureNotFound
+		}
+		return nil, err
+	}
+	return unitOfMeasureModelToEntity(&modelUnit)
+}
+
+// ListUnits returns the whole catalogue ordered by code
+func (r *postgresUnitOfMeasureRepository) ListUnits() ([]*entity.UnitOfMeasure, error) {
+	var modelUnits []model.UnitOfMeasure
+	if err := r.DB.Order("code asc").Find(&modelUnits).Error; err != nil {
+		return nil, err
+	}
+
+	units := make([]*entity.UnitOfMeasure, len(modelUnits))
+	for i := range modelUnits {
+		unit, err := unitOfMeasureModelToEntity(&modelUnits[i])
+		if err != nil {
+			return nil, err
+		}
+		units[i] = unit
+	}
+	return units, nil
+}
+
+// Convert entity.UnitOfMeasure to model.UnitOfMeasure for saving to the database
+func unitOfMeasureEntityToModel(u *entity.UnitOfMeasure) *model.UnitOfMeasure {
+	return &model.UnitOfMeasure{
+		ID:        u.ID(),
+		Code:      u.Code(),
+		Name:      u.Name(),
+		CreatedAt: u.CreatedAt(),
+		UpdatedAt: u.UpdatedAt(),
+	}
+}
+
+// Convert model.UnitOfMeasure to entity.UnitOfMeasure for returning from the database
+func unitOfMeasureModelToEntity(m *model.UnitOfMeasure) (*entity.UnitOfMeasure, error) {
+	u := &entity.UnitOfMeasure{}
+	if err := u.MakeUnitOfMeasure(m.ID, m.Code, m.Name, m.CreatedAt, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import "gorm.io/gorm"
+
+// Repositories bundles every repository bound to the same database session
+type Repositories struct {
+	Products          PostgresProductRepository
+	Warehouses        PostgresWarehouseRepository
+	StockLevels       PostgresStockLevelRepository
+	StockMovements    PostgresStockMovementRepository
+	Reservations      PostgresReservationRepository
+	Transfers         PostgresTransferRepository
+	Suppliers         PostgresSupplierRepository
+	PurchaseOrders    PostgresPurchaseOrderRepository
+	SalesOrders       PostgresSalesOrderRepository
+	ReorderPolicies   PostgresReorderPolicyRepository
+	LowStockAlerts    PostgresLowStockAlertRepository
+	Lots              PostgresLotRepository
+	Serials           PostgresSerialRepository
+	Units             PostgresUnitOfMeasureRepository
+	ProductUnits      PostgresProductUnitRepository
+	Categories        PostgresCategoryRepository
+	Variants          PostgresProductVariantRepository
+	SKUAliases        PostgresSKUAliasRepository
+	Barcodes          PostgresBarcodeRepository
+	ProductAudits     PostgresProductAuditRepository
+	ProductImports    PostgresProductImportRepository
+	Outbox            PostgresOutboxRepository
+	Webhooks          PostgresWebhookSubscriptionRepository
+	WebhookDeliveries PostgresWebhookDeliveryRepository
+}
+
+// NewRepositories creates every repository on top of the given database session
+func NewRepositories(db DB, productOptions ...ProductRepositoryOption) Repositories {
+	products := NewPostgresProductRepository(db, productOptions...)
+	return Repositories{
+		Products:          products,
+		Warehouses:        NewPostgresWarehouseRepository(db),
+		StockLevels:       NewPostgresStockLevelRepository(db),
+		StockMovements:    NewPostgresStockMovementRepository(db),
+		Reservations:      NewPostgresReservationRepository(db),
+		Transfers:         NewPostgresTransferRepository(db),
+		Suppliers:         NewPostgresSupplierRepository(db),
+		PurchaseOrders:    NewPostgresPurchaseOrderRepository(db),
+		SalesOrders:       NewPostgresSalesOrderRepository(db),
+		ReorderPolicies:   NewPostgresReorderPolicyRepository(db),
+		LowStockAlerts:    NewPostgresLowStockAlertRepository(db),
+		Lots:              NewPostgresLotRepository(db),
+		Serials:           NewPostgresSerialRepository(db),
+		Units:             NewPostgresUnitOfMeasureRepository(db),
+		ProductUnits:      NewPostgresProductUnitRepository(db),
+		Categories:        NewPostgresCategoryRepository(db),
+		Variants:          NewPostgresProductVariantRepository(db, products),
+		SKUAliases:        NewPostgresSKUAliasRepository(db),
+		Barcodes:          NewPostgresBarcodeRepository(db),
+		ProductAudits:     NewPostgresProductAuditRepository(db),
+		ProductImports:    NewPostgresProductImportRepository(db),
+		Outbox:            NewPostgresOutboxRepository(db),
+		Webhooks:          NewPostgresWebhookSubscriptionRepository(db),
+		WebhookDeliveries: NewPostgresWebhookDeliveryRepository(db),
+	}
+}
+
+// UnitOfWork runs a group of repository calls atomically
+type UnitOfWork interface {
+	// Do runs fn inside a database transaction with repositories bound to it.
+	// The transaction is rolled back when fn returns an error and committed otherwise.
+	Do(fn func(repos Repositories) error) error
+}
+
+type gormUnitOfWork struct {
+	DB             DB
+	productOptions []ProductRepositoryOption
+}
+
+// NewUnitOfWork creates a unit of work whose repositories are configured like those of NewRepositories
+func NewUnitOfWork(db DB, productOptions ...ProductRepositoryOption) UnitOfWork {
+	return &gormUnitOfWork{DB: db, productOptions: productOptions}
+}
+
+// Do runs fn inside a transaction
+func (u *gormUnitOfWork) Do(fn func(repos Repositories) error) error {
+	return u.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx, u.productOptions...))
+	})
+}
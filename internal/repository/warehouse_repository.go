@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrWarehouseNotFound is returned when a warehouse is not found in the database
+var ErrWarehouseNotFound = errors.New("warehouse not found")
+
+// ErrWarehouseCodeTaken is returned when another warehouse already uses the code
+var ErrWarehouseCodeTaken = errors.New("warehouse code already exists")
+
+type PostgresWarehouseRepository interface {
+	Save(w *entity.Warehouse) error
+	FindByID(id uint) (*entity.Warehouse, error)
+	ListWarehouses() ([]*entity.Warehouse, error)
+}
+
+type postgresWarehouseRepository struct {
+	DB DB
+}
+
+func NewPostgresWarehouseRepository(db DB) PostgresWarehouseRepository {
+	return &postgresWarehouseRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresWarehouseRepository) Save(w *entity.Warehouse) error {
+	modelWarehouse := warehouseEntityToModel(w)
+
+	if err := r.DB.Save(modelWarehouse).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrWarehouseCodeTaken
+		}
+		return err
+	}
+
+	return w.MakeWarehouse(
+		modelWarehouse.ID,
+		modelWarehouse.Code,
+		modelWarehouse.Name,
+		modelWarehouse.CreatedAt,
+		modelWarehouse.UpdatedAt,
+	)
+}
+
+// FindByID fetches a warehouse from the database, converts model to entity, and returns it
+func (r *postgresWarehouseRepository) FindByID(id uint) (*entity.Warehouse, error) {
+	var modelWarehouse model.Warehouse
+	err := r.DB.First(&modelWarehouse, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWarehouseNotFound
+		}
+		return nil, err
+	}
+	return warehouseModelToEntity(&modelWarehouse)
+}
+
+// ListWarehouses returns every warehouse ordered by code
+func (r *postgresWarehouseRepository) ListWarehouses() ([]*entity.Warehouse, error) {
+	var modelWarehouses []model.Warehouse
+	if err := r.DB.Order("code asc").Find(&modelWarehouses).Error; err != nil {
+		return nil, err
+	}
+
+	entityWarehouses := make([]*entity.Warehouse, len(modelWarehouses))
+	for i, modelWarehouse := range modelWarehouses {
+		entityWarehouse, err := warehouseModelToEntity(&modelWarehouse)
+		if err != nil {
+			return nil, err
+		}
+		entityWarehouses[i] = entityWarehouse
+	}
+	return entityWarehouses, nil
+}
+
+// Convert entity.Warehouse to model.Warehouse for saving to the database
+func warehouseEntityToModel(w *entity.Warehouse) *model.Warehouse {
+	return &model.Warehouse{
+		ID:        w.ID(),
+		Code:      w.Code(),
+		Name:      w.Name(),
+		CreatedAt: w.CreatedAt(),
+		UpdatedAt: w.UpdatedAt(),
+	}
+}
+
+// Convert model.Warehouse to entity.Warehouse for returning from the database
+func warehouseModelToEntity(m *model.Warehouse) (*entity.Warehouse, error) {
+	w := &entity.Warehouse{}
+	if err := w.MakeWarehouse(m.ID, m.Code, m.Name, m.CreatedAt, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrWebhookDeliveryNotFound is returned when a webhook delivery is not found in the database
+var ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+// WebhookDeliveryFilter narrows down the deliveries returned from the log of a subscription
+type WebhookDeliveryFilter struct {
+	SubscriptionID uint
+	Status         entity.WebhookDeliveryStatus // Empty means every status
+	Limit          int
+	Offset         int
+}
+
+type PostgresWebhookDeliveryRepository interface {
+	Create(d *entity.WebhookDelivery) error
+	Save(d *entity.WebhookDelivery) error
+	FindForUpdate(id uint) (*entity.WebhookDelivery, error)
+	FindDueForUpdate(now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	ListDeliveries(filter WebhookDeliveryFilter) ([]*entity.WebhookDelivery, int64, error)
+}
+
+type postgresWebhookDeliveryRepository struct {
+	DB DB
+}
+
+func NewPostgresWebhookDeliveryRepository(db DB) PostgresWebhookDeliveryRepository {
+	return &postgresWebhookDeliveryRepository{DB: db}
+}
+
+// Create inserts a new delivery. A delivery of the same event to the same subscription that already
+// exists is kept as it is, so an event relayed twice is posted once.
+func (r *postgresWebhookDeliveryRepository) Create(d *entity.WebhookDelivery) error {
+	modelDelivery := webhookDeliveryEntityToModel(d)
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+		DoNothing: true,
+	}).Create(modelDelivery).Error
+	if err != nil {
+		return err
+	}
+
+	delivery, err := webhookDeliveryModelToEntity(modelDelivery)
+	if err != nil {
+		return err
+	}
+	*d = *delivery
+	return nil
+}
+
+// Save writes the outcome of an attempt of a delivery
+func (r *postgresWebhookDeliveryRepository) Save(d *entity.WebhookDelivery) error {
+	modelDelivery := webhookDeliveryEntityToModel(d)
+	if err := r.DB.Save(modelDelivery).Error; err != nil {
+		return err
+	}
+
+	delivery, err := webhookDeliveryModelToEntity(modelDelivery)
+	if err != nil {
+		return err
+	}
+	*d = *delivery
+	return nil
+}
+
+// FindForUpdate fetches a delivery by its ID and locks it until the end of the unit of work
+func (r *postgresWebhookDeliveryRepository) FindForUpdate(id uint) (*entity.WebhookDelivery, error) {
+	var modelDelivery model.WebhookDelivery
+	if err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).First(&modelDelivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return webhookDeliveryModelToEntity(&modelDelivery)
+}
+
+// FindDueForUpdate locks the pending deliveries whose next attempt is due, oldest first. Deliveries
+// locked by another dispatcher are skipped. It must be called inside a unit of work.
+func (r *postgresWebhookDeliveryRepository) FindDueForUpdate(now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	var modelDeliveries []model.WebhookDelivery
+	err := r.DB.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", string(entity.WebhookDeliveryPending), now).
+		Order("next_attempt_at asc, id asc").
+		Limit(limit).
+		Find(&modelDeliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhookDeliveryModelsToEntities(modelDeliveries)
+}
+
+// ListDeliveries returns a page of the delivery log of a subscription, newest first, with the total
+// number of matching deliveries
+func (r *postgresWebhookDeliveryRepository) ListDeliveries(filter WebhookDeliveryFilter) ([]*entity.WebhookDelivery, int64, error) {
+	var total int64
+	if err := r.applyFilter(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var modelDeliveries []model.WebhookDelivery
+	err := r.applyFilter(filter).
+		Order("id desc").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&modelDeliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, err := webhookDeliveryModelsToEntities(modelDeliveries)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// applyFilter builds the query shared by the list and count queries
+func (r *postgresWebhookDeliveryRepository) applyFilter(filter WebhookDeliveryFilter) *gorm.DB {
+	query := r.DB.Model(&model.WebhookDelivery{}).Where("subscription_id = ?", filter.SubscriptionID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	return query
+}
+
+// Convert a slice of model.WebhookDelivery to entity.WebhookDelivery
+func webhookDeliveryModelsToEntities(modelDeliveries []model.WebhookDelivery) ([]*entity.WebhookDelivery, error) {
+	deliveries := make([]*entity.WebhookDelivery, len(modelDeliveries))
+	for i, modelDelivery := range modelDeliveries {
+		delivery, err := webhookDeliveryModelToEntity(&modelDelivery)
+		if err != nil {
+			return nil, err
+		}
+		deliveries[i] = delivery
+	}
+	return deliveries, nil
+}
+
+// Convert entity.WebhookDelivery to model.WebhookDelivery for saving to the database
+func webhookDeliveryEntityToModel(d *entity.WebhookDelivery) *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		ID:             d.ID(),
+		SubscriptionID: d.SubscriptionID(),
+		EventID:        d.EventID(),
+		EventType:      string(d.EventType()),
+		Payload:        d.Payload(),
+		Status:         string(d.Status()),
+		Attempts:       d.Attempts(),
+		NextAttemptAt:  d.NextAttemptAt(),
+		LastStatusCode: d.LastStatusCode(),
+		LastError:      d.LastError(),
+		DeliveredAt:    d.DeliveredAt(),
+		CreatedAt:      d.CreatedAt(),
+	}
+}
+
+// Convert model.WebhookDelivery to entity.WebhookDelivery for returning from the database
+func webhookDeliveryModelToEntity(m *model.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	d := &entity.WebhookDelivery{}
+	if err := d.MakeWebhookDelivery(
+		m.ID,
+		m.SubscriptionID,
+		m.EventID,
+		entity.EventType(m.EventType),
+		m.Payload,
+		entity.WebhookDeliveryStatus(m.Status),
+		m.Attempts,
+		m.NextAttemptAt,
+		m.LastStatusCode,
+		m.LastError,
+		m.DeliveredAt,
+		m.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrWebhookSubscriptionNotFound is returned when a webhook subscription is not found in the database
+var ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+
+type PostgresWebhookSubscriptionRepository interface {
+	Save(s *entity.WebhookSubscription) error
+	FindByID(id uint) (*entity.WebhookSubscription, error)
+	ListSubscriptions() ([]*entity.WebhookSubscription, error)
+	Delete(id uint) error
+}
+
+type postgresWebhookSubscriptionRepository struct {
+	DB DB
+}
+
+func NewPostgresWebhookSubscriptionRepository(db DB) PostgresWebhookSubscriptionRepository {
+	return &postgresWebhookSubscriptionRepository{DB: db}
+}
+
+// Save converts entity to model, saves it to the database, and updates the entity with the generated values
+func (r *postgresWebhookSubscriptionRepository) Save(s *entity.WebhookSubscription) error {
+	modelSubscription := webhookSubscriptionEntityToModel(s)
+	if err := r.DB.Save(modelSubscription).Error; err != nil {
+		return err
+	}
+
+	subscription, err := webhookSubscriptionModelToEntity(modelSubscription)
+	if err != nil {
+		return err
+	}
+	*s = *subscription
+	return nil
+}
+
+// FindByID fetches a webhook subscription from the database, converts model to entity, and returns it
+func (r *postgresWebhookSubscriptionRepository) FindByID(id uint) (*entity.WebhookSubscription, error) {
+	var modelSubscription model.WebhookSubscription
+	if err := r.DB.First(&modelSubscription, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return webhookSubscriptionModelToEntity(&modelSubscription)
+}
+
+// ListSubscriptions returns every webhook subscription, oldest first
+func (r *postgresWebhookSubscriptionRepository) ListSubscriptions() ([]*entity.WebhookSubscription, error) {
+	var modelSubscriptions []model.WebhookSubscription
+	if err := r.DB.Order("id asc").Find(&modelSubscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*entity.WebhookSubscription, len(modelSubscriptions))
+	for i, modelSubscription := range modelSubscriptions {
+		subscription, err := webhookSubscriptionModelToEntity(&modelSubscription)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions[i] = subscription
+	}
+	return subscriptions, nil
+}
+
+// Delete removes a webhook subscription together with its delivery log
+func (r *postgresWebhookSubscriptionRepository) Delete(id uint) error {
+	result := r.DB.Where("id = ?", id).Delete(&model.WebhookSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookSubscriptionNotFound
+	}
+	return nil
+}
+
+// Convert entity.WebhookSubscription to model.WebhookSubscription for saving to the database
+func webhookSubscriptionEntityToModel(s *entity.WebhookSubscription) *model.WebhookSubscription {
+	eventTypes := make(model.StringList, len(s.EventTypes()))
+	for i, eventType := range s.EventTypes() {
+		eventTypes[i] = string(eventType)
+	}
+	return &model.WebhookSubscription{
+		ID:         s.ID(),
+		URL:        s.URL(),
+		Secret:     s.Secret(),
+		EventTypes: eventTypes,
+		CreatedAt:  s.CreatedAt(),
+		UpdatedAt:  s.UpdatedAt(),
+	}
+}
+
+// Convert model.WebhookSubscription to entity.WebhookSubscription for returning from the database
+func webhookSubscriptionModelToEntity(m *model.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	eventTypes := make([]entity.EventType, len(m.EventTypes))
+	for i, eventType := range m.EventTypes {
+		eventTypes[i] = entity.EventType(eventType)
+	}
+	s := &entity.WebhookSubscription{}
+	if err := s.MakeWebhookSubscription(m.ID, m.URL, m.Secret, eventTypes, m.CreatedAt, m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
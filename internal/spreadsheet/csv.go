@@ -0,0 +1,83 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// utf8BOM is the byte order mark spreadsheet programs put in front of UTF-8 CSV exports
+const utf8BOM = "\xef\xbb\xbf"
+
+// ReadCSV returns the records of a CSV file. Records may differ in length, and a record quoting a line
+// break keeps the line it starts on.
+func ReadCSV(r io.Reader) ([]Row, error) {
+	buffered := bufio.NewReader(r)
+	if prefix, err := buffered.Peek(len(utf8BOM)); err == nil && string(prefix) == utf8BOM {
+		if _, err := buffered.Discard(len(utf8BOM)); err != nil {
+			return nil, err
+		}
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrMalformed, parseErr.StartLine, parseErr.Err)
+			}
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, Row{Line: line, Cells: record})
+	}
+}
+
+// CSVWriter writes rows as CSV as they come. Cells starting like a formula are prefixed with a quote
+// so that a spreadsheet program opening the file shows them as text instead of evaluating them.
+type CSVWriter struct {
+	writer *csv.Writer
+}
+
+// NewCSVWriter creates a CSV writer on top of w
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w)}
+}
+
+// WriteRow writes one record
+func (w *CSVWriter) WriteRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			cell = "'" + cell
+		}
+		escaped[i] = cell
+	}
+	return w.writer.Write(escaped)
+}
+
+// Close flushes the records still buffered
+func (w *CSVWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// WriteCSV writes records as CSV, escaping them like CSVWriter
+func WriteCSV(w io.Writer, records [][]string) error {
+	writer := NewCSVWriter(w)
+	for _, record := range records {
+		if err := writer.WriteRow(record); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
@@ -0,0 +1,88 @@
+// Package spreadsheet reads and writes the rows of the tabular files products are imported from and
+// exported to
+package spreadsheet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format names a supported spreadsheet file format
+type Format string
+
+// Supported spreadsheet formats
+const (
+	FormatCSV  Format = "csv"  // Comma separated values, UTF-8 with or without a byte order mark
+	FormatXLSX Format = "xlsx" // Office Open XML workbook, the first worksheet is read
+)
+
+// Spreadsheet reading errors
+var (
+	ErrUnsupportedFormat = errors.New("unsupported spreadsheet format, use .csv or .xlsx")
+	ErrMalformed         = errors.New("malformed spreadsheet")
+	ErrTooLarge          = errors.New("spreadsheet is too large")
+)
+
+// Row is one line of a spreadsheet with the text of its cells. Line is the line number a user sees
+// when opening the file, starting at 1.
+type Row struct {
+	Line  int
+	Cells []string
+}
+
+// IsBlank reports whether every cell of the row is empty or whitespace
+func (r Row) IsBlank() bool {
+	for _, cell := range r.Cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// RowWriter writes the rows of a spreadsheet one at a time, so that files of any length can be
+// produced without holding them in memory. Close must be called once the last row was written.
+type RowWriter interface {
+	WriteRow(cells []string) error
+	Close() error
+}
+
+// NewWriter creates a writer of files in the given format on top of w
+func NewWriter(format Format, w io.Writer) (RowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		writer, err := NewXLSXWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		return writer, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// FormatOf picks the format of a file from the extension of its name
+func FormatOf(fileName string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Read returns the rows of a file in the given format
+func Read(format Format, data []byte) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return ReadCSV(bytes.NewReader(data))
+	case FormatXLSX:
+		return ReadXLSX(data)
+	}
+	return nil, ErrUnsupportedFormat
+}
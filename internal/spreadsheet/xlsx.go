@@ -0,0 +1,215 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxXLSXPartSize caps how much a single part of a workbook may inflate to, so that a small upload
+// cannot expand into gigabytes
+const maxXLSXPartSize = 64 << 20
+
+// xlsxWorkbook lists the sheets of a workbook in the order they are shown
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationshipID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships maps the relationship IDs of a workbook to the parts they point at
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText is a string item, either plain or made of formatted runs
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String joins the runs of a formatted string
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var joined strings.Builder
+	for _, run := range t.Runs {
+		joined.WriteString(run.Text)
+	}
+	return joined.String()
+}
+
+// xlsxSharedStrings is the table of strings cells refer to by index
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxWorksheet holds the rows of a worksheet. Empty rows and cells are left out of the file.
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Reference string   `xml:"r,attr"`
+			Type      string   `xml:"t,attr"`
+			Value     string   `xml:"v"`
+			Inline    xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX returns the rows of the first worksheet of a workbook. Cells hold the text they show
+// unformatted: numbers as stored and booleans as TRUE or FALSE. Dates come out as serial numbers.
+func ReadXLSX(data []byte) ([]Row, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	parts := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		parts[file.Name] = file
+	}
+
+	sheetPart, err := firstSheetPart(parts)
+	if err != nil {
+		return nil, err
+	}
+	var shared xlsxSharedStrings
+	if _, ok := parts["xl/sharedStrings.xml"]; ok {
+		if err := decodePart(parts, "xl/sharedStrings.xml", &shared); err != nil {
+			return nil, err
+		}
+	}
+	var sheet xlsxWorksheet
+	if err := decodePart(parts, sheetPart, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, 0, len(sheet.Rows))
+	for i, sheetRow := range sheet.Rows {
+		row := Row{Line: sheetRow.Number}
+		if row.Line == 0 {
+			row.Line = i + 1
+		}
+		for _, cell := range sheetRow.Cells {
+			column := len(row.Cells)
+			if cell.Reference != "" {
+				if column, err = columnIndex(cell.Reference); err != nil {
+					return nil, err
+				}
+			}
+			var text string
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(shared.Items) {
+					return nil, fmt.Errorf("%w: cell %s refers to a missing shared string", ErrMalformed, cell.Reference)
+				}
+				text = shared.Items[index].String()
+			case "inlineStr":
+				text = cell.Inline.String()
+			case "b":
+				text = "FALSE"
+				if cell.Value == "1" {
+					text = "TRUE"
+				}
+			default:
+				text = cell.Value
+			}
+			for len(row.Cells) < column {
+				row.Cells = append(row.Cells, "")
+			}
+			row.Cells = append(row.Cells[:column], text)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// firstSheetPart resolves the part holding the first worksheet of the workbook
+func firstSheetPart(parts map[string]*zip.File) (string, error) {
+	var workbook xlsxWorkbook
+	if err := decodePart(parts, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("%w: workbook has no worksheet", ErrMalformed)
+	}
+
+	var relationships xlsxRelationships
+	if err := decodePart(parts, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return "", err
+	}
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID != workbook.Sheets[0].RelationshipID {
+			continue
+		}
+		// Targets are relative to the xl folder unless they start at the root of the package
+		if strings.HasPrefix(relationship.Target, "/") {
+			return strings.TrimPrefix(relationship.Target, "/"), nil
+		}
+		return path.Join("xl", relationship.Target), nil
+	}
+	// Workbooks saved in the strict variant name their relationships in another namespace
+	if _, ok := parts["xl/worksheets/sheet1.xml"]; ok {
+		return "xl/worksheets/sheet1.xml", nil
+	}
+	return "", fmt.Errorf("%w: first worksheet cannot be found", ErrMalformed)
+}
+
+// decodePart unmarshals an XML part of the workbook
+func decodePart(parts map[string]*zip.File, name string, target interface{}) error {
+	file, ok := parts[name]
+	if !ok {
+		return fmt.Errorf("%w: %s is missing", ErrMalformed, name)
+	}
+	if file.UncompressedSize64 > maxXLSXPartSize {
+		return ErrTooLarge
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	defer reader.Close()
+
+	// The declared size cannot be trusted, so inflating stops one byte past the limit
+	content, err := io.ReadAll(io.LimitReader(reader, maxXLSXPartSize+1))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if len(content) > maxXLSXPartSize {
+		return ErrTooLarge
+	}
+	if err := xml.Unmarshal(content, target); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrMalformed, name, err)
+	}
+	return nil
+}
+
+// columnIndex returns the zero based column of a cell reference such as C12
+func columnIndex(reference string) (int, error) {
+	column := 0
+	letters := 0
+	for _, r := range reference {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A') + 1
+		letters++
+	}
+	// The widest worksheet has 16384 columns, XFD
+	if letters == 0 || letters > 3 || column > 16384 {
+		return 0, fmt.Errorf("%w: invalid cell reference %q", ErrMalformed, reference)
+	}
+	return column - 1, nil
+}
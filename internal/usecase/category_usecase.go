@@ -0,0 +1,190 @@
+// /internal/usecase/category_usecase.go
+package usecase
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+)
+
+// CategoryInput carries the name and place in the tree of a category
+type CategoryInput struct {
+	Name     string
+	ParentID uint // Zero places the category at the root of the tree
+}
+
+// CategoryDetail is a category with the path leading to it and the categories directly below it
+type CategoryDetail struct {
+	Category  *entity.Category
+	Ancestors []*entity.Category // From the root down to the parent
+	Children  []*entity.Category
+}
+
+type CategoryUsecase interface {
+	CreateCategory(input CategoryInput) (*entity.Category, error)
+	GetCategory(id uint) (*CategoryDetail, error)
+	ListCategories() ([]*entity.Category, error)
+	UpdateCategory(id uint, input CategoryInput) (*entity.Category, error)
+	DeleteCategory(id uint) error
+}
+
+type categoryUsecase struct {
+	repos repository.Repositories
+	uow   repository.UnitOfWork
+}
+
+func NewCategoryUsecase(repos repository.Repositories, uow repository.UnitOfWork) CategoryUsecase {
+	return &categoryUsecase{repos: repos, uow: uow}
+}
+
+// CreateCategory adds a category below its parent, or at the root when it has none
+func (u *categoryUsecase) CreateCategory(input CategoryInput) (*entity.Category, error) {
+	parent, err := findParentCategory(u.repos, input.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	category, err := entity.NewCategory(input.Name, parent)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	if err := saveCategory(u.repos, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// GetCategory returns a category with its ancestors and children
+func (u *categoryUsecase) GetCategory(id uint) (*CategoryDetail, error) {
+	category, err := u.repos.Categories.FindByID(id)
+	if err != nil {
+		if err == repository.ErrCategoryNotFound {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	ancestors, err := u.repos.Categories.FindByIDs(category.AncestorIDs())
+	if err != nil {
+		return nil, err
+	}
+	children, err := u.repos.Categories.FindChildren(id)
+	if err != nil {
+		return nil, err
+	}
+	return &CategoryDetail{Category: category, Ancestors: ancestors, Children: children}, nil
+}
+
+// ListCategories returns the whole tree with parents before their children
+func (u *categoryUsecase) ListCategories() ([]*entity.Category, error) {
+	return u.repos.Categories.ListCategories()
+}
+
+// UpdateCategory renames a category and moves it, with everything below it, to a new parent
+func (u *categoryUsecase) UpdateCategory(id uint, input CategoryInput) (*entity.Category, error) {
+	var category *entity.Category
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		// The category and its new parent are locked in the order of their IDs, so that two moves
+		// crossing each other wait for one another and check for cycles against the paths the first
+		// one left, instead of both passing the check against the paths they started from
+		var parent *entity.Category
+		var err error
+		if input.ParentID != 0 && input.ParentID < id {
+			if parent, err = lockParentCategory(repos, input.ParentID); err != nil {
+				return err
+			}
+		}
+		category, err = repos.Categories.FindForUpdate(id)
+		if err != nil {
+			if err == repository.ErrCategoryNotFound {
+				return ErrCategoryNotFound
+			}
+			return err
+		}
+		if input.ParentID >= id {
+			if parent, err = lockParentCategory(repos, input.ParentID); err != nil {
+				return err
+			}
+		}
+
+		if err := category.Rename(input.Name); err != nil {
+			return invalidInput(err)
+		}
+		if input.ParentID == category.ParentID() {
+			return saveCategory(repos, category)
+		}
+
+		oldSubtree := category.SubtreePath()
+		if err := category.MoveTo(parent); err != nil {
+			return invalidInput(err)
+		}
+		if err := saveCategory(repos, category); err != nil {
+			return err
+		}
+		return repos.Categories.MoveSubtree(oldSubtree, category.SubtreePath())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// DeleteCategory removes a category that has neither subcategories nor products
+func (u *categoryUsecase) DeleteCategory(id uint) error {
+	return u.uow.Do(func(repos repository.Repositories) error {
+		if _, err := repos.Categories.FindForUpdate(id); err != nil {
+			if err == repository.ErrCategoryNotFound {
+				return ErrCategoryNotFound
+			}
+			return err
+		}
+		children, err := repos.Categories.CountChildren(id)
+		if err != nil {
+			return err
+		}
+		products, err := repos.Categories.CountProducts(id)
+		if err != nil {
+			return err
+		}
+		if children > 0 || products > 0 {
+			return ErrCategoryNotEmpty
+		}
+		return repos.Categories.Delete(id)
+	})
+}
+
+// findParentCategory returns the category a category is placed below, or nil for the root
+func findParentCategory(repos repository.Repositories, parentID uint) (*entity.Category, error) {
+	if parentID == 0 {
+		return nil, nil
+	}
+	parent, err := repos.Categories.FindByID(parentID)
+	if err != nil {
+		if err == repository.ErrCategoryNotFound {
+			return nil, ErrParentCategoryNotFound
+		}
+		return nil, err
+	}
+	return parent, nil
+}
+
+// lockParentCategory returns the category a category is moved below, locked until the end of the unit
+// of work so that its path cannot change while the move is checked and made
+func lockParentCategory(repos repository.Repositories, parentID uint) (*entity.Category, error) {
+	parent, err := repos.Categories.FindForUpdate(parentID)
+	if err != nil {
+		if err == repository.ErrCategoryNotFound {
+			return nil, ErrParentCategoryNotFound
+		}
+		return nil, err
+	}
+	return parent, nil
+}
+
+// saveCategory saves a category and reports a sibling with the same name as a taken name
+func saveCategory(repos repository.Repositories, category *entity.Category) error {
+	if err := repos.Categories.Save(category); err != nil {
+		if err == repository.ErrCategoryNameTaken {
+			return ErrCategoryNameTaken
+		}
+		return err
+	}
+	return nil
+}
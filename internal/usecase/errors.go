@@ -1,6 +1,120 @@
 package usecase
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrProductNotFound is returned when a product is not found in the repository
 var ErrProductNotFound = errors.New("product not found")
+
+// ErrProductVersionMismatch is returned when a product was changed after the caller read it
+var ErrProductVersionMismatch = errors.New("product was changed since it was read")
+
+// ErrNoProductHistory is returned when the audit trail of a product has no record at the time asked for
+var ErrNoProductHistory = errors.New("product has no recorded history at that time")
+
+// ErrProductImportNotFound is returned when the report of a product import is not found in the repository
+var ErrProductImportNotFound = errors.New("product import not found")
+
+// ErrWarehouseNotFound is returned when a warehouse is not found in the repository
+var ErrWarehouseNotFound = errors.New("warehouse not found")
+
+// ErrWarehouseCodeTaken is returned when a warehouse code is already in use
+var ErrWarehouseCodeTaken = errors.New("warehouse code already exists")
+
+// ErrProductNotStockable is returned when bringing new stock in for a discontinued, archived or deleted product
+var ErrProductNotStockable = errors.New("product is discontinued or deleted and cannot take new stock")
+
+// ErrInsufficientStock is returned when a change would take out stock that is not available
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// ErrInvalidInput wraps domain validation failures so callers can report them as unprocessable
+var ErrInvalidInput = errors.New("invalid input")
+
+// invalidInput marks err as a domain validation failure
+func invalidInput(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidInput, err)
+}
+
+// ErrReservationNotFound is returned when a reservation is not found in the repository
+var ErrReservationNotFound = errors.New("reservation not found")
+
+// ErrReservationNotPending is returned when a reservation was already confirmed, released or expired
+var ErrReservationNotPending = errors.New("reservation is no longer pending")
+
+// ErrReservationExpired is returned when confirming a reservation whose time to live has elapsed
+var ErrReservationExpired = errors.New("reservation has expired")
+
+// ErrTransferNotFound is returned when a transfer is not found in the repository
+var ErrTransferNotFound = errors.New("transfer not found")
+
+// ErrTransferNotDraft is returned when dispatching or cancelling a transfer that already left its draft state
+var ErrTransferNotDraft = errors.New("transfer is no longer a draft")
+
+// ErrTransferNotInTransit is returned when receiving or closing a transfer that was not dispatched or is fully received
+var ErrTransferNotInTransit = errors.New("transfer is not in transit")
+
+// ErrSupplierNotFound is returned when a supplier is not found in the repository
+var ErrSupplierNotFound = errors.New("supplier not found")
+
+// ErrSupplierCodeTaken is returned when a supplier code is already in use
+var ErrSupplierCodeTaken = errors.New("supplier code already exists")
+
+// ErrWebhookNotFound is returned when a webhook subscription is not found in the repository
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// ErrPurchaseOrderNotFound is returned when a purchase order is not found in the repository
+var ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+
+// ErrPurchaseOrderNotDraft is returned when submitting a purchase order that was already submitted
+var ErrPurchaseOrderNotDraft = errors.New("purchase order is no longer a draft")
+
+// ErrPurchaseOrderNotReceivable is returned when receiving goods against a draft, fully received or closed order
+var ErrPurchaseOrderNotReceivable = errors.New("purchase order is not open for receiving")
+
+// ErrPurchaseOrderNotClosable is returned when closing a purchase order before any goods arrived
+var ErrPurchaseOrderNotClosable = errors.New("purchase order cannot be closed before goods are received")
+
+// ErrSalesOrderNotFound is returned when a sales order is not found in the repository
+var ErrSalesOrderNotFound = errors.New("sales order not found")
+
+// ErrSalesOrderInvalidTransition is returned when a sales order is not in the state a fulfilment step requires
+var ErrSalesOrderInvalidTransition = errors.New("sales order cannot move to the requested status")
+
+// ErrLotNotFound is returned when taking stock out of a lot the stock level does not hold
+var ErrLotNotFound = errors.New("lot not found")
+
+// ErrSerialNotFound is returned when a serial number was never received
+var ErrSerialNotFound = errors.New("serial not found")
+
+// ErrUnitOfMeasureNotFound is returned when a unit is not in the catalogue
+var ErrUnitOfMeasureNotFound = errors.New("unit of measure not found")
+
+// ErrUnitCodeTaken is returned when a unit code is already in use
+var ErrUnitCodeTaken = errors.New("unit code already exists")
+
+// ErrCategoryNotFound is returned when a category is not found in the repository
+var ErrCategoryNotFound = errors.New("category not found")
+
+// ErrParentCategoryNotFound is returned when placing a category below a category that does not exist
+var ErrParentCategoryNotFound = errors.New("parent category not found")
+
+// ErrCategoryNameTaken is returned when a sibling category already uses the name
+var ErrCategoryNameTaken = errors.New("category name already exists below the parent")
+
+// ErrCategoryNotEmpty is returned when deleting a category that still has subcategories or products
+var ErrCategoryNotEmpty = errors.New("category still has subcategories or products")
+
+// ErrProductVariantExists is returned when another request created the same variant concurrently
+var ErrProductVariantExists = errors.New("product variant already exists")
+
+// ErrSKUTaken is returned when a SKU belongs, or used to belong, to another product, or when no free
+// SKU could be generated for a new product
+var ErrSKUTaken = errors.New("sku already exists")
+
+// ErrBarcodeNotFound is returned when a product does not carry a barcode
+var ErrBarcodeNotFound = errors.New("barcode not found")
+
+// ErrBarcodeTaken is returned when a product already carries the barcode
+var ErrBarcodeTaken = errors.New("barcode already exists")
@@ -0,0 +1,85 @@
+// /internal/usecase/event_relay_usecase.go
+package usecase
+
+import (
+	"fmt"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"time"
+)
+
+// EventPublisher delivers domain events to the services downstream. Events can arrive more than once,
+// so consumers should skip the IDs they have already seen.
+type EventPublisher interface {
+	Publish(event *entity.DomainEvent) error
+}
+
+// EventRelayRun summarises one pass of the relay over the outbox
+type EventRelayRun struct {
+	Published int
+	Failed    int
+}
+
+type EventRelayUsecase interface {
+	RelayEvents(now time.Time) (EventRelayRun, error)
+}
+
+// defaultEventRelayBatchSize is used when the relay is given no usable batch size
+const defaultEventRelayBatchSize = 100
+
+type eventRelayUsecase struct {
+	uow       repository.UnitOfWork
+	publisher EventPublisher
+	batchSize int // Events delivered per transaction
+}
+
+func NewEventRelayUsecase(uow repository.UnitOfWork, publisher EventPublisher, batchSize int) EventRelayUsecase {
+	if batchSize <= 0 {
+		batchSize = defaultEventRelayBatchSize
+	}
+	return &eventRelayUsecase{uow: uow, publisher: publisher, batchSize: batchSize}
+}
+
+// RelayEvents delivers the pending events of the outbox in the order they were written, one batch per
+// transaction, until the outbox is drained. An event is marked published only after the publisher took
+// it, so a crash in between delivers it again. The first failure records the error on the event and
+// stops the run, leaving it and the events after it for the next run.
+func (u *eventRelayUsecase) RelayEvents(now time.Time) (EventRelayRun, error) {
+	var run EventRelayRun
+	for {
+		var fetched, published int
+		var publishErr error
+		err := u.uow.Do(func(repos repository.Repositories) error {
+			events, err := repos.Outbox.FindPendingForUpdate(u.batchSize)
+			if err != nil {
+				return err
+			}
+			fetched, published = len(events), 0
+
+			for _, event := range events {
+				if err := u.publisher.Publish(event); err != nil {
+					publishErr = fmt.Errorf("publish event %d: %w", event.ID(), err)
+					event.RecordFailure(err)
+					return repos.Outbox.Save(event)
+				}
+				event.MarkPublished(now)
+				if err := repos.Outbox.Save(event); err != nil {
+					return err
+				}
+				published++
+			}
+			return nil
+		})
+		if err != nil {
+			return run, err
+		}
+		run.Published += published
+		if publishErr != nil {
+			run.Failed++
+			return run, publishErr
+		}
+		if fetched < u.batchSize {
+			return run, nil
+		}
+	}
+}
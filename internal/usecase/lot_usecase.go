@@ -0,0 +1,159 @@
+// /internal/usecase/lot_usecase.go
+package usecase
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"time"
+)
+
+// ExpiringLotFilter narrows down the lots returned from the expiring-soon report
+type ExpiringLotFilter = repository.ExpiringLotFilter
+
+// LotInput names the lot a stock movement is booked against
+type LotInput struct {
+	Number         string
+	ManufacturedAt *time.Time // Only used when the lot is received for the first time
+	ExpiresAt      time.Time  // Required when the lot is received for the first time
+}
+
+// LotPortion is the part of a stock movement booked against one lot
+type LotPortion struct {
+	Lot      LotInput
+	Quantity int64 // Positive, the movement says whether it goes into or out of the lot
+}
+
+type LotUsecase interface {
+	GetProductLots(productID uint) ([]*entity.Lot, error)
+	ListExpiringLots(within time.Duration, filter ExpiringLotFilter) ([]*entity.Lot, int64, error)
+}
+
+type lotUsecase struct {
+	repos repository.Repositories
+}
+
+func NewLotUsecase(repos repository.Repositories) LotUsecase {
+	return &lotUsecase{repos: repos}
+}
+
+// GetProductLots returns every lot of a product across all warehouses
+func (u *lotUsecase) GetProductLots(productID uint) ([]*entity.Lot, error) {
+	if _, err := u.repos.Products.FindByID(productID); err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return u.repos.Lots.FindByProductID(productID)
+}
+
+// ListExpiringLots returns a page of the lots holding stock that expire within the given period,
+// including lots that already expired, with the total number of matching lots
+func (u *lotUsecase) ListExpiringLots(within time.Duration, filter ExpiringLotFilter) ([]*entity.Lot, int64, error) {
+	filter.ExpiresBefore = time.Now().Add(within)
+	return u.repos.Lots.ListExpiringLots(filter)
+}
+
+// moveLots books a movement against the lots of its locked stock level before the movement is applied
+// and returns the part of it booked against each lot. Inbound movements go into the named lot or are
+// split over the given lot portions, the rest of them staying untracked.
+// It must run inside a unit of work after the stock level was locked.
+func moveLots(repos repository.Repositories, stockLevel *entity.StockLevel, movement *entity.StockMovement, tracking MovementTracking) ([]LotPortion, error) {
+	if tracking.Lot != nil {
+		quantity := movement.Quantity()
+		if quantity < 0 {
+			quantity = -quantity
+		}
+		return moveLotPortions(repos, movement, []LotPortion{{Lot: *tracking.Lot, Quantity: quantity}})
+	}
+	if movement.Quantity() > 0 {
+		return moveLotPortions(repos, movement, tracking.Lots)
+	}
+
+	lots, err := repos.Lots.FindInStock(movement.ProductID(), movement.WarehouseID())
+	if err != nil || len(lots) == 0 {
+		return nil, err
+	}
+
+	untracked := stockLevel.OnHand()
+	held := make(map[*entity.Lot]int64, len(lots))
+	for _, lot := range lots {
+		untracked -= lot.Quantity()
+		held[lot] = lot.Quantity()
+	}
+	consumed, err := entity.ConsumeFirstExpiring(lots, untracked, -movement.Quantity(), time.Now())
+	if err != nil {
+		if errors.Is(err, entity.ErrInsufficientStock) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, invalidInput(err)
+	}
+
+	portions := make([]LotPortion, 0, len(consumed))
+	for _, lot := range consumed {
+		if err := repos.Lots.Save(lot); err != nil {
+			return nil, err
+		}
+		portions = append(portions, LotPortion{
+			Lot:      LotInput{Number: lot.LotNumber(), ManufacturedAt: lot.ManufacturedAt(), ExpiresAt: lot.ExpiresAt()},
+			Quantity: held[lot] - lot.Quantity(),
+		})
+	}
+	return portions, nil
+}
+
+// moveLotPortions books each portion of a movement against its named lot and returns the portions
+func moveLotPortions(repos repository.Repositories, movement *entity.StockMovement, portions []LotPortion) ([]LotPortion, error) {
+	for i := range portions {
+		if err := moveNamedLot(repos, movement, &portions[i].Lot, portions[i].Quantity); err != nil {
+			return nil, err
+		}
+	}
+	return portions, nil
+}
+
+// moveNamedLot receives quantity of an inbound movement into the named lot, creating the lot on its first
+// receipt, or takes quantity of an outbound movement out of it. Expired lots may still be taken out of,
+// e.g. to write them off.
+func moveNamedLot(repos repository.Repositories, movement *entity.StockMovement, input *LotInput, quantity int64) error {
+	lot, err := repos.Lots.FindByNumber(movement.ProductID(), movement.WarehouseID(), input.Number)
+	switch {
+	case err == repository.ErrLotNotFound && movement.Quantity() > 0:
+		if lot, err = entity.NewLot(movement.ProductID(), movement.WarehouseID(), input.Number, input.ManufacturedAt, input.ExpiresAt); err != nil {
+			return invalidInput(err)
+		}
+	case err == repository.ErrLotNotFound:
+		return ErrLotNotFound
+	case err != nil:
+		return err
+	}
+
+	if movement.Quantity() > 0 {
+		err = lot.Receive(quantity, input.ExpiresAt)
+	} else {
+		err = lot.Consume(quantity)
+	}
+	if err != nil {
+		if errors.Is(err, entity.ErrLotQuantityExceeded) {
+			return ErrInsufficientStock
+		}
+		return invalidInput(err)
+	}
+	return repos.Lots.Save(lot)
+}
+
+// lockAllocatableStock locks a stock level and keeps the quantity held in its expired lots from being
+// promised. It must run inside a unit of work.
+func lockAllocatableStock(repos repository.Repositories, productID uint, warehouseID uint) (*entity.StockLevel, error) {
+	stockLevel, err := repos.StockLevels.FindForUpdate(productID, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	expired, err := repos.Lots.SumExpiredQuantity(productID, warehouseID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	stockLevel.ExcludeExpired(expired)
+	return stockLevel, nil
+}
@@ -0,0 +1,279 @@
+// /internal/usecase/product_import_usecase.go
+package usecase
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"strings"
+	"time"
+)
+
+// ProductImportRow is a product read from an import file. Rows carrying errors failed validation while
+// the file was read and are rejected as they are.
+type ProductImportRow struct {
+	Line            int
+	Product         ProductInput
+	SerializedGiven bool              // Set when the row names whether the product is serialized
+	Errors          map[string]string // Validation messages keyed by the request field they concern
+}
+
+// ProductImportInput is a file of products to import
+type ProductImportInput struct {
+	FileName string
+	DryRun   bool // Reports what the import would do and stores nothing but the report
+	Rows     []ProductImportRow
+}
+
+// Messages reported for rows the catalogue refuses
+const (
+	importSKUTaken           = "SKU is already used by another product."
+	importSKUDeleted         = "SKU belongs to a deleted product, restore it before importing."
+	importSKUInvalid         = "SKU may only contain letters, digits, '.', '_' or '-' and must start with a letter or digit."
+	importUnitNotFound       = "Stock unit is not in the unit of measure catalogue."
+	importCategoryNotFound   = "Category does not exist."
+	importSerializedReadOnly = "Serialized cannot be changed on an existing product."
+	importStockUnitReadOnly  = "Stock unit cannot be changed on an existing product."
+)
+
+// productImportBatchSize is the number of rows imported per transaction
+const productImportBatchSize = 500
+
+// errDryRun rolls back the transaction of a dry run once every row was tried
+var errDryRun = errors.New("dry run")
+
+// productImportOutcome tells what importing a row did
+type productImportOutcome int
+
+const (
+	productImportCreated productImportOutcome = iota
+	productImportUpdated
+	productImportUnchanged
+	productImportRejected
+)
+
+// productImportResult is what importing a row did, with the messages of a rejected row
+type productImportResult struct {
+	row     ProductImportRow
+	outcome productImportOutcome
+	errors  map[string]string
+}
+
+type ProductImportUsecase interface {
+	ImportProducts(input ProductImportInput, origin entity.ChangeOrigin) (*entity.ProductImport, error)
+	GetProductImport(id uint) (*entity.ProductImport, error)
+}
+
+type productImportUsecase struct {
+	repos repository.Repositories
+	uow   repository.UnitOfWork
+}
+
+func NewProductImportUsecase(repos repository.Repositories, uow repository.UnitOfWork) ProductImportUsecase {
+	return &productImportUsecase{repos: repos, uow: uow}
+}
+
+// ImportProducts creates a product for every row whose SKU is new or left empty, and updates the name
+// and category of the product carrying the SKU of any other row. Rows are imported in batches, one
+// transaction each. When a batch fails, the batches before it stay imported: the report is marked as
+// failed at the first line of the failed batch, stored, and returned together with the error. A dry
+// run tries every row in one transaction that is rolled back. The report is stored either way.
+func (u *productImportUsecase) ImportProducts(input ProductImportInput, origin entity.ChangeOrigin) (*entity.ProductImport, error) {
+	report := entity.NewProductImport(input.FileName, input.DryRun, time.Now())
+
+	// The outcomes of a batch reach the report only once its transaction is over, so that a batch
+	// rolled back on a failure is not counted
+	importBatch := func(repos repository.Repositories, rows []ProductImportRow) ([]productImportResult, error) {
+		results := make([]productImportResult, 0, len(rows))
+		for _, row := range rows {
+			outcome, rowErrors, err := importProductRow(repos, row, origin)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, productImportResult{row: row, outcome: outcome, errors: rowErrors})
+		}
+		return results, nil
+	}
+
+	if input.DryRun {
+		var results []productImportResult
+		err := u.uow.Do(func(repos repository.Repositories) error {
+			var err error
+			if results, err = importBatch(repos, input.Rows); err != nil {
+				return err
+			}
+			return errDryRun
+		})
+		if err != errDryRun {
+			return nil, err
+		}
+		recordProductImportResults(report, results)
+	} else {
+		for start := 0; start < len(input.Rows); start += productImportBatchSize {
+			batch := input.Rows[start:min(start+productImportBatchSize, len(input.Rows))]
+			var results []productImportResult
+			err := u.uow.Do(func(repos repository.Repositories) error {
+				var err error
+				results, err = importBatch(repos, batch)
+				return err
+			})
+			if err != nil {
+				report.MarkFailed(batch[0].Line)
+				if saveErr := u.repos.ProductImports.Save(report); saveErr != nil {
+					return nil, errors.Join(err, saveErr)
+				}
+				return report, err
+			}
+			recordProductImportResults(report, results)
+		}
+	}
+
+	if err := u.repos.ProductImports.Save(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetProductImport returns the report of an import by its ID
+func (u *productImportUsecase) GetProductImport(id uint) (*entity.ProductImport, error) {
+	report, err := u.repos.ProductImports.FindByID(id)
+	if err != nil {
+		if err == repository.ErrProductImportNotFound {
+			return nil, ErrProductImportNotFound
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+// recordProductImportResults counts the rows of an imported batch in the report
+func recordProductImportResults(report *entity.ProductImport, results []productImportResult) {
+	for _, result := range results {
+		switch result.outcome {
+		case productImportCreated:
+			report.RecordCreated()
+		case productImportUpdated:
+			report.RecordUpdated()
+		case productImportUnchanged:
+			report.RecordUnchanged()
+		default:
+			row := result.row
+			report.RecordRejected(entity.RejectedProductRow{Line: row.Line, SKU: row.Product.SKU, Name: row.Product.Name, Errors: result.errors})
+		}
+	}
+}
+
+// importProductRow creates or updates the product of one row. The references of the row are checked
+// up front: a statement failing on a constraint would abort the transaction of the whole batch.
+func importProductRow(repos repository.Repositories, row ProductImportRow, origin entity.ChangeOrigin) (productImportOutcome, map[string]string, error) {
+	if len(row.Errors) > 0 {
+		return productImportRejected, row.Errors, nil
+	}
+	input := row.Product
+	input.SKU = strings.TrimSpace(input.SKU)
+
+	rowErrors := map[string]string{}
+	if input.StockUnit != "" {
+		if _, err := repos.Units.FindByCode(input.StockUnit); err != nil {
+			if err != repository.ErrUnitOfMeasureNotFound {
+				return 0, nil, err
+			}
+			rowErrors["StockUnit"] = importUnitNotFound
+		}
+	}
+	if input.CategoryID != 0 {
+		if _, err := repos.Categories.FindByID(input.CategoryID); err != nil {
+			if err != repository.ErrCategoryNotFound {
+				return 0, nil, err
+			}
+			rowErrors["CategoryID"] = importCategoryNotFound
+		}
+	}
+
+	var existing *entity.Product
+	if input.SKU != "" {
+		if err := entity.ValidateSKU(input.SKU); err != nil {
+			rowErrors["SKU"] = importSKUInvalid
+		} else {
+			product, err := repos.Products.FindBySKUIncludingDeleted(input.SKU)
+			switch {
+			case err == nil && product.IsDeleted():
+				rowErrors["SKU"] = importSKUDeleted
+			case err == nil:
+				existing = product
+			case err != repository.ErrProductNotFound:
+				return 0, nil, err
+			default:
+				// A former SKU of another product is not handed out again
+				if err := checkSKUAvailable(repos, input.SKU, 0); err != nil {
+					if err != ErrSKUTaken {
+						return 0, nil, err
+					}
+					rowErrors["SKU"] = importSKUTaken
+				}
+			}
+		}
+	}
+	if len(rowErrors) > 0 {
+		return productImportRejected, rowErrors, nil
+	}
+
+	if existing != nil {
+		return updateImportedProduct(repos, existing.ID(), row, origin)
+	}
+
+	product, err := entity.NewProductWithSKU(input.Name, input.SKU)
+	if err != nil {
+		return 0, nil, err
+	}
+	product.SetSerialized(input.Serialized)
+	product.SetStockUnit(input.StockUnit)
+	product.SetCategory(input.CategoryID)
+	if err := repos.Products.Save(product); err != nil {
+		return 0, nil, err
+	}
+	if err := recordProductChange(repos, entity.AuditActionCreated, nil, product, origin); err != nil {
+		return 0, nil, err
+	}
+	return productImportCreated, nil, nil
+}
+
+// updateImportedProduct applies a row to the product carrying its SKU. The name is always taken from
+// the row and the category when the row names one. Whether the product is serialized and the unit its
+// stock is kept in cannot change once it exists, so rows asking for it are rejected.
+func updateImportedProduct(repos repository.Repositories, id uint, row ProductImportRow, origin entity.ChangeOrigin) (productImportOutcome, map[string]string, error) {
+	product, err := repos.Products.FindForUpdate(id)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	rowErrors := map[string]string{}
+	if row.SerializedGiven && row.Product.Serialized != product.IsSerialized() {
+		rowErrors["Serialized"] = importSerializedReadOnly
+	}
+	if row.Product.StockUnit != "" && entity.NormalizeUnitCode(row.Product.StockUnit) != product.StockUnit() {
+		rowErrors["StockUnit"] = importStockUnitReadOnly
+	}
+	if len(rowErrors) > 0 {
+		return productImportRejected, rowErrors, nil
+	}
+
+	before := entity.ProductStateOf(product)
+	if err := product.SetName(row.Product.Name); err != nil {
+		return 0, nil, err
+	}
+	if row.Product.CategoryID != 0 {
+		product.SetCategory(row.Product.CategoryID)
+	}
+	if len(before.Diff(entity.ProductStateOf(product))) == 0 {
+		return productImportUnchanged, nil, nil
+	}
+
+	if err := repos.Products.Save(product); err != nil {
+		return 0, nil, err
+	}
+	if err := recordProductChange(repos, entity.AuditActionUpdated, &before, product, origin); err != nil {
+		return 0, nil, err
+	}
+	return productImportUpdated, nil, nil
+}
@@ -2,31 +2,122 @@
 package usecase
 
 import (
+	"errors"
 	"inventory_management/internal/entity"
+	"inventory_management/internal/label"
 	"inventory_management/internal/repository"
+	"strings"
+	"time"
 )
 
+// ProductListFilter narrows down and orders the products returned from a listing
+type ProductListFilter = repository.ProductListFilter
+
+// ProductAuditFilter selects a page of the audit trail of a product
+type ProductAuditFilter = repository.ProductAuditFilter
+
+// ProductInput carries the details of a new product
+type ProductInput struct {
+	Name       string
+	SKU        string // SKU chosen by the caller, empty generates one
+	Serialized bool   // Tracks every unit by its serial number
+	StockUnit  string // Unit of the catalogue stock is kept in, defaults to each
+	CategoryID uint   // Category the product is filed under, zero leaves it uncategorised
+}
+
+// ProductPatch lists the attributes of a product to change. Nil fields are left as they are.
+type ProductPatch struct {
+	Name        *string
+	Description *string // Empty removes the description
+	Brand       *string // Empty removes the brand
+	Status      *entity.ProductStatus
+	WeightGrams *int64 // Zero marks the weight as unknown
+	Dimensions  *DimensionsPatch
+	Tags        *[]string // Replaces every tag, empty removes them
+	// ClearAttributes removes every custom attribute before Attributes is applied
+	ClearAttributes bool
+	Attributes      map[string]*string // Sets each named attribute, nil values remove it
+}
+
+// DimensionsPatch lists the sides of a product to change. Nil sides are left as they are.
+type DimensionsPatch struct {
+	LengthMM *int64
+	WidthMM  *int64
+	HeightMM *int64
+}
+
+// ProductVariants describes where a product sits among variants: the variant it is when it belongs
+// to a parent product, or the variants it comes in otherwise
+type ProductVariants struct {
+	VariantOf *entity.ProductVariant // Set when the product is a variant of another product
+	Variants  []*entity.ProductVariant
+}
+
 type ProductUsecase interface {
-	CreateProduct(name string) (*entity.Product, error)
+	CreateProduct(input ProductInput, origin entity.ChangeOrigin) (*entity.Product, error)
 	GetProductByID(id uint) (*entity.Product, error)
-	UpdateProductName(id uint, name string) (*entity.Product, error)
-	ListProducts(searchTerm string, sortBy string, sortDirection string, limit int, offset int) ([]*entity.Product, error)
+	GetProductIncludingDeleted(id uint) (*entity.Product, error)
+	GetProductBySKU(sku string) (*entity.Product, error)
+	GetSKUAliases(id uint) ([]*entity.SKUAlias, error)
+	GetProductByBarcode(code string) (*entity.Product, error)
+	GetBarcodes(id uint) ([]*entity.Barcode, error)
+	AddBarcode(id uint, code string) (*entity.Barcode, error)
+	RemoveBarcode(id uint, code string) error
+	GetProductLabel(id uint) (*label.Label, error)
+	GetProductVariants(id uint) (*ProductVariants, error)
+	UpdateProductName(id uint, name string, version int64, origin entity.ChangeOrigin) (*entity.Product, error)
+	PatchProduct(id uint, patch ProductPatch, version int64, origin entity.ChangeOrigin) (*entity.Product, error)
+	AssignCategory(id uint, categoryID uint, version int64, origin entity.ChangeOrigin) (*entity.Product, error)
+	ChangeSKU(id uint, sku string, version int64, origin entity.ChangeOrigin) (*entity.Product, []*entity.SKUAlias, error)
+	ListProducts(filter ProductListFilter) ([]*entity.Product, int64, error)
+	ExportProducts(filter ProductListFilter, fn func(p *entity.Product) error) error
+	DeleteProduct(id uint, version int64, origin entity.ChangeOrigin) (*entity.Product, error)
+	RestoreProduct(id uint, version int64, origin entity.ChangeOrigin) (*entity.Product, error)
+	GetProductHistory(filter ProductAuditFilter) ([]*entity.ProductAuditRecord, int64, error)
+	GetProductAsOf(id uint, at time.Time) (*entity.Product, error)
 }
 
 type productUsecase struct {
-	productRepo repository.PostgresProductRepository
+	repos repository.Repositories
+	uow   repository.UnitOfWork
 }
 
-func NewProductUsecase(repo repository.PostgresProductRepository) ProductUsecase {
-	return &productUsecase{productRepo: repo}
+func NewProductUsecase(repos repository.Repositories, uow repository.UnitOfWork) ProductUsecase {
+	return &productUsecase{repos: repos, uow: uow}
 }
 
-func (u *productUsecase) CreateProduct(name string) (*entity.Product, error) {
-	p, err := entity.NewProduct(name)
+// CreateProduct creates a product under the SKU given in the input, or under one from the generator
+// configured for the repository when none is given
+func (u *productUsecase) CreateProduct(input ProductInput, origin entity.ChangeOrigin) (*entity.Product, error) {
+	p, err := entity.NewProductWithSKU(input.Name, input.SKU)
 	if err != nil {
+		if errors.Is(err, entity.ErrInvalidSKU) {
+			return nil, invalidInput(err)
+		}
 		return nil, err
 	}
-	err = u.productRepo.Save(p)
+	if p.SKU() != "" {
+		if err := checkSKUAvailable(u.repos, p.SKU(), 0); err != nil {
+			return nil, err
+		}
+	}
+	p.SetSerialized(input.Serialized)
+	p.SetStockUnit(input.StockUnit)
+	p.SetCategory(input.CategoryID)
+	err = u.uow.Do(func(repos repository.Repositories) error {
+		if err := repos.Products.Save(p); err != nil {
+			switch err {
+			case repository.ErrUnitOfMeasureNotFound:
+				return ErrUnitOfMeasureNotFound
+			case repository.ErrCategoryNotFound:
+				return ErrCategoryNotFound
+			case repository.ErrSKUTaken:
+				return ErrSKUTaken
+			}
+			return err
+		}
+		return recordProductChange(repos, entity.AuditActionCreated, nil, p, origin)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +125,7 @@ func (u *productUsecase) CreateProduct(name string) (*entity.Product, error) {
 }
 
 func (u *productUsecase) GetProductByID(id uint) (*entity.Product, error) {
-	product, err := u.productRepo.FindByID(id)
+	product, err := u.repos.Products.FindByID(id)
 	if err != nil {
 		if err == repository.ErrProductNotFound {
 			return nil, ErrProductNotFound
@@ -44,27 +135,549 @@ func (u *productUsecase) GetProductByID(id uint) (*entity.Product, error) {
 	return product, nil
 }
 
-// UpdateProductName updates the name of an existing product
-func (u *productUsecase) UpdateProductName(id uint, name string) (*entity.Product, error) {
-	product, err := u.productRepo.FindByID(id)
+// GetProductIncludingDeleted returns a product whether or not it was soft-deleted
+func (u *productUsecase) GetProductIncludingDeleted(id uint) (*entity.Product, error) {
+	product, err := u.repos.Products.FindByIDIncludingDeleted(id)
 	if err != nil {
 		if err == repository.ErrProductNotFound {
 			return nil, ErrProductNotFound
 		}
 		return nil, err
 	}
+	return product, nil
+}
+
+// GetProductBySKU finds the product carrying a SKU, or the product that carried it before its SKU
+// was reassigned
+func (u *productUsecase) GetProductBySKU(sku string) (*entity.Product, error) {
+	product, err := u.repos.Products.FindBySKU(sku)
+	if err != repository.ErrProductNotFound {
+		return product, err
+	}
+
+	alias, err := u.repos.SKUAliases.FindBySKU(sku)
+	if err != nil {
+		if err == repository.ErrSKUAliasNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return u.GetProductByID(alias.ProductID())
+}
+
+// GetSKUAliases returns the former SKUs of a product, most recently retired first
+func (u *productUsecase) GetSKUAliases(id uint) ([]*entity.SKUAlias, error) {
+	return u.repos.SKUAliases.FindByProductID(id)
+}
+
+// GetProductVariants returns the variant a product is, or the variants it comes in
+func (u *productUsecase) GetProductVariants(id uint) (*ProductVariants, error) {
+	variant, err := u.repos.Variants.FindByProductID(id)
+	switch {
+	case err == nil:
+		return &ProductVariants{VariantOf: variant, Variants: []*entity.ProductVariant{}}, nil
+	case err != repository.ErrProductVariantNotFound:
+		return nil, err
+	}
+
+	variants, err := u.repos.Variants.FindByParentID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &ProductVariants{Variants: variants}, nil
+}
+
+// GetProductByBarcode finds the product carrying a scanned barcode. UPC-A, EAN-13 and GTIN-14 forms
+// of the same number find the same product.
+func (u *productUsecase) GetProductByBarcode(code string) (*entity.Product, error) {
+	gtin, err := entity.NormalizeBarcode(code)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+
+	barcode, err := u.repos.Barcodes.FindByGTIN(gtin)
+	if err != nil {
+		if err == repository.ErrBarcodeNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return u.GetProductByID(barcode.ProductID())
+}
+
+// GetBarcodes returns the barcodes of a product in the order they were assigned
+func (u *productUsecase) GetBarcodes(id uint) ([]*entity.Barcode, error) {
+	return u.repos.Barcodes.FindByProductID(id)
+}
+
+// AddBarcode assigns a barcode to a product after checking its check digit
+func (u *productUsecase) AddBarcode(id uint, code string) (*entity.Barcode, error) {
+	barcode, err := entity.NewBarcode(id, code)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	if _, err := u.GetProductByID(id); err != nil {
+		return nil, err
+	}
+
+	if err := u.repos.Barcodes.Save(barcode); err != nil {
+		switch err {
+		case repository.ErrBarcodeTaken:
+			return nil, ErrBarcodeTaken
+		case repository.ErrProductNotFound:
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return barcode, nil
+}
+
+// RemoveBarcode takes a barcode off a product
+func (u *productUsecase) RemoveBarcode(id uint, code string) error {
+	gtin, err := entity.NormalizeBarcode(code)
+	if err != nil {
+		return ErrBarcodeNotFound
+	}
+
+	return u.uow.Do(func(repos repository.Repositories) error {
+		barcode, err := repos.Barcodes.FindByGTIN(gtin)
+		if err != nil {
+			if err == repository.ErrBarcodeNotFound {
+				return ErrBarcodeNotFound
+			}
+			return err
+		}
+		if barcode.ProductID() != id {
+			return ErrBarcodeNotFound
+		}
+		return repos.Barcodes.Delete(gtin)
+	})
+}
+
+// GetProductLabel lays out the shelf label of a product, with its EAN-13 barcode when it has one and
+// its SKU in Code 128 otherwise
+func (u *productUsecase) GetProductLabel(id uint) (*label.Label, error) {
+	product, err := u.GetProductByID(id)
+	if err != nil {
+		return nil, err
+	}
+	barcodes, err := u.repos.Barcodes.FindByProductID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	productLabel, err := label.NewProductLabel(product, barcodes)
+	if err != nil {
+		if errors.Is(err, label.ErrNotEncodable) {
+			return nil, invalidInput(err)
+		}
+		return nil, err
+	}
+	return productLabel, nil
+}
+
+// UpdateProductName updates the name of an existing product. The name is only written while the product
+// is still at the given version, zero updates whatever version is current.
+func (u *productUsecase) UpdateProductName(id uint, name string, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	var product *entity.Product
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		product, err = repos.Products.FindByID(id)
+		if err != nil {
+			if err == repository.ErrProductNotFound {
+				return ErrProductNotFound
+			}
+			return err
+		}
+		if err := checkProductVersion(product, version); err != nil {
+			return err
+		}
+		before := entity.ProductStateOf(product)
+
+		// Update the product name
+		if err := product.SetName(name); err != nil {
+			return err
+		}
+		if err := repos.Products.Save(product); err != nil {
+			if err == repository.ErrProductVersionConflict {
+				return ErrProductVersionMismatch
+			}
+			return err
+		}
+		return recordProductChange(repos, entity.AuditActionUpdated, &before, product, origin)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// PatchProduct changes the attributes of a product named in the patch and leaves the others as they are.
+// The patch is applied in full or not at all, and only while the product is still at the given version.
+// Zero patches whatever version is current.
+func (u *productUsecase) PatchProduct(id uint, patch ProductPatch, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	var product *entity.Product
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		product, err = repos.Products.FindForUpdate(id)
+		if err != nil {
+			if err == repository.ErrProductNotFound {
+				return ErrProductNotFound
+			}
+			return err
+		}
+		if err := checkProductVersion(product, version); err != nil {
+			return err
+		}
+		before := entity.ProductStateOf(product)
+		if err := applyProductPatch(product, patch); err != nil {
+			return invalidInput(err)
+		}
+		if err := repos.Products.Save(product); err != nil {
+			return err
+		}
+		return recordProductChange(repos, entity.AuditActionUpdated, &before, product, origin)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// checkProductVersion reports ErrProductVersionMismatch when the product moved past the version the
+// caller read. Zero skips the check.
+func checkProductVersion(product *entity.Product, version int64) error {
+	if version != 0 && product.Version() != version {
+		return ErrProductVersionMismatch
+	}
+	return nil
+}
+
+// recordProductChange appends the change of a product to its audit trail and writes the event announcing
+// it to the outbox. It must run inside the unit of work that stored the change, after the product was
+// saved. before is nil for a created product.
+func recordProductChange(repos repository.Repositories, action entity.AuditAction, before *entity.ProductState, product *entity.Product, origin entity.ChangeOrigin) error {
+	record, err := entity.NewProductAuditRecord(product.ID(), action, before, entity.ProductStateOf(product), origin, time.Now())
+	if err != nil {
+		return invalidInput(err)
+	}
+	if err := repos.ProductAudits.Save(record); err != nil {
+		return err
+	}
+
+	event, err := entity.NewProductEvent(record)
+	if err != nil {
+		return err
+	}
+	return repos.Outbox.Save(event)
+}
+
+// applyProductPatch sets the attributes named in a patch on a product
+func applyProductPatch(product *entity.Product, patch ProductPatch) error {
+	if patch.Name != nil {
+		if err := product.SetName(*patch.Name); err != nil {
+			return err
+		}
+	}
+	if patch.Description != nil {
+		if err := product.SetDescription(*patch.Description); err != nil {
+			return err
+		}
+	}
+	if patch.Brand != nil {
+		if err := product.SetBrand(*patch.Brand); err != nil {
+			return err
+		}
+	}
+	if patch.Status != nil {
+		if err := product.SetStatus(*patch.Status); err != nil {
+			return err
+		}
+	}
+	if patch.WeightGrams != nil {
+		if err := product.SetWeightGrams(*patch.WeightGrams); err != nil {
+			return err
+		}
+	}
+	if patch.Dimensions != nil {
+		dimensions := product.Dimensions()
+		if patch.Dimensions.LengthMM != nil {
+			dimensions.LengthMM = *patch.Dimensions.LengthMM
+		}
+		if patch.Dimensions.WidthMM != nil {
+			dimensions.WidthMM = *patch.Dimensions.WidthMM
+		}
+		if patch.Dimensions.HeightMM != nil {
+			dimensions.HeightMM = *patch.Dimensions.HeightMM
+		}
+		if err := product.SetDimensions(dimensions); err != nil {
+			return err
+		}
+	}
+	if patch.Tags != nil {
+		if err := product.SetTags(*patch.Tags); err != nil {
+			return err
+		}
+	}
+
+	if patch.ClearAttributes {
+		if err := product.SetAttributes(nil); err != nil {
+			return err
+		}
+	}
+	// Removals go first so that replacing attributes at the limit does not overflow it
+	for name, value := range patch.Attributes {
+		if value == nil {
+			product.RemoveAttribute(name)
+		}
+	}
+	for name, value := range patch.Attributes {
+		if value != nil {
+			if err := product.SetAttribute(name, *value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AssignCategory files a product under a category. Zero removes it from its category.
+// A non-zero version must match the current version of the product.
+func (u *productUsecase) AssignCategory(id uint, categoryID uint, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	var product *entity.Product
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		product, err = repos.Products.FindForUpdate(id)
+		if err != nil {
+			if err == repository.ErrProductNotFound {
+				return ErrProductNotFound
+			}
+			return err
+		}
+		if err := checkProductVersion(product, version); err != nil {
+			return err
+		}
 
-	// Update the product name
-	if err := product.SetName(name); err != nil {
+		before := entity.ProductStateOf(product)
+		product.SetCategory(categoryID)
+		if err := repos.Products.Save(product); err != nil {
+			switch err {
+			case repository.ErrCategoryNotFound:
+				return ErrCategoryNotFound
+			case repository.ErrProductVersionConflict:
+				return ErrProductVersionMismatch
+			}
+			return err
+		}
+		return recordProductChange(repos, entity.AuditActionUpdated, &before, product, origin)
+	})
+	if err != nil {
 		return nil, err
 	}
-	if err := u.productRepo.Save(product); err != nil {
+	return product, nil
+}
+
+// ChangeSKU reassigns the SKU of a product. The previous SKU becomes an alias that still finds the
+// product, and a product may take back one of its own former SKUs. A non-zero version must match the
+// current version of the product.
+func (u *productUsecase) ChangeSKU(id uint, sku string, version int64, origin entity.ChangeOrigin) (*entity.Product, []*entity.SKUAlias, error) {
+	sku = strings.TrimSpace(sku)
+	if err := entity.ValidateSKU(sku); err != nil {
+		return nil, nil, invalidInput(err)
+	}
+
+	var product *entity.Product
+	var aliases []*entity.SKUAlias
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		product, err = repos.Products.FindForUpdate(id)
+		if err != nil {
+			if err == repository.ErrProductNotFound {
+				return ErrProductNotFound
+			}
+			return err
+		}
+		if err := checkProductVersion(product, version); err != nil {
+			return err
+		}
+
+		if product.SKU() != sku {
+			before := entity.ProductStateOf(product)
+			if err := checkSKUAvailable(repos, sku, id); err != nil {
+				return err
+			}
+			// Taking back a former SKU retires its alias
+			if err := repos.SKUAliases.Delete(sku); err != nil {
+				return err
+			}
+			alias, err := entity.NewSKUAlias(product.SKU(), id)
+			if err != nil {
+				return err
+			}
+			if err := repos.SKUAliases.Save(alias); err != nil {
+				if err == repository.ErrSKUTaken {
+					return ErrSKUTaken
+				}
+				return err
+			}
+			if err := product.SetSKU(sku); err != nil {
+				return invalidInput(err)
+			}
+			if err := repos.Products.Save(product); err != nil {
+				switch err {
+				case repository.ErrSKUTaken:
+					return ErrSKUTaken
+				case repository.ErrProductVersionConflict:
+					return ErrProductVersionMismatch
+				}
+				return err
+			}
+			if err := recordProductChange(repos, entity.AuditActionUpdated, &before, product, origin); err != nil {
+				return err
+			}
+		}
+
+		aliases, err = repos.SKUAliases.FindByProductID(id)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return product, aliases, nil
+}
+
+// ListProducts returns a page of products together with the total number of matching products.
+// Filtering by a category includes the products of every category below it.
+func (u *productUsecase) ListProducts(filter ProductListFilter) ([]*entity.Product, int64, error) {
+	return u.repos.Products.ListProducts(filter)
+}
+
+// ExportProducts calls fn with every product matching the filter, without paginating. Products are
+// read from the database as fn consumes them, so catalogues of any size can be exported.
+func (u *productUsecase) ExportProducts(filter ProductListFilter, fn func(p *entity.Product) error) error {
+	return u.repos.Products.StreamProducts(filter, fn)
+}
+
+// DeleteProduct soft-deletes a product. It disappears from lookups and listings but keeps its stock
+// history, SKU and barcodes and can be restored. A non-zero version must match the current version of
+// the product. The deleted product is returned.
+func (u *productUsecase) DeleteProduct(id uint, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	var product *entity.Product
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		product, err = repos.Products.FindForUpdate(id)
+		if err != nil {
+			if err == repository.ErrProductNotFound {
+				return ErrProductNotFound
+			}
+			return err
+		}
+		if err := checkProductVersion(product, version); err != nil {
+			return err
+		}
+		before := entity.ProductStateOf(product)
+
+		product.MarkDeleted(time.Now())
+		if err := repos.Products.Save(product); err != nil {
+			if err == repository.ErrProductVersionConflict {
+				return ErrProductVersionMismatch
+			}
+			return err
+		}
+		return recordProductChange(repos, entity.AuditActionDeleted, &before, product, origin)
+	})
+	if err != nil {
 		return nil, err
 	}
+	return product, nil
+}
+
+// RestoreProduct brings back a soft-deleted product. Restoring a product that is not deleted
+// changes nothing. A non-zero version must match the current version of the product.
+func (u *productUsecase) RestoreProduct(id uint, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	var product *entity.Product
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		product, err = repos.Products.FindForUpdateIncludingDeleted(id)
+		if err != nil {
+			if err == repository.ErrProductNotFound {
+				return ErrProductNotFound
+			}
+			return err
+		}
+		if err := checkProductVersion(product, version); err != nil {
+			return err
+		}
+		if !product.IsDeleted() {
+			return nil
+		}
+		before := entity.ProductStateOf(product)
 
+		if err := repos.Products.Restore(id); err != nil {
+			if err == repository.ErrProductNotFound {
+				return ErrProductNotFound
+			}
+			return err
+		}
+		product, err = repos.Products.FindByID(id)
+		if err != nil {
+			return err
+		}
+		return recordProductChange(repos, entity.AuditActionRestored, &before, product, origin)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return product, nil
 }
 
-func (u *productUsecase) ListProducts(searchTerm string, sortBy string, sortDirection string, limit int, offset int) ([]*entity.Product, error) {
-	return u.productRepo.ListProducts(searchTerm, sortBy, sortDirection, limit, offset)
+// GetProductHistory returns a page of the audit trail of a product, oldest change first, with the
+// total number of records. Deleted products keep their history.
+func (u *productUsecase) GetProductHistory(filter ProductAuditFilter) ([]*entity.ProductAuditRecord, int64, error) {
+	if _, err := u.GetProductIncludingDeleted(filter.ProductID); err != nil {
+		return nil, 0, err
+	}
+	return u.repos.ProductAudits.ListRecords(filter)
+}
+
+// GetProductAsOf rebuilds a product as it was at the given time from the last change recorded in its
+// audit trail at or before that time
+func (u *productUsecase) GetProductAsOf(id uint, at time.Time) (*entity.Product, error) {
+	if _, err := u.GetProductIncludingDeleted(id); err != nil {
+		return nil, err
+	}
+	record, err := u.repos.ProductAudits.FindLatestAt(id, at)
+	if err != nil {
+		if err == repository.ErrProductAuditRecordNotFound {
+			return nil, ErrNoProductHistory
+		}
+		return nil, err
+	}
+	return record.State().Product(id)
+}
+
+// checkSKUAvailable returns ErrSKUTaken when a SKU is carried by another product or is a former SKU
+// of another product. Zero checks against every product.
+func checkSKUAvailable(repos repository.Repositories, sku string, productID uint) error {
+	owner, err := repos.Products.FindBySKU(sku)
+	switch {
+	case err == nil:
+		if owner.ID() != productID {
+			return ErrSKUTaken
+		}
+	case err != repository.ErrProductNotFound:
+		return err
+	}
+
+	alias, err := repos.SKUAliases.FindBySKU(sku)
+	switch {
+	case err == nil:
+		if alias.ProductID() != productID {
+			return ErrSKUTaken
+		}
+	case err != repository.ErrSKUAliasNotFound:
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,107 @@
+// /internal/usecase/product_variant_usecase.go
+package usecase
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+)
+
+// OptionAxisInput is one dimension a product comes in, like size, with its values
+type OptionAxisInput struct {
+	Name   string
+	Values []string
+}
+
+type ProductVariantUsecase interface {
+	GenerateVariants(productID uint, axes []OptionAxisInput, origin entity.ChangeOrigin) (*entity.Product, []*entity.ProductVariant, error)
+}
+
+type productVariantUsecase struct {
+	repos repository.Repositories
+	uow   repository.UnitOfWork
+}
+
+func NewProductVariantUsecase(repos repository.Repositories, uow repository.UnitOfWork) ProductVariantUsecase {
+	return &productVariantUsecase{repos: repos, uow: uow}
+}
+
+// GenerateVariants creates a variant of a product for every combination of the option axes it does
+// not have yet and returns the product with all of its variants. Products that already have variants
+// must be given the same axes, in the same order, so that new values can be added to an axis. Every
+// variant product created is recorded in the audit trail.
+func (u *productVariantUsecase) GenerateVariants(productID uint, axisInputs []OptionAxisInput, origin entity.ChangeOrigin) (*entity.Product, []*entity.ProductVariant, error) {
+	axes := make([]*entity.OptionAxis, len(axisInputs))
+	for i, input := range axisInputs {
+		axis, err := entity.NewOptionAxis(input.Name, input.Values)
+		if err != nil {
+			return nil, nil, invalidInput(err)
+		}
+		axes[i] = axis
+	}
+	combinations, err := entity.CombineOptionAxes(axes)
+	if err != nil {
+		return nil, nil, invalidInput(err)
+	}
+
+	var parent *entity.Product
+	var variants []*entity.ProductVariant
+	err = u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		parent, err = repos.Products.FindByID(productID)
+		if err != nil {
+			if err == repository.ErrProductNotFound {
+				return ErrProductNotFound
+			}
+			return err
+		}
+		if _, err := repos.Variants.FindByProductID(productID); err != repository.ErrProductVariantNotFound {
+			if err == nil {
+				return invalidInput(entity.ErrVariantOfVariant)
+			}
+			return err
+		}
+
+		variants, err = repos.Variants.FindByParentID(productID)
+		if err != nil {
+			return err
+		}
+		existing := make(map[string]bool, len(variants))
+		for _, variant := range variants {
+			if !variant.MatchesAxes(axes) {
+				return invalidInput(entity.ErrVariantAxesMismatch)
+			}
+			existing[variant.Key()] = true
+		}
+
+		for _, options := range combinations {
+			if existing[entity.VariantKey(options)] {
+				continue
+			}
+			if len(variants) == entity.MaxVariantsPerProduct {
+				return invalidInput(entity.ErrTooManyVariants)
+			}
+			variant, err := entity.NewProductVariant(parent, options)
+			if err != nil {
+				return invalidInput(err)
+			}
+			if err := repos.Variants.Save(variant); err != nil {
+				switch err {
+				case repository.ErrProductVariantExists:
+					return ErrProductVariantExists
+				case repository.ErrSKUTaken:
+					return ErrSKUTaken
+				}
+				return err
+			}
+			if err := recordProductChange(repos, entity.AuditActionCreated, nil, variant.Product(), origin); err != nil {
+				return err
+			}
+			variants = append(variants, variant)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return parent, variants, nil
+}
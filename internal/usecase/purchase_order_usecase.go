@@ -0,0 +1,232 @@
+// /internal/usecase/purchase_order_usecase.go
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"time"
+)
+
+// PurchaseOrderFilter narrows down the purchase orders returned from a listing
+type PurchaseOrderFilter = repository.PurchaseOrderFilter
+
+// PurchaseOrderLineInput is the ordered quantity and unit cost of one product
+type PurchaseOrderLineInput struct {
+	ProductID uint
+	Quantity  int64
+	Unit      string // Unit the quantity is given in, defaults to the stock unit of the product
+	UnitCost  int64  // In minor currency units per stock unit
+}
+
+// PurchaseOrderInput carries the details of a new purchase order
+type PurchaseOrderInput struct {
+	SupplierID  uint
+	WarehouseID uint
+	Reference   string
+	Currency    string
+	Lines       []PurchaseOrderLineInput
+}
+
+// GoodsReceiptLineInput is the quantity of one product delivered against a purchase order
+type GoodsReceiptLineInput struct {
+	ProductID uint
+	Quantity  int64
+	Unit      string   // Unit the quantity is given in, defaults to the stock unit of the product
+	Serials   []string // Names every received unit of a serialized product
+}
+
+type PurchaseOrderUsecase interface {
+	CreatePurchaseOrder(input PurchaseOrderInput) (*entity.PurchaseOrder, error)
+	GetPurchaseOrderByID(id uint) (*entity.PurchaseOrder, error)
+	ListPurchaseOrders(filter PurchaseOrderFilter) ([]*entity.PurchaseOrder, int64, error)
+	SubmitPurchaseOrder(id uint) (*entity.PurchaseOrder, error)
+	ReceivePurchaseOrder(id uint, lines []GoodsReceiptLineInput, actor string) (*entity.PurchaseOrder, error)
+	ClosePurchaseOrder(id uint) (*entity.PurchaseOrder, error)
+}
+
+type purchaseOrderUsecase struct {
+	repos repository.Repositories
+	uow   repository.UnitOfWork
+}
+
+func NewPurchaseOrderUsecase(repos repository.Repositories, uow repository.UnitOfWork) PurchaseOrderUsecase {
+	return &purchaseOrderUsecase{repos: repos, uow: uow}
+}
+
+// CreatePurchaseOrder drafts a purchase order with a supplier
+func (u *purchaseOrderUsecase) CreatePurchaseOrder(input PurchaseOrderInput) (*entity.PurchaseOrder, error) {
+	order, err := entity.NewPurchaseOrder(input.SupplierID, input.WarehouseID, input.Reference, input.Currency)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	for _, line := range input.Lines {
+		quantity, err := toStockUnits(u.repos, line.ProductID, line.Unit, line.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if err := order.AddLine(line.ProductID, quantity, line.UnitCost); err != nil {
+			return nil, invalidInput(err)
+		}
+	}
+
+	if _, err := u.repos.Suppliers.FindByID(input.SupplierID); err != nil {
+		if err == repository.ErrSupplierNotFound {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, err
+	}
+	if _, err := u.repos.Warehouses.FindByID(input.WarehouseID); err != nil {
+		if err == repository.ErrWarehouseNotFound {
+			return nil, ErrWarehouseNotFound
+		}
+		return nil, err
+	}
+	for _, line := range order.Lines() {
+		if _, err := u.repos.Products.FindByID(line.ProductID()); err != nil {
+			if err == repository.ErrProductNotFound {
+				return nil, ErrProductNotFound
+			}
+			return nil, err
+		}
+	}
+
+	err = u.uow.Do(func(repos repository.Repositories) error {
+		return repos.PurchaseOrders.Save(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (u *purchaseOrderUsecase) GetPurchaseOrderByID(id uint) (*entity.PurchaseOrder, error) {
+	order, err := u.repos.PurchaseOrders.FindByID(id)
+	if err != nil {
+		if err == repository.ErrPurchaseOrderNotFound {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// ListPurchaseOrders returns a page of purchase orders with the total number of matching orders
+func (u *purchaseOrderUsecase) ListPurchaseOrders(filter PurchaseOrderFilter) ([]*entity.PurchaseOrder, int64, error) {
+	return u.repos.PurchaseOrders.ListPurchaseOrders(filter)
+}
+
+// SubmitPurchaseOrder sends a draft purchase order to its supplier
+func (u *purchaseOrderUsecase) SubmitPurchaseOrder(id uint) (*entity.PurchaseOrder, error) {
+	return u.transition(id, func(repos repository.Repositories, order *entity.PurchaseOrder) error {
+		return order.Submit(time.Now())
+	})
+}
+
+// ReceivePurchaseOrder books delivered goods into the receiving warehouse of the order. When no
+// lines are given, everything outstanding is received.
+func (u *purchaseOrderUsecase) ReceivePurchaseOrder(id uint, lines []GoodsReceiptLineInput, actor string) (*entity.PurchaseOrder, error) {
+	return u.transition(id, func(repos repository.Repositories, order *entity.PurchaseOrder) error {
+		quantities := make(map[uint]int64, len(order.Lines()))
+		serials := make(map[uint][]string, len(lines))
+		if len(lines) == 0 {
+			for _, line := range order.Lines() {
+				if line.Outstanding() > 0 {
+					quantities[line.ProductID()] = line.Outstanding()
+				}
+			}
+		}
+		for _, line := range lines {
+			if _, exists := quantities[line.ProductID]; exists {
+				return entity.ErrDuplicatePurchaseLine
+			}
+			quantity, err := toStockUnits(repos, line.ProductID, line.Unit, line.Quantity)
+			if err != nil {
+				return err
+			}
+			quantities[line.ProductID] = quantity
+			serials[line.ProductID] = line.Serials
+		}
+
+		if err := order.Receive(quantities); err != nil {
+			return err
+		}
+
+		// Walk the lines rather than the map so movements are recorded in a stable order
+		now := time.Now()
+		for _, line := range order.Lines() {
+			quantity, received := quantities[line.ProductID()]
+			if !received {
+				continue
+			}
+			movement, err := entity.NewStockMovement(
+				line.ProductID(),
+				order.WarehouseID(),
+				entity.MovementTypeReceipt,
+				quantity,
+				"PO_RECEIVED",
+				fmt.Sprintf("purchase_order:%d", order.ID()),
+				actor,
+				now,
+			)
+			if err != nil {
+				return err
+			}
+			if err := recordTrackedMovement(repos, movement, MovementTracking{Serials: serials[line.ProductID()]}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClosePurchaseOrder finishes a purchase order, giving up on any outstanding quantity
+func (u *purchaseOrderUsecase) ClosePurchaseOrder(id uint) (*entity.PurchaseOrder, error) {
+	return u.transition(id, func(repos repository.Repositories, order *entity.PurchaseOrder) error {
+		return order.Close(time.Now())
+	})
+}
+
+// transition locks a purchase order, applies change to it and saves it in one transaction
+func (u *purchaseOrderUsecase) transition(id uint, change func(repos repository.Repositories, order *entity.PurchaseOrder) error) (*entity.PurchaseOrder, error) {
+	var order *entity.PurchaseOrder
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		order, err = repos.PurchaseOrders.FindForUpdate(id)
+		if err != nil {
+			if err == repository.ErrPurchaseOrderNotFound {
+				return ErrPurchaseOrderNotFound
+			}
+			return err
+		}
+		if err := change(repos, order); err != nil {
+			return translatePurchaseOrderError(err)
+		}
+		return repos.PurchaseOrders.Save(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// translatePurchaseOrderError maps purchase order state errors to use case errors and the remaining
+// domain errors to invalid input. Use case errors such as insufficient stock pass through unchanged.
+func translatePurchaseOrderError(err error) error {
+	switch {
+	case errors.Is(err, entity.ErrPurchaseOrderNotDraft):
+		return ErrPurchaseOrderNotDraft
+	case errors.Is(err, entity.ErrPurchaseOrderNotReceivable):
+		return ErrPurchaseOrderNotReceivable
+	case errors.Is(err, entity.ErrPurchaseOrderNotClosable):
+		return ErrPurchaseOrderNotClosable
+	case errors.Is(err, entity.ErrEmptyPurchaseOrder),
+		errors.Is(err, entity.ErrDuplicatePurchaseLine),
+		errors.Is(err, entity.ErrPurchaseLineNotFound),
+		errors.Is(err, entity.ErrInvalidPurchaseQuantity),
+		errors.Is(err, entity.ErrReceiveExceedsOrdered):
+		return invalidInput(err)
+	}
+	return err
+}
@@ -0,0 +1,179 @@
+// /internal/usecase/reorder_usecase.go
+package usecase
+
+import (
+	"fmt"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"time"
+)
+
+// LowStockAlertFilter narrows down the low-stock alerts returned from a listing
+type LowStockAlertFilter = repository.LowStockAlertFilter
+
+// ReorderPolicyInput carries the thresholds of a product in a warehouse
+type ReorderPolicyInput struct {
+	ProductID           uint
+	WarehouseID         uint
+	ReorderPoint        int64
+	ReorderQuantity     int64
+	SafetyStock         int64
+	PreferredSupplierID uint // Zero means no purchase order is drafted for alerts
+}
+
+// LowStockEvaluation summarises one pass over the reorder policies
+type LowStockEvaluation struct {
+	Raised   int
+	Resolved int
+}
+
+type ReorderUsecase interface {
+	SetReorderPolicy(input ReorderPolicyInput) (*entity.ReorderPolicy, error)
+	GetProductReorderPolicies(productID uint) ([]*entity.ReorderPolicy, error)
+	EvaluateLowStock(now time.Time) (LowStockEvaluation, error)
+	ListLowStockAlerts(filter LowStockAlertFilter) ([]*entity.LowStockAlert, int64, error)
+}
+
+type reorderUsecase struct {
+	repos    repository.Repositories
+	uow      repository.UnitOfWork
+	currency string // Currency of the purchase orders drafted for alerts
+}
+
+func NewReorderUsecase(repos repository.Repositories, uow repository.UnitOfWork, currency string) ReorderUsecase {
+	return &reorderUsecase{repos: repos, uow: uow, currency: currency}
+}
+
+// SetReorderPolicy creates the reorder policy of a product in a warehouse or replaces its thresholds
+func (u *reorderUsecase) SetReorderPolicy(input ReorderPolicyInput) (*entity.ReorderPolicy, error) {
+	if err := ensureProductAndWarehouseExist(u.repos, input.ProductID, input.WarehouseID); err != nil {
+		return nil, err
+	}
+	if input.PreferredSupplierID != 0 {
+		if _, err := u.repos.Suppliers.FindByID(input.PreferredSupplierID); err != nil {
+			if err == repository.ErrSupplierNotFound {
+				return nil, ErrSupplierNotFound
+			}
+			return nil, err
+		}
+	}
+
+	policy, err := u.repos.ReorderPolicies.FindByProductAndWarehouse(input.ProductID, input.WarehouseID)
+	switch err {
+	case nil:
+		err = policy.Update(input.ReorderPoint, input.ReorderQuantity, input.SafetyStock, input.PreferredSupplierID)
+	case repository.ErrReorderPolicyNotFound:
+		policy, err = entity.NewReorderPolicy(input.ProductID, input.WarehouseID, input.ReorderPoint, input.ReorderQuantity, input.SafetyStock, input.PreferredSupplierID)
+	default:
+		return nil, err
+	}
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+
+	if err := u.repos.ReorderPolicies.Save(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetProductReorderPolicies returns the reorder policies of a product across all warehouses
+func (u *reorderUsecase) GetProductReorderPolicies(productID uint) ([]*entity.ReorderPolicy, error) {
+	if _, err := u.repos.Products.FindByID(productID); err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return u.repos.ReorderPolicies.FindByProductID(productID)
+}
+
+// EvaluateLowStock compares the available stock of every product with a reorder policy against its
+// reorder point. It raises an alert when the stock fell below the point, drafting a purchase order when
+// the policy names a preferred supplier, and resolves the open alert once the stock recovered.
+func (u *reorderUsecase) EvaluateLowStock(now time.Time) (LowStockEvaluation, error) {
+	var evaluation LowStockEvaluation
+
+	policies, err := u.repos.ReorderPolicies.ListReorderPolicies()
+	if err != nil {
+		return evaluation, err
+	}
+	openAlerts, err := u.repos.LowStockAlerts.ListOpen()
+	if err != nil {
+		return evaluation, err
+	}
+	alertsByKey := make(map[[2]uint]*entity.LowStockAlert, len(openAlerts))
+	for _, alert := range openAlerts {
+		alertsByKey[[2]uint{alert.ProductID(), alert.WarehouseID()}] = alert
+	}
+
+	// Policies come grouped by warehouse, so the stock of each warehouse is read once
+	var available map[uint]int64
+	var availableWarehouseID uint
+	for _, policy := range policies {
+		if available == nil || availableWarehouseID != policy.WarehouseID() {
+			stockLevels, err := u.repos.StockLevels.FindByWarehouseID(policy.WarehouseID())
+			if err != nil {
+				return evaluation, err
+			}
+			available = make(map[uint]int64, len(stockLevels))
+			for _, stockLevel := range stockLevels {
+				available[stockLevel.ProductID()] = stockLevel.Available()
+			}
+			availableWarehouseID = policy.WarehouseID()
+		}
+
+		productAvailable := available[policy.ProductID()]
+		alert := alertsByKey[[2]uint{policy.ProductID(), policy.WarehouseID()}]
+		switch {
+		case policy.IsBelowReorderPoint(productAvailable) && alert == nil:
+			if err := u.raiseLowStockAlert(policy, productAvailable, now); err != nil {
+				return evaluation, err
+			}
+			evaluation.Raised++
+		case !policy.IsBelowReorderPoint(productAvailable) && alert != nil:
+			if err := alert.Resolve(now); err != nil {
+				return evaluation, err
+			}
+			if err := u.repos.LowStockAlerts.Save(alert); err != nil {
+				return evaluation, err
+			}
+			evaluation.Resolved++
+		}
+	}
+	return evaluation, nil
+}
+
+// raiseLowStockAlert records an open alert and, when the policy names a preferred supplier, drafts a
+// purchase order for the reorder quantity in the same transaction
+func (u *reorderUsecase) raiseLowStockAlert(policy *entity.ReorderPolicy, available int64, now time.Time) error {
+	return u.uow.Do(func(repos repository.Repositories) error {
+		alert := entity.NewLowStockAlert(policy, available, now)
+		if err := repos.LowStockAlerts.Save(alert); err != nil {
+			return err
+		}
+		if policy.PreferredSupplierID() == 0 {
+			return nil
+		}
+
+		// Unit costs are left at zero for the buyer to fill in before submitting the draft
+		order, err := entity.NewPurchaseOrder(policy.PreferredSupplierID(), policy.WarehouseID(), fmt.Sprintf("low_stock_alert:%d", alert.ID()), u.currency)
+		if err != nil {
+			return invalidInput(err)
+		}
+		if err := order.AddLine(policy.ProductID(), policy.ReorderQuantity(), 0); err != nil {
+			return invalidInput(err)
+		}
+		if err := repos.PurchaseOrders.Save(order); err != nil {
+			return err
+		}
+
+		alert.LinkPurchaseOrder(order.ID())
+		return repos.LowStockAlerts.Save(alert)
+	})
+}
+
+// ListLowStockAlerts returns a page of low-stock alerts with the total number of matching alerts
+func (u *reorderUsecase) ListLowStockAlerts(filter LowStockAlertFilter) ([]*entity.LowStockAlert, int64, error) {
+	return u.repos.LowStockAlerts.ListLowStockAlerts(filter)
+}
@@ -0,0 +1,231 @@
+// /internal/usecase/reservation_usecase.go
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/pkg/utility"
+	"time"
+)
+
+// expiryBatchSize caps how many reservations a single expiry run processes
+const expiryBatchSize = 100
+
+// ReservationInput carries the details of a reservation request
+type ReservationInput struct {
+	ProductID   uint
+	WarehouseID uint
+	Quantity    int64
+	Reference   string
+	TTL         time.Duration // Defaults to the use case's default TTL when zero
+}
+
+type ReservationUsecase interface {
+	Reserve(input ReservationInput) (*entity.Reservation, error)
+	GetReservationByID(id uint) (*entity.Reservation, error)
+	ConfirmReservation(id uint, serials []string, actor string) (*entity.Reservation, error)
+	ReleaseReservation(id uint) (*entity.Reservation, error)
+	ExpireReservations(now time.Time) (int, error)
+}
+
+type reservationUsecase struct {
+	repos      repository.Repositories
+	uow        repository.UnitOfWork
+	defaultTTL time.Duration
+}
+
+func NewReservationUsecase(repos repository.Repositories, uow repository.UnitOfWork, defaultTTL time.Duration) ReservationUsecase {
+	return &reservationUsecase{repos: repos, uow: uow, defaultTTL: defaultTTL}
+}
+
+// Reserve holds available stock for a caller. The stock level row is locked while the
+// availability check and the increment happen, so concurrent reservations cannot over-commit.
+func (u *reservationUsecase) Reserve(input ReservationInput) (*entity.Reservation, error) {
+	if err := ensureProductAndWarehouseExist(u.repos, input.ProductID, input.WarehouseID); err != nil {
+		return nil, err
+	}
+
+	ttl := input.TTL
+	if ttl == 0 {
+		ttl = u.defaultTTL
+	}
+
+	reservation, err := entity.NewReservation(input.ProductID, input.WarehouseID, input.Quantity, input.Reference, ttl, time.Now())
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+
+	err = u.uow.Do(func(repos repository.Repositories) error {
+		if err := reserveStock(repos, input.ProductID, input.WarehouseID, input.Quantity); err != nil {
+			return err
+		}
+		return repos.Reservations.Save(reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (u *reservationUsecase) GetReservationByID(id uint) (*entity.Reservation, error) {
+	reservation, err := u.repos.Reservations.FindByID(id)
+	if err != nil {
+		if err == repository.ErrReservationNotFound {
+			return nil, ErrReservationNotFound
+		}
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// ConfirmReservation consumes a pending reservation: the reserved quantity is issued from the warehouse
+// and recorded in the ledger. Reservations of serialized products must name the issued units.
+func (u *reservationUsecase) ConfirmReservation(id uint, serials []string, actor string) (*entity.Reservation, error) {
+	var reservation *entity.Reservation
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		if reservation, err = lockReservation(repos, id); err != nil {
+			return err
+		}
+		if err := reservation.Confirm(time.Now()); err != nil {
+			return translateReservationError(err)
+		}
+
+		if err := releaseStock(repos, reservation.ProductID(), reservation.WarehouseID(), reservation.Quantity()); err != nil {
+			return err
+		}
+		movement, err := entity.NewStockMovement(
+			reservation.ProductID(),
+			reservation.WarehouseID(),
+			entity.MovementTypeIssue,
+			reservation.Quantity(),
+			"RESERVATION_CONFIRMED",
+			fmt.Sprintf("reservation:%d", reservation.ID()),
+			actor,
+			time.Now(),
+		)
+		if err != nil {
+			return invalidInput(err)
+		}
+		if err := recordTrackedMovement(repos, movement, MovementTracking{Serials: serials}); err != nil {
+			return err
+		}
+		return repos.Reservations.Save(reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// ReleaseReservation cancels a pending reservation and makes its quantity available again
+func (u *reservationUsecase) ReleaseReservation(id uint) (*entity.Reservation, error) {
+	var reservation *entity.Reservation
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		if reservation, err = lockReservation(repos, id); err != nil {
+			return err
+		}
+		if err := reservation.Release(); err != nil {
+			return translateReservationError(err)
+		}
+		if err := releaseStock(repos, reservation.ProductID(), reservation.WarehouseID(), reservation.Quantity()); err != nil {
+			return err
+		}
+		return repos.Reservations.Save(reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// ExpireReservations releases the stock held by every pending reservation whose time to live has
+// elapsed and returns how many were expired. Each reservation is expired in its own transaction so
+// that one failure does not hold back the rest.
+func (u *reservationUsecase) ExpireReservations(now time.Time) (int, error) {
+	ids, err := u.repos.Reservations.FindExpiredIDs(now, expiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		err := u.uow.Do(func(repos repository.Repositories) error {
+			reservation, err := repos.Reservations.FindForUpdate(id)
+			if err != nil {
+				return err
+			}
+			// Confirmed or released since it was listed
+			if err := reservation.Expire(now); err != nil {
+				return err
+			}
+			if err := releaseStock(repos, reservation.ProductID(), reservation.WarehouseID(), reservation.Quantity()); err != nil {
+				return err
+			}
+			return repos.Reservations.Save(reservation)
+		})
+		if err != nil {
+			if !errors.Is(err, entity.ErrReservationNotPending) {
+				utility.LogError("failed to expire reservation", fmt.Sprint(id), err)
+			}
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// lockReservation loads a reservation for update and translates a missing row
+func lockReservation(repos repository.Repositories, id uint) (*entity.Reservation, error) {
+	reservation, err := repos.Reservations.FindForUpdate(id)
+	if err != nil {
+		if err == repository.ErrReservationNotFound {
+			return nil, ErrReservationNotFound
+		}
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// translateReservationError maps reservation state errors to use case errors
+func translateReservationError(err error) error {
+	switch {
+	case errors.Is(err, entity.ErrReservationNotPending):
+		return ErrReservationNotPending
+	case errors.Is(err, entity.ErrReservationExpired):
+		return ErrReservationExpired
+	}
+	return err
+}
+
+// reserveStock locks a stock level and reserves quantity of its available stock.
+// It must run inside a unit of work.
+func reserveStock(repos repository.Repositories, productID uint, warehouseID uint, quantity int64) error {
+	stockLevel, err := lockAllocatableStock(repos, productID, warehouseID)
+	if err != nil {
+		return err
+	}
+	if err := stockLevel.Reserve(quantity); err != nil {
+		if errors.Is(err, entity.ErrInsufficientStock) {
+			return ErrInsufficientStock
+		}
+		return invalidInput(err)
+	}
+	return repos.StockLevels.UpdateQuantities(stockLevel)
+}
+
+// releaseStock locks a stock level and makes reserved quantity available again.
+// It must run inside a unit of work.
+func releaseStock(repos repository.Repositories, productID uint, warehouseID uint, quantity int64) error {
+	stockLevel, err := repos.StockLevels.FindForUpdate(productID, warehouseID)
+	if err != nil {
+		return err
+	}
+	if err := stockLevel.ReleaseReservation(quantity); err != nil {
+		return err
+	}
+	return repos.StockLevels.UpdateQuantities(stockLevel)
+}
@@ -0,0 +1,293 @@
+// /internal/usecase/sales_order_usecase.go
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"sort"
+	"time"
+)
+
+// SalesOrderFilter narrows down the sales orders returned from a listing
+type SalesOrderFilter = repository.SalesOrderFilter
+
+// SalesOrderLineInput is the ordered quantity of one product
+type SalesOrderLineInput struct {
+	ProductID uint
+	Quantity  int64
+	Unit      string // Unit the quantity is given in, defaults to the stock unit of the product
+}
+
+// SalesOrderInput carries the details of a new sales order
+type SalesOrderInput struct {
+	WarehouseID       uint
+	CustomerReference string
+	Lines             []SalesOrderLineInput
+}
+
+// ShipmentLineInput names the units of one serialized product that leave with a shipment or a transfer
+type ShipmentLineInput struct {
+	ProductID uint
+	Serials   []string
+}
+
+type SalesOrderUsecase interface {
+	CreateSalesOrder(input SalesOrderInput) (*entity.SalesOrder, error)
+	GetSalesOrderByID(id uint) (*entity.SalesOrder, error)
+	ListSalesOrders(filter SalesOrderFilter) ([]*entity.SalesOrder, int64, error)
+	AllocateSalesOrder(id uint) (*entity.SalesOrder, error)
+	PickSalesOrder(id uint) (*entity.SalesOrder, error)
+	PackSalesOrder(id uint) (*entity.SalesOrder, error)
+	ShipSalesOrder(id uint, lines []ShipmentLineInput, actor string) (*entity.SalesOrder, error)
+	CancelSalesOrder(id uint) (*entity.SalesOrder, error)
+}
+
+type salesOrderUsecase struct {
+	repos repository.Repositories
+	uow   repository.UnitOfWork
+}
+
+func NewSalesOrderUsecase(repos repository.Repositories, uow repository.UnitOfWork) SalesOrderUsecase {
+	return &salesOrderUsecase{repos: repos, uow: uow}
+}
+
+// CreateSalesOrder places an order and allocates whatever stock is available right away.
+// Lines that cannot be covered in full are backordered.
+func (u *salesOrderUsecase) CreateSalesOrder(input SalesOrderInput) (*entity.SalesOrder, error) {
+	order, err := entity.NewSalesOrder(input.WarehouseID, input.CustomerReference)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	for _, line := range input.Lines {
+		quantity, err := toStockUnits(u.repos, line.ProductID, line.Unit, line.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if err := order.AddLine(line.ProductID, quantity); err != nil {
+			return nil, invalidInput(err)
+		}
+	}
+	if len(order.Lines()) == 0 {
+		return nil, invalidInput(entity.ErrEmptySalesOrder)
+	}
+
+	if _, err := u.repos.Warehouses.FindByID(input.WarehouseID); err != nil {
+		if err == repository.ErrWarehouseNotFound {
+			return nil, ErrWarehouseNotFound
+		}
+		return nil, err
+	}
+	for _, line := range order.Lines() {
+		if _, err := u.repos.Products.FindByID(line.ProductID()); err != nil {
+			if err == repository.ErrProductNotFound {
+				return nil, ErrProductNotFound
+			}
+			return nil, err
+		}
+	}
+
+	err = u.uow.Do(func(repos repository.Repositories) error {
+		if err := allocateSalesOrder(repos, order); err != nil {
+			return err
+		}
+		return repos.SalesOrders.Save(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (u *salesOrderUsecase) GetSalesOrderByID(id uint) (*entity.SalesOrder, error) {
+	order, err := u.repos.SalesOrders.FindByID(id)
+	if err != nil {
+		if err == repository.ErrSalesOrderNotFound {
+			return nil, ErrSalesOrderNotFound
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// ListSalesOrders returns a page of sales orders with the total number of matching orders
+func (u *salesOrderUsecase) ListSalesOrders(filter SalesOrderFilter) ([]*entity.SalesOrder, int64, error) {
+	return u.repos.SalesOrders.ListSalesOrders(filter)
+}
+
+// AllocateSalesOrder retries allocating the backordered quantity of an order, e.g. after a receipt
+func (u *salesOrderUsecase) AllocateSalesOrder(id uint) (*entity.SalesOrder, error) {
+	return u.transition(id, allocateSalesOrder)
+}
+
+// PickSalesOrder starts picking a fully allocated order
+func (u *salesOrderUsecase) PickSalesOrder(id uint) (*entity.SalesOrder, error) {
+	return u.transition(id, func(repos repository.Repositories, order *entity.SalesOrder) error {
+		return order.Pick()
+	})
+}
+
+// PackSalesOrder marks a picked order as packed
+func (u *salesOrderUsecase) PackSalesOrder(id uint) (*entity.SalesOrder, error) {
+	return u.transition(id, func(repos repository.Repositories, order *entity.SalesOrder) error {
+		return order.Pack()
+	})
+}
+
+// ShipSalesOrder issues the allocated stock of a packed order from its warehouse and records the
+// issues in the ledger. Lines of serialized products must name the shipped units.
+func (u *salesOrderUsecase) ShipSalesOrder(id uint, lines []ShipmentLineInput, actor string) (*entity.SalesOrder, error) {
+	return u.transition(id, func(repos repository.Repositories, order *entity.SalesOrder) error {
+		serials := make(map[uint][]string, len(order.Lines()))
+		for _, line := range order.Lines() {
+			serials[line.ProductID()] = nil
+		}
+		for _, line := range lines {
+			shipped, onOrder := serials[line.ProductID]
+			if !onOrder {
+				return invalidInput(entity.ErrInvalidSalesLineProduct)
+			}
+			if shipped != nil {
+				return invalidInput(entity.ErrDuplicateSalesLine)
+			}
+			serials[line.ProductID] = line.Serials
+		}
+
+		now := time.Now()
+		if err := order.Ship(now); err != nil {
+			return err
+		}
+
+		for _, line := range sortedSalesOrderLines(order) {
+			if err := releaseStock(repos, line.ProductID(), order.WarehouseID(), line.AllocatedQuantity()); err != nil {
+				return err
+			}
+			movement, err := entity.NewStockMovement(
+				line.ProductID(),
+				order.WarehouseID(),
+				entity.MovementTypeIssue,
+				line.AllocatedQuantity(),
+				"SALES_ORDER_SHIPPED",
+				fmt.Sprintf("sales_order:%d", order.ID()),
+				actor,
+				now,
+			)
+			if err != nil {
+				return invalidInput(err)
+			}
+			if err := recordTrackedMovement(repos, movement, MovementTracking{Serials: serials[line.ProductID()]}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CancelSalesOrder abandons an order that has not shipped and makes its allocated stock available again
+func (u *salesOrderUsecase) CancelSalesOrder(id uint) (*entity.SalesOrder, error) {
+	return u.transition(id, func(repos repository.Repositories, order *entity.SalesOrder) error {
+		if err := order.Cancel(); err != nil {
+			return err
+		}
+		for _, line := range sortedSalesOrderLines(order) {
+			if line.AllocatedQuantity() == 0 {
+				continue
+			}
+			if err := releaseStock(repos, line.ProductID(), order.WarehouseID(), line.AllocatedQuantity()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// transition locks a sales order, applies change to it and saves it in one transaction
+func (u *salesOrderUsecase) transition(id uint, change func(repos repository.Repositories, order *entity.SalesOrder) error) (*entity.SalesOrder, error) {
+	var order *entity.SalesOrder
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		order, err = repos.SalesOrders.FindForUpdate(id)
+		if err != nil {
+			if err == repository.ErrSalesOrderNotFound {
+				return ErrSalesOrderNotFound
+			}
+			return err
+		}
+		if err := change(repos, order); err != nil {
+			return translateSalesOrderError(err)
+		}
+		return repos.SalesOrders.Save(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// allocateSalesOrder reserves as much of the backordered quantity of every line as is available.
+// It must run inside a unit of work.
+func allocateSalesOrder(repos repository.Repositories, order *entity.SalesOrder) error {
+	if order.Status() != entity.SalesOrderStatusPending && order.Status() != entity.SalesOrderStatusBackordered {
+		return entity.ErrSalesOrderNotAllocatable
+	}
+
+	allocations := make(map[uint]int64, len(order.Lines()))
+	for _, line := range sortedSalesOrderLines(order) {
+		if line.Backordered() == 0 {
+			continue
+		}
+		allocated, err := allocateStock(repos, line.ProductID(), order.WarehouseID(), line.Backordered())
+		if err != nil {
+			return err
+		}
+		allocations[line.ProductID()] = allocated
+	}
+	return order.Allocate(allocations)
+}
+
+// allocateStock locks a stock level and reserves up to quantity of its available stock, returning how
+// much was reserved. It must run inside a unit of work.
+func allocateStock(repos repository.Repositories, productID uint, warehouseID uint, quantity int64) (int64, error) {
+	stockLevel, err := lockAllocatableStock(repos, productID, warehouseID)
+	if err != nil {
+		return 0, err
+	}
+	if stockLevel.Available() < quantity {
+		quantity = stockLevel.Available()
+	}
+	if quantity <= 0 {
+		return 0, nil
+	}
+	if err := stockLevel.Reserve(quantity); err != nil {
+		return 0, err
+	}
+	if err := repos.StockLevels.UpdateQuantities(stockLevel); err != nil {
+		return 0, err
+	}
+	return quantity, nil
+}
+
+// sortedSalesOrderLines returns the lines of an order by product so that stock levels are always
+// locked in the same order and concurrent orders cannot deadlock each other
+func sortedSalesOrderLines(order *entity.SalesOrder) []*entity.SalesOrderLine {
+	lines := append([]*entity.SalesOrderLine(nil), order.Lines()...)
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].ProductID() < lines[j].ProductID()
+	})
+	return lines
+}
+
+// translateSalesOrderError maps sales order state errors to a conflicting transition. Use case errors
+// such as insufficient stock pass through unchanged.
+func translateSalesOrderError(err error) error {
+	switch {
+	case errors.Is(err, entity.ErrSalesOrderNotAllocatable),
+		errors.Is(err, entity.ErrSalesOrderNotAllocated),
+		errors.Is(err, entity.ErrSalesOrderNotPicking),
+		errors.Is(err, entity.ErrSalesOrderNotPacked),
+		errors.Is(err, entity.ErrSalesOrderNotCancellable):
+		return fmt.Errorf("%w: %w", ErrSalesOrderInvalidTransition, err)
+	}
+	return err
+}
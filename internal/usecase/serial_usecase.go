@@ -0,0 +1,131 @@
+// /internal/usecase/serial_usecase.go
+package usecase
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"sort"
+	"strings"
+)
+
+// SerialHistory is a serialized unit with the ledger entries that moved it, oldest first
+type SerialHistory struct {
+	Serial    *entity.Serial
+	Movements []*entity.StockMovement
+}
+
+type SerialUsecase interface {
+	GetSerial(serialNumber string) (*SerialHistory, error)
+}
+
+type serialUsecase struct {
+	repos repository.Repositories
+}
+
+func NewSerialUsecase(repos repository.Repositories) SerialUsecase {
+	return &serialUsecase{repos: repos}
+}
+
+// GetSerial returns where a unit currently is and every movement it took part in
+func (u *serialUsecase) GetSerial(serialNumber string) (*SerialHistory, error) {
+	serial, err := u.repos.Serials.FindBySerialNumber(strings.TrimSpace(serialNumber))
+	if err != nil {
+		if err == repository.ErrSerialNotFound {
+			return nil, ErrSerialNotFound
+		}
+		return nil, err
+	}
+
+	movements, err := u.repos.Serials.FindHistory(serial.ID())
+	if err != nil {
+		return nil, err
+	}
+	return &SerialHistory{Serial: serial, Movements: movements}, nil
+}
+
+// moveSerials checks that a movement of a serialized product names exactly the units it moves and
+// moves them, returning the saved serials so they can be linked to the movement once it is stored.
+// Products that are not serialized must not name serials. Deleted products are looked up as well, their
+// remaining units still move. It must run inside a unit of work.
+func moveSerials(repos repository.Repositories, movement *entity.StockMovement, serialNumbers []string) ([]*entity.Serial, error) {
+	product, err := repos.Products.FindByIDIncludingDeleted(movement.ProductID())
+	if err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if !product.IsSerialized() {
+		if len(serialNumbers) > 0 {
+			return nil, invalidInput(entity.ErrProductNotSerialized)
+		}
+		return nil, nil
+	}
+
+	quantity := movement.Quantity()
+	if quantity < 0 {
+		quantity = -quantity
+	}
+	if int64(len(serialNumbers)) != quantity {
+		return nil, invalidInput(entity.ErrSerialCountMismatch)
+	}
+
+	// Lock the units in a stable order so concurrent movements cannot deadlock each other
+	numbers := make([]string, len(serialNumbers))
+	for i, number := range serialNumbers {
+		numbers[i] = strings.TrimSpace(number)
+	}
+	sort.Strings(numbers)
+
+	serials := make([]*entity.Serial, 0, len(numbers))
+	for i, number := range numbers {
+		if i > 0 && numbers[i-1] == number {
+			return nil, invalidInput(entity.ErrDuplicateSerial)
+		}
+		serial, err := moveSerial(repos, movement, number)
+		if err != nil {
+			return nil, err
+		}
+		if err := repos.Serials.Save(serial); err != nil {
+			return nil, err
+		}
+		serials = append(serials, serial)
+	}
+	return serials, nil
+}
+
+// moveSerial locks one unit and applies the movement to it. Units seen for the first time can only
+// be received.
+func moveSerial(repos repository.Repositories, movement *entity.StockMovement, serialNumber string) (*entity.Serial, error) {
+	inbound := movement.Quantity() > 0
+	serial, err := repos.Serials.FindForUpdate(serialNumber)
+	switch {
+	case err == repository.ErrSerialNotFound && inbound && movement.Type() != entity.MovementTypeTransferIn:
+		if serial, err = entity.NewSerial(movement.ProductID(), serialNumber, movement.WarehouseID()); err != nil {
+			return nil, invalidInput(err)
+		}
+		return serial, nil
+	case err == repository.ErrSerialNotFound:
+		return nil, ErrSerialNotFound
+	case err != nil:
+		return nil, err
+	}
+
+	if serial.ProductID() != movement.ProductID() {
+		return nil, invalidInput(entity.ErrSerialProductMismatch)
+	}
+	switch {
+	case movement.Type() == entity.MovementTypeTransferIn:
+		err = serial.Arrive(movement.WarehouseID())
+	case inbound:
+		err = serial.Receive(movement.WarehouseID())
+	case movement.Type() == entity.MovementTypeTransferOut:
+		err = serial.Dispatch(movement.WarehouseID())
+	default:
+		err = serial.Issue(movement.WarehouseID())
+	}
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	return serial, nil
+}
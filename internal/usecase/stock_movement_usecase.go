@@ -0,0 +1,224 @@
+// /internal/usecase/stock_movement_usecase.go
+package usecase
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"time"
+)
+
+// StockMovementFilter narrows down the movements returned from the ledger
+type StockMovementFilter = repository.StockMovementFilter
+
+// StockMovementInput carries the details of a quantity change to record in the ledger
+type StockMovementInput struct {
+	ProductID   uint
+	WarehouseID uint
+	Type        entity.MovementType
+	Quantity    int64
+	Unit        string // Unit the quantity is given in, defaults to the stock unit of the product
+	ReasonCode  string
+	Reference   string
+	Actor       string
+	OccurredAt  time.Time // Defaults to now when zero
+	Lot         *LotInput // Names the lot to receive into or take from, nil for untracked stock and FEFO issues
+	Serials     []string  // Names every unit that moves, required for serialized products
+}
+
+// MovementTracking names the lot and the serialized units a movement is booked against
+type MovementTracking struct {
+	Lot     *LotInput
+	Lots    []LotPortion // Splits an inbound movement over several lots instead of one, e.g. a transfer receipt
+	Serials []string
+}
+
+type StockMovementUsecase interface {
+	RecordMovement(input StockMovementInput) (*entity.StockMovement, error)
+	ListProductMovements(filter StockMovementFilter) ([]*entity.StockMovement, int64, error)
+	ReconcileProductStock(productID uint) ([]*entity.StockReconciliation, error)
+}
+
+type stockMovementUsecase struct {
+	repos repository.Repositories
+	uow   repository.UnitOfWork
+}
+
+func NewStockMovementUsecase(repos repository.Repositories, uow repository.UnitOfWork) StockMovementUsecase {
+	return &stockMovementUsecase{repos: repos, uow: uow}
+}
+
+// RecordMovement appends a movement to the ledger and applies it to the stock level in one transaction.
+// The stock left of a deleted product can still be issued and adjusted, receipts are refused.
+func (u *stockMovementUsecase) RecordMovement(input StockMovementInput) (*entity.StockMovement, error) {
+	if _, err := u.repos.Products.FindByIDIncludingDeleted(input.ProductID); err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if err := ensureWarehouseExists(u.repos, input.WarehouseID); err != nil {
+		return nil, err
+	}
+	quantity, err := toStockUnits(u.repos, input.ProductID, input.Unit, input.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	movement, err := entity.NewStockMovement(
+		input.ProductID,
+		input.WarehouseID,
+		input.Type,
+		quantity,
+		input.ReasonCode,
+		input.Reference,
+		input.Actor,
+		input.OccurredAt,
+	)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+
+	err = u.uow.Do(func(repos repository.Repositories) error {
+		return recordTrackedMovement(repos, movement, MovementTracking{Lot: input.Lot, Serials: input.Serials})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// ListProductMovements returns a page of the ledger of a product with the total number of matching movements
+func (u *stockMovementUsecase) ListProductMovements(filter StockMovementFilter) ([]*entity.StockMovement, int64, error) {
+	if _, err := u.repos.Products.FindByID(filter.ProductID); err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, 0, ErrProductNotFound
+		}
+		return nil, 0, err
+	}
+	return u.repos.StockMovements.ListMovements(filter)
+}
+
+// ReconcileProductStock compares the stored on-hand quantity of a product in each warehouse with its ledger
+func (u *stockMovementUsecase) ReconcileProductStock(productID uint) ([]*entity.StockReconciliation, error) {
+	if _, err := u.repos.Products.FindByID(productID); err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	stockLevels, err := u.repos.StockLevels.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := u.repos.StockMovements.SumQuantitiesByWarehouse(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliations := make([]*entity.StockReconciliation, 0, len(stockLevels))
+	for _, stockLevel := range stockLevels {
+		reconciliations = append(reconciliations, entity.NewStockReconciliation(
+			productID,
+			stockLevel.WarehouseID(),
+			stockLevel.OnHand(),
+			balances[stockLevel.WarehouseID()],
+		))
+	}
+	return reconciliations, nil
+}
+
+// recordMovement locks the stock level of the movement, applies the movement to it and appends the
+// movement to the ledger. It must run inside a unit of work so the lock and both writes share a transaction.
+func recordMovement(repos repository.Repositories, movement *entity.StockMovement) error {
+	return recordTrackedMovement(repos, movement, MovementTracking{})
+}
+
+// recordTrackedMovement records a movement like recordMovement and books it against the given lot and
+// serials. Without a lot, inbound stock stays untracked and outbound stock is taken from the lots
+// first-expiring first. Receipts and upward adjustments are refused for discontinued, archived and
+// deleted products, while the stock they have left keeps moving.
+// The StockAdjusted event announcing the movement is written to the outbox in the same transaction.
+func recordTrackedMovement(repos repository.Repositories, movement *entity.StockMovement, tracking MovementTracking) error {
+	_, err := recordMovementFromLots(repos, movement, tracking)
+	return err
+}
+
+// recordMovementFromLots records a movement like recordTrackedMovement and returns the part of it booked
+// against each lot, e.g. to learn which lots an issue was taken out of first-expiring first.
+func recordMovementFromLots(repos repository.Repositories, movement *entity.StockMovement, tracking MovementTracking) ([]LotPortion, error) {
+	if movement.AddsStock() {
+		product, err := repos.Products.FindByIDIncludingDeleted(movement.ProductID())
+		if err != nil {
+			if err == repository.ErrProductNotFound {
+				return nil, ErrProductNotFound
+			}
+			return nil, err
+		}
+		if !product.AcceptsStock() {
+			return nil, ErrProductNotStockable
+		}
+	}
+
+	stockLevel, err := repos.StockLevels.FindForUpdate(movement.ProductID(), movement.WarehouseID())
+	if err != nil {
+		return nil, err
+	}
+
+	portions, err := moveLots(repos, stockLevel, movement, tracking)
+	if err != nil {
+		return nil, err
+	}
+	serials, err := moveSerials(repos, movement, tracking.Serials)
+	if err != nil {
+		return nil, err
+	}
+	if err := stockLevel.ApplyMovement(movement); err != nil {
+		if errors.Is(err, entity.ErrInsufficientStock) {
+			return nil, ErrInsufficientStock
+		}
+		return nil, err
+	}
+
+	if err := repos.StockLevels.UpdateQuantities(stockLevel); err != nil {
+		return nil, err
+	}
+	if err := repos.StockMovements.Save(movement); err != nil {
+		return nil, err
+	}
+	if err := repos.Serials.LinkMovement(movement.ID(), serials); err != nil {
+		return nil, err
+	}
+
+	event, err := entity.NewStockAdjustedEvent(movement, stockLevel)
+	if err != nil {
+		return nil, err
+	}
+	if err := repos.Outbox.Save(event); err != nil {
+		return nil, err
+	}
+	return portions, nil
+}
+
+// ensureProductAndWarehouseExist translates missing references into use case errors
+func ensureProductAndWarehouseExist(repos repository.Repositories, productID uint, warehouseID uint) error {
+	if _, err := repos.Products.FindByID(productID); err != nil {
+		if err == repository.ErrProductNotFound {
+			return ErrProductNotFound
+		}
+		return err
+	}
+	return ensureWarehouseExists(repos, warehouseID)
+}
+
+// ensureWarehouseExists translates a missing warehouse into a use case error
+func ensureWarehouseExists(repos repository.Repositories, warehouseID uint) error {
+	if _, err := repos.Warehouses.FindByID(warehouseID); err != nil {
+		if err == repository.ErrWarehouseNotFound {
+			return ErrWarehouseNotFound
+		}
+		return err
+	}
+	return nil
+}
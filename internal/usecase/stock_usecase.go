@@ -0,0 +1,107 @@
+// /internal/usecase/stock_usecase.go
+package usecase
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"strings"
+)
+
+// StockInUnit is a set of stock levels with the conversions that express their quantities in a
+// requested unit. Without a requested unit there are no conversions and quantities stay in stock units.
+type StockInUnit struct {
+	Unit        string // Empty when the stock units of the products differ
+	StockLevels []*entity.StockLevel
+	Conversions map[uint]*entity.ProductUnit // Keyed by product
+}
+
+type StockUsecase interface {
+	GetProductStock(productID uint, unit string) (*StockInUnit, error)
+	GetWarehouseStock(warehouseID uint, unit string) (*StockInUnit, error)
+}
+
+type stockUsecase struct {
+	productRepo     repository.PostgresProductRepository
+	warehouseRepo   repository.PostgresWarehouseRepository
+	stockLevelRepo  repository.PostgresStockLevelRepository
+	productUnitRepo repository.PostgresProductUnitRepository
+}
+
+func NewStockUsecase(
+	productRepo repository.PostgresProductRepository,
+	warehouseRepo repository.PostgresWarehouseRepository,
+	stockLevelRepo repository.PostgresStockLevelRepository,
+	productUnitRepo repository.PostgresProductUnitRepository,
+) StockUsecase {
+	return &stockUsecase{
+		productRepo:     productRepo,
+		warehouseRepo:   warehouseRepo,
+		stockLevelRepo:  stockLevelRepo,
+		productUnitRepo: productUnitRepo,
+	}
+}
+
+// GetProductStock returns the stock levels of a product in every warehouse holding it, in the
+// requested unit or else in its stock unit
+func (u *stockUsecase) GetProductStock(productID uint, unit string) (*StockInUnit, error) {
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	stockLevels, err := u.stockLevelRepo.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(unit) == "" {
+		return &StockInUnit{Unit: product.StockUnit(), StockLevels: stockLevels}, nil
+	}
+	conversion, err := findConversion(u.productRepo, u.productUnitRepo, productID, unit)
+	if err != nil {
+		return nil, err
+	}
+	return &StockInUnit{
+		Unit:        conversion.UnitCode(),
+		StockLevels: stockLevels,
+		Conversions: map[uint]*entity.ProductUnit{productID: conversion},
+	}, nil
+}
+
+// GetWarehouseStock returns the stock levels of every product held in a warehouse. When a unit is
+// requested, every product in the warehouse must convert to it.
+func (u *stockUsecase) GetWarehouseStock(warehouseID uint, unit string) (*StockInUnit, error) {
+	if _, err := u.warehouseRepo.FindByID(warehouseID); err != nil {
+		if err == repository.ErrWarehouseNotFound {
+			return nil, ErrWarehouseNotFound
+		}
+		return nil, err
+	}
+	stockLevels, err := u.stockLevelRepo.FindByWarehouseID(warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(unit) == "" {
+		return &StockInUnit{StockLevels: stockLevels}, nil
+	}
+
+	stock := &StockInUnit{
+		Unit:        entity.NormalizeUnitCode(unit),
+		StockLevels: stockLevels,
+		Conversions: make(map[uint]*entity.ProductUnit),
+	}
+	for _, stockLevel := range stockLevels {
+		if _, found := stock.Conversions[stockLevel.ProductID()]; found {
+			continue
+		}
+		conversion, err := findConversion(u.productRepo, u.productUnitRepo, stockLevel.ProductID(), unit)
+		if err != nil {
+			return nil, err
+		}
+		stock.Conversions[stockLevel.ProductID()] = conversion
+	}
+	return stock, nil
+}
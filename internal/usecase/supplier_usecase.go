@@ -0,0 +1,50 @@
+// /internal/usecase/supplier_usecase.go
+package usecase
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+)
+
+type SupplierUsecase interface {
+	CreateSupplier(code string, name string, contactEmail string) (*entity.Supplier, error)
+	GetSupplierByID(id uint) (*entity.Supplier, error)
+	ListSuppliers() ([]*entity.Supplier, error)
+}
+
+type supplierUsecase struct {
+	supplierRepo repository.PostgresSupplierRepository
+}
+
+func NewSupplierUsecase(repo repository.PostgresSupplierRepository) SupplierUsecase {
+	return &supplierUsecase{supplierRepo: repo}
+}
+
+func (u *supplierUsecase) CreateSupplier(code string, name string, contactEmail string) (*entity.Supplier, error) {
+	s, err := entity.NewSupplier(code, name, contactEmail)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.supplierRepo.Save(s); err != nil {
+		if err == repository.ErrSupplierCodeTaken {
+			return nil, ErrSupplierCodeTaken
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (u *supplierUsecase) GetSupplierByID(id uint) (*entity.Supplier, error) {
+	s, err := u.supplierRepo.FindByID(id)
+	if err != nil {
+		if err == repository.ErrSupplierNotFound {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (u *supplierUsecase) ListSuppliers() ([]*entity.Supplier, error) {
+	return u.supplierRepo.ListSuppliers()
+}
@@ -0,0 +1,322 @@
+// /internal/usecase/transfer_usecase.go
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"time"
+)
+
+// TransferFilter narrows down the transfers returned from a listing
+type TransferFilter = repository.TransferFilter
+
+// TransferLineInput is the quantity of one product on a transfer or receipt. Receipts of serialized
+// products name the units that arrived.
+type TransferLineInput struct {
+	ProductID uint
+	Quantity  int64
+	Serials   []string
+}
+
+// TransferInput carries the details of a new transfer
+type TransferInput struct {
+	SourceWarehouseID      uint
+	DestinationWarehouseID uint
+	Reference              string
+	Lines                  []TransferLineInput
+}
+
+type TransferUsecase interface {
+	CreateTransfer(input TransferInput) (*entity.Transfer, error)
+	GetTransferByID(id uint) (*entity.Transfer, error)
+	ListTransfers(filter TransferFilter) ([]*entity.Transfer, int64, error)
+	DispatchTransfer(id uint, lines []ShipmentLineInput, actor string) (*entity.Transfer, error)
+	ReceiveTransfer(id uint, lines []TransferLineInput, actor string) (*entity.Transfer, error)
+	CancelTransfer(id uint) (*entity.Transfer, error)
+	CloseTransfer(id uint, reasonCode string) (*entity.Transfer, error)
+}
+
+type transferUsecase struct {
+	repos repository.Repositories
+	uow   repository.UnitOfWork
+}
+
+func NewTransferUsecase(repos repository.Repositories, uow repository.UnitOfWork) TransferUsecase {
+	return &transferUsecase{repos: repos, uow: uow}
+}
+
+// CreateTransfer drafts a transfer. No stock moves until it is dispatched.
+func (u *transferUsecase) CreateTransfer(input TransferInput) (*entity.Transfer, error) {
+	transfer, err := entity.NewTransfer(input.SourceWarehouseID, input.DestinationWarehouseID, input.Reference)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	for _, line := range input.Lines {
+		if err := transfer.AddLine(line.ProductID, line.Quantity); err != nil {
+			return nil, invalidInput(err)
+		}
+	}
+
+	for _, warehouseID := range []uint{input.SourceWarehouseID, input.DestinationWarehouseID} {
+		if _, err := u.repos.Warehouses.FindByID(warehouseID); err != nil {
+			if err == repository.ErrWarehouseNotFound {
+				return nil, ErrWarehouseNotFound
+			}
+			return nil, err
+		}
+	}
+	for _, line := range transfer.Lines() {
+		if _, err := u.repos.Products.FindByID(line.ProductID()); err != nil {
+			if err == repository.ErrProductNotFound {
+				return nil, ErrProductNotFound
+			}
+			return nil, err
+		}
+	}
+
+	err = u.uow.Do(func(repos repository.Repositories) error {
+		return repos.Transfers.Save(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (u *transferUsecase) GetTransferByID(id uint) (*entity.Transfer, error) {
+	transfer, err := u.repos.Transfers.FindByID(id)
+	if err != nil {
+		if err == repository.ErrTransferNotFound {
+			return nil, ErrTransferNotFound
+		}
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// ListTransfers returns a page of transfers with the total number of matching transfers
+func (u *transferUsecase) ListTransfers(filter TransferFilter) ([]*entity.Transfer, int64, error) {
+	return u.repos.Transfers.ListTransfers(filter)
+}
+
+// DispatchTransfer takes the stock of every line out of the source warehouse, first-expiring lots first,
+// and records on each line which lots it took. The stock is in transit until the destination receives it.
+// Lines of serialized products must name the dispatched units.
+func (u *transferUsecase) DispatchTransfer(id uint, lines []ShipmentLineInput, actor string) (*entity.Transfer, error) {
+	var transfer *entity.Transfer
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		if transfer, err = lockTransfer(repos, id); err != nil {
+			return err
+		}
+
+		serials := make(map[uint][]string, len(transfer.Lines()))
+		for _, line := range transfer.Lines() {
+			serials[line.ProductID()] = nil
+		}
+		for _, line := range lines {
+			dispatched, onTransfer := serials[line.ProductID]
+			if !onTransfer {
+				return invalidInput(entity.ErrTransferLineNotFound)
+			}
+			if dispatched != nil {
+				return invalidInput(entity.ErrDuplicateTransferLine)
+			}
+			serials[line.ProductID] = line.Serials
+		}
+
+		now := time.Now()
+		if err := transfer.Dispatch(now); err != nil {
+			return translateTransferError(err)
+		}
+
+		for _, line := range transfer.Lines() {
+			tracking := MovementTracking{Serials: serials[line.ProductID()]}
+			portions, err := recordTransferMovement(repos, transfer, line.ProductID(), transfer.SourceWarehouseID(), entity.MovementTypeTransferOut, line.Quantity(), "TRANSFER_DISPATCHED", actor, now, tracking)
+			if err != nil {
+				return err
+			}
+			for _, portion := range portions {
+				err := transfer.AddDispatchedLot(line.ProductID(), portion.Lot.Number, portion.Lot.ManufacturedAt, portion.Lot.ExpiresAt, portion.Quantity)
+				if err != nil {
+					return translateTransferError(err)
+				}
+			}
+		}
+		return repos.Transfers.Save(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// ReceiveTransfer puts arrived stock into the destination warehouse, recreating the lots it was dispatched
+// from. When no lines are given, everything still in transit is received. Lines of serialized products
+// must name the arrived units.
+func (u *transferUsecase) ReceiveTransfer(id uint, lines []TransferLineInput, actor string) (*entity.Transfer, error) {
+	var transfer *entity.Transfer
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		if transfer, err = lockTransfer(repos, id); err != nil {
+			return err
+		}
+
+		quantities := make(map[uint]int64, len(transfer.Lines()))
+		serials := make(map[uint][]string, len(lines))
+		if len(lines) == 0 {
+			for _, line := range transfer.Lines() {
+				if line.Outstanding() > 0 {
+					quantities[line.ProductID()] = line.Outstanding()
+				}
+			}
+		}
+		for _, line := range lines {
+			if _, exists := quantities[line.ProductID]; exists {
+				return invalidInput(entity.ErrDuplicateTransferLine)
+			}
+			quantities[line.ProductID] = line.Quantity
+			serials[line.ProductID] = line.Serials
+		}
+
+		// Which lots arrive must be worked out before the receipt books them
+		lots := make(map[uint][]LotPortion, len(quantities))
+		for _, line := range transfer.Lines() {
+			for _, arriving := range line.ArrivingLots(quantities[line.ProductID()]) {
+				lots[line.ProductID()] = append(lots[line.ProductID()], LotPortion{
+					Lot:      LotInput{Number: arriving.Lot.LotNumber(), ManufacturedAt: arriving.Lot.ManufacturedAt(), ExpiresAt: arriving.Lot.ExpiresAt()},
+					Quantity: arriving.Quantity,
+				})
+			}
+		}
+
+		now := time.Now()
+		if err := transfer.Receive(quantities, now); err != nil {
+			return translateTransferError(err)
+		}
+
+		// Walk the lines rather than the map so movements are recorded in a stable order
+		for _, line := range transfer.Lines() {
+			quantity, received := quantities[line.ProductID()]
+			if !received {
+				continue
+			}
+			tracking := MovementTracking{Lots: lots[line.ProductID()], Serials: serials[line.ProductID()]}
+			_, err := recordTransferMovement(repos, transfer, line.ProductID(), transfer.DestinationWarehouseID(), entity.MovementTypeTransferIn, quantity, "TRANSFER_RECEIVED", actor, now, tracking)
+			if err != nil {
+				return err
+			}
+		}
+		return repos.Transfers.Save(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// CancelTransfer abandons a draft transfer
+func (u *transferUsecase) CancelTransfer(id uint) (*entity.Transfer, error) {
+	var transfer *entity.Transfer
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		if transfer, err = lockTransfer(repos, id); err != nil {
+			return err
+		}
+		if err := transfer.Cancel(); err != nil {
+			return translateTransferError(err)
+		}
+		return repos.Transfers.Save(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// CloseTransfer writes off whatever of a dispatched transfer has not arrived, so the transfer stops
+// waiting for it. Units of serialized products still in transit are written off with it.
+func (u *transferUsecase) CloseTransfer(id uint, reasonCode string) (*entity.Transfer, error) {
+	var transfer *entity.Transfer
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		if transfer, err = lockTransfer(repos, id); err != nil {
+			return err
+		}
+		if err := transfer.Close(reasonCode, time.Now()); err != nil {
+			return translateTransferError(err)
+		}
+
+		for _, line := range transfer.Lines() {
+			if line.Outstanding() == 0 {
+				continue
+			}
+			serials, err := repos.Serials.FindInTransitForUpdate(line.ProductID(), transferReference(transfer))
+			if err != nil {
+				return err
+			}
+			for _, serial := range serials {
+				if err := serial.WriteOff(); err != nil {
+					return err
+				}
+				if err := repos.Serials.Save(serial); err != nil {
+					return err
+				}
+			}
+		}
+		return repos.Transfers.Save(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// recordTransferMovement appends one leg of a transfer to the ledger, referencing the transfer, books it
+// against the given lots and serials and returns the part of it booked against each lot
+func recordTransferMovement(repos repository.Repositories, transfer *entity.Transfer, productID uint, warehouseID uint, movementType entity.MovementType, quantity int64, reasonCode string, actor string, now time.Time, tracking MovementTracking) ([]LotPortion, error) {
+	movement, err := entity.NewStockMovement(
+		productID,
+		warehouseID,
+		movementType,
+		quantity,
+		reasonCode,
+		transferReference(transfer),
+		actor,
+		now,
+	)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	return recordMovementFromLots(repos, movement, tracking)
+}
+
+// transferReference is the reference of the ledger entries of a transfer
+func transferReference(transfer *entity.Transfer) string {
+	return fmt.Sprintf("transfer:%d", transfer.ID())
+}
+
+// lockTransfer loads a transfer for update and translates a missing row
+func lockTransfer(repos repository.Repositories, id uint) (*entity.Transfer, error) {
+	transfer, err := repos.Transfers.FindForUpdate(id)
+	if err != nil {
+		if err == repository.ErrTransferNotFound {
+			return nil, ErrTransferNotFound
+		}
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// translateTransferError maps transfer state errors to use case errors and the rest to invalid input
+func translateTransferError(err error) error {
+	switch {
+	case errors.Is(err, entity.ErrTransferNotDraft):
+		return ErrTransferNotDraft
+	case errors.Is(err, entity.ErrTransferNotInTransit):
+		return ErrTransferNotInTransit
+	}
+	return invalidInput(err)
+}
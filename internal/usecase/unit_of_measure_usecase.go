@@ -0,0 +1,149 @@
+// /internal/usecase/unit_of_measure_usecase.go
+package usecase
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"strings"
+)
+
+// ProductUnits is a product with the conversions from the units it is bought or sold in to its stock unit
+type ProductUnits struct {
+	Product     *entity.Product
+	Conversions []*entity.ProductUnit
+}
+
+type UnitOfMeasureUsecase interface {
+	CreateUnit(code string, name string) (*entity.UnitOfMeasure, error)
+	ListUnits() ([]*entity.UnitOfMeasure, error)
+	SetProductUnit(productID uint, unitCode string, factor int64) (*entity.ProductUnit, error)
+	GetProductUnits(productID uint) (*ProductUnits, error)
+}
+
+type unitOfMeasureUsecase struct {
+	repos repository.Repositories
+}
+
+func NewUnitOfMeasureUsecase(repos repository.Repositories) UnitOfMeasureUsecase {
+	return &unitOfMeasureUsecase{repos: repos}
+}
+
+// CreateUnit adds a unit to the catalogue
+func (u *unitOfMeasureUsecase) CreateUnit(code string, name string) (*entity.UnitOfMeasure, error) {
+	unit, err := entity.NewUnitOfMeasure(code, name)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	if err := u.repos.Units.Save(unit); err != nil {
+		if err == repository.ErrUnitCodeTaken {
+			return nil, ErrUnitCodeTaken
+		}
+		return nil, err
+	}
+	return unit, nil
+}
+
+// ListUnits returns the whole catalogue
+func (u *unitOfMeasureUsecase) ListUnits() ([]*entity.UnitOfMeasure, error) {
+	return u.repos.Units.ListUnits()
+}
+
+// SetProductUnit creates or replaces how many stock units of a product one of the given unit holds
+func (u *unitOfMeasureUsecase) SetProductUnit(productID uint, unitCode string, factor int64) (*entity.ProductUnit, error) {
+	product, err := u.repos.Products.FindByID(productID)
+	if err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if _, err := u.repos.Units.FindByCode(unitCode); err != nil {
+		if err == repository.ErrUnitOfMeasureNotFound {
+			return nil, ErrUnitOfMeasureNotFound
+		}
+		return nil, err
+	}
+	if entity.NormalizeUnitCode(unitCode) == product.StockUnit() {
+		return nil, invalidInput(entity.ErrConversionForStockUnit)
+	}
+
+	conversion, err := u.repos.ProductUnits.FindByProductAndUnit(productID, unitCode)
+	switch {
+	case err == repository.ErrProductUnitNotFound:
+		conversion, err = entity.NewProductUnit(productID, unitCode, factor)
+	case err == nil:
+		err = conversion.SetFactor(factor)
+	default:
+		return nil, err
+	}
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+
+	if err := u.repos.ProductUnits.Save(conversion); err != nil {
+		return nil, err
+	}
+	return conversion, nil
+}
+
+// GetProductUnits returns the stock unit of a product and every conversion it has
+func (u *unitOfMeasureUsecase) GetProductUnits(productID uint) (*ProductUnits, error) {
+	product, err := u.repos.Products.FindByID(productID)
+	if err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	conversions, err := u.repos.ProductUnits.FindByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+	return &ProductUnits{Product: product, Conversions: conversions}, nil
+}
+
+// toStockUnits converts a quantity of a product given in unit to the stock unit of the product. An
+// empty unit means the quantity already is in stock units.
+func toStockUnits(repos repository.Repositories, productID uint, unit string, quantity int64) (int64, error) {
+	if strings.TrimSpace(unit) == "" {
+		return quantity, nil
+	}
+	conversion, err := findConversion(repos.Products, repos.ProductUnits, productID, unit)
+	if err != nil {
+		return 0, err
+	}
+	converted, err := conversion.ToStockUnits(quantity)
+	if err != nil {
+		return 0, invalidInput(err)
+	}
+	return converted, nil
+}
+
+// findConversion returns how the given unit converts to the stock unit of a product. The stock unit
+// itself converts one to one. Deleted products still convert, as the stock they have left still moves.
+func findConversion(
+	productRepo repository.PostgresProductRepository,
+	productUnitRepo repository.PostgresProductUnitRepository,
+	productID uint,
+	unit string,
+) (*entity.ProductUnit, error) {
+	product, err := productRepo.FindByIDIncludingDeleted(productID)
+	if err != nil {
+		if err == repository.ErrProductNotFound {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	if entity.NormalizeUnitCode(unit) == product.StockUnit() {
+		return entity.NewProductUnit(productID, product.StockUnit(), 1)
+	}
+
+	conversion, err := productUnitRepo.FindByProductAndUnit(productID, unit)
+	if err != nil {
+		if err == repository.ErrProductUnitNotFound {
+			return nil, invalidInput(entity.ErrUnitNotConvertible)
+		}
+		return nil, err
+	}
+	return conversion, nil
+}
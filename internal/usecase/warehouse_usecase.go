@@ -0,0 +1,50 @@
+// /internal/usecase/warehouse_usecase.go
+package usecase
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+)
+
+type WarehouseUsecase interface {
+	CreateWarehouse(code string, name string) (*entity.Warehouse, error)
+	GetWarehouseByID(id uint) (*entity.Warehouse, error)
+	ListWarehouses() ([]*entity.Warehouse, error)
+}
+
+type warehouseUsecase struct {
+	warehouseRepo repository.PostgresWarehouseRepository
+}
+
+func NewWarehouseUsecase(repo repository.PostgresWarehouseRepository) WarehouseUsecase {
+	return &warehouseUsecase{warehouseRepo: repo}
+}
+
+func (u *warehouseUsecase) CreateWarehouse(code string, name string) (*entity.Warehouse, error) {
+	w, err := entity.NewWarehouse(code, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.warehouseRepo.Save(w); err != nil {
+		if err == repository.ErrWarehouseCodeTaken {
+			return nil, ErrWarehouseCodeTaken
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+func (u *warehouseUsecase) GetWarehouseByID(id uint) (*entity.Warehouse, error) {
+	w, err := u.warehouseRepo.FindByID(id)
+	if err != nil {
+		if err == repository.ErrWarehouseNotFound {
+			return nil, ErrWarehouseNotFound
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+func (u *warehouseUsecase) ListWarehouses() ([]*entity.Warehouse, error) {
+	return u.warehouseRepo.ListWarehouses()
+}
@@ -0,0 +1,273 @@
+// /internal/usecase/webhook_usecase.go
+package usecase
+
+import (
+	"fmt"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/event"
+	"inventory_management/internal/repository"
+	"time"
+)
+
+// WebhookDeliveryFilter narrows down the deliveries returned from the log of a subscription
+type WebhookDeliveryFilter = repository.WebhookDeliveryFilter
+
+// WebhookSubscriptionInput carries the settings of a webhook subscription
+type WebhookSubscriptionInput struct {
+	URL        string
+	Secret     string             // Empty generates a secret on creation and keeps the current one on update
+	EventTypes []entity.EventType // Empty subscribes to every event
+}
+
+// WebhookSender posts a delivery to the URL of its subscription and returns the HTTP status the receiver
+// answered with. The error is set when no answer came back.
+type WebhookSender interface {
+	Send(subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) (int, error)
+}
+
+// WebhookDispatchRun summarises one pass of the dispatcher over the due deliveries
+type WebhookDispatchRun struct {
+	Succeeded    int
+	Retried      int
+	DeadLettered int
+}
+
+type WebhookUsecase interface {
+	CreateSubscription(input WebhookSubscriptionInput) (*entity.WebhookSubscription, error)
+	GetSubscription(id uint) (*entity.WebhookSubscription, error)
+	ListSubscriptions() ([]*entity.WebhookSubscription, error)
+	UpdateSubscription(id uint, input WebhookSubscriptionInput) (*entity.WebhookSubscription, error)
+	DeleteSubscription(id uint) error
+	ListDeliveries(filter WebhookDeliveryFilter) ([]*entity.WebhookDelivery, int64, error)
+	Publish(e *entity.DomainEvent) error
+	DispatchDeliveries(now time.Time) (WebhookDispatchRun, error)
+}
+
+// webhookDispatchBatchSize is the number of deliveries claimed at a time
+const webhookDispatchBatchSize = 50
+
+// webhookDeliveryLease is how long claimed deliveries are held back from other dispatchers while they are
+// posted. It outlasts a batch of posts to receivers that do not answer before the sender times out.
+const webhookDeliveryLease = 15 * time.Minute
+
+type webhookUsecase struct {
+	repos  repository.Repositories
+	uow    repository.UnitOfWork
+	sender WebhookSender
+	policy entity.WebhookRetryPolicy
+}
+
+func NewWebhookUsecase(repos repository.Repositories, uow repository.UnitOfWork, sender WebhookSender, policy entity.WebhookRetryPolicy) WebhookUsecase {
+	return &webhookUsecase{repos: repos, uow: uow, sender: sender, policy: policy}
+}
+
+// CreateSubscription registers a URL to post events to
+func (u *webhookUsecase) CreateSubscription(input WebhookSubscriptionInput) (*entity.WebhookSubscription, error) {
+	subscription, err := entity.NewWebhookSubscription(input.URL, input.Secret, input.EventTypes)
+	if err != nil {
+		return nil, invalidInput(err)
+	}
+	if err := u.repos.Webhooks.Save(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetSubscription returns a webhook subscription by its ID
+func (u *webhookUsecase) GetSubscription(id uint) (*entity.WebhookSubscription, error) {
+	subscription, err := u.repos.Webhooks.FindByID(id)
+	if err != nil {
+		if err == repository.ErrWebhookSubscriptionNotFound {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions returns every webhook subscription
+func (u *webhookUsecase) ListSubscriptions() ([]*entity.WebhookSubscription, error) {
+	return u.repos.Webhooks.ListSubscriptions()
+}
+
+// UpdateSubscription points a subscription at a new URL and event filter. Events already queued for it
+// are posted to the new URL.
+func (u *webhookUsecase) UpdateSubscription(id uint, input WebhookSubscriptionInput) (*entity.WebhookSubscription, error) {
+	subscription, err := u.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := subscription.Update(input.URL, input.Secret, input.EventTypes); err != nil {
+		return nil, invalidInput(err)
+	}
+	if err := u.repos.Webhooks.Save(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// DeleteSubscription removes a subscription with its delivery log. Queued events are dropped.
+func (u *webhookUsecase) DeleteSubscription(id uint) error {
+	if err := u.repos.Webhooks.Delete(id); err != nil {
+		if err == repository.ErrWebhookSubscriptionNotFound {
+			return ErrWebhookNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ListDeliveries returns a page of the delivery log of a subscription, newest first, with the total
+// number of matching deliveries
+func (u *webhookUsecase) ListDeliveries(filter WebhookDeliveryFilter) ([]*entity.WebhookDelivery, int64, error) {
+	if _, err := u.GetSubscription(filter.SubscriptionID); err != nil {
+		return nil, 0, err
+	}
+	return u.repos.WebhookDeliveries.ListDeliveries(filter)
+}
+
+// Publish queues a delivery of the event for every subscription accepting its type. It lets the outbox
+// relay feed the webhooks: an event relayed again is not queued twice.
+func (u *webhookUsecase) Publish(e *entity.DomainEvent) error {
+	payload, err := event.Encode(e)
+	if err != nil {
+		return err
+	}
+
+	return u.uow.Do(func(repos repository.Repositories) error {
+		subscriptions, err := repos.Webhooks.ListSubscriptions()
+		if err != nil {
+			return err
+		}
+		for _, subscription := range subscriptions {
+			if !subscription.Accepts(e.Type()) {
+				continue
+			}
+			delivery, err := entity.NewWebhookDelivery(subscription.ID(), e, string(payload), time.Now())
+			if err != nil {
+				return err
+			}
+			if err := repos.WebhookDeliveries.Create(delivery); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DispatchDeliveries posts every delivery that is due, a batch at a time. A batch is claimed in a short
+// transaction leasing its deliveries, posted outside of any transaction, and the outcome of every post
+// is recorded in a transaction of its own, so a slow receiver holds neither locks nor connections. A 2xx
+// answer completes a delivery. Any other answer, or none, schedules a retry with exponential backoff
+// until the retry policy runs out of attempts and the delivery is dead-lettered.
+func (u *webhookUsecase) DispatchDeliveries(now time.Time) (WebhookDispatchRun, error) {
+	var run WebhookDispatchRun
+	for {
+		deliveries, leasedUntil, err := u.claimDeliveries(now)
+		if err != nil {
+			return run, err
+		}
+
+		subscriptions := map[uint]*entity.WebhookSubscription{}
+		for _, delivery := range deliveries {
+			subscription, ok := subscriptions[delivery.SubscriptionID()]
+			if !ok {
+				if subscription, err = u.repos.Webhooks.FindByID(delivery.SubscriptionID()); err != nil {
+					if err == repository.ErrWebhookSubscriptionNotFound {
+						continue // Deleted together with its deliveries since they were claimed
+					}
+					return run, err
+				}
+				subscriptions[delivery.SubscriptionID()] = subscription
+			}
+
+			statusCode, sendErr := u.sender.Send(subscription, delivery)
+			status, err := u.recordAttempt(delivery.ID(), leasedUntil, statusCode, sendErr, now)
+			if err != nil {
+				return run, err
+			}
+			switch status {
+			case entity.WebhookDeliverySucceeded:
+				run.Succeeded++
+			case entity.WebhookDeliveryDead:
+				run.DeadLettered++
+			case entity.WebhookDeliveryPending:
+				run.Retried++
+			}
+		}
+		if len(deliveries) < webhookDispatchBatchSize {
+			return run, nil
+		}
+	}
+}
+
+// claimDeliveries leases a batch of the deliveries that are due and returns them with the end of their
+// lease. Deliveries claimed by another dispatcher are skipped.
+func (u *webhookUsecase) claimDeliveries(now time.Time) ([]*entity.WebhookDelivery, time.Time, error) {
+	// Stored times keep microseconds, the lease has to compare equal once read back
+	leasedUntil := now.Add(webhookDeliveryLease).Truncate(time.Microsecond)
+
+	var deliveries []*entity.WebhookDelivery
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		var err error
+		if deliveries, err = repos.WebhookDeliveries.FindDueForUpdate(now, webhookDispatchBatchSize); err != nil {
+			return err
+		}
+		for _, delivery := range deliveries {
+			if err := delivery.Lease(leasedUntil); err != nil {
+				return err
+			}
+			if err := repos.WebhookDeliveries.Save(delivery); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return deliveries, leasedUntil, nil
+}
+
+// recordAttempt records the outcome of posting a delivery and returns the status it was left in. An
+// outcome arriving after the lease ran out and another dispatcher claimed the delivery is dropped, the
+// returned status is empty then.
+func (u *webhookUsecase) recordAttempt(id uint, leasedUntil time.Time, statusCode int, sendErr error, now time.Time) (entity.WebhookDeliveryStatus, error) {
+	var status entity.WebhookDeliveryStatus
+	err := u.uow.Do(func(repos repository.Repositories) error {
+		delivery, err := repos.WebhookDeliveries.FindForUpdate(id)
+		if err != nil {
+			if err == repository.ErrWebhookDeliveryNotFound {
+				return nil
+			}
+			return err
+		}
+		if delivery.Status() != entity.WebhookDeliveryPending || !delivery.NextAttemptAt().Equal(leasedUntil) {
+			return nil
+		}
+
+		if err := u.recordOutcome(delivery, statusCode, sendErr, now); err != nil {
+			return err
+		}
+		if err := repos.WebhookDeliveries.Save(delivery); err != nil {
+			return err
+		}
+		status = delivery.Status()
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// recordOutcome records the answer to a post on the delivery
+func (u *webhookUsecase) recordOutcome(delivery *entity.WebhookDelivery, statusCode int, sendErr error, now time.Time) error {
+	switch {
+	case sendErr != nil:
+		return delivery.RecordFailure(0, sendErr.Error(), now, u.policy)
+	case statusCode < 200 || statusCode > 299:
+		return delivery.RecordFailure(statusCode, fmt.Sprintf("receiver answered with status %d", statusCode), now, u.policy)
+	}
+	return delivery.RecordSuccess(statusCode, now)
+}
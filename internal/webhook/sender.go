@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"bytes"
+	"inventory_management/internal/entity"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// userAgent identifies the webhook requests to receivers
+const userAgent = "inventory-management-webhooks/1.0"
+
+// HTTPSender posts signed webhook requests
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPSender creates a sender giving up on receivers that do not answer within timeout
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{client: &http.Client{Timeout: timeout}, now: time.Now}
+}
+
+// Send posts the payload of a delivery to the URL of its subscription and returns the HTTP status the
+// receiver answered with. The error is set when no answer came back.
+func (s *HTTPSender) Send(subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload())
+	request, err := http.NewRequest(http.MethodPost, subscription.URL(), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := s.now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set(HeaderEvent, string(delivery.EventType()))
+	request.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID()), 10))
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(subscription.Secret(), timestamp, body))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+	return response.StatusCode, nil
+}
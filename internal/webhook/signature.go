@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// Headers sent with every webhook request
+const (
+	HeaderSignature = "X-Webhook-Signature" // "sha256=" followed by the hex HMAC of the timestamp and body
+	HeaderTimestamp = "X-Webhook-Timestamp" // Unix seconds the request was signed at
+	HeaderEvent     = "X-Webhook-Event"     // Type of the event in the body
+	HeaderDelivery  = "X-Webhook-Delivery"  // ID of the delivery, the same on every retry
+)
+
+// signaturePrefix names the algorithm of the signature
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of a request body sent at the given Unix time. The timestamp is signed
+// with the body so that a captured request cannot be replayed later with a fresh timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body sent at timestamp. Receivers written in
+// Go can use it to check the requests they get.
+func Verify(secret string, timestamp string, body []byte, signature string) bool {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, unix, body)), []byte(signature))
+}
@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"inventory_management/pkg/utility"
+	"sync"
+	"time"
+)
+
+// PeriodicWorker runs a task on a fixed interval in a background goroutine until it is stopped
+type PeriodicWorker struct {
+	name     string
+	interval time.Duration
+	task     func(now time.Time) error
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewPeriodicWorker creates a worker that calls task every interval once started
+func NewPeriodicWorker(name string, interval time.Duration, task func(now time.Time) error) *PeriodicWorker {
+	return &PeriodicWorker{
+		name:     name,
+		interval: interval,
+		task:     task,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start launches the background goroutine. Task errors are logged and do not stop the worker.
+func (w *PeriodicWorker) Start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.stop:
+				return
+			case now := <-ticker.C:
+				if err := w.task(now); err != nil {
+					utility.LogError("periodic worker run failed", w.name, err)
+				}
+			}
+		}
+	}()
+	utility.LogSuccess("periodic worker started", w.name, w.interval.String())
+}
+
+// Stop signals the worker to exit and waits for a run in progress to finish
+func (w *PeriodicWorker) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+	w.wg.Wait()
+	utility.LogSuccess("periodic worker stopped", w.name)
+}
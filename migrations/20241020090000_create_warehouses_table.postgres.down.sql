@@ -0,0 +1,3 @@
+-- migrations/20241020090000_create_warehouses_table.postgres.down.sql
+
+DROP TABLE warehouses;
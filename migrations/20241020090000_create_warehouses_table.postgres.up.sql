@@ -0,0 +1,14 @@
+-- migrations/20241020090000_create_warehouses_table.postgres.up.sql
+CREATE TABLE warehouses (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON warehouses
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+-- migrations/20241020090100_create_stock_levels_table.postgres.down.sql
+
+DROP TABLE stock_levels;
@@ -0,0 +1,19 @@
+-- migrations/20241020090100_create_stock_levels_table.postgres.up.sql
+CREATE TABLE stock_levels (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    on_hand BIGINT NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+    reserved BIGINT NOT NULL DEFAULT 0 CHECK (reserved >= 0 AND reserved <= on_hand),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_stock_levels_product_warehouse UNIQUE (product_id, warehouse_id)
+);
+
+CREATE INDEX idx_stock_levels_warehouse_id ON stock_levels (warehouse_id);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON stock_levels
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
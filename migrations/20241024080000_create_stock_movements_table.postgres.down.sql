@@ -0,0 +1,4 @@
+-- migrations/20241024080000_create_stock_movements_table.postgres.down.sql
+
+DROP TABLE stock_movements;
+DROP FUNCTION prevent_stock_movement_changes();
@@ -0,0 +1,29 @@
+-- migrations/20241024080000_create_stock_movements_table.postgres.up.sql
+CREATE TABLE stock_movements (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    movement_type VARCHAR(20) NOT NULL CHECK (movement_type IN ('receipt', 'issue', 'adjustment', 'transfer_in', 'transfer_out')),
+    quantity BIGINT NOT NULL CHECK (quantity <> 0),
+    balance_after BIGINT NOT NULL CHECK (balance_after >= 0),
+    reason_code VARCHAR(50) NOT NULL,
+    reference VARCHAR(100),
+    actor VARCHAR(100) NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_product_warehouse ON stock_movements (product_id, warehouse_id, occurred_at);
+
+-- The ledger is append-only: reject any attempt to rewrite history
+CREATE OR REPLACE FUNCTION prevent_stock_movement_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+   RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_append_only
+BEFORE UPDATE OR DELETE ON stock_movements
+FOR EACH ROW
+EXECUTE FUNCTION prevent_stock_movement_changes();
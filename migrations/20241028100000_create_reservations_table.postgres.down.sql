@@ -0,0 +1,3 @@
+-- migrations/20241028100000_create_reservations_table.postgres.down.sql
+
+DROP TABLE reservations;
@@ -0,0 +1,21 @@
+-- migrations/20241028100000_create_reservations_table.postgres.up.sql
+CREATE TABLE reservations (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'confirmed', 'released', 'expired')),
+    reference VARCHAR(100),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Lets the expiry worker find due pending reservations without scanning the table
+CREATE INDEX idx_reservations_status_expires_at ON reservations (status, expires_at);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON reservations
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
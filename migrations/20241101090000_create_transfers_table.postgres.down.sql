@@ -0,0 +1,4 @@
+-- migrations/20241101090000_create_transfers_table.postgres.down.sql
+
+DROP TABLE transfer_lines;
+DROP TABLE transfers;
@@ -0,0 +1,30 @@
+-- migrations/20241101090000_create_transfers_table.postgres.up.sql
+CREATE TABLE transfers (
+    id SERIAL PRIMARY KEY,
+    source_warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    destination_warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('draft', 'dispatched', 'partially_received', 'received', 'cancelled')),
+    reference VARCHAR(100),
+    dispatched_at TIMESTAMP,
+    received_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (source_warehouse_id <> destination_warehouse_id)
+);
+
+CREATE INDEX idx_transfers_status ON transfers (status);
+
+CREATE TABLE transfer_lines (
+    id SERIAL PRIMARY KEY,
+    transfer_id INTEGER NOT NULL REFERENCES transfers(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    received_quantity BIGINT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0 AND received_quantity <= quantity),
+    CONSTRAINT idx_transfer_lines_transfer_product UNIQUE (transfer_id, product_id)
+);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON transfers
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+-- migrations/20241104090000_create_suppliers_table.postgres.down.sql
+
+DROP TABLE suppliers;
@@ -0,0 +1,15 @@
+-- migrations/20241104090000_create_suppliers_table.postgres.up.sql
+CREATE TABLE suppliers (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    contact_email VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON suppliers
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
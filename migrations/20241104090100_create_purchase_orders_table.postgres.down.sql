@@ -0,0 +1,4 @@
+-- migrations/20241104090100_create_purchase_orders_table.postgres.down.sql
+
+DROP TABLE purchase_order_lines;
+DROP TABLE purchase_orders;
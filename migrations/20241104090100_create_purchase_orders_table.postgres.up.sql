@@ -0,0 +1,33 @@
+-- migrations/20241104090100_create_purchase_orders_table.postgres.up.sql
+CREATE TABLE purchase_orders (
+    id SERIAL PRIMARY KEY,
+    supplier_id INTEGER NOT NULL REFERENCES suppliers(id),
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('draft', 'submitted', 'partially_received', 'received', 'closed')),
+    reference VARCHAR(100),
+    currency CHAR(3) NOT NULL,
+    submitted_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+CREATE INDEX idx_purchase_orders_status ON purchase_orders (status);
+
+-- Unit costs are stored in minor currency units so totals never suffer from rounding
+CREATE TABLE purchase_order_lines (
+    id SERIAL PRIMARY KEY,
+    purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    received_quantity BIGINT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0 AND received_quantity <= quantity),
+    unit_cost BIGINT NOT NULL CHECK (unit_cost >= 0),
+    CONSTRAINT idx_purchase_order_lines_order_product UNIQUE (purchase_order_id, product_id)
+);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON purchase_orders
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
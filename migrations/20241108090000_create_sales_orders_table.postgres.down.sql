@@ -0,0 +1,4 @@
+-- migrations/20241108090000_create_sales_orders_table.postgres.down.sql
+
+DROP TABLE sales_order_lines;
+DROP TABLE sales_orders;
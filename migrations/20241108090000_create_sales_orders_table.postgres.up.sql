@@ -0,0 +1,28 @@
+-- migrations/20241108090000_create_sales_orders_table.postgres.up.sql
+CREATE TABLE sales_orders (
+    id SERIAL PRIMARY KEY,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    customer_reference VARCHAR(100),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'backordered', 'allocated', 'picking', 'packed', 'shipped', 'cancelled')),
+    shipped_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Lets allocation retries find backordered orders without scanning the table
+CREATE INDEX idx_sales_orders_status ON sales_orders (status);
+
+CREATE TABLE sales_order_lines (
+    id SERIAL PRIMARY KEY,
+    sales_order_id INTEGER NOT NULL REFERENCES sales_orders(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    allocated_quantity BIGINT NOT NULL DEFAULT 0 CHECK (allocated_quantity >= 0 AND allocated_quantity <= quantity),
+    CONSTRAINT idx_sales_order_lines_order_product UNIQUE (sales_order_id, product_id)
+);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON sales_orders
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
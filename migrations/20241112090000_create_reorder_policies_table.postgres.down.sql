@@ -0,0 +1,4 @@
+-- migrations/20241112090000_create_reorder_policies_table.postgres.down.sql
+
+DROP TABLE low_stock_alerts;
+DROP TABLE reorder_policies;
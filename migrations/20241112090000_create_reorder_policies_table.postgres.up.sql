@@ -0,0 +1,37 @@
+-- migrations/20241112090000_create_reorder_policies_table.postgres.up.sql
+CREATE TABLE reorder_policies (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    reorder_point BIGINT NOT NULL CHECK (reorder_point >= 0),
+    reorder_quantity BIGINT NOT NULL CHECK (reorder_quantity > 0),
+    safety_stock BIGINT NOT NULL DEFAULT 0 CHECK (safety_stock >= 0 AND safety_stock <= reorder_point),
+    preferred_supplier_id INTEGER REFERENCES suppliers(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_reorder_policies_product_warehouse UNIQUE (product_id, warehouse_id)
+);
+
+CREATE TABLE low_stock_alerts (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    available BIGINT NOT NULL,
+    reorder_point BIGINT NOT NULL,
+    safety_stock BIGINT NOT NULL,
+    suggested_quantity BIGINT NOT NULL CHECK (suggested_quantity > 0),
+    purchase_order_id INTEGER REFERENCES purchase_orders(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('open', 'resolved')),
+    raised_at TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP
+);
+
+-- At most one open alert per product and warehouse, so repeated evaluations do not pile up alerts
+CREATE UNIQUE INDEX idx_low_stock_alerts_open ON low_stock_alerts (product_id, warehouse_id) WHERE status = 'open';
+CREATE INDEX idx_low_stock_alerts_warehouse ON low_stock_alerts (warehouse_id);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON reorder_policies
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+-- migrations/20241115090000_create_lots_table.postgres.down.sql
+
+DROP TABLE lots;
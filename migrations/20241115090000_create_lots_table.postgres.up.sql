@@ -0,0 +1,22 @@
+-- migrations/20241115090000_create_lots_table.postgres.up.sql
+CREATE TABLE lots (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    lot_number VARCHAR(100) NOT NULL,
+    manufactured_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL CHECK (manufactured_at IS NULL OR expires_at >= manufactured_at),
+    quantity BIGINT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_lots_product_warehouse_number UNIQUE (product_id, warehouse_id, lot_number)
+);
+
+-- Lets the expiring-soon report walk lots in expiry order without scanning the table
+CREATE INDEX idx_lots_expires_at ON lots (expires_at) WHERE quantity > 0;
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON lots
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- migrations/20241118090000_create_serials_table.postgres.down.sql
+
+DROP TABLE serial_movements;
+DROP TABLE serials;
+ALTER TABLE products DROP COLUMN serialized;
@@ -0,0 +1,27 @@
+-- migrations/20241118090000_create_serials_table.postgres.up.sql
+ALTER TABLE products ADD COLUMN serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE serials (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    serial_number VARCHAR(100) UNIQUE NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('in_stock', 'in_transit', 'issued')),
+    warehouse_id INTEGER REFERENCES warehouses(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((status = 'in_stock') = (warehouse_id IS NOT NULL))
+);
+
+CREATE INDEX idx_serials_product_id ON serials (product_id);
+
+CREATE TABLE serial_movements (
+    serial_id INTEGER NOT NULL REFERENCES serials(id) ON DELETE CASCADE,
+    stock_movement_id INTEGER NOT NULL REFERENCES stock_movements(id),
+    PRIMARY KEY (serial_id, stock_movement_id)
+);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON serials
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- migrations/20241121090000_create_units_of_measure_table.postgres.down.sql
+
+DROP TABLE product_units;
+ALTER TABLE products DROP COLUMN stock_unit;
+DROP TABLE units_of_measure;
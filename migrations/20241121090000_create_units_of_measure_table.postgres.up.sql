@@ -0,0 +1,35 @@
+-- migrations/20241121090000_create_units_of_measure_table.postgres.up.sql
+CREATE TABLE units_of_measure (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Every existing product is stocked in each
+INSERT INTO units_of_measure (code, name) VALUES ('EA', 'Each');
+
+ALTER TABLE products ADD COLUMN stock_unit VARCHAR(20) NOT NULL DEFAULT 'EA'
+    CONSTRAINT products_stock_unit_fkey REFERENCES units_of_measure(code);
+
+CREATE TABLE product_units (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    unit_code VARCHAR(20) NOT NULL REFERENCES units_of_measure(code),
+    factor BIGINT NOT NULL CHECK (factor > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_product_units_product_unit UNIQUE (product_id, unit_code)
+);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON units_of_measure
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON product_units
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
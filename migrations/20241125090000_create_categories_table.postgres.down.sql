@@ -0,0 +1,4 @@
+-- migrations/20241125090000_create_categories_table.postgres.down.sql
+
+ALTER TABLE products DROP COLUMN category_id;
+DROP TABLE categories;
@@ -0,0 +1,27 @@
+-- migrations/20241125090000_create_categories_table.postgres.up.sql
+CREATE TABLE categories (
+    id SERIAL PRIMARY KEY,
+    parent_id INTEGER REFERENCES categories(id),
+    name VARCHAR(255) NOT NULL,
+    path VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((parent_id IS NULL) = (path = '/'))
+);
+
+-- Siblings need distinct names, root categories included
+CREATE UNIQUE INDEX idx_categories_parent_name ON categories (COALESCE(parent_id, 0), lower(name));
+
+-- Subtrees are found by path prefix
+CREATE INDEX idx_categories_path ON categories (path varchar_pattern_ops);
+
+ALTER TABLE products ADD COLUMN category_id INTEGER
+    CONSTRAINT products_category_id_fkey REFERENCES categories(id);
+
+CREATE INDEX idx_products_category_id ON products (category_id);
+
+-- Trigger to automatically update the updated_at field
+CREATE TRIGGER set_updated_at
+BEFORE UPDATE ON categories
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- migrations/20241128090000_create_product_variants_table.postgres.down.sql
+
+DROP TABLE product_variant_options;
+DROP TABLE product_variants;
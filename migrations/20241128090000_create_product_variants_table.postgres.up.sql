@@ -0,0 +1,18 @@
+-- migrations/20241128090000_create_product_variants_table.postgres.up.sql
+CREATE TABLE product_variants (
+    product_id INTEGER PRIMARY KEY REFERENCES products(id),
+    parent_id INTEGER NOT NULL REFERENCES products(id),
+    variant_key VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (product_id <> parent_id),
+    UNIQUE (parent_id, variant_key)
+);
+
+CREATE TABLE product_variant_options (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES product_variants(product_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    value VARCHAR(50) NOT NULL,
+    UNIQUE (product_id, position)
+);
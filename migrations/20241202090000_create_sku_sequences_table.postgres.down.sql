@@ -0,0 +1,3 @@
+-- migrations/20241202090000_create_sku_sequences_table.postgres.down.sql
+
+DROP TABLE sku_sequences;
@@ -0,0 +1,5 @@
+-- migrations/20241202090000_create_sku_sequences_table.postgres.up.sql
+CREATE TABLE sku_sequences (
+    key VARCHAR(100) PRIMARY KEY,
+    last_value BIGINT NOT NULL CHECK (last_value > 0)
+);
@@ -0,0 +1,3 @@
+-- migrations/20241205090000_create_product_sku_aliases_table.postgres.down.sql
+
+DROP TABLE product_sku_aliases;
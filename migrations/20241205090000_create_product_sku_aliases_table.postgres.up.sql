@@ -0,0 +1,8 @@
+-- migrations/20241205090000_create_product_sku_aliases_table.postgres.up.sql
+CREATE TABLE product_sku_aliases (
+    sku VARCHAR(100) PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_sku_aliases_product_id ON product_sku_aliases (product_id);
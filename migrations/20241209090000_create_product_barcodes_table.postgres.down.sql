@@ -0,0 +1,3 @@
+-- migrations/20241209090000_create_product_barcodes_table.postgres.down.sql
+
+DROP TABLE product_barcodes;
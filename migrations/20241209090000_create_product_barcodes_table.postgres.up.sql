@@ -0,0 +1,10 @@
+-- migrations/20241209090000_create_product_barcodes_table.postgres.up.sql
+CREATE TABLE product_barcodes (
+    gtin VARCHAR(14) PRIMARY KEY,
+    code VARCHAR(14) NOT NULL,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (gtin ~ '^[0-9]{14}$')
+);
+
+CREATE INDEX idx_product_barcodes_product_id ON product_barcodes (product_id);
@@ -0,0 +1,12 @@
+-- migrations/20241212090000_add_product_attributes.postgres.down.sql
+
+ALTER TABLE products
+    DROP COLUMN attributes,
+    DROP COLUMN tags,
+    DROP COLUMN height_mm,
+    DROP COLUMN width_mm,
+    DROP COLUMN length_mm,
+    DROP COLUMN weight_grams,
+    DROP COLUMN status,
+    DROP COLUMN brand,
+    DROP COLUMN description;
@@ -0,0 +1,13 @@
+-- migrations/20241212090000_add_product_attributes.postgres.up.sql
+ALTER TABLE products
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN brand VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('draft', 'active', 'discontinued')),
+    ADD COLUMN weight_grams BIGINT NOT NULL DEFAULT 0 CHECK (weight_grams >= 0),
+    ADD COLUMN length_mm BIGINT NOT NULL DEFAULT 0 CHECK (length_mm >= 0),
+    ADD COLUMN width_mm BIGINT NOT NULL DEFAULT 0 CHECK (width_mm >= 0),
+    ADD COLUMN height_mm BIGINT NOT NULL DEFAULT 0 CHECK (height_mm >= 0),
+    ADD COLUMN tags JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
@@ -0,0 +1,7 @@
+-- migrations/20241216090000_add_product_soft_delete.postgres.down.sql
+
+UPDATE products SET status = 'discontinued' WHERE status = 'archived';
+ALTER TABLE products
+    DROP COLUMN deleted_at,
+    DROP CONSTRAINT products_status_check,
+    ADD CONSTRAINT products_status_check CHECK (status IN ('draft', 'active', 'discontinued'));
@@ -0,0 +1,7 @@
+-- migrations/20241216090000_add_product_soft_delete.postgres.up.sql
+ALTER TABLE products
+    DROP CONSTRAINT products_status_check,
+    ADD CONSTRAINT products_status_check CHECK (status IN ('draft', 'active', 'discontinued', 'archived')),
+    ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_products_deleted_at ON products (deleted_at);
@@ -0,0 +1,4 @@
+-- migrations/20241219090000_add_product_version.postgres.down.sql
+
+ALTER TABLE products
+    DROP COLUMN version;
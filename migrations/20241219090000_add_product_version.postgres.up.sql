@@ -0,0 +1,3 @@
+-- migrations/20241219090000_add_product_version.postgres.up.sql
+ALTER TABLE products
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1 CHECK (version > 0);
@@ -0,0 +1,4 @@
+-- migrations/20241223090000_create_product_audit_records_table.postgres.down.sql
+
+DROP TABLE product_audit_records;
+DROP FUNCTION prevent_product_audit_record_changes();
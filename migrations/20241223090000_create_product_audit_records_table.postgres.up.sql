@@ -0,0 +1,28 @@
+-- migrations/20241223090000_create_product_audit_records_table.postgres.up.sql
+CREATE TABLE product_audit_records (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    action VARCHAR(20) NOT NULL CHECK (action IN ('created', 'updated', 'deleted', 'restored')),
+    actor VARCHAR(100) NOT NULL,
+    request_id VARCHAR(100) NOT NULL DEFAULT '',
+    source_ip VARCHAR(45) NOT NULL DEFAULT '',
+    changes JSONB NOT NULL,
+    state JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_audit_records_product ON product_audit_records (product_id, occurred_at);
+
+-- The audit trail is append-only: reject any attempt to rewrite history
+CREATE OR REPLACE FUNCTION prevent_product_audit_record_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+   RAISE EXCEPTION 'product_audit_records is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_audit_records_append_only
+BEFORE UPDATE OR DELETE ON product_audit_records
+FOR EACH ROW
+EXECUTE FUNCTION prevent_product_audit_record_changes();
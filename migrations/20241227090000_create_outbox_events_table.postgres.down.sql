@@ -0,0 +1,3 @@
+-- migrations/20241227090000_create_outbox_events_table.postgres.down.sql
+
+DROP TABLE outbox_events;
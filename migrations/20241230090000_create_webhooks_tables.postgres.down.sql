@@ -0,0 +1,4 @@
+-- migrations/20241230090000_create_webhooks_tables.postgres.down.sql
+
+DROP TABLE webhook_deliveries;
+DROP TABLE webhook_subscriptions;
@@ -0,0 +1,3 @@
+-- migrations/20250103090000_create_product_imports_table.postgres.down.sql
+
+DROP TABLE product_imports;
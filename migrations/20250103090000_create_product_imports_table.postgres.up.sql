@@ -0,0 +1,11 @@
+-- migrations/20250103090000_create_product_imports_table.postgres.up.sql
+CREATE TABLE product_imports (
+    id SERIAL PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    created INTEGER NOT NULL DEFAULT 0 CHECK (created >= 0),
+    updated INTEGER NOT NULL DEFAULT 0 CHECK (updated >= 0),
+    unchanged INTEGER NOT NULL DEFAULT 0 CHECK (unchanged >= 0),
+    rejected_rows JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
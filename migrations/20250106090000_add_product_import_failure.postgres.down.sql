@@ -0,0 +1,4 @@
+-- migrations/20250106090000_add_product_import_failure.postgres.down.sql
+
+ALTER TABLE product_imports
+    DROP COLUMN failed_at_line;
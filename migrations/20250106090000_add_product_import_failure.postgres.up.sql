@@ -0,0 +1,3 @@
+-- migrations/20250106090000_add_product_import_failure.postgres.up.sql
+ALTER TABLE product_imports
+    ADD COLUMN failed_at_line INTEGER NOT NULL DEFAULT 0 CHECK (failed_at_line >= 0);
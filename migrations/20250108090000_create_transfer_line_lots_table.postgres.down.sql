@@ -0,0 +1,3 @@
+-- migrations/20250108090000_create_transfer_line_lots_table.postgres.down.sql
+
+DROP TABLE transfer_line_lots;
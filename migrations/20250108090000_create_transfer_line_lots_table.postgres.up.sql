@@ -0,0 +1,11 @@
+-- migrations/20250108090000_create_transfer_line_lots_table.postgres.up.sql
+CREATE TABLE transfer_line_lots (
+    id SERIAL PRIMARY KEY,
+    transfer_line_id INTEGER NOT NULL REFERENCES transfer_lines(id) ON DELETE CASCADE,
+    lot_number VARCHAR(100) NOT NULL,
+    manufactured_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    received_quantity BIGINT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0 AND received_quantity <= quantity),
+    CONSTRAINT idx_transfer_line_lots_line_number UNIQUE (transfer_line_id, lot_number)
+);
@@ -0,0 +1,8 @@
+-- migrations/20250110090000_add_transfer_close.postgres.down.sql
+
+UPDATE transfers SET status = 'received', received_at = closed_at WHERE status = 'closed';
+ALTER TABLE transfers
+    DROP COLUMN close_reason_code,
+    DROP COLUMN closed_at,
+    DROP CONSTRAINT transfers_status_check,
+    ADD CONSTRAINT transfers_status_check CHECK (status IN ('draft', 'dispatched', 'partially_received', 'received', 'cancelled'));
@@ -0,0 +1,7 @@
+-- migrations/20250110090000_add_transfer_close.postgres.up.sql
+ALTER TABLE transfers
+    DROP CONSTRAINT transfers_status_check,
+    ADD CONSTRAINT transfers_status_check CHECK (status IN ('draft', 'dispatched', 'partially_received', 'received', 'cancelled', 'closed')),
+    ADD COLUMN closed_at TIMESTAMP,
+    ADD COLUMN close_reason_code VARCHAR(50),
+    ADD CHECK ((status = 'closed') = (closed_at IS NOT NULL));
@@ -0,0 +1,312 @@
+package category_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"inventory_management/api/handler"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Category E2E Tests", func() {
+	var categoryHandler *handler.CategoryHandler
+	var productHandler *handler.ProductHandler
+	var repos repository.Repositories
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var ifMatch string // The If-Match header of requests, none when empty
+
+	// send calls a handler with an optional JSON body and returns the recorder
+	send := func(handle gin.HandlerFunc, method string, path string, params gin.Params, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = params
+		c.Request = httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			c.Request.Header.Set("If-Match", ifMatch)
+		}
+
+		handle(c)
+		c.Writer.WriteHeaderNow() // Responses without a body only set the status
+		return w
+	}
+
+	// createCategory posts a category below parentID and returns its ID
+	createCategory := func(name string, parentID uint) uint {
+		w := send(categoryHandler.CreateCategory, "POST", "/api/v1/categories", nil, map[string]interface{}{
+			"name":      name,
+			"parent_id": parentID,
+		})
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+
+		var response dto.CategoryResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return response.ID
+	}
+
+	// idParams builds the route parameters of a category or product ID
+	idParams := func(id uint) gin.Params {
+		return gin.Params{{Key: "id", Value: strconv.Itoa(int(id))}}
+	}
+
+	// listProductNames lists the products below a category and returns their names
+	listProductNames := func(categoryID uint) []string {
+		path := "/api/v1/products?sortBy=name&sortDirection=asc&category_id=" + strconv.Itoa(int(categoryID))
+		w := send(productHandler.GetProductList, "GET", path, nil, nil)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+		var response dto.ProductListResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		names := make([]string, len(response.Products))
+		for i, product := range response.Products {
+			names[i] = product.Name
+		}
+		return names
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+		ifMatch = "*"
+
+		repos = repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		categoryHandler = handler.NewCategoryHandler(usecase.NewCategoryUsecase(repos, uow))
+		productHandler = handler.NewProductHandler(usecase.NewProductUsecase(repos, uow))
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("Category tree", func() {
+		ginkgo.It("should list the categories as a nested tree", func() {
+			electronics := createCategory("Electronics", 0)
+			audio := createCategory("Audio", electronics)
+			createCategory("Headphones", audio)
+			createCategory("Garden", 0)
+
+			w := send(categoryHandler.GetCategoryList, "GET", "/api/v1/categories", nil, nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			var tree []*dto.CategoryTreeResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&tree)).To(gomega.Succeed())
+			gomega.Expect(tree).To(gomega.HaveLen(2))
+			gomega.Expect(tree[0].Name).To(gomega.Equal("Electronics"))
+			gomega.Expect(tree[0].Children[0].Name).To(gomega.Equal("Audio"))
+			gomega.Expect(tree[0].Children[0].Children[0].Name).To(gomega.Equal("Headphones"))
+			gomega.Expect(tree[1].Name).To(gomega.Equal("Garden"))
+		})
+
+		ginkgo.It("should return the ancestors and children of a category", func() {
+			electronics := createCategory("Electronics", 0)
+			audio := createCategory("Audio", electronics)
+			createCategory("Headphones", audio)
+
+			w := send(categoryHandler.GetCategory, "GET", "/api/v1/categories/"+strconv.Itoa(int(audio)), idParams(audio), nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			var response dto.CategoryDetailResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+			gomega.Expect(*response.ParentID).To(gomega.Equal(electronics))
+			gomega.Expect(response.Ancestors).To(gomega.HaveLen(1))
+			gomega.Expect(response.Children).To(gomega.HaveLen(1))
+			gomega.Expect(response.Children[0].Name).To(gomega.Equal("Headphones"))
+		})
+
+		ginkgo.It("should reject a sibling with the same name", func() {
+			electronics := createCategory("Electronics", 0)
+			createCategory("Audio", electronics)
+
+			w := send(categoryHandler.CreateCategory, "POST", "/api/v1/categories", nil, map[string]interface{}{
+				"name":      "audio",
+				"parent_id": electronics,
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+		})
+
+		ginkgo.It("should reject an unknown parent", func() {
+			w := send(categoryHandler.CreateCategory, "POST", "/api/v1/categories", nil, map[string]interface{}{
+				"name":      "Audio",
+				"parent_id": 99,
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should not move a category below its own descendant", func() {
+			electronics := createCategory("Electronics", 0)
+			audio := createCategory("Audio", electronics)
+
+			w := send(categoryHandler.UpdateCategory, "PUT", "/api/v1/categories/"+strconv.Itoa(int(electronics)), idParams(electronics), map[string]interface{}{
+				"name":      "Electronics",
+				"parent_id": audio,
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should let only one of two moves below each other through", func() {
+			indoor := createCategory("Indoor", 0)
+			outdoor := createCategory("Outdoor", 0)
+			categoryUsecase := usecase.NewCategoryUsecase(repos, repository.NewUnitOfWork(database))
+
+			// Holding the second category makes both moves start before either can finish
+			blocker := database.Begin()
+			gomega.Expect(blocker.Exec("SELECT id FROM categories WHERE id = ? FOR UPDATE", outdoor).Error).To(gomega.Succeed())
+
+			var wg sync.WaitGroup
+			results := make([]error, 2)
+			moves := [][2]uint{{indoor, outdoor}, {outdoor, indoor}}
+			for i, move := range moves {
+				wg.Add(1)
+				go func(i int, id uint, parentID uint) {
+					defer wg.Done()
+					name := map[uint]string{indoor: "Indoor", outdoor: "Outdoor"}[id]
+					_, results[i] = categoryUsecase.UpdateCategory(id, usecase.CategoryInput{Name: name, ParentID: parentID})
+				}(i, move[0], move[1])
+			}
+			time.Sleep(200 * time.Millisecond)
+			gomega.Expect(blocker.Rollback().Error).To(gomega.Succeed())
+			wg.Wait()
+
+			failed := 0
+			for _, err := range results {
+				if err != nil {
+					gomega.Expect(errors.Is(err, entity.ErrCategoryCycle)).To(gomega.BeTrue(), err.Error())
+					failed++
+				}
+			}
+			gomega.Expect(failed).To(gomega.Equal(1))
+
+			// Exactly one of the two is left at the root
+			categories, err := categoryUsecase.ListCategories()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			roots := 0
+			for _, category := range categories {
+				if category.ParentID() == 0 {
+					roots++
+				}
+			}
+			gomega.Expect(roots).To(gomega.Equal(1))
+		})
+
+		ginkgo.It("should refuse to delete a category that still has subcategories or products", func() {
+			electronics := createCategory("Electronics", 0)
+			audio := createCategory("Audio", electronics)
+			seedProduct(repos, "Speaker", audio)
+
+			w := send(categoryHandler.DeleteCategory, "DELETE", "/api/v1/categories/"+strconv.Itoa(int(electronics)), idParams(electronics), nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+
+			w = send(categoryHandler.DeleteCategory, "DELETE", "/api/v1/categories/"+strconv.Itoa(int(audio)), idParams(audio), nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+
+			garden := createCategory("Garden", 0)
+			w = send(categoryHandler.DeleteCategory, "DELETE", "/api/v1/categories/"+strconv.Itoa(int(garden)), idParams(garden), nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNoContent))
+		})
+	})
+
+	ginkgo.Context("Filtering products by category", func() {
+		ginkgo.It("should include the products of every descendant category", func() {
+			electronics := createCategory("Electronics", 0)
+			audio := createCategory("Audio", electronics)
+			headphones := createCategory("Headphones", audio)
+			garden := createCategory("Garden", 0)
+
+			seedProduct(repos, "Laptop", electronics)
+			seedProduct(repos, "Speaker", audio)
+			seedProduct(repos, "Earbuds", headphones)
+			seedProduct(repos, "Shovel", garden)
+			seedProduct(repos, "Mystery Box", 0)
+
+			gomega.Expect(listProductNames(electronics)).To(gomega.Equal([]string{"Earbuds", "Laptop", "Speaker"}))
+			gomega.Expect(listProductNames(audio)).To(gomega.Equal([]string{"Earbuds", "Speaker"}))
+			gomega.Expect(listProductNames(garden)).To(gomega.Equal([]string{"Shovel"}))
+		})
+
+		ginkgo.It("should follow a subtree to its new parent when it is moved", func() {
+			electronics := createCategory("Electronics", 0)
+			audio := createCategory("Audio", electronics)
+			headphones := createCategory("Headphones", audio)
+			garden := createCategory("Garden", 0)
+			seedProduct(repos, "Earbuds", headphones)
+
+			w := send(categoryHandler.UpdateCategory, "PUT", "/api/v1/categories/"+strconv.Itoa(int(audio)), idParams(audio), map[string]interface{}{
+				"name":      "Outdoor Audio",
+				"parent_id": garden,
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			gomega.Expect(listProductNames(electronics)).To(gomega.BeEmpty())
+			gomega.Expect(listProductNames(garden)).To(gomega.Equal([]string{"Earbuds"}))
+		})
+
+		ginkgo.It("should assign and remove the category of a product", func() {
+			audio := createCategory("Audio", 0)
+			productID := seedProduct(repos, "Speaker", 0)
+
+			w := send(productHandler.AssignProductCategory, "PUT", "/api/v1/products/"+strconv.Itoa(int(productID))+"/category", idParams(productID), map[string]interface{}{
+				"category_id": audio,
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(listProductNames(audio)).To(gomega.Equal([]string{"Speaker"}))
+
+			w = send(productHandler.AssignProductCategory, "PUT", "/api/v1/products/"+strconv.Itoa(int(productID))+"/category", idParams(productID), map[string]interface{}{
+				"category_id": 99,
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+
+			w = send(productHandler.AssignProductCategory, "PUT", "/api/v1/products/"+strconv.Itoa(int(productID))+"/category", idParams(productID), map[string]interface{}{})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(listProductNames(audio)).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should only assign the category of the product version named by If-Match", func() {
+			audio := createCategory("Audio", 0)
+			productID := seedProduct(repos, "Speaker", 0)
+			path := "/api/v1/products/" + strconv.Itoa(int(productID)) + "/category"
+
+			ifMatch = ""
+			w := send(productHandler.AssignProductCategory, "PUT", path, idParams(productID), map[string]interface{}{"category_id": audio})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionRequired))
+
+			ifMatch = `"7"`
+			w = send(productHandler.AssignProductCategory, "PUT", path, idParams(productID), map[string]interface{}{"category_id": audio})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionFailed))
+			gomega.Expect(listProductNames(audio)).To(gomega.BeEmpty())
+
+			ifMatch = `"1"`
+			w = send(productHandler.AssignProductCategory, "PUT", path, idParams(productID), map[string]interface{}{"category_id": audio})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(w.Header().Get("ETag")).To(gomega.Equal(`"2"`))
+			gomega.Expect(listProductNames(audio)).To(gomega.Equal([]string{"Speaker"}))
+		})
+
+		ginkgo.It("should reject a category_id that is not a positive number", func() {
+			w := send(productHandler.GetProductList, "GET", "/api/v1/products?sortBy=name&sortDirection=asc&category_id=abc", nil, nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package category_e2e_test
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+func TestCategoryE2E(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "E2E Category Handler Suite")
+}
+
+// Helper function to truncate tables between tests
+func TruncateTables(database *gorm.DB) {
+	database.Exec("TRUNCATE TABLE products, categories RESTART IDENTITY CASCADE;")
+}
+
+// seedProduct creates a product filed under a category directly through the repositories
+func seedProduct(repos repository.Repositories, name string, categoryID uint) uint {
+	product, err := entity.NewProduct(name)
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	product.SetCategory(categoryID)
+	gomega.Expect(repos.Products.Save(product)).To(gomega.Succeed())
+	return product.ID()
+}
@@ -0,0 +1,133 @@
+package lot_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Lot E2E Tests", func() {
+	var movementHandler *handler.StockMovementHandler
+	var lotHandler *handler.LotHandler
+	var reservationUsecase usecase.ReservationUsecase
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID, warehouseID uint
+
+	// recordMovement posts a movement, optionally against a lot, and returns the recorder
+	recordMovement := func(movementType string, quantity int64, lotNumber string, expiresAt *time.Time) *httptest.ResponseRecorder {
+		body := map[string]interface{}{
+			"product_id":   productID,
+			"warehouse_id": warehouseID,
+			"type":         movementType,
+			"quantity":     quantity,
+			"reason_code":  "TEST",
+		}
+		if lotNumber != "" {
+			body["lot_number"] = lotNumber
+		}
+		if expiresAt != nil {
+			body["expires_at"] = expiresAt
+		}
+		payload, _ := json.Marshal(body)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/stock-movements", bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		movementHandler.CreateStockMovement(c)
+		return w
+	}
+
+	// lotQuantity reads the quantity of a lot straight from the database
+	lotQuantity := func(lotNumber string) int64 {
+		var lot model.Lot
+		gomega.Expect(database.Where("lot_number = ?", lotNumber).First(&lot).Error).To(gomega.Succeed())
+		return lot.Quantity
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		movementHandler = handler.NewStockMovementHandler(usecase.NewStockMovementUsecase(repos, uow))
+		lotHandler = handler.NewLotHandler(usecase.NewLotUsecase(repos))
+		reservationUsecase = usecase.NewReservationUsecase(repos, uow, time.Minute)
+		productID, warehouseID = seedProductAndWarehouse(repos)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("Lot receipts and issues", func() {
+		ginkgo.It("should require an expiry date for a new lot", func() {
+			w := recordMovement("receipt", 5, "L-1", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should issue the first-expiring lot first", func() {
+			late := time.Now().AddDate(0, 2, 0)
+			early := time.Now().AddDate(0, 1, 0)
+			gomega.Expect(recordMovement("receipt", 5, "LATE", &late).Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(recordMovement("receipt", 5, "EARLY", &early).Code).To(gomega.Equal(http.StatusCreated))
+
+			gomega.Expect(recordMovement("issue", 7, "", nil).Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(lotQuantity("EARLY")).To(gomega.Equal(int64(0)))
+			gomega.Expect(lotQuantity("LATE")).To(gomega.Equal(int64(3)))
+		})
+
+		ginkgo.It("should not issue or allocate expired lots but allow writing them off", func() {
+			expired := time.Now().Add(-time.Hour)
+			gomega.Expect(recordMovement("receipt", 5, "OLD", &expired).Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(recordMovement("receipt", 2, "", nil).Code).To(gomega.Equal(http.StatusCreated))
+
+			gomega.Expect(recordMovement("issue", 3, "", nil).Code).To(gomega.Equal(http.StatusConflict))
+
+			_, err := reservationUsecase.Reserve(usecase.ReservationInput{ProductID: productID, WarehouseID: warehouseID, Quantity: 3})
+			gomega.Expect(err).To(gomega.MatchError(usecase.ErrInsufficientStock))
+
+			gomega.Expect(recordMovement("adjustment", -5, "OLD", nil).Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(lotQuantity("OLD")).To(gomega.Equal(int64(0)))
+		})
+	})
+
+	ginkgo.Context("GET /lots/expiring", func() {
+		ginkgo.It("should report lots expiring within the requested days", func() {
+			soon := time.Now().AddDate(0, 0, 5)
+			later := time.Now().AddDate(0, 3, 0)
+			recordMovement("receipt", 5, "SOON", &soon)
+			recordMovement("receipt", 5, "LATER", &later)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/api/v1/lots/expiring?days=30", nil)
+
+			lotHandler.GetExpiringLots(c)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			var response map[string]interface{}
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+			gomega.Expect(response["total"]).To(gomega.BeEquivalentTo(1))
+			lots := response["lots"].([]interface{})
+			gomega.Expect(lots[0].(map[string]interface{})["lot_number"]).To(gomega.Equal("SOON"))
+		})
+	})
+})
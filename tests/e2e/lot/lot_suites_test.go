@@ -0,0 +1,34 @@
+package lot_e2e_test
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+func TestLotE2E(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "E2E Lot Handler Suite")
+}
+
+// Helper function to truncate tables between tests
+func TruncateTables(database *gorm.DB) {
+	database.Exec("TRUNCATE TABLE lots, reservations, stock_movements, stock_levels, warehouses, products RESTART IDENTITY CASCADE;")
+}
+
+// seedProductAndWarehouse creates a product and a warehouse directly through the repositories
+func seedProductAndWarehouse(repos repository.Repositories) (uint, uint) {
+	product, err := entity.NewProduct("Perishable Product")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Products.Save(product)).To(gomega.Succeed())
+
+	warehouse, err := entity.NewWarehouse("JKT01", "Jakarta Main")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Warehouses.Save(warehouse)).To(gomega.Succeed())
+
+	return product.ID(), warehouse.ID()
+}
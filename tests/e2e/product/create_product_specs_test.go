@@ -15,6 +15,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
@@ -28,8 +29,7 @@ var _ = ginkgo.Describe("CreateProduct E2E Tests", func() {
 		database, sqlDB = db.InitDB(true)
 		TruncateTables(database) // Ensure tables are clean before each test
 
-		productRepo := repository.NewPostgresProductRepository(database)
-		productUsecase := usecase.NewProductUsecase(productRepo)
+		productUsecase := usecase.NewProductUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database))
 		productHandler = handler.NewProductHandler(productUsecase)
 	})
 
@@ -91,7 +91,7 @@ var _ = ginkgo.Describe("CreateProduct E2E Tests", func() {
 			// Mock the use case to return an error during product creation
 			mockUsecase := new(MockProductUsecase)
 			productHandler := handler.NewProductHandler(mockUsecase)
-			mockUsecase.On("CreateProduct", "Failing Product").Return(nil, errors.New("usecase error"))
+			mockUsecase.On("CreateProduct", usecase.ProductInput{Name: "Failing Product"}, mock.Anything).Return(nil, errors.New("usecase error"))
 
 			// Set up the request body
 			reqBody := map[string]string{"name": "Failing Product"}
@@ -0,0 +1,237 @@
+package product_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"inventory_management/api/handler"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("DeleteProduct E2E Tests", func() {
+	var productHandler *handler.ProductHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+
+	var createdProductID uint
+	var admin bool     // Whether requests are made by an administrator
+	var ifMatch string // The If-Match header of requests, none when empty
+
+	// send calls a product handler for the product with the given ID and returns the recorder
+	send := func(method string, productID string, path string, handle func(c *gin.Context)) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: productID}}
+		c.Request = httptest.NewRequest(method, "/api/v1/products/"+productID+path, nil)
+		if ifMatch != "" {
+			c.Request.Header.Set("If-Match", ifMatch)
+		}
+		c.Set(consts.ContextKeyAdmin, admin)
+
+		handle(c)
+		c.Writer.WriteHeaderNow()
+		return w
+	}
+
+	// list fetches the product list with the given query and decodes it
+	list := func(query string) dto.ProductListResponse {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/v1/products"+query, nil)
+		c.Set(consts.ContextKeyAdmin, admin)
+		productHandler.GetProductList(c)
+
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		var response dto.ProductListResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return response
+	}
+
+	ginkgo.BeforeEach(func() {
+		// Initialize test environment
+		database, sqlDB = db.InitDB(true) // Assuming `true` loads the test environment
+		TruncateTables(database)          // Clean up before each test
+		admin = false
+		ifMatch = "*"
+
+		productUsecase := usecase.NewProductUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database))
+		productHandler = handler.NewProductHandler(productUsecase)
+
+		// The first product is the one the tests delete, the second stays listed
+		for i, name := range []string{"Retired Product", "Current Product"} {
+			payload, _ := json.Marshal(map[string]string{"name": name})
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/api/v1/products", bytes.NewBuffer(payload))
+			c.Request.Header.Set("Content-Type", "application/json")
+			productHandler.CreateProduct(c)
+
+			var created dto.ProductResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&created)).To(gomega.Succeed())
+			if i == 0 {
+				createdProductID = created.ID
+			}
+		}
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database) // Clean up after each test
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("DELETE /products/:id", func() {
+		ginkgo.It("should hide a deleted product from lookups and listings", func() {
+			id := strconv.Itoa(int(createdProductID))
+			w := send("DELETE", id, "", productHandler.DeleteProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNoContent))
+
+			w = send("GET", id, "", productHandler.GetProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+
+			gomega.Expect(list("").Products).To(gomega.HaveLen(1))
+			gomega.Expect(list("").Total).To(gomega.Equal(int64(1)))
+		})
+
+		ginkgo.It("should still find deleted products when an administrator asks to include them", func() {
+			id := strconv.Itoa(int(createdProductID))
+			send("DELETE", id, "", productHandler.DeleteProduct)
+			admin = true
+
+			w := send("GET", id, "?include_deleted=true", productHandler.GetProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var product dto.ProductResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&product)).To(gomega.Succeed())
+			gomega.Expect(product.DeletedAt).NotTo(gomega.BeNil())
+
+			gomega.Expect(list("?include_deleted=true").Products).To(gomega.HaveLen(2))
+		})
+
+		ginkgo.It("should return 403 when a caller who is not an administrator includes deleted products", func() {
+			id := strconv.Itoa(int(createdProductID))
+			send("DELETE", id, "", productHandler.DeleteProduct)
+
+			w := send("GET", id, "?include_deleted=true", productHandler.GetProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusForbidden))
+		})
+
+		ginkgo.It("should return 404 when the product is already deleted", func() {
+			id := strconv.Itoa(int(createdProductID))
+			send("DELETE", id, "", productHandler.DeleteProduct)
+
+			w := send("DELETE", id, "", productHandler.DeleteProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should return 422 for a malformed include_deleted flag", func() {
+			w := send("GET", strconv.Itoa(int(createdProductID)), "?include_deleted=maybe", productHandler.GetProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should return 400 if the product ID is invalid", func() {
+			w := send("DELETE", "invalid-id", "", productHandler.DeleteProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusBadRequest))
+		})
+
+		ginkgo.It("should return 500 if there's an internal server error", func() {
+			mockUsecase := new(MockProductUsecase)
+			mockUsecase.On("DeleteProduct", createdProductID, int64(0), mock.Anything).Return(nil, errors.New("internal server error"))
+
+			w := send("DELETE", strconv.Itoa(int(createdProductID)), "", handler.NewProductHandler(mockUsecase).DeleteProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+		})
+
+		ginkgo.It("should delete the version named by If-Match and return the next one", func() {
+			ifMatch = `"1"`
+			w := send("DELETE", strconv.Itoa(int(createdProductID)), "", productHandler.DeleteProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNoContent))
+			gomega.Expect(w.Header().Get("ETag")).To(gomega.Equal(`"2"`))
+		})
+
+		ginkgo.It("should return 412 and keep the product when If-Match names an old version", func() {
+			id := strconv.Itoa(int(createdProductID))
+			ifMatch = `"7"`
+			w := send("DELETE", id, "", productHandler.DeleteProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionFailed))
+
+			w = send("GET", id, "", productHandler.GetProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		})
+
+		ginkgo.It("should return 428 without an If-Match header", func() {
+			ifMatch = ""
+			w := send("DELETE", strconv.Itoa(int(createdProductID)), "", productHandler.DeleteProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionRequired))
+		})
+	})
+
+	ginkgo.Context("POST /products/:id/restore", func() {
+		ginkgo.It("should bring back a deleted product", func() {
+			id := strconv.Itoa(int(createdProductID))
+			send("DELETE", id, "", productHandler.DeleteProduct)
+
+			w := send("POST", id, "/restore", productHandler.RestoreProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var product dto.ProductResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&product)).To(gomega.Succeed())
+			gomega.Expect(product.DeletedAt).To(gomega.BeNil())
+
+			w = send("GET", id, "", productHandler.GetProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(list("").Products).To(gomega.HaveLen(2))
+		})
+
+		ginkgo.It("should leave a product that is not deleted as it is", func() {
+			w := send("POST", strconv.Itoa(int(createdProductID)), "/restore", productHandler.RestoreProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		})
+
+		ginkgo.It("should return 404 if the product never existed", func() {
+			w := send("POST", strconv.Itoa(int(createdProductID+999)), "/restore", productHandler.RestoreProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should restore the version the delete returned and move it on", func() {
+			id := strconv.Itoa(int(createdProductID))
+			ifMatch = send("DELETE", id, "", productHandler.DeleteProduct).Header().Get("ETag")
+
+			w := send("POST", id, "/restore", productHandler.RestoreProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(w.Header().Get("ETag")).To(gomega.Equal(`"3"`))
+		})
+
+		ginkgo.It("should return 412 and keep the product deleted when If-Match names an old version", func() {
+			id := strconv.Itoa(int(createdProductID))
+			send("DELETE", id, "", productHandler.DeleteProduct)
+
+			ifMatch = `"1"`
+			w := send("POST", id, "/restore", productHandler.RestoreProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionFailed))
+
+			w = send("GET", id, "", productHandler.GetProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should return 428 without an If-Match header", func() {
+			id := strconv.Itoa(int(createdProductID))
+			send("DELETE", id, "", productHandler.DeleteProduct)
+
+			ifMatch = ""
+			w := send("POST", id, "/restore", productHandler.RestoreProduct)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionRequired))
+		})
+	})
+})
@@ -30,8 +30,7 @@ var _ = ginkgo.Describe("GetProductList E2E Tests", func() {
 		database, sqlDB = db.InitDB(true)
 		TruncateTables(database) // Clean up before each test
 
-		productRepo := repository.NewPostgresProductRepository(database)
-		productUsecase := usecase.NewProductUsecase(productRepo)
+		productUsecase := usecase.NewProductUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database))
 		productHandler = handler.NewProductHandler(productUsecase)
 
 		// Create some test products
@@ -97,15 +96,73 @@ var _ = ginkgo.Describe("GetProductList E2E Tests", func() {
 			gomega.Expect(total).To(gomega.Equal(0))
 		})
 
-		// 3. Internal server error
+		// 3. Total reflects every matching product, not just the current page
+		ginkgo.It("should return the full count and pagination links when paginating", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest("GET", "/api/v1/products?sortBy=name&sortDirection=asc&limit=2&offset=2", nil)
+
+			productHandler.GetProductList(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response map[string]interface{}
+			err := json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+			gomega.Expect(response["products"]).To(gomega.HaveLen(2))
+			gomega.Expect(int(response["total"].(float64))).To(gomega.Equal(5))
+			gomega.Expect(int(response["limit"].(float64))).To(gomega.Equal(2))
+			gomega.Expect(int(response["offset"].(float64))).To(gomega.Equal(2))
+			gomega.Expect(response["has_more"]).To(gomega.BeTrue())
+			gomega.Expect(response["next"]).To(gomega.ContainSubstring("offset=4"))
+			gomega.Expect(response["prev"]).To(gomega.ContainSubstring("offset=0"))
+		})
+
+		// 4. Last page has no next link
+		ginkgo.It("should report no more pages on the last page", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest("GET", "/api/v1/products?sortBy=name&sortDirection=asc&limit=2&offset=4", nil)
+
+			productHandler.GetProductList(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response map[string]interface{}
+			err := json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+			gomega.Expect(response["products"]).To(gomega.HaveLen(1))
+			gomega.Expect(response["has_more"]).To(gomega.BeFalse())
+			gomega.Expect(response["next"]).To(gomega.BeNil())
+		})
+
+		// 5. Invalid pagination parameters
+		ginkgo.It("should return 422 for an out of range limit", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest("GET", "/api/v1/products?sortBy=name&sortDirection=asc&limit=0", nil)
+
+			productHandler.GetProductList(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			var response map[string]interface{}
+			err := json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(response["errors"]).To(gomega.HaveKey("Limit"))
+		})
+
+		// 6. Internal server error
 		ginkgo.It("should return 500 if there is an internal server error", func() {
 			// Mock use case to return an internal server error
 			mockUsecase := new(MockProductUsecase)
 			productHandler := handler.NewProductHandler(mockUsecase)
 
 			// Simulate an error when calling ListProducts after validation passes
-			mockUsecase.On("ListProducts", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-				Return(nil, errors.New("internal server error"))
+			mockUsecase.On("ListProducts", mock.Anything).
+				Return(nil, int64(0), errors.New("internal server error"))
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
@@ -30,8 +30,7 @@ var _ = ginkgo.Describe("GetProduct E2E Tests", func() {
 		database, sqlDB = db.InitDB(true)
 		TruncateTables(database) // Clean up before each test
 
-		productRepo := repository.NewPostgresProductRepository(database)
-		productUsecase := usecase.NewProductUsecase(productRepo)
+		productUsecase := usecase.NewProductUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database))
 		productHandler = handler.NewProductHandler(productUsecase)
 
 		// Create a test product
@@ -0,0 +1,242 @@
+package product_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"inventory_management/api/handler"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("PatchProduct E2E Tests", func() {
+	var productHandler *handler.ProductHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+
+	var createdProductID uint
+
+	// patch sends a merge patch of a product, whatever its version, and returns the recorder
+	patch := func(h *handler.ProductHandler, productID string, body string, contentType string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: productID}}
+		c.Request = httptest.NewRequest("PATCH", "/api/v1/products/"+productID, bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", contentType)
+		c.Request.Header.Set("If-Match", "*")
+
+		h.PatchProduct(c)
+		return w
+	}
+
+	// patchCreated sends a merge patch of the product created for the test and decodes the product
+	patchCreated := func(body string) dto.ProductResponse {
+		w := patch(productHandler, strconv.Itoa(int(createdProductID)), body, "application/merge-patch+json")
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+		var response dto.ProductResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return response
+	}
+
+	// errorsOf decodes the per-field errors of a rejected patch
+	errorsOf := func(w *httptest.ResponseRecorder) map[string]string {
+		var response struct {
+			Errors map[string]string `json:"errors"`
+		}
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return response.Errors
+	}
+
+	ginkgo.BeforeEach(func() {
+		// Initialize test environment
+		database, sqlDB = db.InitDB(true) // Assuming `true` loads the test environment
+		TruncateTables(database)          // Clean up before each test
+
+		productUsecase := usecase.NewProductUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database))
+		productHandler = handler.NewProductHandler(productUsecase)
+
+		payload, _ := json.Marshal(map[string]string{"name": "Trail Shoe"})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/products", bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+		productHandler.CreateProduct(c)
+
+		var created dto.ProductResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&created)).To(gomega.Succeed())
+		createdProductID = created.ID
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database) // Clean up after each test
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("PATCH /products/:id", func() {
+		ginkgo.It("should start new products active without details", func() {
+			product := patchCreated(`{}`)
+
+			gomega.Expect(product.Name).To(gomega.Equal("Trail Shoe"))
+			gomega.Expect(product.Status).To(gomega.Equal("active"))
+			gomega.Expect(product.Description).To(gomega.BeEmpty())
+			gomega.Expect(product.Tags).To(gomega.BeEmpty())
+			gomega.Expect(product.Attributes).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should change only the supplied fields", func() {
+			patchCreated(`{
+				"description": "Lightweight shoe for rough terrain",
+				"brand": "Summit",
+				"weight_grams": 640,
+				"dimensions": {"length_mm": 330, "width_mm": 210, "height_mm": 120},
+				"tags": ["Outdoor", " running ", "outdoor"],
+				"attributes": {"color": "red", "size": "42"}
+			}`)
+
+			product := patchCreated(`{"status": "draft", "dimensions": {"height_mm": 125}, "attributes": {"size": "43"}}`)
+
+			gomega.Expect(product.Name).To(gomega.Equal("Trail Shoe"))
+			gomega.Expect(product.Status).To(gomega.Equal("draft"))
+			gomega.Expect(product.Description).To(gomega.Equal("Lightweight shoe for rough terrain"))
+			gomega.Expect(product.Brand).To(gomega.Equal("Summit"))
+			gomega.Expect(product.WeightGrams).To(gomega.Equal(int64(640)))
+			gomega.Expect(product.Dimensions).To(gomega.Equal(dto.DimensionsResponse{LengthMM: 330, WidthMM: 210, HeightMM: 125}))
+			gomega.Expect(product.Tags).To(gomega.Equal([]string{"outdoor", "running"}))
+			gomega.Expect(product.Attributes).To(gomega.Equal(map[string]string{"color": "red", "size": "43"}))
+		})
+
+		ginkgo.It("should remove fields patched with null", func() {
+			patchCreated(`{"brand": "Summit", "weight_grams": 640, "tags": ["outdoor"], "attributes": {"color": "red", "size": "42"}}`)
+
+			product := patchCreated(`{"brand": null, "weight_grams": null, "tags": null, "attributes": {"color": null}}`)
+
+			gomega.Expect(product.Brand).To(gomega.BeEmpty())
+			gomega.Expect(product.WeightGrams).To(gomega.BeZero())
+			gomega.Expect(product.Tags).To(gomega.BeEmpty())
+			gomega.Expect(product.Attributes).To(gomega.Equal(map[string]string{"size": "42"}))
+
+			product = patchCreated(`{"attributes": null}`)
+			gomega.Expect(product.Attributes).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should persist the patch", func() {
+			patchCreated(`{"description": "Stored", "tags": ["outdoor"]}`)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(createdProductID))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(createdProductID)), nil)
+			productHandler.GetProduct(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var product dto.ProductResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&product)).To(gomega.Succeed())
+			gomega.Expect(product.Description).To(gomega.Equal("Stored"))
+			gomega.Expect(product.Tags).To(gomega.Equal([]string{"outdoor"}))
+		})
+
+		ginkgo.It("should report every invalid field and change nothing", func() {
+			w := patch(productHandler, strconv.Itoa(int(createdProductID)),
+				`{"name": "X", "status": "sold", "weight_grams": -1, "dimensions": {"width_mm": -5}, "tags": [""], "attributes": {"1st": "x"}, "colour": "red"}`,
+				"application/merge-patch+json")
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			gomega.Expect(errorsOf(w)).To(gomega.And(
+				gomega.HaveKey("Name"),
+				gomega.HaveKey("Status"),
+				gomega.HaveKey("WeightGrams"),
+				gomega.HaveKey("Dimensions"),
+				gomega.HaveKey("Tags"),
+				gomega.HaveKey("Attributes"),
+				gomega.HaveKeyWithValue("colour", "Unknown field."),
+			))
+
+			product := patchCreated(`{}`)
+			gomega.Expect(product.Name).To(gomega.Equal("Trail Shoe"))
+			gomega.Expect(product.Status).To(gomega.Equal("active"))
+		})
+
+		ginkgo.It("should return 422 when the name or status is removed", func() {
+			w := patch(productHandler, strconv.Itoa(int(createdProductID)), `{"name": null, "status": null}`, "application/json")
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			gomega.Expect(errorsOf(w)).To(gomega.And(gomega.HaveKey("Name"), gomega.HaveKey("Status")))
+		})
+
+		ginkgo.It("should return 400 when the patch is not a JSON object", func() {
+			for _, body := range []string{`["name"]`, `null`, `{"name": 5}`, `{"name":`} {
+				w := patch(productHandler, strconv.Itoa(int(createdProductID)), body, "application/merge-patch+json")
+				gomega.Expect(w.Code).To(gomega.Equal(http.StatusBadRequest), body)
+			}
+		})
+
+		ginkgo.It("should return 415 for other media types", func() {
+			w := patch(productHandler, strconv.Itoa(int(createdProductID)), `{"brand": "Summit"}`, "text/plain")
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnsupportedMediaType))
+		})
+
+		ginkgo.It("should only apply a patch based on the current version", func() {
+			id := strconv.Itoa(int(createdProductID))
+			patchAt := func(ifMatch string, body string) *httptest.ResponseRecorder {
+				w := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(w)
+				c.Params = gin.Params{{Key: "id", Value: id}}
+				c.Request = httptest.NewRequest("PATCH", "/api/v1/products/"+id, bytes.NewBufferString(body))
+				c.Request.Header.Set("Content-Type", "application/merge-patch+json")
+				if ifMatch != "" {
+					c.Request.Header.Set("If-Match", ifMatch)
+				}
+				productHandler.PatchProduct(c)
+				return w
+			}
+
+			w := patchAt(`"1"`, `{"brand": "Summit"}`)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(w.Header().Get("ETag")).To(gomega.Equal(`"2"`))
+
+			w = patchAt(`"1"`, `{"brand": "Ridge"}`)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionFailed))
+
+			w = patchAt("", `{"brand": "Ridge"}`)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionRequired))
+
+			gomega.Expect(patchCreated(`{}`).Brand).To(gomega.Equal("Summit"))
+		})
+
+		ginkgo.It("should return 404 if the product is not found", func() {
+			w := patch(productHandler, strconv.Itoa(int(createdProductID+999)), `{"brand": "Summit"}`, "application/merge-patch+json")
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should return 400 if the product ID is invalid", func() {
+			w := patch(productHandler, "invalid-id", `{"brand": "Summit"}`, "application/merge-patch+json")
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusBadRequest))
+		})
+
+		ginkgo.It("should return 500 if there's an internal server error", func() {
+			mockUsecase := new(MockProductUsecase)
+			brand := "Summit"
+			mockUsecase.On("PatchProduct", createdProductID, usecase.ProductPatch{Brand: &brand}, int64(0), mock.Anything).Return(nil, errors.New("internal server error"))
+
+			w := patch(handler.NewProductHandler(mockUsecase), strconv.Itoa(int(createdProductID)), `{"brand": "Summit"}`, "application/merge-patch+json")
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+		})
+	})
+})
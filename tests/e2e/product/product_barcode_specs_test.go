@@ -0,0 +1,130 @@
+package product_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Product Barcode E2E Tests", func() {
+	var productHandler *handler.ProductHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID uint
+
+	// addBarcode assigns a barcode to a product and returns the recorder
+	addBarcode := func(productID uint, code string) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(map[string]string{"code": code})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+		c.Request = httptest.NewRequest("POST", "/api/v1/products/"+strconv.Itoa(int(productID))+"/barcodes", bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		productHandler.AddProductBarcode(c)
+		return w
+	}
+
+	// lookup fetches a product by a scanned barcode and returns the recorder
+	lookup := func(code string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "code", Value: code}}
+		c.Request = httptest.NewRequest("GET", "/api/v1/products/by-barcode/"+code, nil)
+
+		productHandler.GetProductByBarcode(c)
+		return w
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		productHandler = handler.NewProductHandler(usecase.NewProductUsecase(repos, repository.NewUnitOfWork(database)))
+
+		product, err := entity.NewProduct("Cola 330ml")
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Expect(repos.Products.Save(product)).To(gomega.Succeed())
+		productID = product.ID()
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.It("should find a product by any form of its barcode", func() {
+		w := addBarcode(productID, "036000291452")
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+		var barcode dto.BarcodeResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&barcode)).To(gomega.Succeed())
+		gomega.Expect(barcode.Type).To(gomega.Equal("UPC-A"))
+
+		for _, code := range []string{"036000291452", "0036000291452", "00036000291452"} {
+			w = lookup(code)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response dto.ProductResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+			gomega.Expect(response.ID).To(gomega.Equal(productID))
+		}
+	})
+
+	ginkgo.It("should reject barcodes with a wrong check digit", func() {
+		gomega.Expect(addBarcode(productID, "4006381333932").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(lookup("4006381333932").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+	})
+
+	ginkgo.It("should return 409 when another product carries the barcode", func() {
+		gomega.Expect(addBarcode(productID, "4006381333931").Code).To(gomega.Equal(http.StatusCreated))
+
+		other, err := entity.NewProduct("Pencil")
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Expect(repository.NewPostgresProductRepository(database).Save(other)).To(gomega.Succeed())
+		gomega.Expect(addBarcode(other.ID(), "4006381333931").Code).To(gomega.Equal(http.StatusConflict))
+	})
+
+	ginkgo.It("should match barcodes in the product search term", func() {
+		gomega.Expect(addBarcode(productID, "96385074").Code).To(gomega.Equal(http.StatusCreated))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/v1/products?"+url.Values{"search": {"963850"}}.Encode(), nil)
+		productHandler.GetProductList(c)
+
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		var response dto.ProductListResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		gomega.Expect(response.Products).To(gomega.HaveLen(1))
+		gomega.Expect(response.Products[0].ID).To(gomega.Equal(productID))
+	})
+
+	ginkgo.It("should stop finding a product once its barcode is removed", func() {
+		gomega.Expect(addBarcode(productID, "96385074").Code).To(gomega.Equal(http.StatusCreated))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}, {Key: "code", Value: "96385074"}}
+		c.Request = httptest.NewRequest("DELETE", "/api/v1/products/"+strconv.Itoa(int(productID))+"/barcodes/96385074", nil)
+		productHandler.RemoveProductBarcode(c)
+		gomega.Expect(c.Writer.Status()).To(gomega.Equal(http.StatusNoContent))
+
+		gomega.Expect(lookup("96385074").Code).To(gomega.Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,164 @@
+package product_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"inventory_management/api/handler"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/event"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("ProductEvents E2E Tests", func() {
+	var productHandler *handler.ProductHandler
+	var publisher *event.InMemoryPublisher
+	var relay usecase.EventRelayUsecase
+	var database *gorm.DB
+	var sqlDB *sql.DB
+
+	// send calls a product handler with a JSON body and returns the recorder
+	send := func(method string, productID string, body interface{}, handle func(c *gin.Context)) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		if productID != "" {
+			c.Params = gin.Params{{Key: "id", Value: productID}}
+		}
+		c.Request = httptest.NewRequest(method, "/api/v1/products/"+productID, bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("If-Match", "*")
+		c.Request.Header.Set("X-Actor", "alice")
+
+		handle(c)
+		return w
+	}
+
+	// createProduct creates a product and returns its ID
+	createProduct := func(name string) string {
+		w := send("POST", "", map[string]string{"name": name}, productHandler.CreateProduct)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+
+		var created dto.ProductResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&created)).To(gomega.Succeed())
+		return strconv.Itoa(int(created.ID))
+	}
+
+	// pendingEvents counts the events the relay has not delivered yet
+	pendingEvents := func() int64 {
+		var count int64
+		database.Model(&model.OutboxEvent{}).Where("published_at IS NULL").Count(&count)
+		return count
+	}
+
+	ginkgo.BeforeEach(func() {
+		// Initialize test environment
+		database, sqlDB = db.InitDB(true) // Assuming `true` loads the test environment
+		TruncateTables(database)          // Clean up before each test
+
+		uow := repository.NewUnitOfWork(database)
+		productHandler = handler.NewProductHandler(usecase.NewProductUsecase(repository.NewRepositories(database), uow))
+		publisher = event.NewInMemoryPublisher()
+		relay = usecase.NewEventRelayUsecase(uow, publisher, 2)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database) // Clean up after each test
+		sqlDB.Close()
+	})
+
+	ginkgo.It("should write an event to the outbox with every product change", func() {
+		id := createProduct("Trail Shoe")
+		send("PUT", id, map[string]string{"name": "Trail Runner"}, productHandler.UpdateProductName)
+		send("PATCH", id, map[string]interface{}{"brand": "Summit", "tags": []string{"outdoor"}}, productHandler.PatchProduct)
+		send("DELETE", id, nil, productHandler.DeleteProduct)
+
+		var events []model.OutboxEvent
+		database.Order("id asc").Find(&events)
+		gomega.Expect(events).To(gomega.HaveLen(4))
+		gomega.Expect(events[0].EventType).To(gomega.Equal("ProductCreated"))
+		gomega.Expect(events[1].EventType).To(gomega.Equal("ProductRenamed"))
+		gomega.Expect(events[1].Payload["previous_name"]).To(gomega.Equal("Trail Shoe"))
+		gomega.Expect(events[2].EventType).To(gomega.Equal("ProductUpdated"))
+		gomega.Expect(events[3].EventType).To(gomega.Equal("ProductDeleted"))
+		for _, e := range events {
+			gomega.Expect(strconv.Itoa(int(e.AggregateID))).To(gomega.Equal(id))
+			gomega.Expect(e.Payload["actor"]).To(gomega.Equal("alice"))
+		}
+	})
+
+	ginkgo.It("should write no event when the change is refused", func() {
+		id := createProduct("Trail Shoe")
+		w := send("PUT", id, map[string]string{"name": ""}, productHandler.UpdateProductName)
+		gomega.Expect(w.Code).NotTo(gomega.Equal(http.StatusOK))
+
+		gomega.Expect(pendingEvents()).To(gomega.Equal(int64(1)))
+	})
+
+	ginkgo.It("should deliver pending events in order across batches and only once", func() {
+		id := createProduct("Trail Shoe")
+		send("PUT", id, map[string]string{"name": "Trail Runner"}, productHandler.UpdateProductName)
+		createProduct("Summit Boot")
+
+		run, err := relay.RelayEvents(time.Now())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(run.Published).To(gomega.Equal(3))
+		gomega.Expect(pendingEvents()).To(gomega.BeZero())
+
+		published := publisher.Published()
+		gomega.Expect(published).To(gomega.HaveLen(3))
+		gomega.Expect(published[0].Type).To(gomega.Equal(entity.EventProductCreated))
+		gomega.Expect(published[1].Type).To(gomega.Equal(entity.EventProductRenamed))
+		gomega.Expect(published[2].Payload["name"]).To(gomega.Equal("Summit Boot"))
+
+		// Delivered events are not delivered again
+		run, err = relay.RelayEvents(time.Now())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(run.Published).To(gomega.BeZero())
+		gomega.Expect(publisher.Published()).To(gomega.HaveLen(3))
+	})
+
+	ginkgo.It("should keep an event that failed to deliver and retry it on the next run", func() {
+		failing := true
+		publisher.Subscribe(entity.EventProductRenamed, func(message event.Message) error {
+			if failing {
+				return errors.New("search index unavailable")
+			}
+			return nil
+		})
+
+		id := createProduct("Trail Shoe")
+		send("PUT", id, map[string]string{"name": "Trail Runner"}, productHandler.UpdateProductName)
+
+		run, err := relay.RelayEvents(time.Now())
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		gomega.Expect(run.Published).To(gomega.Equal(1))
+		gomega.Expect(run.Failed).To(gomega.Equal(1))
+
+		var renamed model.OutboxEvent
+		database.Where("event_type = ?", "ProductRenamed").First(&renamed)
+		gomega.Expect(renamed.PublishedAt).To(gomega.BeNil())
+		gomega.Expect(renamed.Attempts).To(gomega.Equal(1))
+		gomega.Expect(renamed.LastError).To(gomega.Equal("search index unavailable"))
+
+		failing = false
+		run, err = relay.RelayEvents(time.Now())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(run.Published).To(gomega.Equal(1))
+		gomega.Expect(pendingEvents()).To(gomega.BeZero())
+	})
+})
@@ -0,0 +1,180 @@
+package product_e2e_test
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"inventory_management/api/handler"
+	consts "inventory_management/api/handler/const"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/spreadsheet"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("ProductExport E2E Tests", func() {
+	var productHandler *handler.ProductHandler
+	var productUsecase usecase.ProductUsecase
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var categoryID uint
+	var admin bool // Whether exports are requested by an administrator
+
+	// export calls the export handler with the given query string
+	export := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/v1/products/export?"+query, nil)
+		c.Set(consts.ContextKeyAdmin, admin)
+
+		productHandler.ExportProducts(c)
+		return w
+	}
+
+	// exportCSV exports as CSV and returns the records of the file
+	exportCSV := func(query string) [][]string {
+		w := export("format=csv&" + query)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		return records
+	}
+
+	// skus returns the SKU column of exported records, leaving out the header
+	skus := func(records [][]string) []string {
+		values := make([]string, 0, len(records))
+		for _, record := range records[1:] {
+			values = append(values, record[2])
+		}
+		return values
+	}
+
+	ginkgo.BeforeEach(func() {
+		// Initialize test environment
+		database, sqlDB = db.InitDB(true) // Assuming `true` loads the test environment
+		TruncateTables(database)          // Clean up before each test
+		admin = false
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		productUsecase = usecase.NewProductUsecase(repos, uow)
+		productHandler = handler.NewProductHandler(productUsecase)
+
+		category, err := entity.NewCategory("Footwear", nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(repos.Categories.Save(category)).To(gomega.Succeed())
+		categoryID = category.ID()
+
+		for _, input := range []usecase.ProductInput{
+			{Name: "Trail Shoe", SKU: "TS-1", CategoryID: categoryID},
+			{Name: "Camp Mug", SKU: "CM-1"},
+			{Name: "Road Shoe", SKU: "RS-1", CategoryID: categoryID, Serialized: true},
+		} {
+			_, err := productUsecase.CreateProduct(input, entity.ChangeOrigin{Actor: "alice"})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database) // Clean up after each test
+		sqlDB.Close()
+	})
+
+	ginkgo.It("should export every product as CSV in the order they were created", func() {
+		w := export("")
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		gomega.Expect(w.Header().Get("Content-Type")).To(gomega.HavePrefix("text/csv"))
+		gomega.Expect(w.Header().Get("Content-Disposition")).To(gomega.MatchRegexp(`attachment; filename="products-\d{8}\.csv"`))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(records[0][:3]).To(gomega.Equal([]string{"id", "name", "sku"}))
+		gomega.Expect(skus(records)).To(gomega.Equal([]string{"TS-1", "CM-1", "RS-1"}))
+
+		roadShoe := records[3]
+		gomega.Expect(roadShoe[1]).To(gomega.Equal("Road Shoe"))
+		gomega.Expect(roadShoe[12]).To(gomega.Equal("true"))
+		gomega.Expect(roadShoe[14]).To(gomega.Equal(fmt.Sprint(categoryID)))
+	})
+
+	ginkgo.It("should honour the filters and sorting of the product list", func() {
+		gomega.Expect(skus(exportCSV(fmt.Sprintf("category_id=%d", categoryID)))).To(gomega.Equal([]string{"TS-1", "RS-1"}))
+		gomega.Expect(skus(exportCSV("search=shoe&sortBy=name&sortDirection=desc"))).To(gomega.Equal([]string{"TS-1", "RS-1"}))
+		gomega.Expect(skus(exportCSV("sortBy=sku"))).To(gomega.Equal([]string{"CM-1", "RS-1", "TS-1"}))
+
+		mug, err := productUsecase.GetProductBySKU("CM-1")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = productUsecase.DeleteProduct(mug.ID(), 0, entity.ChangeOrigin{Actor: "alice"})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Expect(skus(exportCSV(""))).To(gomega.Equal([]string{"TS-1", "RS-1"}))
+		gomega.Expect(export("format=csv&include_deleted=true").Code).To(gomega.Equal(http.StatusForbidden))
+
+		admin = true
+		withDeleted := exportCSV("include_deleted=true")
+		gomega.Expect(skus(withDeleted)).To(gomega.Equal([]string{"TS-1", "CM-1", "RS-1"}))
+		gomega.Expect(withDeleted[2][18]).NotTo(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should export one product response per line as JSON Lines", func() {
+		w := export("format=jsonl")
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		gomega.Expect(w.Header().Get("Content-Type")).To(gomega.Equal("application/x-ndjson"))
+
+		var exported []dto.ProductResponse
+		scanner := bufio.NewScanner(w.Body)
+		for scanner.Scan() {
+			var product dto.ProductResponse
+			gomega.Expect(json.Unmarshal(scanner.Bytes(), &product)).To(gomega.Succeed())
+			exported = append(exported, product)
+		}
+		gomega.Expect(exported).To(gomega.HaveLen(3))
+		gomega.Expect(exported[0].SKU).To(gomega.Equal("TS-1"))
+		gomega.Expect(*exported[0].CategoryID).To(gomega.Equal(categoryID))
+	})
+
+	ginkgo.It("should export a workbook that can be imported again", func() {
+		w := export("format=xlsx")
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		gomega.Expect(w.Header().Get("Content-Disposition")).To(gomega.HaveSuffix(`.xlsx"`))
+
+		rows, err := spreadsheet.ReadXLSX(w.Body.Bytes())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(rows).To(gomega.HaveLen(4))
+		gomega.Expect(rows[1].Cells[2]).To(gomega.Equal("TS-1"))
+
+		columns, validationErrors := dto.NewProductImportColumns(rows[0].Cells, nil)
+		gomega.Expect(validationErrors).To(gomega.BeNil())
+		request, serializedGiven, validationErrors := columns.Request(rows[3].Cells)
+		gomega.Expect(validationErrors).To(gomega.BeNil())
+		gomega.Expect(serializedGiven).To(gomega.BeTrue())
+		gomega.Expect(request.SKU).To(gomega.Equal("RS-1"))
+		gomega.Expect(request.Serialized).To(gomega.BeTrue())
+		gomega.Expect(request.CategoryID).To(gomega.Equal(categoryID))
+	})
+
+	ginkgo.It("should refuse unknown formats and filters", func() {
+		w := export("format=pdf")
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(w.Body.String()).To(gomega.ContainSubstring("format must be either 'csv', 'jsonl' or 'xlsx'."))
+		gomega.Expect(w.Header().Get("Content-Disposition")).To(gomega.BeEmpty())
+
+		for _, query := range []string{"sortBy=price", "sortDirection=up", "category_id=0", "include_deleted=maybe"} {
+			gomega.Expect(export(query).Code).To(gomega.Equal(http.StatusUnprocessableEntity), query)
+		}
+		gomega.Expect(strings.Count(export("").Body.String(), "\n")).To(gomega.Equal(4))
+	})
+})
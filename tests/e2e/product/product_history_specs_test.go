@@ -0,0 +1,185 @@
+package product_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("ProductHistory E2E Tests", func() {
+	var productHandler *handler.ProductHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+
+	var createdProductID uint
+
+	// get calls a product handler for the product with the given ID and returns the recorder
+	get := func(productID string, path string, handle func(c *gin.Context)) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: productID}}
+		c.Request = httptest.NewRequest("GET", "/api/v1/products/"+productID+path, nil)
+
+		handle(c)
+		return w
+	}
+
+	// history fetches the audit trail of the created product and decodes it
+	history := func() dto.ProductHistoryResponse {
+		w := get(strconv.Itoa(int(createdProductID)), "/history", productHandler.GetProductHistory)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+		var response dto.ProductHistoryResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return response
+	}
+
+	// snapshot rebuilds the created product as of the given time
+	snapshot := func(asOf time.Time) *httptest.ResponseRecorder {
+		query := "/snapshot?as_of=" + url.QueryEscape(asOf.Format(time.RFC3339Nano))
+		return get(strconv.Itoa(int(createdProductID)), query, productHandler.GetProductSnapshot)
+	}
+
+	ginkgo.BeforeEach(func() {
+		// Initialize test environment
+		database, sqlDB = db.InitDB(true) // Assuming `true` loads the test environment
+		TruncateTables(database)          // Clean up before each test
+
+		productUsecase := usecase.NewProductUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database))
+		productHandler = handler.NewProductHandler(productUsecase)
+
+		payload, _ := json.Marshal(map[string]string{"name": "Trail Shoe"})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/products", bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("X-Actor", "alice")
+		c.Request.Header.Set("X-Request-ID", "req-create")
+		productHandler.CreateProduct(c)
+
+		var created dto.ProductResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&created)).To(gomega.Succeed())
+		createdProductID = created.ID
+
+		// Bob renames the product afterwards
+		payload, _ = json.Marshal(map[string]string{"name": "Trail Runner"})
+		w = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(createdProductID))}}
+		c.Request = httptest.NewRequest("PUT", "/api/v1/products/"+strconv.Itoa(int(createdProductID)), bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("If-Match", `"1"`)
+		c.Request.Header.Set("X-Actor", "bob")
+		c.Request.Header.Set("X-Request-ID", "req-rename")
+		productHandler.UpdateProductName(c)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database) // Clean up after each test
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("GET /products/:id/history", func() {
+		ginkgo.It("should record who made each change, from where and what changed", func() {
+			response := history()
+
+			gomega.Expect(response.Total).To(gomega.Equal(int64(2)))
+			gomega.Expect(response.Records).To(gomega.HaveLen(2))
+
+			created, renamed := response.Records[0], response.Records[1]
+			gomega.Expect(created.Action).To(gomega.Equal("created"))
+			gomega.Expect(created.Actor).To(gomega.Equal("alice"))
+			gomega.Expect(created.RequestID).To(gomega.Equal("req-create"))
+			gomega.Expect(created.SourceIP).To(gomega.Equal("192.0.2.1"))
+			gomega.Expect(created.Changes).To(gomega.ContainElement(&dto.FieldChangeResponse{Field: "name", Before: nil, After: "Trail Shoe"}))
+
+			gomega.Expect(renamed.Action).To(gomega.Equal("updated"))
+			gomega.Expect(renamed.Actor).To(gomega.Equal("bob"))
+			gomega.Expect(renamed.RequestID).To(gomega.Equal("req-rename"))
+			gomega.Expect(renamed.Changes).To(gomega.Equal([]*dto.FieldChangeResponse{
+				{Field: "name", Before: "Trail Shoe", After: "Trail Runner"},
+			}))
+		})
+
+		ginkgo.It("should keep the history of deleted products", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(createdProductID))}}
+			c.Request = httptest.NewRequest("DELETE", "/api/v1/products/"+strconv.Itoa(int(createdProductID)), nil)
+			c.Request.Header.Set("If-Match", `"2"`)
+			productHandler.DeleteProduct(c)
+
+			records := history().Records
+			gomega.Expect(records).To(gomega.HaveLen(3))
+			gomega.Expect(records[2].Action).To(gomega.Equal("deleted"))
+			gomega.Expect(records[2].Actor).To(gomega.Equal("anonymous"))
+		})
+
+		ginkgo.It("should refuse to rewrite the audit trail", func() {
+			err := database.Exec("UPDATE product_audit_records SET actor = 'mallory'").Error
+			gomega.Expect(err).To(gomega.HaveOccurred())
+
+			err = database.Exec("DELETE FROM product_audit_records").Error
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should return 404 if the product is not found", func() {
+			w := get(strconv.Itoa(int(createdProductID+999)), "/history", productHandler.GetProductHistory)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should return 422 for an invalid limit", func() {
+			w := get(strconv.Itoa(int(createdProductID)), "/history?limit=0", productHandler.GetProductHistory)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	ginkgo.Context("GET /products/:id/snapshot", func() {
+		ginkgo.It("should rebuild the product as it was at the given time", func() {
+			records := history().Records
+
+			w := snapshot(records[0].OccurredAt)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var product dto.ProductResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&product)).To(gomega.Succeed())
+			gomega.Expect(product.ID).To(gomega.Equal(createdProductID))
+			gomega.Expect(product.Name).To(gomega.Equal("Trail Shoe"))
+			gomega.Expect(product.Version).To(gomega.Equal(int64(1)))
+
+			w = snapshot(records[1].OccurredAt)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&product)).To(gomega.Succeed())
+			gomega.Expect(product.Name).To(gomega.Equal("Trail Runner"))
+			gomega.Expect(product.Version).To(gomega.Equal(int64(2)))
+		})
+
+		ginkgo.It("should return 404 before the product was created", func() {
+			w := snapshot(history().Records[0].OccurredAt.Add(-time.Hour))
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should return 422 without a valid as_of time", func() {
+			w := get(strconv.Itoa(int(createdProductID)), "/snapshot", productHandler.GetProductSnapshot)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+
+			w = get(strconv.Itoa(int(createdProductID)), "/snapshot?as_of=yesterday", productHandler.GetProductSnapshot)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+})
@@ -0,0 +1,264 @@
+package product_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"inventory_management/api/handler"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("ProductImport E2E Tests", func() {
+	var importHandler *handler.ProductImportHandler
+	var productUsecase usecase.ProductUsecase
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var categoryID uint
+
+	// upload posts a file with the given form fields to the import handler
+	upload := func(fileName string, content string, fields map[string]string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		if fileName != "" {
+			part, _ := form.CreateFormFile("file", fileName)
+			part.Write([]byte(content))
+		}
+		for name, value := range fields {
+			form.WriteField(name, value)
+		}
+		form.Close()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/products/import", &body)
+		c.Request.Header.Set("Content-Type", form.FormDataContentType())
+		c.Request.Header.Set("X-Actor", "importer")
+
+		importHandler.ImportProducts(c)
+		return w
+	}
+
+	// importFile uploads a file and returns the report of the import
+	importFile := func(content string, fields map[string]string) dto.ProductImportResponse {
+		w := upload("catalogue.csv", content, fields)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+
+		var report dto.ProductImportResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&report)).To(gomega.Succeed())
+		gomega.Expect(w.Header().Get("Location")).To(gomega.Equal(fmt.Sprintf("/api/v1/products/imports/%d", report.ID)))
+		return report
+	}
+
+	// get calls a handler of an import by its ID
+	get := func(id string, handle func(c *gin.Context)) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		c.Request = httptest.NewRequest("GET", "/api/v1/products/imports/"+id, nil)
+
+		handle(c)
+		return w
+	}
+
+	// productCount counts the products stored
+	productCount := func() int64 {
+		var count int64
+		database.Model(&model.Product{}).Count(&count)
+		return count
+	}
+
+	ginkgo.BeforeEach(func() {
+		// Initialize test environment
+		database, sqlDB = db.InitDB(true) // Assuming `true` loads the test environment
+		TruncateTables(database)          // Clean up before each test
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		importHandler = handler.NewProductImportHandler(usecase.NewProductImportUsecase(repos, uow))
+		productUsecase = usecase.NewProductUsecase(repos, uow)
+
+		category, err := entity.NewCategory("Footwear", nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(repos.Categories.Save(category)).To(gomega.Succeed())
+		categoryID = category.ID()
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database) // Clean up after each test
+		sqlDB.Close()
+	})
+
+	ginkgo.It("should create new products, update products by SKU and reject invalid rows", func() {
+		_, err := productUsecase.CreateProduct(usecase.ProductInput{Name: "Trail Shoe", SKU: "TS-1"}, entity.ChangeOrigin{Actor: "alice"})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = productUsecase.CreateProduct(usecase.ProductInput{Name: "Summit Boot", SKU: "SB-1"}, entity.ChangeOrigin{Actor: "alice"})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		report := importFile(fmt.Sprintf(
+			"Name,SKU,Serialized,Stock Unit,Category ID\n"+
+				"Trail Runner,TS-1,,,%d\n"+
+				"Summit Boot,SB-1,,,\n"+
+				"Road Shoe,RS-1,yes,ea,%d\n"+
+				"\n"+
+				"X,XX-1,,,\n"+
+				"Camp Sock,CS-1,maybe,,\n"+
+				"Camp Mug,CM-1,,BOX,999\n"+
+				"Rain Jacket,,,,\n",
+			categoryID, categoryID), nil)
+
+		gomega.Expect(report.DryRun).To(gomega.BeFalse())
+		gomega.Expect(report.Status).To(gomega.Equal("completed"))
+		gomega.Expect(report.FailedAtLine).To(gomega.BeNil())
+		gomega.Expect(report.TotalRows).To(gomega.Equal(7))
+		gomega.Expect(report.Created).To(gomega.Equal(2))
+		gomega.Expect(report.Updated).To(gomega.Equal(1))
+		gomega.Expect(report.Unchanged).To(gomega.Equal(1))
+		gomega.Expect(report.Rejected).To(gomega.Equal(3))
+
+		// Lines are those of the file, and messages those product creation answers with
+		rejected := report.RejectedRows
+		gomega.Expect(rejected[0].Line).To(gomega.Equal(6))
+		gomega.Expect(rejected[0].Errors).To(gomega.Equal(map[string]string{"Name": "Product name must be at least 2 characters long."}))
+		gomega.Expect(rejected[1].Line).To(gomega.Equal(7))
+		gomega.Expect(rejected[1].Errors).To(gomega.HaveKeyWithValue("Serialized", "Serialized must be true or false."))
+		gomega.Expect(rejected[2].Line).To(gomega.Equal(8))
+		gomega.Expect(rejected[2].Errors).To(gomega.HaveKey("StockUnit"))
+		gomega.Expect(rejected[2].Errors).To(gomega.HaveKey("CategoryID"))
+
+		renamed, err := productUsecase.GetProductBySKU("TS-1")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(renamed.Name()).To(gomega.Equal("Trail Runner"))
+		gomega.Expect(renamed.CategoryID()).To(gomega.Equal(categoryID))
+
+		created, err := productUsecase.GetProductBySKU("RS-1")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(created.IsSerialized()).To(gomega.BeTrue())
+		gomega.Expect(created.StockUnit()).To(gomega.Equal("EA"))
+		gomega.Expect(productCount()).To(gomega.Equal(int64(4)))
+
+		// Imported changes are audited like any other
+		history, _, err := productUsecase.GetProductHistory(usecase.ProductAuditFilter{ProductID: renamed.ID(), Limit: 10})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(history).To(gomega.HaveLen(2))
+		gomega.Expect(history[0].Origin().Actor).To(gomega.Equal("importer"))
+	})
+
+	ginkgo.It("should store nothing but the report on a dry run", func() {
+		report := importFile("name,sku\nTrail Shoe,TS-1\nTrail Runner,TS-1\nX,\n", map[string]string{"dry_run": "true"})
+
+		gomega.Expect(report.DryRun).To(gomega.BeTrue())
+		gomega.Expect(report.Created).To(gomega.Equal(1))
+		gomega.Expect(report.Updated).To(gomega.Equal(1))
+		gomega.Expect(report.Rejected).To(gomega.Equal(1))
+		gomega.Expect(productCount()).To(gomega.BeZero())
+
+		w := get(strconv.Itoa(int(report.ID)), importHandler.GetProductImport)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	})
+
+	ginkgo.It("should read the columns named in the mapping", func() {
+		report := importFile("Item Code,Description,Name\nTS-1,Trail Shoe,ignored\n", map[string]string{
+			"mapping": `{"sku": "item code", "name": "Description"}`,
+		})
+		gomega.Expect(report.Created).To(gomega.Equal(1))
+
+		product, err := productUsecase.GetProductBySKU("TS-1")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(product.Name()).To(gomega.Equal("Trail Shoe"))
+
+		w := upload("catalogue.csv", "Description\nTrail Shoe\n", map[string]string{"mapping": `{"name": "Description", "sku": "Item Code"}`})
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(w.Body.String()).To(gomega.ContainSubstring(`file has no column \"Item Code\".`))
+	})
+
+	ginkgo.It("should keep the batches before a failed one and report where the import stopped", func() {
+		// Make the database refuse one product of the second batch
+		gomega.Expect(database.Exec(`CREATE FUNCTION refuse_exploding_boot() RETURNS trigger AS $$
+			BEGIN
+				IF NEW.name = 'Exploding Boot' THEN RAISE EXCEPTION 'refused'; END IF;
+				RETURN NEW;
+			END $$ LANGUAGE plpgsql`).Error).To(gomega.Succeed())
+		gomega.Expect(database.Exec("CREATE TRIGGER refuse_exploding_boot BEFORE INSERT ON products FOR EACH ROW EXECUTE FUNCTION refuse_exploding_boot()").Error).To(gomega.Succeed())
+		ginkgo.DeferCleanup(func() {
+			database.Exec("DROP TRIGGER IF EXISTS refuse_exploding_boot ON products")
+			database.Exec("DROP FUNCTION IF EXISTS refuse_exploding_boot")
+		})
+
+		// Lines 2 to 501 make the first batch, the second starts at line 502
+		var file bytes.Buffer
+		file.WriteString("name,sku\nX,REJ-1\n")
+		for i := 2; i <= 600; i++ {
+			name := fmt.Sprintf("Boot %d", i)
+			if i == 550 {
+				name = "Exploding Boot"
+			}
+			fmt.Fprintf(&file, "%s,B-%d\n", name, i)
+		}
+
+		w := upload("catalogue.csv", file.String(), nil)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+
+		var response struct {
+			Import dto.ProductImportResponse `json:"import"`
+		}
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		report := response.Import
+		gomega.Expect(report.Status).To(gomega.Equal("failed"))
+		gomega.Expect(*report.FailedAtLine).To(gomega.Equal(502))
+		gomega.Expect(report.Created).To(gomega.Equal(499))
+		gomega.Expect(report.Rejected).To(gomega.Equal(1))
+		gomega.Expect(report.RejectedRows[0].Line).To(gomega.Equal(2))
+		gomega.Expect(w.Header().Get("Location")).To(gomega.Equal(fmt.Sprintf("/api/v1/products/imports/%d", report.ID)))
+		gomega.Expect(productCount()).To(gomega.Equal(int64(499)))
+
+		// The stored report tells the same
+		w = get(strconv.Itoa(int(report.ID)), importHandler.GetProductImport)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		var stored dto.ProductImportResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&stored)).To(gomega.Succeed())
+		gomega.Expect(stored.Status).To(gomega.Equal("failed"))
+		gomega.Expect(*stored.FailedAtLine).To(gomega.Equal(502))
+		gomega.Expect(stored.Created).To(gomega.Equal(499))
+	})
+
+	ginkgo.It("should refuse files it cannot read", func() {
+		gomega.Expect(upload("", "", nil).Code).To(gomega.Equal(http.StatusBadRequest))
+		gomega.Expect(upload("catalogue.txt", "name\nTrail Shoe\n", nil).Code).To(gomega.Equal(http.StatusUnsupportedMediaType))
+		gomega.Expect(upload("catalogue.xlsx", "name\nTrail Shoe\n", nil).Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(upload("catalogue.csv", "\n\n", nil).Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(upload("catalogue.csv", "title,sku\nTrail Shoe,TS-1\n", nil).Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(upload("catalogue.csv", "name\nTrail Shoe\n", map[string]string{"mapping": `{"price": "Price"}`}).Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(upload("catalogue.csv", "name\nTrail Shoe\n", map[string]string{"dry_run": "perhaps"}).Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(productCount()).To(gomega.BeZero())
+	})
+
+	ginkgo.It("should download the rejected rows as CSV", func() {
+		report := importFile("name,sku,serialized\nX,TS-1,maybe\nTrail Shoe,TS-2,no\n", nil)
+
+		w := get(strconv.Itoa(int(report.ID)), importHandler.GetProductImportReport)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		gomega.Expect(w.Header().Get("Content-Type")).To(gomega.HavePrefix("text/csv"))
+		gomega.Expect(w.Header().Get("Content-Disposition")).To(gomega.ContainSubstring(fmt.Sprintf("product-import-%d-report.csv", report.ID)))
+		gomega.Expect(w.Body.String()).To(gomega.Equal(
+			"line,sku,name,field,message\n" +
+				"2,TS-1,X,Name,Product name must be at least 2 characters long.\n" +
+				"2,TS-1,X,Serialized,Serialized must be true or false.\n"))
+
+		gomega.Expect(get("999", importHandler.GetProductImportReport).Code).To(gomega.Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,79 @@
+package product_e2e_test
+
+import (
+	"database/sql"
+	"inventory_management/api/handler"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Product Label E2E Tests", func() {
+	var productHandler *handler.ProductHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID uint
+
+	// getLabel renders the label of a product with the given query string and returns the recorder
+	getLabel := func(productID uint, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+		c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(productID))+"/label?"+query, nil)
+
+		productHandler.GetProductLabel(c)
+		return w
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		productHandler = handler.NewProductHandler(usecase.NewProductUsecase(repos, repository.NewUnitOfWork(database)))
+
+		product, err := entity.NewProductWithSKU("Cola 330ml", "COLA-330")
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Expect(repos.Products.Save(product)).To(gomega.Succeed())
+		productID = product.ID()
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.It("should render a PNG label by default", func() {
+		w := getLabel(productID, "")
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		gomega.Expect(w.Header().Get("Content-Type")).To(gomega.Equal("image/png"))
+		gomega.Expect(w.Body.Bytes()[:8]).To(gomega.Equal([]byte("\x89PNG\r\n\x1a\n")))
+	})
+
+	ginkgo.It("should render an SVG label at the requested size", func() {
+		w := getLabel(productID, "format=svg&width=60&height=30&dpi=300")
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+		gomega.Expect(w.Header().Get("Content-Type")).To(gomega.Equal("image/svg+xml"))
+		gomega.Expect(w.Body.String()).To(gomega.ContainSubstring(`width="60mm" height="30mm"`))
+		gomega.Expect(w.Body.String()).To(gomega.ContainSubstring("COLA-330"))
+	})
+
+	ginkgo.It("should return 422 for invalid query parameters or a label too small for its barcode", func() {
+		gomega.Expect(getLabel(productID, "format=gif").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(getLabel(productID, "dpi=1200").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		gomega.Expect(getLabel(productID, "width=10&height=10&dpi=72").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+	})
+
+	ginkgo.It("should return 404 for an unknown product", func() {
+		gomega.Expect(getLabel(productID+100, "").Code).To(gomega.Equal(http.StatusNotFound))
+	})
+})
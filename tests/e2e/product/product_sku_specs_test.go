@@ -0,0 +1,185 @@
+package product_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Product SKU E2E Tests", func() {
+	var productHandler *handler.ProductHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var ifMatch string // The If-Match header of SKU changes, none when empty
+
+	// create posts a new product and returns the recorder
+	create := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/products", bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		productHandler.CreateProduct(c)
+		return w
+	}
+
+	// changeSKU reassigns the SKU of a product and returns the recorder
+	changeSKU := func(productID uint, sku string) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(map[string]string{"sku": sku})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+		c.Request = httptest.NewRequest("PUT", "/api/v1/products/"+strconv.Itoa(int(productID))+"/sku", bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			c.Request.Header.Set("If-Match", ifMatch)
+		}
+
+		productHandler.ChangeProductSKU(c)
+		return w
+	}
+
+	// lookup fetches a product by SKU and returns the recorder
+	lookup := func(sku string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "sku", Value: sku}}
+		c.Request = httptest.NewRequest("GET", "/api/v1/products/by-sku/"+sku, nil)
+
+		productHandler.GetProductBySKU(c)
+		return w
+	}
+
+	// decode reads a product response
+	decode := func(w *httptest.ResponseRecorder) *dto.ProductResponse {
+		var response dto.ProductResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return &response
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+		ifMatch = "*"
+
+		productUsecase := usecase.NewProductUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database))
+		productHandler = handler.NewProductHandler(productUsecase)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("POST /products with a SKU", func() {
+		ginkgo.It("should keep the SKU given by the client", func() {
+			w := create(map[string]interface{}{"name": "Drill", "sku": "BOSCH-GSR-12V"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(decode(w).SKU).To(gomega.Equal("BOSCH-GSR-12V"))
+		})
+
+		ginkgo.It("should return 409 when the SKU is taken", func() {
+			gomega.Expect(create(map[string]interface{}{"name": "Drill", "sku": "BOSCH-GSR-12V"}).Code).To(gomega.Equal(http.StatusCreated))
+
+			w := create(map[string]interface{}{"name": "Other drill", "sku": "BOSCH-GSR-12V"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+		})
+
+		ginkgo.It("should return 422 when the SKU has an invalid format", func() {
+			w := create(map[string]interface{}{"name": "Drill", "sku": "BOSCH GSR/12V"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	ginkgo.Context("PUT /products/:id/sku", func() {
+		var productID uint
+
+		ginkgo.BeforeEach(func() {
+			w := create(map[string]interface{}{"name": "Drill", "sku": "LEGACY-0042"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			productID = decode(w).ID
+		})
+
+		ginkgo.It("should keep the previous SKU as an alias", func() {
+			w := changeSKU(productID, "BOSCH-GSR-12V")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			response := decode(w)
+			gomega.Expect(response.SKU).To(gomega.Equal("BOSCH-GSR-12V"))
+			gomega.Expect(response.SKUAliases).To(gomega.HaveLen(1))
+			gomega.Expect(response.SKUAliases[0].SKU).To(gomega.Equal("LEGACY-0042"))
+
+			for _, sku := range []string{"BOSCH-GSR-12V", "LEGACY-0042"} {
+				w = lookup(sku)
+				gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+				gomega.Expect(decode(w).ID).To(gomega.Equal(productID))
+			}
+		})
+
+		ginkgo.It("should let a product take back a former SKU", func() {
+			gomega.Expect(changeSKU(productID, "BOSCH-GSR-12V").Code).To(gomega.Equal(http.StatusOK))
+
+			w := changeSKU(productID, "LEGACY-0042")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			response := decode(w)
+			gomega.Expect(response.SKU).To(gomega.Equal("LEGACY-0042"))
+			gomega.Expect(response.SKUAliases).To(gomega.HaveLen(1))
+			gomega.Expect(response.SKUAliases[0].SKU).To(gomega.Equal("BOSCH-GSR-12V"))
+		})
+
+		ginkgo.It("should return 409 for a SKU another product carries or used to carry", func() {
+			gomega.Expect(changeSKU(productID, "BOSCH-GSR-12V").Code).To(gomega.Equal(http.StatusOK))
+
+			w := create(map[string]interface{}{"name": "Saw", "sku": "LEGACY-0042"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+
+			w = create(map[string]interface{}{"name": "Saw", "sku": "MAKITA-DHS680"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			sawID := decode(w).ID
+			gomega.Expect(changeSKU(sawID, "BOSCH-GSR-12V").Code).To(gomega.Equal(http.StatusConflict))
+			gomega.Expect(changeSKU(sawID, "LEGACY-0042").Code).To(gomega.Equal(http.StatusConflict))
+		})
+
+		ginkgo.It("should return 404 for an unknown product or SKU", func() {
+			gomega.Expect(changeSKU(productID+100, "BOSCH-GSR-12V").Code).To(gomega.Equal(http.StatusNotFound))
+			gomega.Expect(lookup("UNKNOWN-1").Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should change the version named by If-Match and return the next one", func() {
+			ifMatch = `"1"`
+			w := changeSKU(productID, "BOSCH-GSR-12V")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(w.Header().Get("ETag")).To(gomega.Equal(`"2"`))
+		})
+
+		ginkgo.It("should return 412 and keep the SKU when If-Match names an old version", func() {
+			gomega.Expect(changeSKU(productID, "BOSCH-GSR-12V").Code).To(gomega.Equal(http.StatusOK))
+
+			ifMatch = `"1"`
+			w := changeSKU(productID, "MAKITA-DHS680")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionFailed))
+			gomega.Expect(lookup("MAKITA-DHS680").Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should return 428 without an If-Match header", func() {
+			ifMatch = ""
+			w := changeSKU(productID, "BOSCH-GSR-12V")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionRequired))
+		})
+	})
+})
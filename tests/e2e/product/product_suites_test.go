@@ -2,7 +2,10 @@ package product_e2e_test
 
 import (
 	"inventory_management/internal/entity"
+	"inventory_management/internal/label"
+	"inventory_management/internal/usecase"
 	"testing"
+	"time"
 
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
@@ -17,7 +20,7 @@ func TestProductE2E(t *testing.T) {
 
 // Helper function to truncate tables between tests
 func TruncateTables(database *gorm.DB) {
-	database.Exec("TRUNCATE TABLE products RESTART IDENTITY CASCADE;")
+	database.Exec("TRUNCATE TABLE product_imports, outbox_events, product_barcodes, product_sku_aliases, products, categories RESTART IDENTITY CASCADE;")
 }
 
 // MockProductUsecase is the mock implementation of the ProductUsecase interface.
@@ -26,17 +29,23 @@ type MockProductUsecase struct {
 }
 
 // ListProducts mock method
-func (m *MockProductUsecase) ListProducts(searchTerm string, sortBy string, sortDirection string, limit int, offset int) ([]*entity.Product, error) {
-	args := m.Called(searchTerm, sortBy, sortDirection, limit, offset)
+func (m *MockProductUsecase) ListProducts(filter usecase.ProductListFilter) ([]*entity.Product, int64, error) {
+	args := m.Called(filter)
 	if args.Get(0) != nil {
-		return args.Get(0).([]*entity.Product), args.Error(1)
+		return args.Get(0).([]*entity.Product), args.Get(1).(int64), args.Error(2)
 	}
-	return nil, args.Error(1)
+	return nil, args.Get(1).(int64), args.Error(2)
+}
+
+// ExportProducts mock method
+func (m *MockProductUsecase) ExportProducts(filter usecase.ProductListFilter, fn func(p *entity.Product) error) error {
+	args := m.Called(filter, fn)
+	return args.Error(0)
 }
 
 // CreateProduct mock method
-func (m *MockProductUsecase) CreateProduct(name string) (*entity.Product, error) {
-	args := m.Called(name)
+func (m *MockProductUsecase) CreateProduct(input usecase.ProductInput, origin entity.ChangeOrigin) (*entity.Product, error) {
+	args := m.Called(input, origin)
 	if args.Get(0) != nil {
 		return args.Get(0).(*entity.Product), args.Error(1)
 	}
@@ -53,10 +62,151 @@ func (m *MockProductUsecase) GetProductByID(id uint) (*entity.Product, error) {
 }
 
 // UpdateProductName mock method
-func (m *MockProductUsecase) UpdateProductName(id uint, name string) (*entity.Product, error) {
-	args := m.Called(id, name)
+func (m *MockProductUsecase) UpdateProductName(id uint, name string, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	args := m.Called(id, name, version, origin)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Product), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// PatchProduct mock method
+func (m *MockProductUsecase) PatchProduct(id uint, patch usecase.ProductPatch, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	args := m.Called(id, patch, version, origin)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Product), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetProductIncludingDeleted mock method
+func (m *MockProductUsecase) GetProductIncludingDeleted(id uint) (*entity.Product, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Product), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// DeleteProduct mock method
+func (m *MockProductUsecase) DeleteProduct(id uint, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	args := m.Called(id, version, origin)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Product), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// RestoreProduct mock method
+func (m *MockProductUsecase) RestoreProduct(id uint, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	args := m.Called(id, version, origin)
 	if args.Get(0) != nil {
 		return args.Get(0).(*entity.Product), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
+
+// GetProductHistory mock method
+func (m *MockProductUsecase) GetProductHistory(filter usecase.ProductAuditFilter) ([]*entity.ProductAuditRecord, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*entity.ProductAuditRecord), args.Get(1).(int64), args.Error(2)
+	}
+	return nil, args.Get(1).(int64), args.Error(2)
+}
+
+// GetProductAsOf mock method
+func (m *MockProductUsecase) GetProductAsOf(id uint, at time.Time) (*entity.Product, error) {
+	args := m.Called(id, at)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Product), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// AssignCategory mock method
+func (m *MockProductUsecase) AssignCategory(id uint, categoryID uint, version int64, origin entity.ChangeOrigin) (*entity.Product, error) {
+	args := m.Called(id, categoryID, version, origin)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Product), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetProductVariants mock method
+func (m *MockProductUsecase) GetProductVariants(id uint) (*usecase.ProductVariants, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*usecase.ProductVariants), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetProductBySKU mock method
+func (m *MockProductUsecase) GetProductBySKU(sku string) (*entity.Product, error) {
+	args := m.Called(sku)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Product), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetSKUAliases mock method
+func (m *MockProductUsecase) GetSKUAliases(id uint) ([]*entity.SKUAlias, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*entity.SKUAlias), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ChangeSKU mock method
+func (m *MockProductUsecase) ChangeSKU(id uint, sku string, version int64, origin entity.ChangeOrigin) (*entity.Product, []*entity.SKUAlias, error) {
+	args := m.Called(id, sku, version, origin)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Product), args.Get(1).([]*entity.SKUAlias), args.Error(2)
+	}
+	return nil, nil, args.Error(2)
+}
+
+// GetProductByBarcode mock method
+func (m *MockProductUsecase) GetProductByBarcode(code string) (*entity.Product, error) {
+	args := m.Called(code)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Product), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetBarcodes mock method
+func (m *MockProductUsecase) GetBarcodes(id uint) ([]*entity.Barcode, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*entity.Barcode), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// AddBarcode mock method
+func (m *MockProductUsecase) AddBarcode(id uint, code string) (*entity.Barcode, error) {
+	args := m.Called(id, code)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Barcode), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// RemoveBarcode mock method
+func (m *MockProductUsecase) RemoveBarcode(id uint, code string) error {
+	args := m.Called(id, code)
+	return args.Error(0)
+}
+
+// GetProductLabel mock method
+func (m *MockProductUsecase) GetProductLabel(id uint) (*label.Label, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*label.Label), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
@@ -45,14 +46,31 @@ var _ = ginkgo.Describe("UpdateProductName E2E Tests", func() {
 		return uint(response["id"].(float64)) // Return the created product ID
 	}
 
+	// rename sends a name update of the created product based on the version named by ifMatch
+	rename := func(ifMatch string, name string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"name": name})
+		id := strconv.Itoa(int(createdProductID))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		c.Request = httptest.NewRequest("PUT", "/api/v1/products/"+id, bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			c.Request.Header.Set("If-Match", ifMatch)
+		}
+
+		productHandler.UpdateProductName(c)
+		return w
+	}
+
 	ginkgo.BeforeEach(func() {
 		// Initialize test environment
 		database, sqlDB = db.InitDB(true) // Assuming `true` loads the test environment
 		TruncateTables(database)          // Clean up before each test
 
 		// Initialize the handler with a real database connection (no mocks)
-		productRepo := repository.NewPostgresProductRepository(database)
-		productUsecase := usecase.NewProductUsecase(productRepo)
+		productUsecase := usecase.NewProductUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database))
 		productHandler = handler.NewProductHandler(productUsecase)
 
 		// Create a product before testing updates
@@ -76,6 +94,7 @@ var _ = ginkgo.Describe("UpdateProductName E2E Tests", func() {
 			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(createdProductID))}}
 			c.Request = httptest.NewRequest("PUT", "/api/v1/products/"+strconv.Itoa(int(createdProductID)), bytes.NewBuffer(body))
 			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set("If-Match", `"1"`)
 
 			// Call the handler to update the product
 			productHandler.UpdateProductName(c)
@@ -88,6 +107,51 @@ var _ = ginkgo.Describe("UpdateProductName E2E Tests", func() {
 			gomega.Expect(response["name"]).To(gomega.Equal("Updated Name"))
 		})
 
+		ginkgo.It("should send the product version as its ETag", func() {
+			id := strconv.Itoa(int(createdProductID))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: id}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/"+id, nil)
+			productHandler.GetProduct(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(w.Header().Get("ETag")).To(gomega.Equal(`"1"`))
+
+			w = rename(`"1"`, "Updated Name")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(w.Header().Get("ETag")).To(gomega.Equal(`"2"`))
+		})
+
+		ginkgo.It("should return 412 when the product changed since it was read", func() {
+			gomega.Expect(rename(`"1"`, "First Edit").Code).To(gomega.Equal(http.StatusOK))
+
+			w := rename(`"1"`, "Second Edit")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionFailed))
+			var response map[string]interface{}
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+			gomega.Expect(response["errors"]).To(gomega.Equal("product was changed since it was read"))
+
+			gomega.Expect(rename(`"2"`, "Second Edit").Code).To(gomega.Equal(http.StatusOK))
+		})
+
+		ginkgo.It("should return 412 for an If-Match that names no version", func() {
+			for _, ifMatch := range []string{`W/"1"`, `"1", "2"`, "1", `"latest"`} {
+				gomega.Expect(rename(ifMatch, "Updated Name").Code).To(gomega.Equal(http.StatusPreconditionFailed), ifMatch)
+			}
+		})
+
+		ginkgo.It("should update any version when If-Match is a wildcard", func() {
+			gomega.Expect(rename(`"1"`, "First Edit").Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(rename("*", "Second Edit").Code).To(gomega.Equal(http.StatusOK))
+		})
+
+		ginkgo.It("should return 428 without an If-Match header", func() {
+			w := rename("", "Updated Name")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusPreconditionRequired))
+		})
+
 		ginkgo.It("should return 422 if the request body validation fails", func() {
 			// Create an invalid request body (empty name)
 			reqBody := map[string]string{"name": ""}
@@ -98,6 +162,7 @@ var _ = ginkgo.Describe("UpdateProductName E2E Tests", func() {
 			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(createdProductID))}}
 			c.Request = httptest.NewRequest("PUT", "/api/v1/products/"+strconv.Itoa(int(createdProductID)), bytes.NewBuffer(body))
 			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set("If-Match", `"1"`)
 
 			// Call the handler to update the product
 			productHandler.UpdateProductName(c)
@@ -122,6 +187,7 @@ var _ = ginkgo.Describe("UpdateProductName E2E Tests", func() {
 			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(nonExistentProductID))}}
 			c.Request = httptest.NewRequest("PUT", "/api/v1/products/"+strconv.Itoa(int(nonExistentProductID)), bytes.NewBuffer(body))
 			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set("If-Match", `"1"`)
 
 			// Call the handler to update the product
 			productHandler.UpdateProductName(c)
@@ -144,6 +210,7 @@ var _ = ginkgo.Describe("UpdateProductName E2E Tests", func() {
 			c.Params = gin.Params{{Key: "id", Value: "invalid-id"}} // Invalid product ID
 			c.Request = httptest.NewRequest("PUT", "/api/v1/products/invalid-id", bytes.NewBuffer(body))
 			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set("If-Match", `"1"`)
 
 			// Call the handler to update the product
 			productHandler.UpdateProductName(c)
@@ -162,7 +229,7 @@ var _ = ginkgo.Describe("UpdateProductName E2E Tests", func() {
 			productHandler := handler.NewProductHandler(mockUsecase)
 
 			// Simulate the use case returning an error
-			mockUsecase.On("UpdateProductName", createdProductID, "Error Case").Return(nil, errors.New("internal server error"))
+			mockUsecase.On("UpdateProductName", createdProductID, "Error Case", int64(1), mock.Anything).Return(nil, errors.New("internal server error"))
 
 			// Create the request body
 			reqBody := map[string]string{"name": "Error Case"}
@@ -174,6 +241,7 @@ var _ = ginkgo.Describe("UpdateProductName E2E Tests", func() {
 			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(createdProductID))}}
 			c.Request = httptest.NewRequest("PUT", "/api/v1/products/"+strconv.Itoa(int(createdProductID)), bytes.NewBuffer(body))
 			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set("If-Match", `"1"`)
 
 			// Call the handler
 			productHandler.UpdateProductName(c)
@@ -197,6 +265,7 @@ var _ = ginkgo.Describe("UpdateProductName E2E Tests", func() {
 		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(createdProductID))}}
 		c.Request = httptest.NewRequest("PUT", "/api/v1/products/"+strconv.Itoa(int(createdProductID)), bytes.NewBuffer(invalidJSON))
 		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("If-Match", `"1"`)
 
 		// Call the handler to update the product name
 		productHandler.UpdateProductName(c)
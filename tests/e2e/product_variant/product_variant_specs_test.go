@@ -0,0 +1,142 @@
+package product_variant_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Product Variant E2E Tests", func() {
+	var variantHandler *handler.ProductVariantHandler
+	var productHandler *handler.ProductHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var parentID uint
+
+	// generate posts option axes for the parent product and returns the recorder
+	generate := func(productID uint, options interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(map[string]interface{}{"options": options})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+		c.Request = httptest.NewRequest("POST", "/api/v1/products/"+strconv.Itoa(int(productID))+"/variants/generate", bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		variantHandler.GenerateVariants(c)
+		return w
+	}
+
+	// getProduct fetches a product and decodes the response
+	getProduct := func(productID uint) *dto.ProductResponse {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+		c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(productID)), nil)
+
+		productHandler.GetProduct(c)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+		var response dto.ProductResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return &response
+	}
+
+	sizeAndColour := []map[string]interface{}{
+		{"name": "Size", "values": []string{"S", "M", "L"}},
+		{"name": "Colour", "values": []string{"Red", "Blue"}},
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		variantHandler = handler.NewProductVariantHandler(usecase.NewProductVariantUsecase(repos, uow))
+		productHandler = handler.NewProductHandler(usecase.NewProductUsecase(repos, uow))
+
+		parent, err := entity.NewProduct("Shirt")
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Expect(repos.Products.Save(parent)).To(gomega.Succeed())
+		parentID = parent.ID()
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("POST /products/:id/variants/generate", func() {
+		ginkgo.It("should create a variant for every combination of options", func() {
+			w := generate(parentID, sizeAndColour)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			var response dto.ProductResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+			gomega.Expect(response.Variants).To(gomega.HaveLen(6))
+			gomega.Expect(response.Variants[0].Name).To(gomega.Equal("Shirt - S / Red"))
+			gomega.Expect(*response.Variants[0].ParentID).To(gomega.Equal(parentID))
+			gomega.Expect(response.Variants[0].Options).To(gomega.HaveLen(2))
+
+			skus := map[string]bool{response.SKU: true}
+			for _, variant := range response.Variants {
+				skus[variant.SKU] = true
+			}
+			gomega.Expect(skus).To(gomega.HaveLen(7))
+		})
+
+		ginkgo.It("should only add the combinations that are missing when regenerating", func() {
+			gomega.Expect(generate(parentID, sizeAndColour).Code).To(gomega.Equal(http.StatusOK))
+
+			w := generate(parentID, []map[string]interface{}{
+				{"name": "size", "values": []string{"M", "XL"}},
+				{"name": "colour", "values": []string{"red", "Blue"}},
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			gomega.Expect(getProduct(parentID).Variants).To(gomega.HaveLen(8))
+		})
+
+		ginkgo.It("should reject axes that differ from the existing variants", func() {
+			gomega.Expect(generate(parentID, sizeAndColour).Code).To(gomega.Equal(http.StatusOK))
+
+			w := generate(parentID, []map[string]interface{}{{"name": "Material", "values": []string{"Cotton"}}})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should not generate variants of a variant", func() {
+			gomega.Expect(generate(parentID, sizeAndColour).Code).To(gomega.Equal(http.StatusOK))
+			variantID := getProduct(parentID).Variants[0].ID
+
+			w := generate(variantID, []map[string]interface{}{{"name": "Fit", "values": []string{"Slim"}}})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+
+			variant := getProduct(variantID)
+			gomega.Expect(*variant.ParentID).To(gomega.Equal(parentID))
+			gomega.Expect(variant.Variants).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("should return 404 for an unknown product", func() {
+			gomega.Expect(generate(999, sizeAndColour).Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should return 422 when no options are given", func() {
+			gomega.Expect(generate(parentID, []map[string]interface{}{}).Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+})
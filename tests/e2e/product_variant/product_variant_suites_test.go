@@ -0,0 +1,19 @@
+package product_variant_e2e_test
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+func TestProductVariantE2E(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "E2E Product Variant Handler Suite")
+}
+
+// Helper function to truncate tables between tests
+func TruncateTables(database *gorm.DB) {
+	database.Exec("TRUNCATE TABLE product_variant_options, product_variants, stock_movements, stock_levels, warehouses, products RESTART IDENTITY CASCADE;")
+}
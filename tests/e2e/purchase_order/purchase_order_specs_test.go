@@ -0,0 +1,173 @@
+package purchase_order_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Purchase Order E2E Tests", func() {
+	var supplierHandler *handler.SupplierHandler
+	var purchaseOrderHandler *handler.PurchaseOrderHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID, warehouseID, supplierID uint
+
+	// post sends a JSON body to a handler and returns the recorder
+	post := func(handlerFunc gin.HandlerFunc, path string, params gin.Params, body interface{}) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = params
+		if body != nil {
+			payload, _ := json.Marshal(body)
+			c.Request = httptest.NewRequest("POST", path, bytes.NewBuffer(payload))
+			c.Request.Header.Set("Content-Type", "application/json")
+		} else {
+			c.Request = httptest.NewRequest("POST", path, nil)
+		}
+
+		handlerFunc(c)
+		return w
+	}
+
+	// decode reads a JSON object response body
+	decode := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var response map[string]interface{}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		return response
+	}
+
+	// createOrder drafts a purchase order for quantity units at 1250 minor units each
+	createOrder := func(quantity int64) uint {
+		w := post(purchaseOrderHandler.CreatePurchaseOrder, "/api/v1/purchase-orders", nil, map[string]interface{}{
+			"supplier_id":  supplierID,
+			"warehouse_id": warehouseID,
+			"currency":     "IDR",
+			"lines":        []map[string]interface{}{{"product_id": productID, "quantity": quantity, "unit_cost": 1250}},
+		})
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+		return uint(decode(w)["id"].(float64))
+	}
+
+	// action runs a purchase order state transition
+	action := func(handlerFunc gin.HandlerFunc, id uint, name string, body interface{}) *httptest.ResponseRecorder {
+		path := "/api/v1/purchase-orders/" + strconv.Itoa(int(id)) + "/" + name
+		return post(handlerFunc, path, gin.Params{{Key: "id", Value: strconv.Itoa(int(id))}}, body)
+	}
+
+	// onHand reads the on-hand quantity of the seeded product
+	onHand := func() int64 {
+		var level model.StockLevel
+		database.Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).First(&level)
+		return level.OnHand
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		supplierHandler = handler.NewSupplierHandler(usecase.NewSupplierUsecase(repos.Suppliers))
+		purchaseOrderHandler = handler.NewPurchaseOrderHandler(usecase.NewPurchaseOrderUsecase(repos, uow))
+		productID, warehouseID, supplierID = seedPurchasing(repos)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("POST /suppliers", func() {
+		ginkgo.It("should return 409 for a duplicate supplier code", func() {
+			w := post(supplierHandler.CreateSupplier, "/api/v1/suppliers", nil, map[string]interface{}{
+				"code": "acme01",
+				"name": "Another Acme",
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+		})
+	})
+
+	ginkgo.Context("POST /purchase-orders", func() {
+		ginkgo.It("should draft an order with its total in minor units", func() {
+			w := post(purchaseOrderHandler.CreatePurchaseOrder, "/api/v1/purchase-orders", nil, map[string]interface{}{
+				"supplier_id":  supplierID,
+				"warehouse_id": warehouseID,
+				"currency":     "IDR",
+				"lines":        []map[string]interface{}{{"product_id": productID, "quantity": 4, "unit_cost": 1250}},
+			})
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			response := decode(w)
+			gomega.Expect(response["status"]).To(gomega.Equal("draft"))
+			gomega.Expect(response["total"]).To(gomega.BeEquivalentTo(5000))
+		})
+
+		ginkgo.It("should return 422 for an unknown supplier", func() {
+			w := post(purchaseOrderHandler.CreatePurchaseOrder, "/api/v1/purchase-orders", nil, map[string]interface{}{
+				"supplier_id":  999,
+				"warehouse_id": warehouseID,
+				"currency":     "IDR",
+				"lines":        []map[string]interface{}{{"product_id": productID, "quantity": 1, "unit_cost": 1}},
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	ginkgo.Context("Goods receipt", func() {
+		ginkgo.It("should increase stock as goods arrive and close the order", func() {
+			id := createOrder(10)
+
+			w := action(purchaseOrderHandler.ReceivePurchaseOrder, id, "receive", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+
+			w = action(purchaseOrderHandler.SubmitPurchaseOrder, id, "submit", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			w = action(purchaseOrderHandler.ReceivePurchaseOrder, id, "receive", map[string]interface{}{
+				"lines": []map[string]interface{}{{"product_id": productID, "quantity": 4}},
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(decode(w)["status"]).To(gomega.Equal("partially_received"))
+			gomega.Expect(onHand()).To(gomega.Equal(int64(4)))
+
+			w = action(purchaseOrderHandler.ReceivePurchaseOrder, id, "receive", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(decode(w)["status"]).To(gomega.Equal("received"))
+			gomega.Expect(onHand()).To(gomega.Equal(int64(10)))
+
+			w = action(purchaseOrderHandler.ClosePurchaseOrder, id, "close", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(decode(w)["status"]).To(gomega.Equal("closed"))
+
+			var receipts int64
+			database.Model(&model.StockMovement{}).Where("movement_type = ? AND reference = ?", "receipt", "purchase_order:"+strconv.Itoa(int(id))).Count(&receipts)
+			gomega.Expect(receipts).To(gomega.Equal(int64(2)))
+		})
+
+		ginkgo.It("should reject receiving more than was ordered", func() {
+			id := createOrder(3)
+			action(purchaseOrderHandler.SubmitPurchaseOrder, id, "submit", nil)
+
+			w := action(purchaseOrderHandler.ReceivePurchaseOrder, id, "receive", map[string]interface{}{
+				"lines": []map[string]interface{}{{"product_id": productID, "quantity": 4}},
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			gomega.Expect(onHand()).To(gomega.Equal(int64(0)))
+		})
+	})
+})
@@ -0,0 +1,38 @@
+package purchase_order_e2e_test
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+func TestPurchaseOrderE2E(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "E2E Purchase Order Handler Suite")
+}
+
+// Helper function to truncate tables between tests
+func TruncateTables(database *gorm.DB) {
+	database.Exec("TRUNCATE TABLE purchase_order_lines, purchase_orders, suppliers, stock_movements, stock_levels, warehouses, products RESTART IDENTITY CASCADE;")
+}
+
+// seedPurchasing creates a product, a warehouse and a supplier directly through the repositories
+func seedPurchasing(repos repository.Repositories) (uint, uint, uint) {
+	product, err := entity.NewProduct("Purchased Product")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Products.Save(product)).To(gomega.Succeed())
+
+	warehouse, err := entity.NewWarehouse("JKT01", "Jakarta Main")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Warehouses.Save(warehouse)).To(gomega.Succeed())
+
+	supplier, err := entity.NewSupplier("ACME01", "Acme Trading", "orders@acme.test")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Suppliers.Save(supplier)).To(gomega.Succeed())
+
+	return product.ID(), warehouse.ID(), supplier.ID()
+}
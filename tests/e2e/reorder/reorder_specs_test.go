@@ -0,0 +1,176 @@
+package reorder_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Reorder E2E Tests", func() {
+	var reorderHandler *handler.ReorderHandler
+	var reorderUsecase usecase.ReorderUsecase
+	var movementUsecase usecase.StockMovementUsecase
+	var repos repository.Repositories
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID, warehouseID uint
+
+	// setPolicy sets the reorder policy of the seeded product and returns the recorder
+	setPolicy := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		body["product_id"] = productID
+		body["warehouse_id"] = warehouseID
+		payload, _ := json.Marshal(body)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("PUT", "/api/v1/reorder-policies", bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		reorderHandler.SetReorderPolicy(c)
+		return w
+	}
+
+	// listAlerts lists low-stock alerts with the given query string
+	listAlerts := func(query string) map[string]interface{} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/v1/alerts/low-stock"+query, nil)
+
+		reorderHandler.GetLowStockAlertList(c)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+		var response map[string]interface{}
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return response
+	}
+
+	// receive books quantity units of the seeded product into the seeded warehouse
+	receive := func(quantity int64) {
+		_, err := movementUsecase.RecordMovement(usecase.StockMovementInput{
+			ProductID:   productID,
+			WarehouseID: warehouseID,
+			Type:        entity.MovementTypeReceipt,
+			Quantity:    quantity,
+			ReasonCode:  "RESTOCK",
+			Actor:       "test",
+		})
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos = repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		reorderUsecase = usecase.NewReorderUsecase(repos, uow, "USD")
+		reorderHandler = handler.NewReorderHandler(reorderUsecase)
+		movementUsecase = usecase.NewStockMovementUsecase(repos, uow)
+		productID, warehouseID = seedStock(repos, uow, 5)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("PUT /reorder-policies", func() {
+		ginkgo.It("should create and then replace the policy", func() {
+			w := setPolicy(map[string]interface{}{"reorder_point": 10, "reorder_quantity": 50, "safety_stock": 2})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			w = setPolicy(map[string]interface{}{"reorder_point": 20, "reorder_quantity": 40})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			policies, err := reorderUsecase.GetProductReorderPolicies(productID)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(policies).To(gomega.HaveLen(1))
+			gomega.Expect(policies[0].ReorderPoint()).To(gomega.Equal(int64(20)))
+		})
+
+		ginkgo.It("should reject safety stock above the reorder point", func() {
+			w := setPolicy(map[string]interface{}{"reorder_point": 10, "reorder_quantity": 50, "safety_stock": 11})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should reject an unknown preferred supplier", func() {
+			w := setPolicy(map[string]interface{}{"reorder_point": 10, "reorder_quantity": 50, "preferred_supplier_id": 999})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	ginkgo.Context("Low-stock evaluation", func() {
+		ginkgo.It("should raise one alert and resolve it once stock recovers", func() {
+			gomega.Expect(setPolicy(map[string]interface{}{"reorder_point": 10, "reorder_quantity": 50}).Code).To(gomega.Equal(http.StatusOK))
+
+			evaluation, err := reorderUsecase.EvaluateLowStock(time.Now())
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(evaluation.Raised).To(gomega.Equal(1))
+
+			// A second pass must not raise a duplicate alert
+			evaluation, err = reorderUsecase.EvaluateLowStock(time.Now())
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(evaluation.Raised).To(gomega.Equal(0))
+
+			response := listAlerts("?status=open")
+			gomega.Expect(response["total"]).To(gomega.BeEquivalentTo(1))
+			alert := response["low_stock_alerts"].([]interface{})[0].(map[string]interface{})
+			gomega.Expect(alert["available"]).To(gomega.BeEquivalentTo(5))
+			gomega.Expect(alert["purchase_order_id"]).To(gomega.BeNil())
+
+			receive(5)
+			evaluation, err = reorderUsecase.EvaluateLowStock(time.Now())
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(evaluation.Resolved).To(gomega.Equal(1))
+			gomega.Expect(listAlerts("?status=open")["total"]).To(gomega.BeEquivalentTo(0))
+			gomega.Expect(listAlerts("?status=resolved")["total"]).To(gomega.BeEquivalentTo(1))
+		})
+
+		ginkgo.It("should draft a purchase order with the preferred supplier", func() {
+			supplier, err := entity.NewSupplier("ACME", "Acme Supplies", "")
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(repos.Suppliers.Save(supplier)).To(gomega.Succeed())
+
+			w := setPolicy(map[string]interface{}{"reorder_point": 10, "reorder_quantity": 50, "preferred_supplier_id": supplier.ID()})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			_, err = reorderUsecase.EvaluateLowStock(time.Now())
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+			alert := listAlerts("")["low_stock_alerts"].([]interface{})[0].(map[string]interface{})
+			purchaseOrderID := uint(alert["purchase_order_id"].(float64))
+
+			var order model.PurchaseOrder
+			gomega.Expect(database.First(&order, purchaseOrderID).Error).To(gomega.Succeed())
+			gomega.Expect(order.Status).To(gomega.Equal("draft"))
+			gomega.Expect(order.SupplierID).To(gomega.Equal(supplier.ID()))
+
+			var line model.PurchaseOrderLine
+			gomega.Expect(database.Where("purchase_order_id = ?", purchaseOrderID).First(&line).Error).To(gomega.Succeed())
+			gomega.Expect(line.Quantity).To(gomega.Equal(int64(50)))
+		})
+
+		ginkgo.It("should reject an unknown status filter", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/api/v1/alerts/low-stock?status=closed", nil)
+
+			reorderHandler.GetLowStockAlertList(c)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+})
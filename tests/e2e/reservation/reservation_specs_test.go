@@ -0,0 +1,201 @@
+package reservation_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Reservation E2E Tests", func() {
+	var reservationHandler *handler.ReservationHandler
+	var reservationUsecase usecase.ReservationUsecase
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID, warehouseID uint
+
+	// reserve posts a reservation request and returns the recorder
+	reserve := func(quantity int64, ttlSeconds int) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"product_id":   productID,
+			"warehouse_id": warehouseID,
+			"quantity":     quantity,
+			"reference":    "ORDER-1",
+			"ttl_seconds":  ttlSeconds,
+		})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/reservations", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		reservationHandler.CreateReservation(c)
+		return w
+	}
+
+	// transition calls a reservation state transition handler
+	transition := func(handlerFunc gin.HandlerFunc, id uint, action string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(id))}}
+		c.Request = httptest.NewRequest("POST", "/api/v1/reservations/"+strconv.Itoa(int(id))+"/"+action, nil)
+
+		handlerFunc(c)
+		return w
+	}
+
+	// stockLevel reads the stock level of the seeded product straight from the database
+	stockLevel := func() model.StockLevel {
+		var level model.StockLevel
+		database.Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).First(&level)
+		return level
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		reservationUsecase = usecase.NewReservationUsecase(repos, uow, 15*time.Minute)
+		reservationHandler = handler.NewReservationHandler(reservationUsecase)
+		productID, warehouseID = seedStock(repos, uow, 10)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("POST /reservations", func() {
+		ginkgo.It("should reserve available stock", func() {
+			w := reserve(4, 0)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			var response map[string]interface{}
+			err := json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(response["status"]).To(gomega.Equal("pending"))
+			gomega.Expect(stockLevel().Reserved).To(gomega.Equal(int64(4)))
+		})
+
+		ginkgo.It("should return 409 when the quantity is not available", func() {
+			reserve(8, 0)
+			w := reserve(3, 0)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+			gomega.Expect(stockLevel().Reserved).To(gomega.Equal(int64(8)))
+		})
+
+		ginkgo.It("should never over-commit stock under concurrent reservations", func() {
+			var wg sync.WaitGroup
+			codes := make(chan int, 5)
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer ginkgo.GinkgoRecover()
+					codes <- reserve(3, 0).Code
+				}()
+			}
+			wg.Wait()
+			close(codes)
+
+			created := 0
+			for code := range codes {
+				if code == http.StatusCreated {
+					created++
+				}
+			}
+			gomega.Expect(created).To(gomega.Equal(3))
+			gomega.Expect(stockLevel().Reserved).To(gomega.Equal(int64(9)))
+		})
+	})
+
+	ginkgo.Context("POST /reservations/:id/confirm and /release", func() {
+		ginkgo.It("should issue the reserved quantity when confirmed", func() {
+			var created map[string]interface{}
+			_ = json.NewDecoder(reserve(4, 0).Body).Decode(&created)
+			id := uint(created["id"].(float64))
+
+			w := transition(reservationHandler.ConfirmReservation, id, "confirm")
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			level := stockLevel()
+			gomega.Expect(level.OnHand).To(gomega.Equal(int64(6)))
+			gomega.Expect(level.Reserved).To(gomega.Equal(int64(0)))
+
+			var issues int64
+			database.Model(&model.StockMovement{}).Where("movement_type = ?", "issue").Count(&issues)
+			gomega.Expect(issues).To(gomega.Equal(int64(1)))
+		})
+
+		ginkgo.It("should still confirm a reservation of a product deleted since", func() {
+			var created map[string]interface{}
+			_ = json.NewDecoder(reserve(4, 0).Body).Decode(&created)
+			id := uint(created["id"].(float64))
+
+			productUsecase := usecase.NewProductUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database))
+			_, err := productUsecase.DeleteProduct(productID, 0, entity.ChangeOrigin{Actor: "test"})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			w := transition(reservationHandler.ConfirmReservation, id, "confirm")
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			level := stockLevel()
+			gomega.Expect(level.OnHand).To(gomega.Equal(int64(6)))
+			gomega.Expect(level.Reserved).To(gomega.Equal(int64(0)))
+		})
+
+		ginkgo.It("should make the quantity available again when released", func() {
+			var created map[string]interface{}
+			_ = json.NewDecoder(reserve(4, 0).Body).Decode(&created)
+			id := uint(created["id"].(float64))
+
+			w := transition(reservationHandler.ReleaseReservation, id, "release")
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(stockLevel().Reserved).To(gomega.Equal(int64(0)))
+
+			w = transition(reservationHandler.ConfirmReservation, id, "confirm")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+		})
+
+		ginkgo.It("should return 404 for an unknown reservation", func() {
+			w := transition(reservationHandler.ConfirmReservation, 999, "confirm")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+	})
+
+	ginkgo.Context("Expiry", func() {
+		ginkgo.It("should release the stock of reservations whose TTL elapsed", func() {
+			var created map[string]interface{}
+			_ = json.NewDecoder(reserve(4, 1).Body).Decode(&created)
+			id := uint(created["id"].(float64))
+
+			expired, err := reservationUsecase.ExpireReservations(time.Now().Add(2 * time.Second))
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(expired).To(gomega.Equal(1))
+			gomega.Expect(stockLevel().Reserved).To(gomega.Equal(int64(0)))
+
+			reservation, err := reservationUsecase.GetReservationByID(id)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(string(reservation.Status())).To(gomega.Equal("expired"))
+		})
+	})
+})
@@ -0,0 +1,45 @@
+package reservation_e2e_test
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+func TestReservationE2E(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "E2E Reservation Handler Suite")
+}
+
+// Helper function to truncate tables between tests
+func TruncateTables(database *gorm.DB) {
+	database.Exec("TRUNCATE TABLE reservations, stock_movements, stock_levels, warehouses, products RESTART IDENTITY CASCADE;")
+}
+
+// seedStock creates a product and a warehouse and receives the given on-hand quantity through the ledger
+func seedStock(repos repository.Repositories, uow repository.UnitOfWork, onHand int64) (uint, uint) {
+	product, err := entity.NewProduct("Reserved Product")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Products.Save(product)).To(gomega.Succeed())
+
+	warehouse, err := entity.NewWarehouse("JKT01", "Jakarta Main")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Warehouses.Save(warehouse)).To(gomega.Succeed())
+
+	_, err = usecase.NewStockMovementUsecase(repos, uow).RecordMovement(usecase.StockMovementInput{
+		ProductID:   product.ID(),
+		WarehouseID: warehouse.ID(),
+		Type:        entity.MovementTypeReceipt,
+		Quantity:    onHand,
+		ReasonCode:  "SEED",
+		Actor:       "test",
+	})
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	return product.ID(), warehouse.ID()
+}
@@ -0,0 +1,157 @@
+package sales_order_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Sales Order E2E Tests", func() {
+	var salesOrderHandler *handler.SalesOrderHandler
+	var movementUsecase usecase.StockMovementUsecase
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID, warehouseID uint
+
+	// createOrder places an order for quantity units and returns the recorder
+	createOrder := func(quantity int64) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"warehouse_id":       warehouseID,
+			"customer_reference": "WEB-1",
+			"lines":              []map[string]interface{}{{"product_id": productID, "quantity": quantity}},
+		})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/sales-orders", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		salesOrderHandler.CreateSalesOrder(c)
+		return w
+	}
+
+	// step runs a fulfilment step of a sales order
+	step := func(handlerFunc gin.HandlerFunc, id uint, action string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(id))}}
+		c.Request = httptest.NewRequest("POST", "/api/v1/sales-orders/"+strconv.Itoa(int(id))+"/"+action, nil)
+
+		handlerFunc(c)
+		return w
+	}
+
+	// decode reads a JSON object response body
+	decode := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var response map[string]interface{}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		return response
+	}
+
+	// stockLevel reads the stock level of the seeded product straight from the database
+	stockLevel := func() model.StockLevel {
+		var level model.StockLevel
+		database.Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).First(&level)
+		return level
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		salesOrderHandler = handler.NewSalesOrderHandler(usecase.NewSalesOrderUsecase(repos, uow))
+		movementUsecase = usecase.NewStockMovementUsecase(repos, uow)
+		productID, warehouseID = seedStock(repos, uow, 10)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("POST /sales-orders", func() {
+		ginkgo.It("should allocate available stock", func() {
+			w := createOrder(4)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(decode(w)["status"]).To(gomega.Equal("allocated"))
+			gomega.Expect(stockLevel().Reserved).To(gomega.Equal(int64(4)))
+		})
+
+		ginkgo.It("should backorder what is short and allocate it after a receipt", func() {
+			w := createOrder(13)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			response := decode(w)
+			gomega.Expect(response["status"]).To(gomega.Equal("backordered"))
+			gomega.Expect(response["backordered"]).To(gomega.BeEquivalentTo(3))
+			id := uint(response["id"].(float64))
+
+			_, err := movementUsecase.RecordMovement(usecase.StockMovementInput{
+				ProductID:   productID,
+				WarehouseID: warehouseID,
+				Type:        entity.MovementTypeReceipt,
+				Quantity:    5,
+				ReasonCode:  "RESTOCK",
+				Actor:       "test",
+			})
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+			w = step(salesOrderHandler.AllocateSalesOrder, id, "allocate")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(decode(w)["status"]).To(gomega.Equal("allocated"))
+			gomega.Expect(stockLevel().Reserved).To(gomega.Equal(int64(13)))
+		})
+	})
+
+	ginkgo.Context("Fulfilment", func() {
+		ginkgo.It("should decrement on-hand only when the order ships", func() {
+			id := uint(decode(createOrder(4))["id"].(float64))
+
+			gomega.Expect(step(salesOrderHandler.ShipSalesOrder, id, "ship").Code).To(gomega.Equal(http.StatusConflict))
+			gomega.Expect(step(salesOrderHandler.PickSalesOrder, id, "pick").Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(step(salesOrderHandler.PackSalesOrder, id, "pack").Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(stockLevel().OnHand).To(gomega.Equal(int64(10)))
+
+			w := step(salesOrderHandler.ShipSalesOrder, id, "ship")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(decode(w)["status"]).To(gomega.Equal("shipped"))
+			level := stockLevel()
+			gomega.Expect(level.OnHand).To(gomega.Equal(int64(6)))
+			gomega.Expect(level.Reserved).To(gomega.Equal(int64(0)))
+		})
+
+		ginkgo.It("should not pick a backordered order", func() {
+			id := uint(decode(createOrder(12))["id"].(float64))
+			gomega.Expect(step(salesOrderHandler.PickSalesOrder, id, "pick").Code).To(gomega.Equal(http.StatusConflict))
+		})
+
+		ginkgo.It("should release the allocation when cancelled", func() {
+			id := uint(decode(createOrder(4))["id"].(float64))
+
+			w := step(salesOrderHandler.CancelSalesOrder, id, "cancel")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(stockLevel().Reserved).To(gomega.Equal(int64(0)))
+		})
+
+		ginkgo.It("should return 404 for an unknown sales order", func() {
+			gomega.Expect(step(salesOrderHandler.PickSalesOrder, 999, "pick").Code).To(gomega.Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,182 @@
+package serial_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Serial E2E Tests", func() {
+	var movementHandler *handler.StockMovementHandler
+	var serialHandler *handler.SerialHandler
+	var transferUsecase usecase.TransferUsecase
+	var reservationUsecase usecase.ReservationUsecase
+	var repos repository.Repositories
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID, warehouseID uint
+
+	// recordMovement posts a movement naming the given serials and returns the recorder
+	recordMovement := func(movementType string, quantity int64, serials ...string) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"product_id":   productID,
+			"warehouse_id": warehouseID,
+			"type":         movementType,
+			"quantity":     quantity,
+			"reason_code":  "TEST",
+			"serials":      serials,
+		})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/stock-movements", bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		movementHandler.CreateStockMovement(c)
+		return w
+	}
+
+	// getSerial requests the location and history of a serial and returns the recorder
+	getSerial := func(serial string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/v1/serials/"+serial, nil)
+		c.Params = gin.Params{{Key: "serial", Value: serial}}
+
+		serialHandler.GetSerial(c)
+		return w
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos = repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		movementHandler = handler.NewStockMovementHandler(usecase.NewStockMovementUsecase(repos, uow))
+		serialHandler = handler.NewSerialHandler(usecase.NewSerialUsecase(repos))
+		transferUsecase = usecase.NewTransferUsecase(repos, uow)
+		reservationUsecase = usecase.NewReservationUsecase(repos, uow, time.Minute)
+		productID, warehouseID = seedSerializedProductAndWarehouse(repos)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("Serialized movements", func() {
+		ginkgo.It("should require one serial per moved unit", func() {
+			gomega.Expect(recordMovement("receipt", 2).Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			gomega.Expect(recordMovement("receipt", 2, "SN-1").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			gomega.Expect(recordMovement("receipt", 2, "SN-1", "SN-1").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			gomega.Expect(recordMovement("receipt", 2, "SN-1", "SN-2").Code).To(gomega.Equal(http.StatusCreated))
+		})
+
+		ginkgo.It("should only issue serials that are in stock", func() {
+			gomega.Expect(recordMovement("receipt", 1, "SN-1").Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(recordMovement("receipt", 1, "SN-1").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			gomega.Expect(recordMovement("issue", 1, "SN-9").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			gomega.Expect(recordMovement("issue", 1, "SN-1").Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(recordMovement("issue", 1, "SN-1").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	ginkgo.Context("Transfers and reservations", func() {
+		// serialStatus returns the status and warehouse of a unit as reported by the serial endpoint
+		serialStatus := func(serial string) (string, interface{}) {
+			var response map[string]interface{}
+			gomega.Expect(json.NewDecoder(getSerial(serial).Body).Decode(&response)).To(gomega.Succeed())
+			return response["status"].(string), response["warehouse_id"]
+		}
+
+		ginkgo.It("should move the named units with a transfer", func() {
+			destination, err := entity.NewWarehouse("SBY01", "Surabaya Main")
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(repos.Warehouses.Save(destination)).To(gomega.Succeed())
+			recordMovement("receipt", 2, "SN-1", "SN-2")
+
+			transfer, err := transferUsecase.CreateTransfer(usecase.TransferInput{
+				SourceWarehouseID:      warehouseID,
+				DestinationWarehouseID: destination.ID(),
+				Lines:                  []usecase.TransferLineInput{{ProductID: productID, Quantity: 2}},
+			})
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+			_, err = transferUsecase.DispatchTransfer(transfer.ID(), nil, "test")
+			gomega.Expect(err).To(gomega.MatchError(usecase.ErrInvalidInput))
+			_, err = transferUsecase.DispatchTransfer(transfer.ID(), []usecase.ShipmentLineInput{{ProductID: productID, Serials: []string{"SN-1", "SN-2"}}}, "test")
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			status, _ := serialStatus("SN-1")
+			gomega.Expect(status).To(gomega.Equal("in_transit"))
+
+			_, err = transferUsecase.ReceiveTransfer(transfer.ID(), []usecase.TransferLineInput{{ProductID: productID, Quantity: 1, Serials: []string{"SN-1"}}}, "test")
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			status, warehouse := serialStatus("SN-1")
+			gomega.Expect(status).To(gomega.Equal("in_stock"))
+			gomega.Expect(warehouse).To(gomega.BeEquivalentTo(destination.ID()))
+			status, _ = serialStatus("SN-2")
+			gomega.Expect(status).To(gomega.Equal("in_transit"))
+
+			// Closing the transfer writes off the unit that never arrived
+			_, err = transferUsecase.CloseTransfer(transfer.ID(), "LOST_IN_TRANSIT")
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			status, _ = serialStatus("SN-2")
+			gomega.Expect(status).To(gomega.Equal("issued"))
+			status, _ = serialStatus("SN-1")
+			gomega.Expect(status).To(gomega.Equal("in_stock"))
+		})
+
+		ginkgo.It("should issue the named units when a reservation is confirmed", func() {
+			recordMovement("receipt", 2, "SN-1", "SN-2")
+			reservation, err := reservationUsecase.Reserve(usecase.ReservationInput{ProductID: productID, WarehouseID: warehouseID, Quantity: 1})
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+			_, err = reservationUsecase.ConfirmReservation(reservation.ID(), nil, "test")
+			gomega.Expect(err).To(gomega.MatchError(usecase.ErrInvalidInput))
+			_, err = reservationUsecase.ConfirmReservation(reservation.ID(), []string{"SN-2"}, "test")
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+			status, _ := serialStatus("SN-2")
+			gomega.Expect(status).To(gomega.Equal("issued"))
+			status, _ = serialStatus("SN-1")
+			gomega.Expect(status).To(gomega.Equal("in_stock"))
+		})
+	})
+
+	ginkgo.Context("GET /serials/:serial", func() {
+		ginkgo.It("should return the location and history of a unit", func() {
+			recordMovement("receipt", 2, "SN-1", "SN-2")
+			recordMovement("issue", 1, "SN-1")
+
+			w := getSerial("SN-1")
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			var response map[string]interface{}
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+			gomega.Expect(response["status"]).To(gomega.Equal("issued"))
+			gomega.Expect(response["warehouse_id"]).To(gomega.BeNil())
+			history := response["history"].([]interface{})
+			gomega.Expect(history).To(gomega.HaveLen(2))
+			gomega.Expect(history[0].(map[string]interface{})["type"]).To(gomega.Equal("receipt"))
+			gomega.Expect(history[1].(map[string]interface{})["type"]).To(gomega.Equal("issue"))
+		})
+
+		ginkgo.It("should return 404 for an unknown serial", func() {
+			gomega.Expect(getSerial("UNKNOWN").Code).To(gomega.Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,35 @@
+package serial_e2e_test
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+func TestSerialE2E(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "E2E Serial Handler Suite")
+}
+
+// Helper function to truncate tables between tests
+func TruncateTables(database *gorm.DB) {
+	database.Exec("TRUNCATE TABLE serial_movements, serials, lots, reservations, transfer_lines, transfers, stock_movements, stock_levels, warehouses, products RESTART IDENTITY CASCADE;")
+}
+
+// seedSerializedProductAndWarehouse creates a serialized product and a warehouse directly through the repositories
+func seedSerializedProductAndWarehouse(repos repository.Repositories) (uint, uint) {
+	product, err := entity.NewProduct("Handheld Scanner")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	product.SetSerialized(true)
+	gomega.Expect(repos.Products.Save(product)).To(gomega.Succeed())
+
+	warehouse, err := entity.NewWarehouse("JKT01", "Jakarta Main")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Warehouses.Save(warehouse)).To(gomega.Succeed())
+
+	return product.ID(), warehouse.ID()
+}
@@ -0,0 +1,74 @@
+package sku_e2e_test
+
+import (
+	"database/sql"
+	"fmt"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/pkg/db"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("SKU Generation E2E Tests", func() {
+	var database *gorm.DB
+	var sqlDB *sql.DB
+
+	// newRepository returns a product repository generating SKUs with the given strategy
+	newRepository := func(strategy string, template string) repository.PostgresProductRepository {
+		generator, err := entity.NewSKUGenerator(strategy, template)
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		return repository.NewPostgresProductRepository(database, repository.WithSKUGenerator(generator))
+	}
+
+	// create saves a new product without a SKU and returns the generated one
+	create := func(repo repository.PostgresProductRepository, name string, categoryID uint) string {
+		product, err := entity.NewProductWithSKU(name, "")
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		product.SetCategory(categoryID)
+		gomega.Expect(repo.Save(product)).To(gomega.Succeed())
+		return product.SKU()
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.It("should number products sequentially per name prefix", func() {
+		repo := newRepository(entity.SKUStrategySequential, "")
+
+		gomega.Expect(create(repo, "Shirt", 0)).To(gomega.Equal("SKU-SHI-000001"))
+		gomega.Expect(create(repo, "Shield", 0)).To(gomega.Equal("SKU-SHI-000002"))
+		gomega.Expect(create(repo, "Boots", 0)).To(gomega.Equal("SKU-BOO-000001"))
+	})
+
+	ginkgo.It("should skip SKUs that are already taken", func() {
+		repo := newRepository(entity.SKUStrategySequential, "")
+
+		taken, err := entity.NewProductWithSKU("Imported shirt", "SKU-SHI-000001")
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Expect(repo.Save(taken)).To(gomega.Succeed())
+
+		gomega.Expect(create(repo, "Shirt", 0)).To(gomega.Equal("SKU-SHI-000002"))
+	})
+
+	ginkgo.It("should render templates with the category and year", func() {
+		repo := newRepository(entity.SKUStrategyTemplate, "{CAT}-{YYYY}-{SEQ:4}")
+		category, err := entity.NewCategory("Electronics", nil)
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		gomega.Expect(repository.NewPostgresCategoryRepository(database).Save(category)).To(gomega.Succeed())
+
+		year := time.Now().Year()
+		gomega.Expect(create(repo, "Earbuds", category.ID())).To(gomega.Equal(fmt.Sprintf("ELE-%d-0001", year)))
+		gomega.Expect(create(repo, "Shovel", 0)).To(gomega.Equal(fmt.Sprintf("GEN-%d-0001", year)))
+	})
+})
@@ -0,0 +1,213 @@
+package stock_movement_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("StockMovement E2E Tests", func() {
+	var movementHandler *handler.StockMovementHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID, warehouseID uint
+
+	// recordMovement posts a movement and returns the recorder
+	recordMovement := func(movementType string, quantity int64) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"product_id":   productID,
+			"warehouse_id": warehouseID,
+			"type":         movementType,
+			"quantity":     quantity,
+			"reason_code":  "TEST",
+			"reference":    "DOC-1",
+		})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/stock-movements", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("X-Actor", "auditor@example.com")
+
+		movementHandler.CreateStockMovement(c)
+		return w
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		movementHandler = handler.NewStockMovementHandler(usecase.NewStockMovementUsecase(repos, repository.NewUnitOfWork(database)))
+		productID, warehouseID = seedProductAndWarehouse(repos)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("POST /stock-movements", func() {
+		ginkgo.It("should record a receipt and update the on-hand balance", func() {
+			w := recordMovement("receipt", 10)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			var response map[string]interface{}
+			err := json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(response["balance_after"]).To(gomega.Equal(float64(10)))
+			gomega.Expect(response["actor"]).To(gomega.Equal("auditor@example.com"))
+
+			var stockLevel model.StockLevel
+			database.Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).First(&stockLevel)
+			gomega.Expect(stockLevel.OnHand).To(gomega.Equal(int64(10)))
+		})
+
+		ginkgo.It("should write a StockAdjusted event to the outbox with the movement", func() {
+			recordMovement("receipt", 10)
+			recordMovement("issue", 4)
+
+			var events []model.OutboxEvent
+			database.Order("id asc").Find(&events)
+			gomega.Expect(events).To(gomega.HaveLen(2))
+			gomega.Expect(events[1].EventType).To(gomega.Equal("StockAdjusted"))
+			gomega.Expect(events[1].PublishedAt).To(gomega.BeNil())
+			gomega.Expect(events[1].Payload["quantity"]).To(gomega.Equal(json.Number("-4")))
+			gomega.Expect(events[1].Payload["on_hand"]).To(gomega.Equal(json.Number("6")))
+		})
+
+		ginkgo.It("should write no event when the movement is refused", func() {
+			w := recordMovement("issue", 4)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+
+			var count int64
+			database.Model(&model.OutboxEvent{}).Count(&count)
+			gomega.Expect(count).To(gomega.BeZero())
+		})
+
+		ginkgo.It("should record issues as negative quantities", func() {
+			recordMovement("receipt", 10)
+			w := recordMovement("issue", 4)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			var response map[string]interface{}
+			_ = json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(response["quantity"]).To(gomega.Equal(float64(-4)))
+			gomega.Expect(response["balance_after"]).To(gomega.Equal(float64(6)))
+		})
+
+		ginkgo.It("should return 409 when issuing more than is available", func() {
+			recordMovement("receipt", 2)
+			w := recordMovement("issue", 3)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+			var count int64
+			database.Model(&model.StockMovement{}).Count(&count)
+			gomega.Expect(count).To(gomega.Equal(int64(1)))
+		})
+
+		ginkgo.It("should return 409 when receiving stock for a discontinued product", func() {
+			recordMovement("receipt", 5)
+			database.Model(&model.Product{}).Where("id = ?", productID).Update("status", "discontinued")
+
+			gomega.Expect(recordMovement("receipt", 1).Code).To(gomega.Equal(http.StatusConflict))
+			gomega.Expect(recordMovement("adjustment", 1).Code).To(gomega.Equal(http.StatusConflict))
+
+			// Remaining stock can still be sold off and corrected downwards
+			gomega.Expect(recordMovement("issue", 2).Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(recordMovement("adjustment", -1).Code).To(gomega.Equal(http.StatusCreated))
+		})
+
+		ginkgo.It("should return 422 for an unknown movement type", func() {
+			w := recordMovement("theft", 1)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should return 422 for a negative receipt", func() {
+			w := recordMovement("receipt", -1)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	ginkgo.Context("GET /products/:id/stock-movements", func() {
+		ginkgo.It("should list the movement history filtered by type", func() {
+			recordMovement("receipt", 10)
+			recordMovement("issue", 3)
+			recordMovement("receipt", 5)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(productID))+"/stock-movements?type=receipt", nil)
+
+			movementHandler.GetProductMovements(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response map[string]interface{}
+			_ = json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(response["movements"]).To(gomega.HaveLen(2))
+			gomega.Expect(response["total"]).To(gomega.Equal(float64(2)))
+		})
+
+		ginkgo.It("should return 422 for a malformed from timestamp", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(productID))+"/stock-movements?from=yesterday", nil)
+
+			movementHandler.GetProductMovements(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	ginkgo.Context("GET /products/:id/stock/reconciliation", func() {
+		ginkgo.It("should report balances that match the ledger as in sync", func() {
+			recordMovement("receipt", 10)
+			recordMovement("issue", 3)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(productID))+"/stock/reconciliation", nil)
+
+			movementHandler.GetProductStockReconciliation(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response map[string]interface{}
+			_ = json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(response["in_sync"]).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("should report drift when a balance was changed outside the ledger", func() {
+			recordMovement("receipt", 10)
+			database.Model(&model.StockLevel{}).Where("product_id = ?", productID).Update("on_hand", 12)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(productID))+"/stock/reconciliation", nil)
+
+			movementHandler.GetProductStockReconciliation(c)
+
+			var response map[string]interface{}
+			_ = json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(response["in_sync"]).To(gomega.BeFalse())
+			reconciliation := response["reconciliations"].([]interface{})[0].(map[string]interface{})
+			gomega.Expect(reconciliation["discrepancy"]).To(gomega.Equal(float64(2)))
+		})
+	})
+})
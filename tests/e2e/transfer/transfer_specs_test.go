@@ -0,0 +1,291 @@
+package transfer_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Transfer E2E Tests", func() {
+	var transferHandler *handler.TransferHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID, sourceID, destinationID uint
+
+	// createTransfer posts a draft transfer of quantity units and returns the recorder
+	createTransfer := func(quantity int64) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"source_warehouse_id":      sourceID,
+			"destination_warehouse_id": destinationID,
+			"reference":                "DN-1",
+			"lines":                    []map[string]interface{}{{"product_id": productID, "quantity": quantity}},
+		})
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/v1/transfers", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		transferHandler.CreateTransfer(c)
+		return w
+	}
+
+	// transition calls a transfer state transition handler with an optional JSON body
+	transition := func(handlerFunc gin.HandlerFunc, id uint, action string, body interface{}) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(id))}}
+		path := "/api/v1/transfers/" + strconv.Itoa(int(id)) + "/" + action
+		if body != nil {
+			payload, _ := json.Marshal(body)
+			c.Request = httptest.NewRequest("POST", path, bytes.NewBuffer(payload))
+			c.Request.Header.Set("Content-Type", "application/json")
+		} else {
+			c.Request = httptest.NewRequest("POST", path, nil)
+		}
+
+		handlerFunc(c)
+		return w
+	}
+
+	// decode reads a JSON object response body
+	decode := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var response map[string]interface{}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+		return response
+	}
+
+	// onHand reads the on-hand quantity of the seeded product in a warehouse
+	onHand := func(warehouseID uint) int64 {
+		var level model.StockLevel
+		database.Where("product_id = ? AND warehouse_id = ?", productID, warehouseID).First(&level)
+		return level.OnHand
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		transferHandler = handler.NewTransferHandler(usecase.NewTransferUsecase(repos, uow))
+		productID, sourceID, destinationID = seedWarehousesWithStock(repos, uow, 10)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("POST /transfers", func() {
+		ginkgo.It("should draft a transfer without moving stock", func() {
+			w := createTransfer(4)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			response := decode(w)
+			gomega.Expect(response["status"]).To(gomega.Equal("draft"))
+			gomega.Expect(response["lines"]).To(gomega.HaveLen(1))
+			gomega.Expect(onHand(sourceID)).To(gomega.Equal(int64(10)))
+		})
+
+		ginkgo.It("should reject a transfer into the source warehouse", func() {
+			destinationID = sourceID
+			w := createTransfer(4)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	ginkgo.Context("Dispatch and receive", func() {
+		ginkgo.It("should track stock in transit until the destination receives it", func() {
+			id := uint(decode(createTransfer(6))["id"].(float64))
+
+			w := transition(transferHandler.DispatchTransfer, id, "dispatch", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			response := decode(w)
+			gomega.Expect(response["status"]).To(gomega.Equal("dispatched"))
+			gomega.Expect(response["in_transit"]).To(gomega.BeEquivalentTo(6))
+			gomega.Expect(onHand(sourceID)).To(gomega.Equal(int64(4)))
+			gomega.Expect(onHand(destinationID)).To(gomega.Equal(int64(0)))
+
+			w = transition(transferHandler.ReceiveTransfer, id, "receive", map[string]interface{}{
+				"lines": []map[string]interface{}{{"product_id": productID, "quantity": 2}},
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			response = decode(w)
+			gomega.Expect(response["status"]).To(gomega.Equal("partially_received"))
+			gomega.Expect(response["in_transit"]).To(gomega.BeEquivalentTo(4))
+			gomega.Expect(onHand(destinationID)).To(gomega.Equal(int64(2)))
+
+			w = transition(transferHandler.ReceiveTransfer, id, "receive", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			response = decode(w)
+			gomega.Expect(response["status"]).To(gomega.Equal("received"))
+			gomega.Expect(response["in_transit"]).To(gomega.BeEquivalentTo(0))
+			gomega.Expect(onHand(destinationID)).To(gomega.Equal(int64(6)))
+
+			var legs int64
+			database.Model(&model.StockMovement{}).Where("reference = ?", "transfer:"+strconv.Itoa(int(id))).Count(&legs)
+			gomega.Expect(legs).To(gomega.Equal(int64(3)))
+		})
+
+		ginkgo.It("should still receive the stock in transit of a product deleted since, but no new receipts", func() {
+			id := uint(decode(createTransfer(6))["id"].(float64))
+			transition(transferHandler.DispatchTransfer, id, "dispatch", nil)
+
+			repos := repository.NewRepositories(database)
+			uow := repository.NewUnitOfWork(database)
+			_, err := usecase.NewProductUsecase(repos, uow).DeleteProduct(productID, 0, entity.ChangeOrigin{Actor: "test"})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			w := transition(transferHandler.ReceiveTransfer, id, "receive", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(decode(w)["status"]).To(gomega.Equal("received"))
+			gomega.Expect(onHand(destinationID)).To(gomega.Equal(int64(6)))
+
+			_, err = usecase.NewStockMovementUsecase(repos, uow).RecordMovement(usecase.StockMovementInput{
+				ProductID:   productID,
+				WarehouseID: destinationID,
+				Type:        entity.MovementTypeReceipt,
+				Quantity:    1,
+				ReasonCode:  "PURCHASE",
+				Actor:       "test",
+			})
+			gomega.Expect(err).To(gomega.MatchError(usecase.ErrProductNotStockable))
+		})
+
+		ginkgo.It("should recreate the dispatched lots at the destination", func() {
+			expiresAt := time.Now().AddDate(0, 1, 0).UTC().Truncate(time.Second)
+			_, err := usecase.NewStockMovementUsecase(repository.NewRepositories(database), repository.NewUnitOfWork(database)).RecordMovement(usecase.StockMovementInput{
+				ProductID:   productID,
+				WarehouseID: sourceID,
+				Type:        entity.MovementTypeReceipt,
+				Quantity:    4,
+				ReasonCode:  "PURCHASE",
+				Actor:       "test",
+				Lot:         &usecase.LotInput{Number: "L-1", ExpiresAt: expiresAt},
+			})
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			id := uint(decode(createTransfer(6))["id"].(float64))
+
+			w := transition(transferHandler.DispatchTransfer, id, "dispatch", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			lots := decode(w)["lines"].([]interface{})[0].(map[string]interface{})["lots"].([]interface{})
+			gomega.Expect(lots).To(gomega.HaveLen(1))
+			gomega.Expect(lots[0].(map[string]interface{})["lot_number"]).To(gomega.Equal("L-1"))
+			gomega.Expect(lots[0].(map[string]interface{})["quantity"]).To(gomega.BeEquivalentTo(4))
+
+			// lotAt reads lot L-1 of the product in a warehouse
+			lotAt := func(warehouseID uint) model.Lot {
+				var lot model.Lot
+				database.Where("product_id = ? AND warehouse_id = ? AND lot_number = ?", productID, warehouseID, "L-1").First(&lot)
+				return lot
+			}
+			gomega.Expect(lotAt(sourceID).Quantity).To(gomega.Equal(int64(0)))
+
+			w = transition(transferHandler.ReceiveTransfer, id, "receive", map[string]interface{}{
+				"lines": []map[string]interface{}{{"product_id": productID, "quantity": 3}},
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(lotAt(destinationID).Quantity).To(gomega.Equal(int64(3)))
+			gomega.Expect(lotAt(destinationID).ExpiresAt.Equal(expiresAt)).To(gomega.BeTrue())
+
+			w = transition(transferHandler.ReceiveTransfer, id, "receive", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(lotAt(destinationID).Quantity).To(gomega.Equal(int64(4)))
+			gomega.Expect(onHand(destinationID)).To(gomega.Equal(int64(6)))
+		})
+
+		ginkgo.It("should return 409 when the source warehouse is short", func() {
+			id := uint(decode(createTransfer(11))["id"].(float64))
+
+			w := transition(transferHandler.DispatchTransfer, id, "dispatch", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+			gomega.Expect(onHand(sourceID)).To(gomega.Equal(int64(10)))
+		})
+
+		ginkgo.It("should reject receiving more than is in transit", func() {
+			id := uint(decode(createTransfer(3))["id"].(float64))
+			transition(transferHandler.DispatchTransfer, id, "dispatch", nil)
+
+			w := transition(transferHandler.ReceiveTransfer, id, "receive", map[string]interface{}{
+				"lines": []map[string]interface{}{{"product_id": productID, "quantity": 4}},
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+
+	ginkgo.Context("POST /transfers/:id/cancel", func() {
+		ginkgo.It("should cancel a draft but not a dispatched transfer", func() {
+			id := uint(decode(createTransfer(2))["id"].(float64))
+			w := transition(transferHandler.CancelTransfer, id, "cancel", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			id = uint(decode(createTransfer(2))["id"].(float64))
+			transition(transferHandler.DispatchTransfer, id, "dispatch", nil)
+			w = transition(transferHandler.CancelTransfer, id, "cancel", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+		})
+
+		ginkgo.It("should return 404 for an unknown transfer", func() {
+			w := transition(transferHandler.CancelTransfer, 999, "cancel", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+	})
+
+	ginkgo.Context("POST /transfers/:id/close", func() {
+		ginkgo.It("should write off what a partially received transfer still waits for", func() {
+			id := uint(decode(createTransfer(6))["id"].(float64))
+			transition(transferHandler.DispatchTransfer, id, "dispatch", nil)
+			transition(transferHandler.ReceiveTransfer, id, "receive", map[string]interface{}{
+				"lines": []map[string]interface{}{{"product_id": productID, "quantity": 4}},
+			})
+
+			w := transition(transferHandler.CloseTransfer, id, "close", map[string]interface{}{"reason_code": "lost_in_transit"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			response := decode(w)
+			gomega.Expect(response["status"]).To(gomega.Equal("closed"))
+			gomega.Expect(response["close_reason_code"]).To(gomega.Equal("LOST_IN_TRANSIT"))
+			gomega.Expect(response["closed_at"]).NotTo(gomega.BeNil())
+			gomega.Expect(response["in_transit"]).To(gomega.BeEquivalentTo(0))
+			gomega.Expect(response["written_off"]).To(gomega.BeEquivalentTo(2))
+			gomega.Expect(onHand(sourceID)).To(gomega.Equal(int64(4)))
+			gomega.Expect(onHand(destinationID)).To(gomega.Equal(int64(4)))
+
+			w = transition(transferHandler.ReceiveTransfer, id, "receive", nil)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+			w = transition(transferHandler.CloseTransfer, id, "close", map[string]interface{}{"reason_code": "LOST_IN_TRANSIT"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+		})
+
+		ginkgo.It("should return 409 for a transfer that was not dispatched", func() {
+			id := uint(decode(createTransfer(2))["id"].(float64))
+			w := transition(transferHandler.CloseTransfer, id, "close", map[string]interface{}{"reason_code": "LOST_IN_TRANSIT"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+		})
+
+		ginkgo.It("should return 422 without a reason code", func() {
+			id := uint(decode(createTransfer(2))["id"].(float64))
+			transition(transferHandler.DispatchTransfer, id, "dispatch", nil)
+
+			w := transition(transferHandler.CloseTransfer, id, "close", map[string]interface{}{})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+})
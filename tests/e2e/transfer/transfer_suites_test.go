@@ -0,0 +1,49 @@
+package transfer_e2e_test
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+func TestTransferE2E(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "E2E Transfer Handler Suite")
+}
+
+// Helper function to truncate tables between tests
+func TruncateTables(database *gorm.DB) {
+	database.Exec("TRUNCATE TABLE transfer_line_lots, transfer_lines, transfers, lots, stock_movements, stock_levels, warehouses, products RESTART IDENTITY CASCADE;")
+}
+
+// seedWarehousesWithStock creates a product and two warehouses and receives onHand of the product into the first one
+func seedWarehousesWithStock(repos repository.Repositories, uow repository.UnitOfWork, onHand int64) (uint, uint, uint) {
+	product, err := entity.NewProduct("Transferred Product")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Products.Save(product)).To(gomega.Succeed())
+
+	source, err := entity.NewWarehouse("JKT01", "Jakarta Main")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Warehouses.Save(source)).To(gomega.Succeed())
+
+	destination, err := entity.NewWarehouse("SBY01", "Surabaya Main")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Warehouses.Save(destination)).To(gomega.Succeed())
+
+	_, err = usecase.NewStockMovementUsecase(repos, uow).RecordMovement(usecase.StockMovementInput{
+		ProductID:   product.ID(),
+		WarehouseID: source.ID(),
+		Type:        entity.MovementTypeReceipt,
+		Quantity:    onHand,
+		ReasonCode:  "SEED",
+		Actor:       "test",
+	})
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	return product.ID(), source.ID(), destination.ID()
+}
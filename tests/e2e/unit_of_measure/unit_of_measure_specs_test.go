@@ -0,0 +1,104 @@
+package unit_of_measure_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Unit Of Measure E2E Tests", func() {
+	var unitHandler *handler.UnitOfMeasureHandler
+	var movementHandler *handler.StockMovementHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productID, warehouseID uint
+
+	// send posts or puts a JSON body to a handler and returns the recorder
+	send := func(handle gin.HandlerFunc, method string, path string, params gin.Params, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = params
+		c.Request = httptest.NewRequest(method, path, bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handle(c)
+		return w
+	}
+
+	// recordReceipt posts a receipt of quantity in unit and returns the recorder
+	recordReceipt := func(quantity int64, unit string) *httptest.ResponseRecorder {
+		return send(movementHandler.CreateStockMovement, "POST", "/api/v1/stock-movements", nil, map[string]interface{}{
+			"product_id":   productID,
+			"warehouse_id": warehouseID,
+			"type":         "receipt",
+			"quantity":     quantity,
+			"unit":         unit,
+			"reason_code":  "TEST",
+		})
+	}
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		unitHandler = handler.NewUnitOfMeasureHandler(usecase.NewUnitOfMeasureUsecase(repos))
+		movementHandler = handler.NewStockMovementHandler(usecase.NewStockMovementUsecase(repos, uow))
+		productID, warehouseID = seedProductAndWarehouse(repos)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("Unit conversions", func() {
+		ginkgo.It("should convert movement quantities to the stock unit", func() {
+			w := send(unitHandler.CreateUnit, "POST", "/api/v1/units", nil, map[string]string{"code": "CASE", "name": "Case"})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+
+			params := gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+			w = send(unitHandler.SetProductUnit, "PUT", "/api/v1/products/"+strconv.Itoa(int(productID))+"/units", params, map[string]interface{}{
+				"unit_code": "case",
+				"factor":    24,
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+			gomega.Expect(recordReceipt(2, "CASE").Code).To(gomega.Equal(http.StatusCreated))
+			gomega.Expect(recordReceipt(5, "EA").Code).To(gomega.Equal(http.StatusCreated))
+
+			var level model.StockLevel
+			gomega.Expect(database.Where("product_id = ?", productID).First(&level).Error).To(gomega.Succeed())
+			gomega.Expect(level.OnHand).To(gomega.Equal(int64(53)))
+		})
+
+		ginkgo.It("should reject quantities in a unit the product does not convert", func() {
+			gomega.Expect(recordReceipt(2, "CASE").Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should not convert the stock unit to itself", func() {
+			params := gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+			w := send(unitHandler.SetProductUnit, "PUT", "/api/v1/products/"+strconv.Itoa(int(productID))+"/units", params, map[string]interface{}{
+				"unit_code": "EA",
+				"factor":    2,
+			})
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+})
@@ -0,0 +1,35 @@
+package unit_of_measure_e2e_test
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+func TestUnitOfMeasureE2E(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "E2E Unit Of Measure Handler Suite")
+}
+
+// Helper function to truncate tables between tests. The seeded EA unit is kept.
+func TruncateTables(database *gorm.DB) {
+	database.Exec("TRUNCATE TABLE product_units, lots, reservations, stock_movements, stock_levels, warehouses, products RESTART IDENTITY CASCADE;")
+	database.Exec("DELETE FROM units_of_measure WHERE code <> 'EA';")
+}
+
+// seedProductAndWarehouse creates a product and a warehouse directly through the repositories
+func seedProductAndWarehouse(repos repository.Repositories) (uint, uint) {
+	product, err := entity.NewProduct("Canned Soda")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Products.Save(product)).To(gomega.Succeed())
+
+	warehouse, err := entity.NewWarehouse("JKT01", "Jakarta Main")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(repos.Warehouses.Save(warehouse)).To(gomega.Succeed())
+
+	return product.ID(), warehouse.ID()
+}
@@ -0,0 +1,150 @@
+package warehouse_e2e_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/model"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Stock Level E2E Tests", func() {
+	var stockHandler *handler.StockHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+	var productUnitRepo repository.PostgresProductUnitRepository
+	var productID, jakartaID, surabayaID uint
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		productRepo := repository.NewPostgresProductRepository(database)
+		warehouseRepo := repository.NewPostgresWarehouseRepository(database)
+		stockLevelRepo := repository.NewPostgresStockLevelRepository(database)
+		productUnitRepo = repository.NewPostgresProductUnitRepository(database)
+		stockHandler = handler.NewStockHandler(usecase.NewStockUsecase(productRepo, warehouseRepo, stockLevelRepo, productUnitRepo))
+
+		// Seed a product held in two warehouses
+		product, _ := entity.NewProduct("Stocked Product")
+		gomega.Expect(productRepo.Save(product)).To(gomega.Succeed())
+		productID = product.ID()
+
+		warehouseHandler := handler.NewWarehouseHandler(usecase.NewWarehouseUsecase(warehouseRepo))
+		jakartaID = createWarehouse(warehouseHandler, "JKT01", "Jakarta Main")
+		surabayaID = createWarehouse(warehouseHandler, "SBY01", "Surabaya")
+
+		database.Create(&model.StockLevel{ProductID: productID, WarehouseID: jakartaID, OnHand: 10, Reserved: 3})
+		database.Create(&model.StockLevel{ProductID: productID, WarehouseID: surabayaID, OnHand: 5})
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("GET /products/:id/stock", func() {
+		ginkgo.It("should return the stock of the product in every warehouse", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(productID))+"/stock", nil)
+
+			stockHandler.GetProductStock(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response map[string]interface{}
+			err := json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(response["stock_levels"]).To(gomega.HaveLen(2))
+
+			totals := response["totals"].(map[string]interface{})
+			gomega.Expect(totals["on_hand"]).To(gomega.Equal(float64(15)))
+			gomega.Expect(totals["reserved"]).To(gomega.Equal(float64(3)))
+			gomega.Expect(totals["available"]).To(gomega.Equal(float64(12)))
+		})
+
+		ginkgo.It("should return quantities in the requested unit", func() {
+			database.Exec("INSERT INTO units_of_measure (code, name) VALUES ('PK5', 'Pack of 5') ON CONFLICT DO NOTHING")
+			conversion, _ := entity.NewProductUnit(productID, "PK5", 5)
+			gomega.Expect(productUnitRepo.Save(conversion)).To(gomega.Succeed())
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(productID))+"/stock?unit=pk5", nil)
+
+			stockHandler.GetProductStock(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response map[string]interface{}
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+			gomega.Expect(response["unit"]).To(gomega.Equal("PK5"))
+			totals := response["totals"].(map[string]interface{})
+			gomega.Expect(totals["on_hand"]).To(gomega.Equal(float64(3)))
+			gomega.Expect(totals["available"]).To(gomega.Equal(2.4))
+		})
+
+		ginkgo.It("should return 422 for a unit the product does not convert to", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(productID))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/"+strconv.Itoa(int(productID))+"/stock?unit=CASE", nil)
+
+			stockHandler.GetProductStock(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should return 404 if the product does not exist", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "999"}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/products/999/stock", nil)
+
+			stockHandler.GetProductStock(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+	})
+
+	ginkgo.Context("GET /warehouses/:id/stock", func() {
+		ginkgo.It("should return the stock held in the warehouse", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(jakartaID))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/warehouses/"+strconv.Itoa(int(jakartaID))+"/stock", nil)
+
+			stockHandler.GetWarehouseStock(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response map[string]interface{}
+			_ = json.NewDecoder(w.Body).Decode(&response)
+			levels := response["stock_levels"].([]interface{})
+			gomega.Expect(levels).To(gomega.HaveLen(1))
+			gomega.Expect(levels[0].(map[string]interface{})["available"]).To(gomega.Equal(float64(7)))
+		})
+
+		ginkgo.It("should return 400 for an invalid warehouse ID", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "abc"}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/warehouses/abc/stock", nil)
+
+			stockHandler.GetWarehouseStock(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusBadRequest))
+		})
+	})
+})
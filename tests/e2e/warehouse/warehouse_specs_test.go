@@ -0,0 +1,136 @@
+package warehouse_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"inventory_management/api/handler"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/pkg/db"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+var _ = ginkgo.Describe("Warehouse E2E Tests", func() {
+	var warehouseHandler *handler.WarehouseHandler
+	var database *gorm.DB
+	var sqlDB *sql.DB
+
+	ginkgo.BeforeEach(func() {
+		database, sqlDB = db.InitDB(true)
+		TruncateTables(database)
+
+		warehouseRepo := repository.NewPostgresWarehouseRepository(database)
+		warehouseUsecase := usecase.NewWarehouseUsecase(warehouseRepo)
+		warehouseHandler = handler.NewWarehouseHandler(warehouseUsecase)
+	})
+
+	ginkgo.AfterEach(func() {
+		TruncateTables(database)
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("POST /warehouses", func() {
+		ginkgo.It("should create a warehouse successfully", func() {
+			body, _ := json.Marshal(map[string]string{"code": "jkt01", "name": "Jakarta Main"})
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/api/v1/warehouses", bytes.NewBuffer(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			warehouseHandler.CreateWarehouse(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+			var response map[string]interface{}
+			err := json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+			gomega.Expect(response["code"]).To(gomega.Equal("JKT01"))
+			gomega.Expect(response["id"]).ShouldNot(gomega.BeZero())
+		})
+
+		ginkgo.It("should return 409 when the code is already used", func() {
+			createWarehouse(warehouseHandler, "JKT01", "Jakarta Main")
+
+			body, _ := json.Marshal(map[string]string{"code": "JKT01", "name": "Jakarta Second"})
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/api/v1/warehouses", bytes.NewBuffer(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			warehouseHandler.CreateWarehouse(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusConflict))
+		})
+
+		ginkgo.It("should return 422 when the code is missing", func() {
+			body, _ := json.Marshal(map[string]string{"name": "Jakarta Main"})
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/api/v1/warehouses", bytes.NewBuffer(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			warehouseHandler.CreateWarehouse(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+			var response map[string]interface{}
+			_ = json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(response["errors"].(map[string]interface{})["Code"]).To(gomega.Equal("Warehouse code is required."))
+		})
+	})
+
+	ginkgo.Context("GET /warehouses/:id", func() {
+		ginkgo.It("should retrieve an existing warehouse", func() {
+			id := createWarehouse(warehouseHandler, "JKT01", "Jakarta Main")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(int(id))}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/warehouses/"+strconv.Itoa(int(id)), nil)
+
+			warehouseHandler.GetWarehouse(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response map[string]interface{}
+			_ = json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(response["name"]).To(gomega.Equal("Jakarta Main"))
+		})
+
+		ginkgo.It("should return 404 if the warehouse does not exist", func() {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "999"}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/warehouses/999", nil)
+
+			warehouseHandler.GetWarehouse(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should return 500 if there is an internal error", func() {
+			mockUsecase := new(MockWarehouseUsecase)
+			warehouseHandler := handler.NewWarehouseHandler(mockUsecase)
+			mockUsecase.On("GetWarehouseByID", uint(1)).Return(nil, errors.New("database connection error"))
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			c.Request = httptest.NewRequest("GET", "/api/v1/warehouses/1", nil)
+
+			warehouseHandler.GetWarehouse(c)
+
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+			var response map[string]interface{}
+			_ = json.NewDecoder(w.Body).Decode(&response)
+			gomega.Expect(response["errors"]).To(gomega.Equal("failed to retrieve warehouse"))
+		})
+	})
+})
@@ -0,0 +1,74 @@
+package warehouse_e2e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"inventory_management/api/handler"
+	"inventory_management/internal/entity"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestWarehouseE2E(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "E2E Warehouse Handler Suite")
+}
+
+// Helper function to truncate tables between tests
+func TruncateTables(database *gorm.DB) {
+	database.Exec("TRUNCATE TABLE product_units, stock_levels, warehouses, products RESTART IDENTITY CASCADE;")
+}
+
+// createWarehouse creates a warehouse through the handler and returns its ID
+func createWarehouse(warehouseHandler *handler.WarehouseHandler, code string, name string) uint {
+	body, _ := json.Marshal(map[string]string{"code": code, "name": name})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/warehouses", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	warehouseHandler.CreateWarehouse(c)
+
+	var response map[string]interface{}
+	_ = json.NewDecoder(w.Body).Decode(&response)
+	return uint(response["id"].(float64))
+}
+
+// MockWarehouseUsecase is the mock implementation of the WarehouseUsecase interface.
+type MockWarehouseUsecase struct {
+	mock.Mock
+}
+
+// CreateWarehouse mock method
+func (m *MockWarehouseUsecase) CreateWarehouse(code string, name string) (*entity.Warehouse, error) {
+	args := m.Called(code, name)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Warehouse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetWarehouseByID mock method
+func (m *MockWarehouseUsecase) GetWarehouseByID(id uint) (*entity.Warehouse, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entity.Warehouse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ListWarehouses mock method
+func (m *MockWarehouseUsecase) ListWarehouses() ([]*entity.Warehouse, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).([]*entity.Warehouse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
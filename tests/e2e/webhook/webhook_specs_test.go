@@ -0,0 +1,301 @@
+package webhook_e2e_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"inventory_management/api/handler"
+	"inventory_management/api/handler/dto"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/repository"
+	"inventory_management/internal/usecase"
+	"inventory_management/internal/webhook"
+	"inventory_management/pkg/db"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"gorm.io/gorm"
+)
+
+// receivedRequest is a webhook request as the receiver saw it
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+var _ = ginkgo.Describe("Webhook E2E Tests", func() {
+	var webhookHandler *handler.WebhookHandler
+	var webhookUsecase usecase.WebhookUsecase
+	var productUsecase usecase.ProductUsecase
+	var relay usecase.EventRelayUsecase
+	var database *gorm.DB
+	var sqlDB *sql.DB
+
+	var receiver *httptest.Server
+	var mu sync.Mutex
+	var received []receivedRequest
+	var answerStatus int
+	var onReceive func() // Runs while the receiver holds a request, before it answers
+
+	policy := entity.WebhookRetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	// send calls a webhook handler with a JSON body and returns the recorder
+	send := func(method string, path string, webhookID string, body interface{}, handle func(c *gin.Context)) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		if webhookID != "" {
+			c.Params = gin.Params{{Key: "id", Value: webhookID}}
+		}
+		c.Request = httptest.NewRequest(method, "/api/v1/webhooks"+path, bytes.NewBuffer(payload))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handle(c)
+		return w
+	}
+
+	// subscribe creates a webhook pointing at the receiver and returns it with its secret
+	subscribe := func(eventTypes ...string) dto.WebhookResponse {
+		w := send("POST", "", "", map[string]interface{}{"url": receiver.URL + "/hooks", "event_types": eventTypes}, webhookHandler.CreateWebhook)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusCreated))
+
+		var response dto.WebhookResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return response
+	}
+
+	// deliveries returns the delivery log of a webhook
+	deliveries := func(webhookID uint, query string) dto.WebhookDeliveryListResponse {
+		id := strconv.Itoa(int(webhookID))
+		w := send("GET", "/"+id+"/deliveries"+query, id, nil, webhookHandler.GetWebhookDeliveries)
+		gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+
+		var response dto.WebhookDeliveryListResponse
+		gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+		return response
+	}
+
+	// createProduct makes a product change and relays its event to the webhooks
+	createProduct := func(name string) {
+		_, err := productUsecase.CreateProduct(usecase.ProductInput{Name: name}, entity.ChangeOrigin{Actor: "alice"})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = relay.RelayEvents(time.Now())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+
+	ginkgo.BeforeEach(func() {
+		// Initialize test environment
+		database, sqlDB = db.InitDB(true) // Assuming `true` loads the test environment
+		TruncateTables(database)          // Clean up before each test
+
+		mu.Lock()
+		received, answerStatus, onReceive = nil, http.StatusOK, nil
+		mu.Unlock()
+		receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			hook := onReceive
+			mu.Unlock()
+			if hook != nil {
+				hook()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, receivedRequest{header: r.Header.Clone(), body: body})
+			w.WriteHeader(answerStatus)
+		}))
+
+		repos := repository.NewRepositories(database)
+		uow := repository.NewUnitOfWork(database)
+		webhookUsecase = usecase.NewWebhookUsecase(repos, uow, webhook.NewHTTPSender(time.Second), policy)
+		webhookHandler = handler.NewWebhookHandler(webhookUsecase)
+		productUsecase = usecase.NewProductUsecase(repos, uow)
+		relay = usecase.NewEventRelayUsecase(uow, webhookUsecase, 10)
+	})
+
+	ginkgo.AfterEach(func() {
+		receiver.Close()
+		TruncateTables(database) // Clean up after each test
+		sqlDB.Close()
+	})
+
+	ginkgo.Context("Subscriptions", func() {
+		ginkgo.It("should show the secret on creation only", func() {
+			created := subscribe("ProductCreated")
+			gomega.Expect(created.Secret).To(gomega.HaveLen(64))
+			gomega.Expect(created.EventTypes).To(gomega.Equal([]string{"ProductCreated"}))
+
+			id := strconv.Itoa(int(created.ID))
+			w := send("GET", "/"+id, id, nil, webhookHandler.GetWebhook)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(w.Body.String()).NotTo(gomega.ContainSubstring("secret"))
+		})
+
+		ginkgo.It("should update and delete a webhook", func() {
+			id := strconv.Itoa(int(subscribe().ID))
+
+			w := send("PUT", "/"+id, id, map[string]interface{}{"url": "https://partner.example.com/v2", "event_types": []string{"StockAdjusted"}}, webhookHandler.UpdateWebhook)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var updated dto.WebhookResponse
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&updated)).To(gomega.Succeed())
+			gomega.Expect(updated.URL).To(gomega.Equal("https://partner.example.com/v2"))
+			gomega.Expect(updated.EventTypes).To(gomega.Equal([]string{"StockAdjusted"}))
+
+			w = send("DELETE", "/"+id, id, nil, webhookHandler.DeleteWebhook)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNoContent))
+
+			w = send("GET", "/"+id, id, nil, webhookHandler.GetWebhook)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+
+		ginkgo.It("should reject invalid URLs, secrets and event types", func() {
+			w := send("POST", "", "", map[string]interface{}{"url": "not a url", "secret": "short", "event_types": []string{"ProductExploded"}}, webhookHandler.CreateWebhook)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+
+			var response map[string]map[string]string
+			gomega.Expect(json.NewDecoder(w.Body).Decode(&response)).To(gomega.Succeed())
+			gomega.Expect(response["errors"]).To(gomega.HaveKey("URL"))
+			gomega.Expect(response["errors"]).To(gomega.HaveKey("Secret"))
+			gomega.Expect(response["errors"]).To(gomega.HaveKey("EventTypes"))
+
+			w = send("POST", "", "", map[string]interface{}{"url": "ftp://partner.example.com"}, webhookHandler.CreateWebhook)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+
+		ginkgo.It("should return 404 for the deliveries of an unknown webhook", func() {
+			w := send("GET", "/999/deliveries", "999", nil, webhookHandler.GetWebhookDeliveries)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusNotFound))
+		})
+	})
+
+	ginkgo.Context("Deliveries", func() {
+		ginkgo.It("should post signed events the webhook subscribed to", func() {
+			created := subscribe("ProductCreated")
+			stockOnly := subscribe("StockAdjusted")
+
+			createProduct("Trail Shoe")
+			createProduct("Trail Shoe") // An event relayed again would not be posted twice either
+
+			run, err := webhookUsecase.DispatchDeliveries(time.Now())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(run.Succeeded).To(gomega.Equal(2))
+
+			mu.Lock()
+			defer mu.Unlock()
+			gomega.Expect(received).To(gomega.HaveLen(2))
+			request := received[0]
+			gomega.Expect(request.header.Get(webhook.HeaderEvent)).To(gomega.Equal("ProductCreated"))
+			gomega.Expect(webhook.Verify(created.Secret, request.header.Get(webhook.HeaderTimestamp), request.body, request.header.Get(webhook.HeaderSignature))).To(gomega.BeTrue())
+
+			var message map[string]interface{}
+			gomega.Expect(json.Unmarshal(request.body, &message)).To(gomega.Succeed())
+			gomega.Expect(message["type"]).To(gomega.Equal("ProductCreated"))
+			gomega.Expect(message["payload"]).To(gomega.HaveKeyWithValue("name", "Trail Shoe"))
+
+			log := deliveries(created.ID, "")
+			gomega.Expect(log.Total).To(gomega.Equal(int64(2)))
+			gomega.Expect(log.Deliveries[0].Status).To(gomega.Equal("succeeded"))
+			gomega.Expect(*log.Deliveries[0].LastStatusCode).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(log.Deliveries[0].NextAttemptAt).To(gomega.BeNil())
+
+			gomega.Expect(deliveries(stockOnly.ID, "").Total).To(gomega.BeZero())
+		})
+
+		ginkgo.It("should retry failed deliveries with backoff and dead-letter them", func() {
+			created := subscribe()
+			mu.Lock()
+			answerStatus = http.StatusServiceUnavailable
+			mu.Unlock()
+
+			createProduct("Trail Shoe")
+			now := time.Now()
+
+			run, err := webhookUsecase.DispatchDeliveries(now)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(run.Retried).To(gomega.Equal(1))
+
+			delivery := deliveries(created.ID, "?status=pending").Deliveries[0]
+			gomega.Expect(delivery.Attempts).To(gomega.Equal(1))
+			gomega.Expect(*delivery.LastStatusCode).To(gomega.Equal(http.StatusServiceUnavailable))
+			gomega.Expect(delivery.NextAttemptAt.Sub(now)).To(gomega.BeNumerically("~", time.Minute, time.Second))
+
+			// Nothing is due before the backoff ran out
+			run, err = webhookUsecase.DispatchDeliveries(now.Add(30 * time.Second))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(run).To(gomega.Equal(usecase.WebhookDispatchRun{}))
+
+			run, err = webhookUsecase.DispatchDeliveries(now.Add(time.Minute + time.Second))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(run.Retried).To(gomega.Equal(1))
+
+			run, err = webhookUsecase.DispatchDeliveries(now.Add(4 * time.Minute))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(run.DeadLettered).To(gomega.Equal(1))
+
+			dead := deliveries(created.ID, "?status=dead")
+			gomega.Expect(dead.Total).To(gomega.Equal(int64(1)))
+			gomega.Expect(dead.Deliveries[0].Attempts).To(gomega.Equal(3))
+			gomega.Expect(dead.Deliveries[0].LastError).To(gomega.Equal("receiver answered with status 503"))
+
+			// Dead letters are not retried
+			mu.Lock()
+			answerStatus = http.StatusOK
+			mu.Unlock()
+			run, err = webhookUsecase.DispatchDeliveries(now.Add(time.Hour))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(run).To(gomega.Equal(usecase.WebhookDispatchRun{}))
+		})
+
+		ginkgo.It("should not hold deliveries locked while a slow receiver answers", func() {
+			created := subscribe()
+			createProduct("Trail Shoe")
+			now := time.Now()
+
+			var lockErr, concurrentErr error
+			var concurrentRun usecase.WebhookDispatchRun
+			var leasedUntil time.Time
+			mu.Lock()
+			onReceive = func() {
+				time.Sleep(300 * time.Millisecond)
+
+				// The row can be locked right away, no transaction of the dispatcher is open
+				lockErr = database.Transaction(func(tx *gorm.DB) error {
+					return tx.Exec("SELECT id FROM webhook_deliveries WHERE subscription_id = ? FOR UPDATE NOWAIT", created.ID).Error
+				})
+				lockErr = errors.Join(lockErr, database.Raw("SELECT next_attempt_at FROM webhook_deliveries WHERE subscription_id = ?", created.ID).Row().Scan(&leasedUntil))
+
+				// Another dispatcher leaves the claimed delivery alone
+				concurrentRun, concurrentErr = webhookUsecase.DispatchDeliveries(now)
+			}
+			mu.Unlock()
+
+			run, err := webhookUsecase.DispatchDeliveries(now)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(run.Succeeded).To(gomega.Equal(1))
+
+			gomega.Expect(lockErr).NotTo(gomega.HaveOccurred())
+			gomega.Expect(leasedUntil).To(gomega.BeTemporally(">", now.Add(time.Minute)))
+			gomega.Expect(concurrentErr).NotTo(gomega.HaveOccurred())
+			gomega.Expect(concurrentRun).To(gomega.Equal(usecase.WebhookDispatchRun{}))
+
+			mu.Lock()
+			gomega.Expect(received).To(gomega.HaveLen(1))
+			mu.Unlock()
+			gomega.Expect(deliveries(created.ID, "?status=succeeded").Total).To(gomega.Equal(int64(1)))
+		})
+
+		ginkgo.It("should return 422 for an invalid status filter", func() {
+			id := strconv.Itoa(int(subscribe().ID))
+			w := send("GET", "/"+id+"/deliveries?status=lost", id, nil, webhookHandler.GetWebhookDeliveries)
+			gomega.Expect(w.Code).To(gomega.Equal(http.StatusUnprocessableEntity))
+		})
+	})
+})
@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidateBarcode tests telling barcode types apart and checking their check digits
+func TestValidateBarcode(t *testing.T) {
+	valid := map[string]entity.BarcodeType{
+		"96385074":       entity.BarcodeTypeEAN8,
+		"036000291452":   entity.BarcodeTypeUPCA,
+		"4006381333931":  entity.BarcodeTypeEAN13,
+		"10036000291459": entity.BarcodeTypeGTIN14,
+	}
+	for code, expected := range valid {
+		barcodeType, err := entity.ValidateBarcode(code)
+		assert.NoError(t, err, code)
+		assert.Equal(t, expected, barcodeType, code)
+	}
+
+	_, err := entity.ValidateBarcode("4006381333932")
+	assert.ErrorIs(t, err, entity.ErrInvalidBarcodeChecksum)
+	_, err = entity.ValidateBarcode("40063813339")
+	assert.ErrorIs(t, err, entity.ErrInvalidBarcode)
+	_, err = entity.ValidateBarcode("40063813339A1")
+	assert.ErrorIs(t, err, entity.ErrInvalidBarcode)
+}
+
+// TestNormalizeBarcode tests that UPC-A and EAN-13 forms of a number share one GTIN
+func TestNormalizeBarcode(t *testing.T) {
+	upc, err := entity.NormalizeBarcode("036000291452")
+	assert.NoError(t, err)
+	ean, err := entity.NormalizeBarcode(" 0036000291452 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "00036000291452", upc)
+	assert.Equal(t, upc, ean)
+}
+
+// TestNewBarcode tests assigning a barcode to a product
+func TestNewBarcode(t *testing.T) {
+	barcode, err := entity.NewBarcode(1, "96385074")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), barcode.ProductID())
+	assert.Equal(t, "96385074", barcode.Code())
+	assert.Equal(t, entity.BarcodeTypeEAN8, barcode.Type())
+	assert.Equal(t, "00000096385074", barcode.GTIN())
+
+	_, err = entity.NewBarcode(0, "96385074")
+	assert.ErrorIs(t, err, entity.ErrInvalidBarcodeProduct)
+}
@@ -0,0 +1,67 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// makeCategory rehydrates a category as it would be loaded from the database
+func makeCategory(t *testing.T, id uint, parentID uint, path string) *entity.Category {
+	category := &entity.Category{}
+	assert.NoError(t, category.MakeCategory(id, parentID, "Category", path, time.Now(), time.Now()))
+	return category
+}
+
+// TestNewCategory tests creating root and nested categories
+func TestNewCategory(t *testing.T) {
+	_, err := entity.NewCategory(" ", nil)
+	assert.ErrorIs(t, err, entity.ErrEmptyCategoryName)
+
+	root, err := entity.NewCategory(" Electronics ", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Electronics", root.Name())
+	assert.Equal(t, "/", root.Path())
+	assert.Equal(t, 0, root.Depth())
+
+	audio := makeCategory(t, 4, 1, "/1/")
+	headphones, err := entity.NewCategory("Headphones", audio)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), headphones.ParentID())
+	assert.Equal(t, "/1/4/", headphones.Path())
+	assert.Equal(t, []uint{1, 4}, headphones.AncestorIDs())
+	assert.Equal(t, 2, headphones.Depth())
+}
+
+// TestMakeCategory tests that the path must match the parent
+func TestMakeCategory(t *testing.T) {
+	category := &entity.Category{}
+	assert.ErrorIs(t, category.MakeCategory(2, 1, "Audio", "/", time.Now(), time.Now()), entity.ErrInvalidCategoryPath)
+	assert.ErrorIs(t, category.MakeCategory(2, 0, "Audio", "/1/", time.Now(), time.Now()), entity.ErrInvalidCategoryPath)
+	assert.ErrorIs(t, category.MakeCategory(2, 1, "Audio", "1", time.Now(), time.Now()), entity.ErrInvalidCategoryPath)
+}
+
+// TestCategoryMoveTo tests moving categories and rejecting cycles
+func TestCategoryMoveTo(t *testing.T) {
+	electronics := makeCategory(t, 1, 0, "/")
+	audio := makeCategory(t, 4, 1, "/1/")
+	headphones := makeCategory(t, 9, 4, "/1/4/")
+	garden := makeCategory(t, 2, 0, "/")
+
+	assert.True(t, electronics.IsAncestorOf(headphones))
+	assert.False(t, headphones.IsAncestorOf(electronics))
+
+	assert.ErrorIs(t, audio.MoveTo(audio), entity.ErrCategoryCycle)
+	assert.ErrorIs(t, electronics.MoveTo(headphones), entity.ErrCategoryCycle)
+
+	assert.NoError(t, audio.MoveTo(garden))
+	assert.Equal(t, uint(2), audio.ParentID())
+	assert.Equal(t, "/2/", audio.Path())
+	assert.Equal(t, "/2/4/", audio.SubtreePath())
+
+	assert.NoError(t, audio.MoveTo(nil))
+	assert.Equal(t, uint(0), audio.ParentID())
+	assert.Equal(t, "/", audio.Path())
+}
@@ -0,0 +1,105 @@
+package entity_test
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewProductEvent tests the event announcing each kind of product change
+func TestNewProductEvent(t *testing.T) {
+	origin := entity.ChangeOrigin{Actor: "alice", RequestID: "req-1"}
+
+	product, err := entity.NewProductWithSKU("Trail Shoe", "SKU-TRL-1")
+	assert.NoError(t, err)
+	product.SetVersion(1)
+
+	created, err := entity.NewProductAuditRecord(7, entity.AuditActionCreated, nil, entity.ProductStateOf(product), origin, time.Time{})
+	assert.NoError(t, err)
+	event, err := entity.NewProductEvent(created)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.EventProductCreated, event.Type())
+	assert.Equal(t, entity.AggregateProduct, event.AggregateType())
+	assert.Equal(t, uint(7), event.AggregateID())
+	assert.Equal(t, "SKU-TRL-1", event.Payload()["sku"])
+	assert.Equal(t, "req-1", event.Payload()["request_id"])
+	assert.Equal(t, created.OccurredAt(), event.OccurredAt())
+	assert.False(t, event.IsPublished())
+
+	// Changing nothing but the name is a rename
+	before := entity.ProductStateOf(product)
+	assert.NoError(t, product.SetName("Trail Runner"))
+	renamed, err := entity.NewProductAuditRecord(7, entity.AuditActionUpdated, &before, entity.ProductStateOf(product), origin, time.Time{})
+	assert.NoError(t, err)
+	event, err = entity.NewProductEvent(renamed)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.EventProductRenamed, event.Type())
+	assert.Equal(t, "Trail Runner", event.Payload()["name"])
+	assert.Equal(t, "Trail Shoe", event.Payload()["previous_name"])
+
+	// Any other change is an update listing what changed
+	before = entity.ProductStateOf(product)
+	assert.NoError(t, product.SetName("Trail Racer"))
+	assert.NoError(t, product.SetBrand("Summit"))
+	updated, err := entity.NewProductAuditRecord(7, entity.AuditActionUpdated, &before, entity.ProductStateOf(product), origin, time.Time{})
+	assert.NoError(t, err)
+	event, err = entity.NewProductEvent(updated)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.EventProductUpdated, event.Type())
+	assert.Equal(t, []map[string]interface{}{
+		{"field": "name", "before": "Trail Runner", "after": "Trail Racer"},
+		{"field": "brand", "before": "", "after": "Summit"},
+	}, event.Payload()["changes"])
+
+	deleted, err := entity.NewProductAuditRecord(7, entity.AuditActionDeleted, &before, before, origin, time.Time{})
+	assert.NoError(t, err)
+	event, err = entity.NewProductEvent(deleted)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.EventProductDeleted, event.Type())
+}
+
+// TestNewStockAdjustedEvent tests the event announcing a stock movement
+func TestNewStockAdjustedEvent(t *testing.T) {
+	movement, err := entity.NewStockMovement(1, 2, entity.MovementTypeReceipt, 10, "PO_RECEIPT", "GRN-1", "alice", time.Time{})
+	assert.NoError(t, err)
+	stockLevel := &entity.StockLevel{}
+	assert.NoError(t, stockLevel.MakeStockLevel(5, 1, 2, 0, 0, time.Now()))
+	assert.NoError(t, stockLevel.ApplyMovement(movement))
+
+	event, err := entity.NewStockAdjustedEvent(movement, stockLevel)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.EventStockAdjusted, event.Type())
+	assert.Equal(t, entity.AggregateStockLevel, event.AggregateType())
+	assert.Equal(t, uint(5), event.AggregateID())
+	assert.Equal(t, int64(10), event.Payload()["quantity"])
+	assert.Equal(t, int64(10), event.Payload()["on_hand"])
+	assert.Equal(t, "receipt", event.Payload()["movement_type"])
+}
+
+// TestDomainEventDelivery tests the delivery bookkeeping and validation of events
+func TestDomainEventDelivery(t *testing.T) {
+	event, err := entity.NewDomainEvent(entity.EventProductCreated, entity.AggregateProduct, 1, nil, time.Time{})
+	assert.NoError(t, err)
+	assert.NotNil(t, event.Payload())
+	assert.WithinDuration(t, time.Now(), event.OccurredAt(), time.Second)
+
+	event.RecordFailure(errors.New("broker unavailable"))
+	event.RecordFailure(errors.New("timeout"))
+	assert.Equal(t, 2, event.Attempts())
+	assert.Equal(t, "timeout", event.LastError())
+	assert.False(t, event.IsPublished())
+
+	now := time.Now()
+	event.MarkPublished(now)
+	assert.True(t, event.IsPublished())
+	assert.Equal(t, now, *event.PublishedAt())
+
+	_, err = entity.NewDomainEvent(entity.EventType("ProductExploded"), entity.AggregateProduct, 1, nil, time.Time{})
+	assert.ErrorIs(t, err, entity.ErrInvalidEventType)
+
+	_, err = entity.NewDomainEvent(entity.EventProductCreated, entity.AggregateProduct, 0, nil, time.Time{})
+	assert.ErrorIs(t, err, entity.ErrInvalidEventAggregate)
+}
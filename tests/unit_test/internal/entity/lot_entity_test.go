@@ -0,0 +1,67 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewLot tests the NewLot function and receiving into and consuming from a lot
+func TestNewLot(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.AddDate(0, 1, 0)
+
+	lot, err := entity.NewLot(1, 2, " L-001 ", nil, expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, "L-001", lot.LotNumber())
+	assert.False(t, lot.IsExpired(now))
+	assert.True(t, lot.IsExpired(expiresAt))
+
+	_, err = entity.NewLot(1, 2, "L-002", nil, time.Time{})
+	assert.ErrorIs(t, err, entity.ErrMissingLotExpiry)
+	_, err = entity.NewLot(1, 2, "", nil, expiresAt)
+	assert.ErrorIs(t, err, entity.ErrEmptyLotNumber)
+	madeAt := expiresAt.Add(time.Hour)
+	_, err = entity.NewLot(1, 2, "L-003", &madeAt, expiresAt)
+	assert.ErrorIs(t, err, entity.ErrLotExpiresBeforeMade)
+
+	assert.NoError(t, lot.Receive(10, time.Time{}))
+	assert.ErrorIs(t, lot.Receive(1, expiresAt.Add(time.Hour)), entity.ErrLotExpiryMismatch)
+	assert.ErrorIs(t, lot.Consume(11), entity.ErrLotQuantityExceeded)
+	assert.NoError(t, lot.Consume(4))
+	assert.Equal(t, int64(6), lot.Quantity())
+}
+
+// TestConsumeFirstExpiring tests FEFO consumption across lots and untracked stock
+func TestConsumeFirstExpiring(t *testing.T) {
+	now := time.Now()
+	newLot := func(number string, expiresAt time.Time, quantity int64) *entity.Lot {
+		lot, _ := entity.NewLot(1, 2, number, nil, expiresAt)
+		_ = lot.Receive(quantity, time.Time{})
+		return lot
+	}
+
+	expired := newLot("OLD", now.Add(-time.Hour), 5)
+	late := newLot("LATE", now.AddDate(0, 2, 0), 5)
+	early := newLot("EARLY", now.AddDate(0, 1, 0), 5)
+	lots := []*entity.Lot{expired, late, early}
+
+	consumed, err := entity.ConsumeFirstExpiring(lots, 3, 7, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Lot{early, late}, consumed)
+	assert.Equal(t, int64(0), early.Quantity())
+	assert.Equal(t, int64(3), late.Quantity())
+	assert.Equal(t, int64(5), expired.Quantity())
+
+	// Lots first, then the untracked stock; expired lots never count
+	_, err = entity.ConsumeFirstExpiring(lots, 3, 7, now)
+	assert.ErrorIs(t, err, entity.ErrInsufficientStock)
+	assert.Equal(t, int64(3), late.Quantity())
+
+	consumed, err = entity.ConsumeFirstExpiring(lots, 3, 6, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Lot{late}, consumed)
+	assert.Equal(t, int64(0), late.Quantity())
+}
@@ -0,0 +1,68 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewProductAuditRecord tests the field changes recorded for created and updated products
+func TestNewProductAuditRecord(t *testing.T) {
+	origin := entity.ChangeOrigin{Actor: "alice", RequestID: "req-1", SourceIP: "192.0.2.1"}
+
+	product, err := entity.NewProductWithSKU("Trail Shoe", "SKU-TRL-1")
+	assert.NoError(t, err)
+	product.SetVersion(1)
+
+	created, err := entity.NewProductAuditRecord(1, entity.AuditActionCreated, nil, entity.ProductStateOf(product), origin, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.AuditActionCreated, created.Action())
+	assert.Equal(t, origin, created.Origin())
+	assert.WithinDuration(t, time.Now(), created.OccurredAt(), time.Second)
+	assert.Contains(t, created.Changes(), entity.FieldChange{Field: "name", Before: nil, After: "Trail Shoe"})
+
+	before := entity.ProductStateOf(product)
+	assert.NoError(t, product.SetBrand("Summit"))
+	assert.NoError(t, product.SetTags([]string{"outdoor"}))
+	product.SetCategory(4)
+
+	updated, err := entity.NewProductAuditRecord(1, entity.AuditActionUpdated, &before, entity.ProductStateOf(product), origin, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.FieldChange{
+		{Field: "brand", Before: "", After: "Summit"},
+		{Field: "tags", Before: []string{}, After: []string{"outdoor"}},
+		{Field: "category_id", Before: nil, After: uint(4)},
+	}, updated.Changes())
+
+	// Bookkeeping fields never show up as changes
+	unchanged := entity.ProductStateOf(product)
+	unchanged.Version++
+	unchanged.UpdatedAt = unchanged.UpdatedAt.Add(time.Minute)
+	assert.Empty(t, entity.ProductStateOf(product).Diff(unchanged))
+
+	_, err = entity.NewProductAuditRecord(0, entity.AuditActionUpdated, &before, before, origin, time.Time{})
+	assert.ErrorIs(t, err, entity.ErrInvalidAuditProduct)
+
+	_, err = entity.NewProductAuditRecord(1, entity.AuditAction("renamed"), &before, before, origin, time.Time{})
+	assert.ErrorIs(t, err, entity.ErrInvalidAuditAction)
+
+	_, err = entity.NewProductAuditRecord(1, entity.AuditActionUpdated, &before, before, entity.ChangeOrigin{}, time.Time{})
+	assert.ErrorIs(t, err, entity.ErrEmptyAuditActor)
+}
+
+// TestProductStateProduct tests rebuilding a product from a recorded state
+func TestProductStateProduct(t *testing.T) {
+	product, err := entity.NewProductWithSKU("Trail Shoe", "SKU-TRL-1")
+	assert.NoError(t, err)
+	assert.NoError(t, product.SetStatus(entity.ProductStatusDraft))
+	assert.NoError(t, product.SetAttributes(map[string]string{"color": "red"}))
+	product.MarkDeleted(time.Now())
+	product.SetVersion(3)
+
+	rebuilt, err := entity.ProductStateOf(product).Product(7)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), rebuilt.ID())
+	assert.Equal(t, entity.ProductStateOf(product), entity.ProductStateOf(rebuilt))
+}
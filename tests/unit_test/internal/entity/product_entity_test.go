@@ -2,7 +2,9 @@ package entity_test
 
 import (
 	"errors"
+	"fmt"
 	"inventory_management/internal/entity"
+	"strings"
 	"testing"
 	"time"
 
@@ -95,3 +97,150 @@ func TestGenerateSKUError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "random generation error")
 }
+
+// TestNewProductWithSKU tests creating a product under a SKU chosen by the caller
+func TestNewProductWithSKU(t *testing.T) {
+	product, err := entity.NewProductWithSKU("TestProduct", "  MFR-1000.b_2 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "MFR-1000.b_2", product.SKU())
+
+	product, err = entity.NewProductWithSKU("TestProduct", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "", product.SKU()) // Generated on save
+
+	_, err = entity.NewProductWithSKU("TestProduct", "-MFR")
+	assert.ErrorIs(t, err, entity.ErrInvalidSKU)
+	_, err = entity.NewProductWithSKU("TestProduct", "MFR 1000")
+	assert.ErrorIs(t, err, entity.ErrInvalidSKU)
+	_, err = entity.NewProductWithSKU("TestProduct", "MFR/1000")
+	assert.ErrorIs(t, err, entity.ErrInvalidSKU)
+}
+
+// TestSetSKU tests reassigning the SKU of a product
+func TestSetSKU(t *testing.T) {
+	product := &entity.Product{}
+	assert.NoError(t, product.MakeProduct(1, "TestProduct", "SKU-TES-12345", time.Now(), time.Now()))
+
+	assert.NoError(t, product.SetSKU("ÄRMEL-7"))
+	assert.Equal(t, "ÄRMEL-7", product.SKU())
+
+	assert.ErrorIs(t, product.SetSKU(""), entity.ErrInvalidSKU)
+	assert.ErrorIs(t, product.SetSKU(strings.Repeat("A", entity.MaxSKULength+1)), entity.ErrInvalidSKU)
+	assert.Equal(t, "ÄRMEL-7", product.SKU())
+}
+
+// TestProductDetails tests the descriptive attributes a product starts with and how they are changed
+func TestProductDetails(t *testing.T) {
+	product, err := entity.NewProductWithSKU("TestProduct", "")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ProductStatusActive, product.Status())
+	assert.Empty(t, product.Tags())
+	assert.Empty(t, product.Attributes())
+
+	assert.NoError(t, product.SetDescription("  Waterproof shell  "))
+	assert.Equal(t, "Waterproof shell", product.Description())
+	assert.ErrorIs(t, product.SetDescription(strings.Repeat("a", entity.MaxDescriptionLength+1)), entity.ErrDescriptionTooLong)
+
+	assert.NoError(t, product.SetBrand("Summit"))
+	assert.Equal(t, "Summit", product.Brand())
+	assert.ErrorIs(t, product.SetBrand(strings.Repeat("a", entity.MaxBrandLength+1)), entity.ErrBrandTooLong)
+
+	assert.NoError(t, product.SetStatus(entity.ProductStatusDiscontinued))
+	assert.Equal(t, entity.ProductStatusDiscontinued, product.Status())
+	assert.ErrorIs(t, product.SetStatus("sold"), entity.ErrInvalidProductStatus)
+
+	assert.NoError(t, product.SetWeightGrams(640))
+	assert.Equal(t, int64(640), product.WeightGrams())
+	assert.ErrorIs(t, product.SetWeightGrams(-1), entity.ErrInvalidWeight)
+
+	dimensions := entity.Dimensions{LengthMM: 330, WidthMM: 210, HeightMM: 120}
+	assert.NoError(t, product.SetDimensions(dimensions))
+	assert.Equal(t, dimensions, product.Dimensions())
+	assert.ErrorIs(t, product.SetDimensions(entity.Dimensions{WidthMM: -1}), entity.ErrInvalidDimensions)
+	assert.Equal(t, dimensions, product.Dimensions())
+}
+
+// TestSetTags tests that tags are normalised and limited
+func TestSetTags(t *testing.T) {
+	product := &entity.Product{}
+
+	assert.NoError(t, product.SetTags([]string{"Outdoor", " running ", "outdoor"}))
+	assert.Equal(t, []string{"outdoor", "running"}, product.Tags())
+
+	assert.ErrorIs(t, product.SetTags([]string{"outdoor", " "}), entity.ErrInvalidTag)
+	assert.ErrorIs(t, product.SetTags([]string{strings.Repeat("a", entity.MaxTagLength+1)}), entity.ErrInvalidTag)
+
+	tooMany := make([]string, entity.MaxTags+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag-%d", i)
+	}
+	assert.ErrorIs(t, product.SetTags(tooMany), entity.ErrTooManyTags)
+	assert.Equal(t, []string{"outdoor", "running"}, product.Tags())
+
+	assert.NoError(t, product.SetTags(nil))
+	assert.Empty(t, product.Tags())
+}
+
+// TestProductAttributes tests setting and removing custom attributes
+func TestProductAttributes(t *testing.T) {
+	product := &entity.Product{}
+
+	assert.NoError(t, product.SetAttribute("color", "red"))
+	assert.NoError(t, product.SetAttribute("größe", "42"))
+	assert.Equal(t, map[string]string{"color": "red", "größe": "42"}, product.Attributes())
+
+	// Changing the returned map does not change the product
+	product.Attributes()["color"] = "blue"
+	assert.Equal(t, "red", product.Attributes()["color"])
+
+	product.RemoveAttribute("color")
+	product.RemoveAttribute("missing")
+	assert.Equal(t, map[string]string{"größe": "42"}, product.Attributes())
+
+	assert.ErrorIs(t, product.SetAttribute("", "x"), entity.ErrInvalidAttributeName)
+	assert.ErrorIs(t, product.SetAttribute("1st", "x"), entity.ErrInvalidAttributeName)
+	assert.ErrorIs(t, product.SetAttribute("pack size", "x"), entity.ErrInvalidAttributeName)
+	assert.ErrorIs(t, product.SetAttribute("color", strings.Repeat("a", entity.MaxAttributeValueLength+1)), entity.ErrAttributeValueTooLong)
+
+	attributes := make(map[string]string, entity.MaxAttributes)
+	for i := 0; i < entity.MaxAttributes; i++ {
+		attributes[fmt.Sprintf("attr_%d", i)] = "x"
+	}
+	assert.NoError(t, product.SetAttributes(attributes))
+	assert.NoError(t, product.SetAttribute("attr_0", "y")) // Replacing stays within the limit
+	assert.ErrorIs(t, product.SetAttribute("extra", "x"), entity.ErrTooManyAttributes)
+
+	attributes["extra"] = "x"
+	assert.ErrorIs(t, product.SetAttributes(attributes), entity.ErrTooManyAttributes)
+}
+
+// TestProductLifecycle tests which statuses accept new stock and soft deletion
+func TestProductLifecycle(t *testing.T) {
+	product := &entity.Product{}
+	assert.NoError(t, product.MakeProduct(1, "TestProduct", "SKU-TES-12345", time.Now(), time.Now()))
+	assert.True(t, product.AcceptsStock()) // Active by default
+
+	for status, accepts := range map[entity.ProductStatus]bool{
+		entity.ProductStatusDraft:        true,
+		entity.ProductStatusActive:       true,
+		entity.ProductStatusDiscontinued: false,
+		entity.ProductStatusArchived:     false,
+	} {
+		assert.NoError(t, product.SetStatus(status))
+		assert.Equal(t, accepts, product.AcceptsStock(), status)
+	}
+
+	assert.False(t, product.IsDeleted())
+	deletedAt := time.Now()
+	product.MarkDeleted(deletedAt)
+	assert.True(t, product.IsDeleted())
+	assert.Equal(t, deletedAt, *product.DeletedAt())
+
+	assert.NoError(t, product.SetStatus(entity.ProductStatusActive))
+	assert.False(t, product.AcceptsStock()) // Deleted products only sell off what is left
+
+	product.Restore()
+	assert.False(t, product.IsDeleted())
+	assert.Nil(t, product.DeletedAt())
+	assert.True(t, product.AcceptsStock())
+}
@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProductImportCounts tests that every row of an import is counted once
+func TestProductImportCounts(t *testing.T) {
+	report := entity.NewProductImport("catalogue.csv", true, time.Now())
+	report.RecordCreated()
+	report.RecordCreated()
+	report.RecordUpdated()
+	report.RecordUnchanged()
+	report.RecordRejected(entity.RejectedProductRow{Line: 4, Name: "X", Errors: map[string]string{"Name": "Product name must be at least 2 characters long."}})
+
+	assert.True(t, report.DryRun())
+	assert.Equal(t, 5, report.TotalRows())
+	assert.Equal(t, 2, report.Created())
+	assert.Equal(t, 1, report.Updated())
+	assert.Equal(t, 1, report.Unchanged())
+	assert.Equal(t, 1, report.Rejected())
+	assert.Equal(t, 4, report.RejectedRows()[0].Line)
+
+	assert.False(t, report.Failed())
+	report.MarkFailed(502)
+	assert.True(t, report.Failed())
+	assert.Equal(t, 502, report.FailedAtLine())
+}
+
+// TestMakeProductImport tests that stored imports are rehydrated and negative counts or lines refused
+func TestMakeProductImport(t *testing.T) {
+	report := &entity.ProductImport{}
+	assert.NoError(t, report.MakeProductImport(7, "catalogue.xlsx", false, 3, 0, 0, nil, 0, time.Now()))
+	assert.Equal(t, uint(7), report.ID())
+	assert.Equal(t, 3, report.TotalRows())
+	assert.NotNil(t, report.RejectedRows())
+	assert.False(t, report.Failed())
+
+	assert.ErrorIs(t, report.MakeProductImport(7, "catalogue.xlsx", false, -1, 0, 0, nil, 0, time.Now()), entity.ErrInvalidProductImportCounts)
+	assert.ErrorIs(t, report.MakeProductImport(7, "catalogue.xlsx", false, 3, 0, 0, nil, -1, time.Now()), entity.ErrInvalidProductImportCounts)
+}
@@ -0,0 +1,87 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewOptionAxis tests the NewOptionAxis function and its validations
+func TestNewOptionAxis(t *testing.T) {
+	axis, err := entity.NewOptionAxis(" Size ", []string{" S", "M "})
+	assert.NoError(t, err)
+	assert.Equal(t, "Size", axis.Name())
+	assert.Equal(t, []string{"S", "M"}, axis.Values())
+
+	_, err = entity.NewOptionAxis(" ", []string{"S"})
+	assert.ErrorIs(t, err, entity.ErrEmptyOptionName)
+	_, err = entity.NewOptionAxis("Size", nil)
+	assert.ErrorIs(t, err, entity.ErrNoOptionValues)
+	_, err = entity.NewOptionAxis("Size", []string{"S", " "})
+	assert.ErrorIs(t, err, entity.ErrEmptyOptionValue)
+	_, err = entity.NewOptionAxis("Size", []string{"S", "s"})
+	assert.ErrorIs(t, err, entity.ErrDuplicateOptionValue)
+}
+
+// TestCombineOptionAxes tests building the cartesian product of option axes
+func TestCombineOptionAxes(t *testing.T) {
+	size, _ := entity.NewOptionAxis("Size", []string{"S", "M", "L"})
+	colour, _ := entity.NewOptionAxis("Colour", []string{"Red", "Blue"})
+
+	combinations, err := entity.CombineOptionAxes([]*entity.OptionAxis{size, colour})
+	assert.NoError(t, err)
+	assert.Len(t, combinations, 6)
+	assert.Equal(t, "size=s;colour=red", entity.VariantKey(combinations[0]))
+	assert.Equal(t, "size=s;colour=blue", entity.VariantKey(combinations[1]))
+	assert.Equal(t, "size=l;colour=blue", entity.VariantKey(combinations[5]))
+
+	_, err = entity.CombineOptionAxes(nil)
+	assert.ErrorIs(t, err, entity.ErrNoOptionAxes)
+
+	sizeAgain, _ := entity.NewOptionAxis("size", []string{"XL"})
+	_, err = entity.CombineOptionAxes([]*entity.OptionAxis{size, sizeAgain})
+	assert.ErrorIs(t, err, entity.ErrDuplicateOptionAxis)
+
+	values := make([]string, 16)
+	for i := range values {
+		values[i] = strings.Repeat("x", i+1)
+	}
+	wide, _ := entity.NewOptionAxis("Width", values)
+	long, _ := entity.NewOptionAxis("Length", values)
+	_, err = entity.CombineOptionAxes([]*entity.OptionAxis{wide, long})
+	assert.ErrorIs(t, err, entity.ErrTooManyVariants)
+}
+
+// TestNewProductVariant tests creating a variant product from its parent
+func TestNewProductVariant(t *testing.T) {
+	parent := &entity.Product{}
+	assert.NoError(t, parent.MakeProduct(7, "Shirt", "SKU-SHI-00001", time.Now(), time.Now()))
+	parent.SetSerialized(true)
+	parent.SetStockUnit("PK")
+	parent.SetCategory(3)
+
+	size, _ := entity.MakeVariantOption("Size", "M")
+	colour, _ := entity.MakeVariantOption("Colour", "Red")
+	variant, err := entity.NewProductVariant(parent, []entity.VariantOption{size, colour})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), variant.ParentID())
+	assert.Equal(t, "Shirt - M / Red", variant.Product().Name())
+	assert.Empty(t, variant.Product().SKU()) // Generated when the variant is saved
+	assert.True(t, variant.Product().IsSerialized())
+	assert.Equal(t, "PK", variant.Product().StockUnit())
+	assert.Equal(t, uint(3), variant.Product().CategoryID())
+
+	sizeAxis, _ := entity.NewOptionAxis("size", []string{"M"})
+	colourAxis, _ := entity.NewOptionAxis("Colour", []string{"Red"})
+	assert.True(t, variant.MatchesAxes([]*entity.OptionAxis{sizeAxis, colourAxis}))
+	assert.False(t, variant.MatchesAxes([]*entity.OptionAxis{colourAxis, sizeAxis}))
+	assert.False(t, variant.MatchesAxes([]*entity.OptionAxis{sizeAxis}))
+
+	_, err = entity.NewProductVariant(&entity.Product{}, []entity.VariantOption{size})
+	assert.ErrorIs(t, err, entity.ErrInvalidVariantParent)
+	_, err = entity.NewProductVariant(parent, nil)
+	assert.ErrorIs(t, err, entity.ErrInvalidVariantOptions)
+}
@@ -0,0 +1,65 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewSupplier tests the NewSupplier function
+func TestNewSupplier(t *testing.T) {
+	supplier, err := entity.NewSupplier(" acme01 ", "Acme", "orders@acme.test")
+	assert.NoError(t, err)
+	assert.Equal(t, "ACME01", supplier.Code())
+
+	_, err = entity.NewSupplier("", "Acme", "")
+	assert.ErrorIs(t, err, entity.ErrEmptySupplierCode)
+
+	_, err = entity.NewSupplier("ACME01", " ", "")
+	assert.ErrorIs(t, err, entity.ErrEmptySupplierName)
+}
+
+// TestNewPurchaseOrder tests the NewPurchaseOrder function and adding lines
+func TestNewPurchaseOrder(t *testing.T) {
+	order, err := entity.NewPurchaseOrder(1, 2, "Q-1", "idr")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.PurchaseOrderStatusDraft, order.Status())
+	assert.Equal(t, "IDR", order.Currency())
+
+	_, err = entity.NewPurchaseOrder(1, 2, "", "RUPIAH")
+	assert.ErrorIs(t, err, entity.ErrInvalidCurrency)
+
+	assert.NoError(t, order.AddLine(10, 4, 1250))
+	assert.NoError(t, order.AddLine(11, 1, 99))
+	assert.ErrorIs(t, order.AddLine(10, 1, 1), entity.ErrDuplicatePurchaseLine)
+	assert.ErrorIs(t, order.AddLine(12, 1, -1), entity.ErrNegativeUnitCost)
+	assert.Equal(t, int64(5099), order.Total())
+}
+
+// TestPurchaseOrderTransitions tests submitting, receiving and closing purchase orders
+func TestPurchaseOrderTransitions(t *testing.T) {
+	now := time.Now()
+
+	order, _ := entity.NewPurchaseOrder(1, 2, "", "IDR")
+	assert.ErrorIs(t, order.Submit(now), entity.ErrEmptyPurchaseOrder)
+	_ = order.AddLine(10, 5, 100)
+	assert.ErrorIs(t, order.Receive(map[uint]int64{10: 1}), entity.ErrPurchaseOrderNotReceivable)
+	assert.ErrorIs(t, order.Close(now), entity.ErrPurchaseOrderNotClosable)
+
+	assert.NoError(t, order.Submit(now))
+	assert.ErrorIs(t, order.Submit(now), entity.ErrPurchaseOrderNotDraft)
+	assert.ErrorIs(t, order.Receive(map[uint]int64{10: 6}), entity.ErrReceiveExceedsOrdered)
+	assert.ErrorIs(t, order.Receive(map[uint]int64{11: 1}), entity.ErrPurchaseLineNotFound)
+
+	assert.NoError(t, order.Receive(map[uint]int64{10: 2}))
+	assert.Equal(t, entity.PurchaseOrderStatusPartiallyReceived, order.Status())
+	assert.NoError(t, order.Receive(map[uint]int64{10: 3}))
+	assert.Equal(t, entity.PurchaseOrderStatusReceived, order.Status())
+	assert.ErrorIs(t, order.Receive(map[uint]int64{10: 1}), entity.ErrPurchaseOrderNotReceivable)
+
+	assert.NoError(t, order.Close(now))
+	assert.Equal(t, entity.PurchaseOrderStatusClosed, order.Status())
+	assert.NotNil(t, order.ClosedAt())
+}
@@ -0,0 +1,50 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewReorderPolicy tests the NewReorderPolicy function and its invariants
+func TestNewReorderPolicy(t *testing.T) {
+	policy, err := entity.NewReorderPolicy(1, 2, 10, 50, 4, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), policy.ReorderPoint())
+	assert.True(t, policy.IsBelowReorderPoint(9))
+	assert.False(t, policy.IsBelowReorderPoint(10))
+
+	_, err = entity.NewReorderPolicy(1, 2, -1, 50, 0, 0)
+	assert.ErrorIs(t, err, entity.ErrNegativeReorderPoint)
+	_, err = entity.NewReorderPolicy(1, 2, 10, 0, 0, 0)
+	assert.ErrorIs(t, err, entity.ErrInvalidReorderQuantity)
+	_, err = entity.NewReorderPolicy(1, 2, 10, 50, 11, 0)
+	assert.ErrorIs(t, err, entity.ErrSafetyStockAboveReorderPoint)
+
+	assert.ErrorIs(t, policy.Update(10, 50, -1, 0), entity.ErrNegativeSafetyStock)
+	assert.Equal(t, int64(4), policy.SafetyStock())
+	assert.NoError(t, policy.Update(20, 40, 5, 3))
+	assert.Equal(t, uint(3), policy.PreferredSupplierID())
+}
+
+// TestLowStockAlert tests raising and resolving a low-stock alert
+func TestLowStockAlert(t *testing.T) {
+	now := time.Now()
+	policy, _ := entity.NewReorderPolicy(1, 2, 10, 50, 4, 0)
+
+	alert := entity.NewLowStockAlert(policy, 3, now)
+	assert.Equal(t, entity.LowStockAlertStatusOpen, alert.Status())
+	assert.Equal(t, int64(50), alert.SuggestedQuantity())
+	assert.True(t, alert.BelowSafetyStock())
+	assert.Nil(t, alert.ResolvedAt())
+
+	alert.LinkPurchaseOrder(7)
+	assert.Equal(t, uint(7), alert.PurchaseOrderID())
+
+	assert.NoError(t, alert.Resolve(now))
+	assert.Equal(t, entity.LowStockAlertStatusResolved, alert.Status())
+	assert.NotNil(t, alert.ResolvedAt())
+	assert.ErrorIs(t, alert.Resolve(now), entity.ErrLowStockAlertResolved)
+}
@@ -0,0 +1,59 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewReservation tests the NewReservation function
+func TestNewReservation(t *testing.T) {
+	now := time.Now()
+	reservation, err := entity.NewReservation(1, 2, 5, "ORDER-1", 10*time.Minute, now)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ReservationStatusPending, reservation.Status())
+	assert.Equal(t, now.Add(10*time.Minute), reservation.ExpiresAt())
+
+	_, err = entity.NewReservation(1, 2, 0, "ORDER-1", time.Minute, now)
+	assert.ErrorIs(t, err, entity.ErrInvalidReservationQuantity)
+
+	_, err = entity.NewReservation(1, 2, 1, "ORDER-1", 0, now)
+	assert.ErrorIs(t, err, entity.ErrInvalidReservationTTL)
+}
+
+// TestReservationTransitions tests confirming, releasing and expiring reservations
+func TestReservationTransitions(t *testing.T) {
+	now := time.Now()
+
+	reservation, _ := entity.NewReservation(1, 2, 5, "", time.Minute, now)
+	assert.NoError(t, reservation.Confirm(now))
+	assert.Equal(t, entity.ReservationStatusConfirmed, reservation.Status())
+	assert.ErrorIs(t, reservation.Release(), entity.ErrReservationNotPending)
+
+	reservation, _ = entity.NewReservation(1, 2, 5, "", time.Minute, now)
+	assert.ErrorIs(t, reservation.Confirm(now.Add(time.Minute)), entity.ErrReservationExpired)
+	assert.ErrorIs(t, reservation.Expire(now), entity.ErrReservationNotExpired)
+	assert.NoError(t, reservation.Expire(now.Add(time.Minute)))
+	assert.Equal(t, entity.ReservationStatusExpired, reservation.Status())
+
+	reservation, _ = entity.NewReservation(1, 2, 5, "", time.Minute, now)
+	assert.NoError(t, reservation.Release())
+	assert.ErrorIs(t, reservation.Expire(now.Add(time.Hour)), entity.ErrReservationNotPending)
+}
+
+// TestStockLevelReserve tests reserving and releasing stock on a stock level
+func TestStockLevelReserve(t *testing.T) {
+	stockLevel := &entity.StockLevel{}
+	assert.NoError(t, stockLevel.MakeStockLevel(1, 1, 2, 10, 0, time.Now()))
+
+	assert.NoError(t, stockLevel.Reserve(6))
+	assert.Equal(t, int64(4), stockLevel.Available())
+	assert.ErrorIs(t, stockLevel.Reserve(5), entity.ErrInsufficientStock)
+	assert.ErrorIs(t, stockLevel.Reserve(0), entity.ErrInvalidReserveQuantity)
+
+	assert.NoError(t, stockLevel.ReleaseReservation(6))
+	assert.Equal(t, int64(10), stockLevel.Available())
+	assert.ErrorIs(t, stockLevel.ReleaseReservation(1), entity.ErrReleaseExceedsReserved)
+}
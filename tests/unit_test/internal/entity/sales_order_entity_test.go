@@ -0,0 +1,66 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewSalesOrder tests the NewSalesOrder function and adding lines
+func TestNewSalesOrder(t *testing.T) {
+	order, err := entity.NewSalesOrder(1, "WEB-1")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SalesOrderStatusPending, order.Status())
+
+	_, err = entity.NewSalesOrder(0, "")
+	assert.ErrorIs(t, err, entity.ErrInvalidSalesOrderWarehouse)
+
+	assert.NoError(t, order.AddLine(10, 3))
+	assert.ErrorIs(t, order.AddLine(10, 1), entity.ErrDuplicateSalesLine)
+	assert.ErrorIs(t, order.AddLine(11, 0), entity.ErrInvalidSalesQuantity)
+}
+
+// TestSalesOrderAllocation tests backordering and completing allocation
+func TestSalesOrderAllocation(t *testing.T) {
+	order, _ := entity.NewSalesOrder(1, "")
+	_ = order.AddLine(10, 5)
+	_ = order.AddLine(11, 2)
+
+	assert.NoError(t, order.Allocate(map[uint]int64{10: 3, 11: 2}))
+	assert.Equal(t, entity.SalesOrderStatusBackordered, order.Status())
+	assert.Equal(t, int64(2), order.Backordered())
+	assert.ErrorIs(t, order.Pick(), entity.ErrSalesOrderNotAllocated)
+	assert.ErrorIs(t, order.AddLine(12, 1), entity.ErrSalesOrderNotPending)
+	assert.ErrorIs(t, order.Allocate(map[uint]int64{10: 3}), entity.ErrAllocationExceedsOrdered)
+
+	assert.NoError(t, order.Allocate(map[uint]int64{10: 2}))
+	assert.Equal(t, entity.SalesOrderStatusAllocated, order.Status())
+	assert.ErrorIs(t, order.Allocate(map[uint]int64{}), entity.ErrSalesOrderNotAllocatable)
+}
+
+// TestSalesOrderFulfilment tests the pick, pack and ship flow and cancellation
+func TestSalesOrderFulfilment(t *testing.T) {
+	now := time.Now()
+
+	order, _ := entity.NewSalesOrder(1, "")
+	_ = order.AddLine(10, 1)
+	_ = order.Allocate(map[uint]int64{10: 1})
+
+	assert.ErrorIs(t, order.Ship(now), entity.ErrSalesOrderNotPacked)
+	assert.ErrorIs(t, order.Pack(), entity.ErrSalesOrderNotPicking)
+	assert.NoError(t, order.Pick())
+	assert.NoError(t, order.Pack())
+	assert.NoError(t, order.Ship(now))
+	assert.Equal(t, entity.SalesOrderStatusShipped, order.Status())
+	assert.NotNil(t, order.ShippedAt())
+	assert.ErrorIs(t, order.Cancel(), entity.ErrSalesOrderNotCancellable)
+
+	order, _ = entity.NewSalesOrder(1, "")
+	_ = order.AddLine(10, 1)
+	_ = order.Allocate(map[uint]int64{10: 1})
+	_ = order.Pick()
+	assert.NoError(t, order.Cancel())
+	assert.Equal(t, entity.SalesOrderStatusCancelled, order.Status())
+}
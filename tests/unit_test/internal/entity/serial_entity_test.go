@@ -0,0 +1,56 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewSerial tests the NewSerial function and its validations
+func TestNewSerial(t *testing.T) {
+	serial, err := entity.NewSerial(1, " SN-001 ", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "SN-001", serial.SerialNumber())
+	assert.Equal(t, entity.SerialStatusInStock, serial.Status())
+	assert.Equal(t, uint(2), serial.WarehouseID())
+
+	_, err = entity.NewSerial(1, " ", 2)
+	assert.ErrorIs(t, err, entity.ErrEmptySerialNumber)
+	_, err = entity.NewSerial(0, "SN-002", 2)
+	assert.ErrorIs(t, err, entity.ErrInvalidSerialProduct)
+	_, err = entity.NewSerial(1, "SN-003", 0)
+	assert.ErrorIs(t, err, entity.ErrSerialMissingWarehouse)
+}
+
+// TestSerialMovements tests moving a unit between warehouses, issuing it and receiving it back
+func TestSerialMovements(t *testing.T) {
+	serial, _ := entity.NewSerial(1, "SN-001", 2)
+
+	assert.ErrorIs(t, serial.Receive(2), entity.ErrSerialAlreadyInStock)
+	assert.ErrorIs(t, serial.Arrive(3), entity.ErrSerialNotInTransit)
+	assert.ErrorIs(t, serial.Dispatch(3), entity.ErrSerialNotInWarehouse)
+
+	assert.NoError(t, serial.Dispatch(2))
+	assert.Equal(t, entity.SerialStatusInTransit, serial.Status())
+	assert.Equal(t, uint(0), serial.WarehouseID())
+	assert.ErrorIs(t, serial.Receive(3), entity.ErrSerialInTransit)
+	assert.ErrorIs(t, serial.Issue(2), entity.ErrSerialNotInWarehouse)
+
+	assert.NoError(t, serial.Arrive(3))
+	assert.Equal(t, uint(3), serial.WarehouseID())
+
+	assert.NoError(t, serial.Issue(3))
+	assert.Equal(t, entity.SerialStatusIssued, serial.Status())
+	assert.ErrorIs(t, serial.Issue(3), entity.ErrSerialNotInWarehouse)
+
+	assert.NoError(t, serial.Receive(2))
+	assert.Equal(t, entity.SerialStatusInStock, serial.Status())
+	assert.Equal(t, uint(2), serial.WarehouseID())
+
+	assert.ErrorIs(t, serial.WriteOff(), entity.ErrSerialNotInTransit)
+	assert.NoError(t, serial.Dispatch(2))
+	assert.NoError(t, serial.WriteOff())
+	assert.Equal(t, entity.SerialStatusIssued, serial.Status())
+	assert.Equal(t, uint(0), serial.WarehouseID())
+}
@@ -0,0 +1,86 @@
+package entity_test
+
+import (
+	"errors"
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSKUSequence counts per key in memory
+type fakeSKUSequence map[string]int64
+
+// Next increments the counter of a key
+func (s fakeSKUSequence) Next(key string) (int64, error) {
+	s[key]++
+	return s[key], nil
+}
+
+// TestRandomSKUGenerator tests the historical random format and multi-byte names
+func TestRandomSKUGenerator(t *testing.T) {
+	zeros := func(b []byte) (int, error) { return len(b), nil }
+	generator := entity.NewRandomSKUGenerator(zeros)
+
+	sku, err := generator.GenerateSKU(entity.SKUInput{Name: "Test Product"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "SKU-TES-00000", sku)
+
+	sku, err = generator.GenerateSKU(entity.SKUInput{Name: "ölçü aleti"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "SKU-ÖLÇ-00000", sku)
+
+	failing := entity.NewRandomSKUGenerator(func(b []byte) (int, error) { return 0, errors.New("no entropy") })
+	_, err = failing.GenerateSKU(entity.SKUInput{Name: "Test"}, nil)
+	assert.EqualError(t, err, "no entropy")
+}
+
+// TestTemplateSKUGenerator tests rendering templates with counters per prefix
+func TestTemplateSKUGenerator(t *testing.T) {
+	generator, err := entity.NewTemplateSKUGenerator("{CAT}-{YYYY}-{SEQ:4}")
+	assert.NoError(t, err)
+
+	sequence := fakeSKUSequence{}
+	now := time.Date(2024, 11, 30, 0, 0, 0, 0, time.UTC)
+	input := entity.SKUInput{Name: "Earbuds", CategoryName: "Audio & Video", Now: now}
+
+	sku, err := generator.GenerateSKU(input, sequence)
+	assert.NoError(t, err)
+	assert.Equal(t, "AUD-2024-0001", sku)
+	sku, _ = generator.GenerateSKU(input, sequence)
+	assert.Equal(t, "AUD-2024-0002", sku)
+
+	sku, _ = generator.GenerateSKU(entity.SKUInput{Name: "Shovel", Now: now}, sequence)
+	assert.Equal(t, "GEN-2024-0001", sku)
+
+	_, err = generator.GenerateSKU(input, nil)
+	assert.ErrorIs(t, err, entity.ErrSKUSequenceUnavailable)
+}
+
+// TestNewSKUGenerator tests selecting strategies and rejecting invalid templates
+func TestNewSKUGenerator(t *testing.T) {
+	sequence := fakeSKUSequence{}
+	input := entity.SKUInput{Name: "Shirt", CategoryName: "Apparel", Now: time.Now()}
+
+	sequential, err := entity.NewSKUGenerator(entity.SKUStrategySequential, "")
+	assert.NoError(t, err)
+	sku, _ := sequential.GenerateSKU(input, sequence)
+	assert.Equal(t, "SKU-SHI-000001", sku)
+
+	category, err := entity.NewSKUGenerator(entity.SKUStrategyCategory, "")
+	assert.NoError(t, err)
+	sku, _ = category.GenerateSKU(input, sequence)
+	assert.Equal(t, "APP-000001", sku)
+
+	_, err = entity.NewSKUGenerator("fancy", "")
+	assert.ErrorIs(t, err, entity.ErrUnknownSKUStrategy)
+	_, err = entity.NewSKUGenerator(entity.SKUStrategyTemplate, "{CAT}-{YYYY}")
+	assert.ErrorIs(t, err, entity.ErrSKUTemplateNotUnique)
+	_, err = entity.NewSKUGenerator(entity.SKUStrategyTemplate, "{CAT}-{WEEK}-{SEQ}")
+	assert.ErrorIs(t, err, entity.ErrInvalidSKUTemplate)
+	_, err = entity.NewSKUGenerator(entity.SKUStrategyTemplate, "{CAT} {SEQ}")
+	assert.ErrorIs(t, err, entity.ErrInvalidSKUTemplate)
+	_, err = entity.NewSKUGenerator(entity.SKUStrategyTemplate, "{YYYY:2}-{SEQ}")
+	assert.ErrorIs(t, err, entity.ErrInvalidSKUTemplate)
+}
@@ -0,0 +1,52 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewStockLevel tests the NewStockLevel function
+func TestNewStockLevel(t *testing.T) {
+	stockLevel, err := entity.NewStockLevel(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), stockLevel.ProductID())
+	assert.Equal(t, uint(2), stockLevel.WarehouseID())
+	assert.Equal(t, int64(0), stockLevel.OnHand())
+	assert.Equal(t, int64(0), stockLevel.Available())
+
+	// Test error when product or warehouse are missing
+	_, err = entity.NewStockLevel(0, 2)
+	assert.ErrorIs(t, err, entity.ErrInvalidStockLevelProduct)
+	_, err = entity.NewStockLevel(1, 0)
+	assert.ErrorIs(t, err, entity.ErrInvalidStockLevelWarehouse)
+}
+
+// TestMakeStockLevel tests the MakeStockLevel invariants and the Available calculation
+func TestMakeStockLevel(t *testing.T) {
+	stockLevel := &entity.StockLevel{}
+
+	err := stockLevel.MakeStockLevel(1, 1, 2, 10, 4, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), stockLevel.OnHand())
+	assert.Equal(t, int64(4), stockLevel.Reserved())
+	assert.Equal(t, int64(6), stockLevel.Available())
+
+	assert.ErrorIs(t, stockLevel.MakeStockLevel(1, 1, 2, -1, 0, time.Now()), entity.ErrNegativeOnHand)
+	assert.ErrorIs(t, stockLevel.MakeStockLevel(1, 1, 2, 1, -1, time.Now()), entity.ErrNegativeReserved)
+	assert.ErrorIs(t, stockLevel.MakeStockLevel(1, 1, 2, 1, 2, time.Now()), entity.ErrReservedExceedsOnHand)
+}
+
+// TestStockLevelExcludeExpired tests that stock in expired lots cannot be reserved
+func TestStockLevelExcludeExpired(t *testing.T) {
+	stockLevel := &entity.StockLevel{}
+	_ = stockLevel.MakeStockLevel(1, 1, 2, 10, 2, time.Now())
+
+	stockLevel.ExcludeExpired(5)
+	assert.Equal(t, int64(5), stockLevel.Expired())
+	assert.Equal(t, int64(3), stockLevel.Available())
+	assert.ErrorIs(t, stockLevel.Reserve(4), entity.ErrInsufficientStock)
+	assert.NoError(t, stockLevel.Reserve(3))
+}
@@ -0,0 +1,95 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewStockMovement tests the NewStockMovement function and the sign of the stored quantity
+func TestNewStockMovement(t *testing.T) {
+	occurredAt := time.Now().Add(-time.Hour)
+
+	receipt, err := entity.NewStockMovement(1, 2, entity.MovementTypeReceipt, 10, "po_receipt", "GRN-1", "alice", occurredAt)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), receipt.Quantity())
+	assert.Equal(t, "PO_RECEIPT", receipt.ReasonCode())
+	assert.Equal(t, occurredAt, receipt.OccurredAt())
+
+	issue, err := entity.NewStockMovement(1, 2, entity.MovementTypeIssue, 4, "SALE", "", "alice", time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-4), issue.Quantity())
+	assert.WithinDuration(t, time.Now(), issue.OccurredAt(), time.Second)
+
+	adjustment, err := entity.NewStockMovement(1, 2, entity.MovementTypeAdjustment, -3, "COUNT", "", "alice", time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-3), adjustment.Quantity())
+
+	transferOut, err := entity.NewStockMovement(1, 2, entity.MovementTypeTransferOut, 2, "TRANSFER", "", "alice", time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-2), transferOut.Quantity())
+
+	// Only receipts and upward adjustments bring new stock in
+	upward, err := entity.NewStockMovement(1, 2, entity.MovementTypeAdjustment, 3, "COUNT", "", "alice", time.Time{})
+	assert.NoError(t, err)
+	transferIn, err := entity.NewStockMovement(1, 2, entity.MovementTypeTransferIn, 2, "TRANSFER", "", "alice", time.Time{})
+	assert.NoError(t, err)
+	assert.True(t, receipt.AddsStock())
+	assert.True(t, upward.AddsStock())
+	assert.False(t, adjustment.AddsStock())
+	assert.False(t, issue.AddsStock())
+	assert.False(t, transferIn.AddsStock())
+}
+
+// TestNewStockMovementValidation tests the validation errors of NewStockMovement
+func TestNewStockMovementValidation(t *testing.T) {
+	_, err := entity.NewStockMovement(1, 2, entity.MovementType("theft"), 1, "X", "", "alice", time.Time{})
+	assert.ErrorIs(t, err, entity.ErrInvalidMovementType)
+
+	_, err = entity.NewStockMovement(1, 2, entity.MovementTypeReceipt, -1, "X", "", "alice", time.Time{})
+	assert.ErrorIs(t, err, entity.ErrInvalidMovementQuantity)
+
+	_, err = entity.NewStockMovement(1, 2, entity.MovementTypeAdjustment, 0, "X", "", "alice", time.Time{})
+	assert.ErrorIs(t, err, entity.ErrInvalidMovementQuantity)
+
+	_, err = entity.NewStockMovement(1, 2, entity.MovementTypeReceipt, 1, " ", "", "alice", time.Time{})
+	assert.ErrorIs(t, err, entity.ErrEmptyReasonCode)
+
+	_, err = entity.NewStockMovement(1, 2, entity.MovementTypeReceipt, 1, "X", "", "", time.Time{})
+	assert.ErrorIs(t, err, entity.ErrEmptyActor)
+
+	_, err = entity.NewStockMovement(0, 2, entity.MovementTypeReceipt, 1, "X", "", "alice", time.Time{})
+	assert.ErrorIs(t, err, entity.ErrInvalidMovementProduct)
+}
+
+// TestStockLevelApplyMovement tests applying ledger movements to a stock level
+func TestStockLevelApplyMovement(t *testing.T) {
+	stockLevel := &entity.StockLevel{}
+	assert.NoError(t, stockLevel.MakeStockLevel(1, 1, 2, 10, 4, time.Now()))
+
+	receipt, _ := entity.NewStockMovement(1, 2, entity.MovementTypeReceipt, 5, "RECEIPT", "", "alice", time.Time{})
+	assert.NoError(t, stockLevel.ApplyMovement(receipt))
+	assert.Equal(t, int64(15), stockLevel.OnHand())
+	assert.Equal(t, int64(15), receipt.BalanceAfter())
+
+	// Reserved stock cannot be issued
+	issue, _ := entity.NewStockMovement(1, 2, entity.MovementTypeIssue, 12, "SALE", "", "alice", time.Time{})
+	assert.ErrorIs(t, stockLevel.ApplyMovement(issue), entity.ErrInsufficientStock)
+	assert.Equal(t, int64(15), stockLevel.OnHand())
+
+	// Movements for another warehouse are rejected
+	other, _ := entity.NewStockMovement(1, 3, entity.MovementTypeReceipt, 1, "RECEIPT", "", "alice", time.Time{})
+	assert.ErrorIs(t, stockLevel.ApplyMovement(other), entity.ErrMovementLevelMismatch)
+}
+
+// TestStockReconciliation tests the discrepancy between a stock level and its ledger
+func TestStockReconciliation(t *testing.T) {
+	reconciliation := entity.NewStockReconciliation(1, 2, 10, 10)
+	assert.True(t, reconciliation.InSync())
+
+	reconciliation = entity.NewStockReconciliation(1, 2, 10, 7)
+	assert.False(t, reconciliation.InSync())
+	assert.Equal(t, int64(3), reconciliation.Discrepancy())
+}
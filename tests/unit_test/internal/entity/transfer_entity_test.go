@@ -0,0 +1,123 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewTransfer tests the NewTransfer function and adding lines
+func TestNewTransfer(t *testing.T) {
+	transfer, err := entity.NewTransfer(1, 2, "DN-1")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.TransferStatusDraft, transfer.Status())
+
+	_, err = entity.NewTransfer(1, 1, "")
+	assert.ErrorIs(t, err, entity.ErrSameTransferWarehouses)
+
+	_, err = entity.NewTransfer(0, 1, "")
+	assert.ErrorIs(t, err, entity.ErrInvalidTransferSource)
+
+	assert.NoError(t, transfer.AddLine(10, 5))
+	assert.ErrorIs(t, transfer.AddLine(10, 1), entity.ErrDuplicateTransferLine)
+	assert.ErrorIs(t, transfer.AddLine(11, 0), entity.ErrInvalidTransferQuantity)
+	assert.Len(t, transfer.Lines(), 1)
+}
+
+// TestTransferTransitions tests dispatching, receiving and cancelling transfers
+func TestTransferTransitions(t *testing.T) {
+	now := time.Now()
+
+	transfer, _ := entity.NewTransfer(1, 2, "")
+	assert.ErrorIs(t, transfer.Dispatch(now), entity.ErrEmptyTransfer)
+	assert.ErrorIs(t, transfer.Receive(map[uint]int64{10: 1}, now), entity.ErrTransferNotInTransit)
+
+	_ = transfer.AddLine(10, 5)
+	_ = transfer.AddLine(11, 3)
+	assert.NoError(t, transfer.Dispatch(now))
+	assert.Equal(t, entity.TransferStatusDispatched, transfer.Status())
+	assert.Equal(t, int64(8), transfer.InTransit())
+	assert.ErrorIs(t, transfer.AddLine(12, 1), entity.ErrTransferNotDraft)
+	assert.ErrorIs(t, transfer.Cancel(), entity.ErrTransferNotDraft)
+
+	// Rejected receipts leave every line untouched
+	assert.ErrorIs(t, transfer.Receive(map[uint]int64{10: 2, 11: 4}, now), entity.ErrReceiveExceedsInTransit)
+	assert.ErrorIs(t, transfer.Receive(map[uint]int64{99: 1}, now), entity.ErrTransferLineNotFound)
+	assert.Equal(t, int64(8), transfer.InTransit())
+
+	assert.NoError(t, transfer.Receive(map[uint]int64{10: 5, 11: 1}, now))
+	assert.Equal(t, entity.TransferStatusPartiallyReceived, transfer.Status())
+	assert.Equal(t, int64(2), transfer.InTransit())
+	assert.Nil(t, transfer.ReceivedAt())
+
+	assert.NoError(t, transfer.Receive(map[uint]int64{11: 2}, now))
+	assert.Equal(t, entity.TransferStatusReceived, transfer.Status())
+	assert.Equal(t, int64(0), transfer.InTransit())
+	assert.NotNil(t, transfer.ReceivedAt())
+
+	transfer, _ = entity.NewTransfer(1, 2, "")
+	assert.NoError(t, transfer.Cancel())
+	assert.Equal(t, entity.TransferStatusCancelled, transfer.Status())
+}
+
+// TestTransferClose tests writing off what a partially received transfer still waits for
+func TestTransferClose(t *testing.T) {
+	now := time.Now()
+
+	transfer, _ := entity.NewTransfer(1, 2, "")
+	_ = transfer.AddLine(10, 5)
+	_ = transfer.AddLine(11, 3)
+	assert.ErrorIs(t, transfer.Close("LOST_IN_TRANSIT", now), entity.ErrTransferNotInTransit)
+
+	_ = transfer.Dispatch(now)
+	assert.NoError(t, transfer.Receive(map[uint]int64{10: 4}, now))
+	assert.ErrorIs(t, transfer.Close("  ", now), entity.ErrEmptyCloseReasonCode)
+	assert.Equal(t, entity.TransferStatusPartiallyReceived, transfer.Status())
+
+	assert.NoError(t, transfer.Close(" lost_in_transit ", now))
+	assert.Equal(t, entity.TransferStatusClosed, transfer.Status())
+	assert.Equal(t, "LOST_IN_TRANSIT", transfer.CloseReasonCode())
+	assert.Equal(t, now, *transfer.ClosedAt())
+	assert.Equal(t, int64(0), transfer.InTransit())
+	assert.Equal(t, int64(4), transfer.WrittenOff())
+	assert.Nil(t, transfer.ReceivedAt())
+
+	assert.ErrorIs(t, transfer.Receive(map[uint]int64{11: 1}, now), entity.ErrTransferNotInTransit)
+	assert.ErrorIs(t, transfer.Close("LOST_IN_TRANSIT", now), entity.ErrTransferNotInTransit)
+}
+
+// TestTransferLots tests recording dispatched lots and receiving them back first-expiring first
+func TestTransferLots(t *testing.T) {
+	now := time.Now()
+	early := now.AddDate(0, 1, 0)
+	late := now.AddDate(0, 2, 0)
+
+	transfer, _ := entity.NewTransfer(1, 2, "")
+	_ = transfer.AddLine(10, 10)
+	assert.ErrorIs(t, transfer.AddDispatchedLot(10, "L-1", nil, late, 4), entity.ErrTransferNotInTransit)
+
+	assert.NoError(t, transfer.Dispatch(now))
+	assert.NoError(t, transfer.AddDispatchedLot(10, "L-LATE", nil, late, 4))
+	assert.NoError(t, transfer.AddDispatchedLot(10, "L-EARLY", nil, early, 3))
+	assert.ErrorIs(t, transfer.AddDispatchedLot(10, "L-MORE", nil, late, 4), entity.ErrTransferLotsExceedLine)
+	assert.ErrorIs(t, transfer.AddDispatchedLot(99, "L-1", nil, late, 1), entity.ErrTransferLineNotFound)
+	line := transfer.Lines()[0]
+	assert.Len(t, line.Lots(), 2)
+
+	arriving := line.ArrivingLots(5)
+	assert.Len(t, arriving, 2)
+	assert.Equal(t, "L-EARLY", arriving[0].Lot.LotNumber())
+	assert.Equal(t, int64(3), arriving[0].Quantity)
+	assert.Equal(t, "L-LATE", arriving[1].Lot.LotNumber())
+	assert.Equal(t, int64(2), arriving[1].Quantity)
+
+	// Lots arrive before the untracked rest of the line
+	assert.NoError(t, transfer.Receive(map[uint]int64{10: 5}, now))
+	assert.Equal(t, int64(3), line.Lots()[1].ReceivedQuantity())
+	assert.Equal(t, int64(2), line.Lots()[0].ReceivedQuantity())
+	assert.NoError(t, transfer.Receive(map[uint]int64{10: 4}, now))
+	assert.Equal(t, int64(0), line.Lots()[0].Outstanding())
+	assert.Empty(t, line.ArrivingLots(1))
+}
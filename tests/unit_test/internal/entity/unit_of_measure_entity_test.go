@@ -0,0 +1,57 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewUnitOfMeasure tests the NewUnitOfMeasure function and its validations
+func TestNewUnitOfMeasure(t *testing.T) {
+	unit, err := entity.NewUnitOfMeasure(" case ", "Case")
+	assert.NoError(t, err)
+	assert.Equal(t, "CASE", unit.Code())
+
+	_, err = entity.NewUnitOfMeasure("", "Case")
+	assert.ErrorIs(t, err, entity.ErrEmptyUnitCode)
+	_, err = entity.NewUnitOfMeasure("CASE", " ")
+	assert.ErrorIs(t, err, entity.ErrEmptyUnitName)
+}
+
+// TestProductUnitConversion tests converting quantities to and from the stock unit
+func TestProductUnitConversion(t *testing.T) {
+	_, err := entity.NewProductUnit(1, "CASE", 0)
+	assert.ErrorIs(t, err, entity.ErrInvalidConversionFactor)
+	_, err = entity.NewProductUnit(0, "CASE", 24)
+	assert.ErrorIs(t, err, entity.ErrInvalidProductUnit)
+
+	conversion, err := entity.NewProductUnit(1, "case", 24)
+	assert.NoError(t, err)
+	assert.Equal(t, "CASE", conversion.UnitCode())
+
+	quantity, err := conversion.ToStockUnits(3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(72), quantity)
+	quantity, err = conversion.ToStockUnits(-2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-48), quantity)
+	_, err = conversion.ToStockUnits(math.MaxInt64 / 2)
+	assert.ErrorIs(t, err, entity.ErrConvertedQuantityTooBig)
+
+	assert.Equal(t, 1.25, conversion.FromStockUnits(30))
+	assert.ErrorIs(t, conversion.SetFactor(-1), entity.ErrInvalidConversionFactor)
+}
+
+// TestProductStockUnit tests the default stock unit of a product
+func TestProductStockUnit(t *testing.T) {
+	product, err := entity.NewProduct("Bottled Water")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.DefaultStockUnit, product.StockUnit())
+
+	product.SetStockUnit(" kg ")
+	assert.Equal(t, "KG", product.StockUnit())
+	product.SetStockUnit("")
+	assert.Equal(t, entity.DefaultStockUnit, product.StockUnit())
+}
@@ -0,0 +1,44 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewWarehouse tests the NewWarehouse function
+func TestNewWarehouse(t *testing.T) {
+	// Test valid warehouse creation, the code is normalized to upper case
+	warehouse, err := entity.NewWarehouse(" jkt01 ", "Jakarta Main")
+	assert.NoError(t, err)
+	assert.Equal(t, "JKT01", warehouse.Code())
+	assert.Equal(t, "Jakarta Main", warehouse.Name())
+	assert.WithinDuration(t, time.Now(), warehouse.CreatedAt(), time.Second)
+
+	// Test error when code is empty
+	warehouse, err = entity.NewWarehouse("", "Jakarta Main")
+	assert.Nil(t, warehouse)
+	assert.ErrorIs(t, err, entity.ErrEmptyWarehouseCode)
+
+	// Test error when name is empty
+	warehouse, err = entity.NewWarehouse("JKT01", " ")
+	assert.Nil(t, warehouse)
+	assert.ErrorIs(t, err, entity.ErrEmptyWarehouseName)
+}
+
+// TestMakeWarehouse tests the MakeWarehouse method and getters
+func TestMakeWarehouse(t *testing.T) {
+	createdAt := time.Now().Add(-24 * time.Hour)
+	updatedAt := time.Now()
+	warehouse := &entity.Warehouse{}
+
+	err := warehouse.MakeWarehouse(3, "SBY01", "Surabaya", createdAt, updatedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), warehouse.ID())
+	assert.Equal(t, "SBY01", warehouse.Code())
+	assert.Equal(t, "Surabaya", warehouse.Name())
+	assert.Equal(t, createdAt, warehouse.CreatedAt())
+	assert.Equal(t, updatedAt, warehouse.UpdatedAt())
+}
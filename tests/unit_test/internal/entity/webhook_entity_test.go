@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"inventory_management/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewWebhookSubscription tests the validation and event filter of webhook subscriptions
+func TestNewWebhookSubscription(t *testing.T) {
+	subscription, err := entity.NewWebhookSubscription("https://partner.example.com/hooks", "", []entity.EventType{entity.EventStockAdjusted})
+	assert.NoError(t, err)
+	assert.Len(t, subscription.Secret(), 64)
+	assert.True(t, subscription.Accepts(entity.EventStockAdjusted))
+	assert.False(t, subscription.Accepts(entity.EventProductCreated))
+
+	// An update without a secret keeps the current one, and no event types means every event
+	secret := subscription.Secret()
+	assert.NoError(t, subscription.Update("http://partner.example.com/v2/hooks", "", nil))
+	assert.Equal(t, secret, subscription.Secret())
+	assert.True(t, subscription.Accepts(entity.EventProductCreated))
+
+	_, err = entity.NewWebhookSubscription("ftp://partner.example.com", "", nil)
+	assert.ErrorIs(t, err, entity.ErrInvalidWebhookURL)
+
+	_, err = entity.NewWebhookSubscription("/hooks", "", nil)
+	assert.ErrorIs(t, err, entity.ErrInvalidWebhookURL)
+
+	_, err = entity.NewWebhookSubscription("https://partner.example.com/hooks", "short", nil)
+	assert.ErrorIs(t, err, entity.ErrWebhookSecretTooShort)
+
+	_, err = entity.NewWebhookSubscription("https://partner.example.com/hooks", "", []entity.EventType{"ProductExploded"})
+	assert.ErrorIs(t, err, entity.ErrInvalidEventType)
+}
+
+// TestWebhookRetryPolicyDelay tests that the wait doubles after every failure up to the maximum
+func TestWebhookRetryPolicyDelay(t *testing.T) {
+	policy := entity.WebhookRetryPolicy{MaxAttempts: 10, BaseDelay: 30 * time.Second, MaxDelay: 5 * time.Minute}
+
+	assert.Equal(t, 30*time.Second, policy.Delay(1))
+	assert.Equal(t, time.Minute, policy.Delay(2))
+	assert.Equal(t, 4*time.Minute, policy.Delay(4))
+	assert.Equal(t, 5*time.Minute, policy.Delay(5))
+	assert.Equal(t, 5*time.Minute, policy.Delay(60))
+}
+
+// TestWebhookDelivery tests the retries, dead-lettering and success of a delivery
+func TestWebhookDelivery(t *testing.T) {
+	policy := entity.WebhookRetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	event, err := entity.NewDomainEvent(entity.EventProductCreated, entity.AggregateProduct, 1, nil, time.Time{})
+	assert.NoError(t, err)
+	now := time.Now()
+
+	delivery, err := entity.NewWebhookDelivery(4, event, `{"id":0}`, now)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status())
+	assert.Equal(t, now, delivery.NextAttemptAt())
+
+	// A lease holds the delivery back without counting as an attempt
+	assert.NoError(t, delivery.Lease(now.Add(15*time.Minute)))
+	assert.Equal(t, now.Add(15*time.Minute), delivery.NextAttemptAt())
+	assert.Equal(t, 0, delivery.Attempts())
+
+	assert.NoError(t, delivery.RecordFailure(500, "receiver answered with status 500", now, policy))
+	assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status())
+	assert.Equal(t, now.Add(time.Minute), delivery.NextAttemptAt())
+	assert.Equal(t, 500, delivery.LastStatusCode())
+
+	assert.NoError(t, delivery.RecordFailure(0, "connection refused", now, policy))
+	assert.Equal(t, now.Add(2*time.Minute), delivery.NextAttemptAt())
+
+	// The last attempt dead-letters the delivery
+	assert.NoError(t, delivery.RecordFailure(503, "receiver answered with status 503", now, policy))
+	assert.Equal(t, entity.WebhookDeliveryDead, delivery.Status())
+	assert.Equal(t, 3, delivery.Attempts())
+	assert.ErrorIs(t, delivery.RecordSuccess(200, now), entity.ErrWebhookDeliveryNotPending)
+	assert.ErrorIs(t, delivery.Lease(now), entity.ErrWebhookDeliveryNotPending)
+
+	delivered, err := entity.NewWebhookDelivery(4, event, `{"id":0}`, now)
+	assert.NoError(t, err)
+	assert.NoError(t, delivered.RecordSuccess(204, now))
+	assert.Equal(t, entity.WebhookDeliverySucceeded, delivered.Status())
+	assert.Equal(t, 1, delivered.Attempts())
+	assert.Equal(t, now, *delivered.DeliveredAt())
+	assert.ErrorIs(t, delivered.RecordFailure(500, "late failure", now, policy), entity.ErrWebhookDeliveryNotPending)
+}
@@ -0,0 +1,86 @@
+package event_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/event"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newEvent creates a stored event with the given ID
+func newEvent(t *testing.T, id uint, eventType entity.EventType) *entity.DomainEvent {
+	e := &entity.DomainEvent{}
+	occurredAt := time.Date(2024, 12, 27, 9, 0, 0, 0, time.UTC)
+	assert.NoError(t, e.MakeDomainEvent(id, eventType, entity.AggregateProduct, 3, map[string]interface{}{"name": "Trail Shoe"}, occurredAt, 0, "", nil))
+	return e
+}
+
+// TestWriterPublisher tests that every event is written as one JSON line
+func TestWriterPublisher(t *testing.T) {
+	var out bytes.Buffer
+	publisher := event.NewWriterPublisher(&out)
+
+	assert.NoError(t, publisher.Publish(newEvent(t, 1, entity.EventProductCreated)))
+	assert.NoError(t, publisher.Publish(newEvent(t, 2, entity.EventProductRenamed)))
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var message map[string]interface{}
+	assert.NoError(t, json.Unmarshal(lines[1], &message))
+	assert.Equal(t, map[string]interface{}{
+		"id":             float64(2),
+		"type":           "ProductRenamed",
+		"aggregate_type": "product",
+		"aggregate_id":   float64(3),
+		"occurred_at":    "2024-12-27T09:00:00Z",
+		"payload":        map[string]interface{}{"name": "Trail Shoe"},
+	}, message)
+}
+
+// TestInMemoryPublisher tests that events reach the handlers of their type and failures are reported
+func TestInMemoryPublisher(t *testing.T) {
+	publisher := event.NewInMemoryPublisher()
+
+	var renamed []uint
+	publisher.Subscribe(entity.EventProductRenamed, func(message event.Message) error {
+		renamed = append(renamed, message.ID)
+		return nil
+	})
+
+	assert.NoError(t, publisher.Publish(newEvent(t, 1, entity.EventProductCreated)))
+	assert.NoError(t, publisher.Publish(newEvent(t, 2, entity.EventProductRenamed)))
+	assert.Equal(t, []uint{2}, renamed)
+
+	// A failing handler fails the delivery and the event is not recorded as published
+	publisher.Subscribe(entity.EventProductDeleted, func(message event.Message) error {
+		return errors.New("search index unavailable")
+	})
+	assert.Error(t, publisher.Publish(newEvent(t, 3, entity.EventProductDeleted)))
+
+	published := publisher.Published()
+	assert.Len(t, published, 2)
+	assert.Equal(t, uint(1), published[0].ID)
+	assert.Equal(t, entity.EventProductRenamed, published[1].Type)
+}
+
+// TestFanOutPublisher tests that every publisher gets the event until one fails
+func TestFanOutPublisher(t *testing.T) {
+	first, last := event.NewInMemoryPublisher(), event.NewInMemoryPublisher()
+	failing := event.NewInMemoryPublisher()
+	failing.Subscribe(entity.EventProductDeleted, func(message event.Message) error {
+		return errors.New("webhooks unavailable")
+	})
+	publisher := event.NewFanOutPublisher(first, failing, last)
+
+	assert.NoError(t, publisher.Publish(newEvent(t, 1, entity.EventProductCreated)))
+	assert.Error(t, publisher.Publish(newEvent(t, 2, entity.EventProductDeleted)))
+
+	assert.Len(t, first.Published(), 2)
+	assert.Len(t, last.Published(), 1)
+}
@@ -0,0 +1,114 @@
+package label_test
+
+import (
+	"bytes"
+	"image/png"
+	"inventory_management/internal/entity"
+	"inventory_management/internal/label"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// modulesString writes modules as 1 for a bar and 0 for a space
+func modulesString(modules []bool) string {
+	var b strings.Builder
+	for _, bar := range modules {
+		if bar {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	return b.String()
+}
+
+// newProduct makes a saved product for a label
+func newProduct(t *testing.T, name string, sku string) *entity.Product {
+	product := &entity.Product{}
+	assert.NoError(t, product.MakeProduct(1, name, sku, time.Now(), time.Now()))
+	return product
+}
+
+// TestEncodeCode128 tests the start, check and stop symbols of a Code 128 barcode
+func TestEncodeCode128(t *testing.T) {
+	modules, err := label.EncodeCode128("PJJ123C")
+	assert.NoError(t, err)
+
+	// Start B, 7 characters and the check symbol take 11 modules each, the stop symbol 13
+	assert.Len(t, modules, 11*9+13)
+	encoded := modulesString(modules)
+	assert.True(t, strings.HasPrefix(encoded, "11010010000"))   // Start B
+	assert.True(t, strings.HasSuffix(encoded, "1100011101011")) // Stop
+	// Check value (104 + 48 + 2*42 + 3*42 + 4*17 + 5*18 + 6*19 + 7*35) % 103 = 55
+	assert.Equal(t, "11101000110", encoded[len(encoded)-24:len(encoded)-13])
+
+	_, err = label.EncodeCode128("ÄRMEL-7")
+	assert.ErrorIs(t, err, label.ErrNotEncodable)
+	_, err = label.EncodeCode128("")
+	assert.ErrorIs(t, err, label.ErrNotEncodable)
+}
+
+// TestEncodeEAN13 tests the guards and digit patterns of an EAN-13 barcode
+func TestEncodeEAN13(t *testing.T) {
+	modules, err := label.EncodeEAN13("4006381333931")
+	assert.NoError(t, err)
+	assert.Len(t, modules, 95)
+
+	encoded := modulesString(modules)
+	assert.Equal(t, "101", encoded[:3])
+	assert.Equal(t, "01010", encoded[45:50])
+	assert.Equal(t, "101", encoded[92:])
+	assert.Equal(t, "0001101", encoded[3:10])  // 0 with odd parity, first digit 4 starts odd
+	assert.Equal(t, "0100111", encoded[10:17]) // 0 with even parity
+	assert.Equal(t, "1100110", encoded[85:92]) // Check digit 1 on the right
+
+	_, err = label.EncodeEAN13("036000291452")
+	assert.ErrorIs(t, err, label.ErrNotEncodable)
+}
+
+// TestNewProductLabel tests choosing between the EAN-13 barcode and the SKU
+func TestNewProductLabel(t *testing.T) {
+	product := newProduct(t, "Cola 330ml", "SKU-COL-12345")
+
+	productLabel, err := label.NewProductLabel(product, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Code 128", productLabel.Symbology())
+	assert.Equal(t, "SKU-COL-12345", productLabel.BarcodeText())
+
+	ean8, _ := entity.NewBarcode(1, "96385074")
+	upc, _ := entity.NewBarcode(1, "036000291452")
+	productLabel, err = label.NewProductLabel(product, []*entity.Barcode{ean8, upc})
+	assert.NoError(t, err)
+	assert.Equal(t, "EAN-13", productLabel.Symbology())
+	assert.Equal(t, "0036000291452", productLabel.BarcodeText())
+
+	_, err = label.NewProductLabel(newProduct(t, "Ärmel", "ÄRMEL-7"), nil)
+	assert.ErrorIs(t, err, label.ErrNotEncodable)
+}
+
+// TestWriteLabel tests rendering labels at a size and DPI
+func TestWriteLabel(t *testing.T) {
+	productLabel, err := label.NewProductLabel(newProduct(t, "Cola <330ml> & ice", "SKU-COL-12345"), nil)
+	assert.NoError(t, err)
+	size := label.Size{WidthMM: 50, HeightMM: 25, DPI: 203}
+
+	var buffer bytes.Buffer
+	assert.NoError(t, productLabel.WritePNG(&buffer, size))
+	img, err := png.Decode(&buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, 400, img.Bounds().Dx())
+	assert.Equal(t, 200, img.Bounds().Dy())
+
+	buffer.Reset()
+	assert.NoError(t, productLabel.WriteSVG(&buffer, size))
+	svg := buffer.String()
+	assert.Contains(t, svg, `width="50mm" height="25mm" viewBox="0 0 400 200"`)
+	assert.Contains(t, svg, "Cola &lt;330ml&gt; &amp; ice")
+	assert.Contains(t, svg, "SKU-COL-12345")
+
+	err = productLabel.WritePNG(&buffer, label.Size{WidthMM: 10, HeightMM: 10, DPI: 72})
+	assert.ErrorIs(t, err, label.ErrLabelTooSmall)
+}
@@ -1,6 +1,7 @@
 package repository_test
 
 import (
+	"database/sql"
 	"errors"
 	"inventory_management/internal/entity"
 	"inventory_management/internal/model"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // MockDB is a mock for the DB interface in the repository package
@@ -66,6 +68,36 @@ func (m *MockDB) Find(dest interface{}, conds ...interface{}) *gorm.DB {
 	return &gorm.DB{Error: args.Error(0)}
 }
 
+// Mock Create function for gorm.DB
+func (m *MockDB) Create(value interface{}) *gorm.DB {
+	args := m.Called(value)
+	return &gorm.DB{Error: args.Error(0)}
+}
+
+// Mock Clauses function for gorm.DB
+func (m *MockDB) Clauses(conds ...clause.Expression) *gorm.DB {
+	args := m.Called(conds)
+	return &gorm.DB{Error: args.Error(0)}
+}
+
+// Mock Transaction function for gorm.DB
+func (m *MockDB) Transaction(fc func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+	args := m.Called(fc)
+	return args.Error(0)
+}
+
+// Mock Delete function for gorm.DB
+func (m *MockDB) Delete(value interface{}, conds ...interface{}) *gorm.DB {
+	args := m.Called(append([]interface{}{value}, conds...)...)
+	return &gorm.DB{Error: args.Error(0)}
+}
+
+// Mock Unscoped function for gorm.DB
+func (m *MockDB) Unscoped() *gorm.DB {
+	args := m.Called()
+	return &gorm.DB{Error: args.Error(0)}
+}
+
 // TestPostgresProductRepository_Save tests the Save method
 func TestPostgresProductRepository_Save(t *testing.T) {
 	mockDB := new(MockDB) // Fresh mock object for this test
@@ -82,6 +114,7 @@ func TestPostgresProductRepository_Save(t *testing.T) {
 	// Call the repository save method
 	err = repo.Save(product)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(1), product.Version()) // New products start at the first version
 
 	// Ensure the mock expectations were met
 	mockDB.AssertExpectations(t)
@@ -109,6 +142,7 @@ func TestPostgresProductRepository_FindByID(t *testing.T) {
 		ID:        1,
 		Name:      "Test Product",
 		SKU:       "SKU-TST-12345",
+		Version:   3,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -121,6 +155,7 @@ func TestPostgresProductRepository_FindByID(t *testing.T) {
 	product, err := repo.FindByID(1)
 	assert.NoError(t, err)
 	assert.Equal(t, modelProduct.Name, product.Name())
+	assert.Equal(t, int64(3), product.Version())
 
 	// Ensure the mock expectations were met
 	mockDB.AssertExpectations(t)
@@ -206,3 +241,24 @@ func TestPostgresProductRepository_FindByID_ModelToEntityError(t *testing.T) {
 	// Ensure the mock expectations were met
 	mockDB.AssertExpectations(t)
 }
+
+// TestPostgresProductRepository_FindByID_Deleted tests that deleted products keep their deletion time
+func TestPostgresProductRepository_FindByID_Deleted(t *testing.T) {
+	mockDB := new(MockDB)
+	repo := repository.NewPostgresProductRepository(mockDB)
+
+	deletedAt := time.Now()
+	mockDB.On("First", mock.Anything, uint(1)).Return(nil).Run(func(args mock.Arguments) {
+		dest := args.Get(0).(*model.Product)
+		*dest = model.Product{
+			ID:        1,
+			Name:      "Test Product",
+			SKU:       "SKU-TST-12345",
+			DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true},
+		}
+	})
+	product, err := repo.FindByID(1)
+	assert.NoError(t, err)
+	assert.True(t, product.IsDeleted())
+	assert.Equal(t, deletedAt, *product.DeletedAt())
+}
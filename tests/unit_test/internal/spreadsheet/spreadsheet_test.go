@@ -0,0 +1,131 @@
+package spreadsheet_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"inventory_management/internal/spreadsheet"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildXLSX zips the given parts into a workbook
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, content := range parts {
+		part, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return buffer.Bytes()
+}
+
+// workbookParts returns the parts of a workbook whose first sheet is stored as sheet.xml
+func workbookParts(sheet string, sharedStrings string) map[string]string {
+	return map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Products" sheetId="1" r:id="rId7"/><sheet name="Notes" sheetId="2" r:id="rId8"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId8" Target="worksheets/notes.xml"/><Relationship Id="rId7" Target="worksheets/sheet.xml"/></Relationships>`,
+		"xl/worksheets/sheet.xml": sheet,
+		"xl/worksheets/notes.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>ignored</t></is></c></row></sheetData></worksheet>`,
+		"xl/sharedStrings.xml":    sharedStrings,
+	}
+}
+
+// TestReadCSV tests the lines reported for records and the byte order mark being dropped
+func TestReadCSV(t *testing.T) {
+	rows, err := spreadsheet.ReadCSV(strings.NewReader("\xef\xbb\xbfname,sku\n\"Trail\nShoe\",TS-1\n\nBoot\n"))
+	require.NoError(t, err)
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, spreadsheet.Row{Line: 1, Cells: []string{"name", "sku"}}, rows[0])
+	assert.Equal(t, spreadsheet.Row{Line: 2, Cells: []string{"Trail\nShoe", "TS-1"}}, rows[1])
+	assert.Equal(t, spreadsheet.Row{Line: 5, Cells: []string{"Boot"}}, rows[2])
+
+	_, err = spreadsheet.ReadCSV(strings.NewReader("name\n\"Trail Shoe\n"))
+	assert.ErrorIs(t, err, spreadsheet.ErrMalformed)
+}
+
+// TestReadXLSX tests that the first sheet is read with shared, inline, rich, numeric and boolean cells
+func TestReadXLSX(t *testing.T) {
+	sheet := `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+		<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="inlineStr"><is><t>serialized</t></is></c></row>
+		<row r="3"><c r="A3" t="s"><v>2</v></c><c r="B3"><v>1042</v></c><c r="D3" t="b"><v>1</v></c></row>
+	</sheetData></worksheet>`
+	sharedStrings := `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+		<si><t>name</t></si><si><t>sku</t></si><si><r><t>Trail </t></r><r><t>Shoe</t></r></si></sst>`
+
+	rows, err := spreadsheet.ReadXLSX(buildXLSX(t, workbookParts(sheet, sharedStrings)))
+	require.NoError(t, err)
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, spreadsheet.Row{Line: 1, Cells: []string{"name", "sku", "", "serialized"}}, rows[0])
+	assert.Equal(t, spreadsheet.Row{Line: 3, Cells: []string{"Trail Shoe", "1042", "", "TRUE"}}, rows[1])
+}
+
+// TestReadXLSXMalformed tests that files which are not workbooks are refused
+func TestReadXLSXMalformed(t *testing.T) {
+	_, err := spreadsheet.ReadXLSX([]byte("name,sku\n"))
+	assert.ErrorIs(t, err, spreadsheet.ErrMalformed)
+
+	_, err = spreadsheet.ReadXLSX(buildXLSX(t, map[string]string{"xl/workbook.xml": "<workbook/>"}))
+	assert.ErrorIs(t, err, spreadsheet.ErrMalformed)
+
+	sheet := `<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>3</v></c></row></sheetData></worksheet>`
+	_, err = spreadsheet.ReadXLSX(buildXLSX(t, workbookParts(sheet, `<sst><si><t>name</t></si></sst>`)))
+	assert.ErrorIs(t, err, spreadsheet.ErrMalformed)
+}
+
+// TestFormatOf tests that the format is picked from the file extension
+func TestFormatOf(t *testing.T) {
+	format, err := spreadsheet.FormatOf("Catalogue.CSV")
+	require.NoError(t, err)
+	assert.Equal(t, spreadsheet.FormatCSV, format)
+
+	format, err = spreadsheet.FormatOf("catalogue.xlsx")
+	require.NoError(t, err)
+	assert.Equal(t, spreadsheet.FormatXLSX, format)
+
+	_, err = spreadsheet.FormatOf("catalogue.xls")
+	assert.ErrorIs(t, err, spreadsheet.ErrUnsupportedFormat)
+}
+
+// TestWriteCSV tests that cells starting like a formula are written as text
+func TestWriteCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	err := spreadsheet.WriteCSV(&buffer, [][]string{{"line", "name"}, {"2", "=HYPERLINK(\"x\")"}, {"3", "-5 Boot"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "line,name\n2,\"'=HYPERLINK(\"\"x\"\")\"\n3,'-5 Boot\n", buffer.String())
+}
+
+// TestWriteXLSX tests that a written workbook reads back with its rows, escaping and wide columns
+func TestWriteXLSX(t *testing.T) {
+	wide := make([]string, 28)
+	wide[27] = "AB"
+
+	var buffer bytes.Buffer
+	writer, err := spreadsheet.NewWriter(spreadsheet.FormatXLSX, &buffer)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteRow([]string{"name", "sku"}))
+	require.NoError(t, writer.WriteRow([]string{"Boots <Trail & Road>", ""}))
+	require.NoError(t, writer.WriteRow(wide))
+	require.NoError(t, writer.Close())
+
+	rows, err := spreadsheet.ReadXLSX(buffer.Bytes())
+	require.NoError(t, err)
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, spreadsheet.Row{Line: 1, Cells: []string{"name", "sku"}}, rows[0])
+	assert.Equal(t, spreadsheet.Row{Line: 2, Cells: []string{"Boots <Trail & Road>", ""}}, rows[1])
+	assert.Equal(t, spreadsheet.Row{Line: 3, Cells: wide}, rows[2])
+
+	_, err = spreadsheet.NewWriter(spreadsheet.Format("ods"), &buffer)
+	assert.ErrorIs(t, err, spreadsheet.ErrUnsupportedFormat)
+}
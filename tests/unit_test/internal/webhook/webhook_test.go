@@ -0,0 +1,69 @@
+package webhook_test
+
+import (
+	"inventory_management/internal/entity"
+	"inventory_management/internal/webhook"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSignAndVerify tests that signatures cover both the body and the timestamp
+func TestSignAndVerify(t *testing.T) {
+	secret := "0123456789abcdef"
+	body := []byte(`{"id":1}`)
+
+	signature := webhook.Sign(secret, 1735290000, body)
+	assert.Regexp(t, "^sha256=[0-9a-f]{64}$", signature)
+	assert.True(t, webhook.Verify(secret, "1735290000", body, signature))
+
+	assert.False(t, webhook.Verify("fedcba9876543210", "1735290000", body, signature))
+	assert.False(t, webhook.Verify(secret, "1735290001", body, signature))
+	assert.False(t, webhook.Verify(secret, "1735290000", []byte(`{"id":2}`), signature))
+	assert.False(t, webhook.Verify(secret, "not-a-time", body, signature))
+}
+
+// TestHTTPSender tests the signed request posted to a receiver and the status it answered with
+func TestHTTPSender(t *testing.T) {
+	var request *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer receiver.Close()
+
+	subscription, err := entity.NewWebhookSubscription(receiver.URL, "0123456789abcdef", nil)
+	assert.NoError(t, err)
+	event, err := entity.NewDomainEvent(entity.EventStockAdjusted, entity.AggregateStockLevel, 2, nil, time.Time{})
+	assert.NoError(t, err)
+	delivery := &entity.WebhookDelivery{}
+	assert.NoError(t, delivery.MakeWebhookDelivery(9, subscription.ID(), event.ID(), event.Type(), `{"type":"StockAdjusted"}`, entity.WebhookDeliveryPending, 0, time.Now(), 0, "", nil, time.Now()))
+
+	statusCode, err := webhook.NewHTTPSender(time.Second).Send(subscription, delivery)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, statusCode)
+
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, `{"type":"StockAdjusted"}`, string(body))
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	assert.Equal(t, "StockAdjusted", request.Header.Get(webhook.HeaderEvent))
+	assert.Equal(t, "9", request.Header.Get(webhook.HeaderDelivery))
+	timestamp := request.Header.Get(webhook.HeaderTimestamp)
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), time.Unix(unix, 0), 5*time.Second)
+	assert.True(t, webhook.Verify("0123456789abcdef", timestamp, body, request.Header.Get(webhook.HeaderSignature)))
+
+	// An unreachable receiver is an error without a status
+	receiver.Close()
+	statusCode, err = webhook.NewHTTPSender(time.Second).Send(subscription, delivery)
+	assert.Error(t, err)
+	assert.Zero(t, statusCode)
+}
@@ -0,0 +1,32 @@
+package worker_test
+
+import (
+	"errors"
+	"inventory_management/internal/worker"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPeriodicWorker tests that the worker runs its task until stopped, even when the task fails
+func TestPeriodicWorker(t *testing.T) {
+	var runs int32
+	w := worker.NewPeriodicWorker("test", 5*time.Millisecond, func(now time.Time) error {
+		atomic.AddInt32(&runs, 1)
+		return errors.New("task error")
+	})
+
+	w.Start()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 3 }, time.Second, time.Millisecond)
+	w.Stop()
+
+	// No more runs happen once Stop has returned
+	stoppedAt := atomic.LoadInt32(&runs)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stoppedAt, atomic.LoadInt32(&runs))
+
+	// Stopping twice is safe
+	w.Stop()
+}